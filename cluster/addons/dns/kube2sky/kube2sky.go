@@ -57,22 +57,40 @@ func addDNS(record string, service *kapi.Service, etcdClient *etcd.Client) error
 		return nil
 	}
 
-	svc := skymsg.Service{
-		Host:     service.Spec.PortalIP,
-		Port:     service.Spec.Port,
-		Priority: 10,
-		Weight:   10,
-		Ttl:      30,
-	}
-	b, err := json.Marshal(svc)
-	if err != nil {
+	// Replace all records of the service, so that the records of removed ports go away.
+	if _, err := etcdClient.Delete(skymsg.Path(record), true); err != nil && !tools.IsEtcdNotFound(err) {
 		return err
 	}
-	// Set with no TTL, and hope that kubernetes events are accurate.
+	for _, port := range service.Spec.Ports {
+		svc := skymsg.Service{
+			Host:     service.Spec.PortalIP,
+			Port:     port.Port,
+			Priority: 10,
+			Weight:   10,
+			Ttl:      30,
+		}
+		b, err := json.Marshal(svc)
+		if err != nil {
+			return err
+		}
+		// Set with no TTL, and hope that kubernetes events are accurate.
 
-	log.Printf("Setting dns record: %v -> %s:%d\n", record, service.Spec.PortalIP, service.Spec.Port)
-	_, err = etcdClient.Set(skymsg.Path(record), string(b), uint64(0))
-	return err
+		log.Printf("Setting dns record: %v -> %s:%d\n", record, service.Spec.PortalIP, port.Port)
+		_, err = etcdClient.Set(skymsg.Path(record)+"/"+portKey(port), string(b), uint64(0))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// portKey returns the etcd key of the record of a service port under the record of the
+// service. The name of a port is optional if it is the only port of the service.
+func portKey(port kapi.ServicePort) string {
+	if len(port.Name) > 0 {
+		return port.Name
+	}
+	return fmt.Sprintf("%d", port.Port)
 }
 
 // Implements retry logic for arbitrary mutator. Crashes after retrying for
@@ -463,12 +463,11 @@ func runSelfLinkTestOnNamespace(c *client.Client, namespace string) {
 			},
 		},
 		Spec: api.ServiceSpec{
-			Port: 12345,
+			Ports: []api.ServicePort{{Port: 12345, Protocol: "TCP"}},
 			// This is here because validation requires it.
 			Selector: map[string]string{
 				"foo": "bar",
 			},
-			Protocol:        "TCP",
 			SessionAffinity: "None",
 		},
 	}
@@ -525,12 +524,11 @@ func runAtomicPutTest(c *client.Client) {
 			},
 		},
 		Spec: api.ServiceSpec{
-			Port: 12345,
+			Ports: []api.ServicePort{{Port: 12345, Protocol: "TCP"}},
 			// This is here because validation requires it.
 			Selector: map[string]string{
 				"foo": "bar",
 			},
-			Protocol:        "TCP",
 			SessionAffinity: "None",
 		},
 	}
@@ -604,12 +602,11 @@ func runPatchTest(c *client.Client) {
 			},
 		},
 		Spec: api.ServiceSpec{
-			Port: 12345,
+			Ports: []api.ServicePort{{Port: 12345, Protocol: "TCP"}},
 			// This is here because validation requires it.
 			Selector: map[string]string{
 				"foo": "bar",
 			},
-			Protocol:        "TCP",
 			SessionAffinity: "None",
 		},
 	}
@@ -742,11 +739,10 @@ func runServiceTest(client *client.Client) {
 	svc1 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "service1"},
 		Spec: api.ServiceSpec{
+			Ports: []api.ServicePort{{Port: 8080, Protocol: "TCP"}},
 			Selector: map[string]string{
 				"name": "thisisalonglabel",
 			},
-			Port:            8080,
-			Protocol:        "TCP",
 			SessionAffinity: "None",
 		},
 	}
@@ -759,11 +755,10 @@ func runServiceTest(client *client.Client) {
 	svc3 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "service1"},
 		Spec: api.ServiceSpec{
+			Ports: []api.ServicePort{{Port: 8080, Protocol: "TCP"}},
 			Selector: map[string]string{
 				"name": "thisisalonglabel",
 			},
-			Port:            8080,
-			Protocol:        "TCP",
 			SessionAffinity: "None",
 		},
 	}
@@ -779,11 +774,10 @@ func runServiceTest(client *client.Client) {
 	svc2 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "service2"},
 		Spec: api.ServiceSpec{
+			Ports: []api.ServicePort{{Port: 8080, Protocol: "TCP"}},
 			Selector: map[string]string{
 				"name": "thisisalonglabel",
 			},
-			Port:            8080,
-			Protocol:        "TCP",
 			SessionAffinity: "None",
 		},
 	}
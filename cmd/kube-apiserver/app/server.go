@@ -21,6 +21,7 @@ package app
 
 import (
 	"crypto/tls"
+	"io"
 	"net"
 	"net/http"
 	"os"
@@ -35,8 +36,11 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/cloudprovider"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/master"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/serviceaccount"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/encryption"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/logrotate"
 
 	"github.com/coreos/go-etcd/etcd"
 	"github.com/golang/glog"
@@ -45,56 +49,83 @@ import (
 
 // APIServer runs a kubernetes api server.
 type APIServer struct {
-	WideOpenPort               int
-	ExternalHost               string
-	Address                    util.IP
-	PublicAddressOverride      util.IP
-	ReadOnlyPort               int
-	APIRate                    float32
-	APIBurst                   int
-	SecurePort                 int
-	TLSCertFile                string
-	TLSPrivateKeyFile          string
-	APIPrefix                  string
-	StorageVersion             string
-	CloudProvider              string
-	CloudConfigFile            string
-	EventTTL                   time.Duration
-	TokenAuthFile              string
-	AuthorizationMode          string
-	AuthorizationPolicyFile    string
-	AdmissionControl           string
-	AdmissionControlConfigFile string
-	EtcdServerList             util.StringList
-	EtcdConfigFile             string
-	CorsAllowedOriginList      util.StringList
-	AllowPrivileged            bool
-	PortalNet                  util.IPNet // TODO: make this a list
-	EnableLogsSupport          bool
-	MasterServiceNamespace     string
-	RuntimeConfig              util.ConfigurationMap
-	KubeletConfig              client.KubeletConfig
-	ClusterName                string
-	EnableProfiling            bool
+	WideOpenPort                   int
+	ExternalHost                   string
+	Address                        util.IP
+	PublicAddressOverride          util.IP
+	ReadOnlyPort                   int
+	APIRate                        float32
+	APIBurst                       int
+	SecurePort                     int
+	TLSCertFile                    string
+	TLSPrivateKeyFile              string
+	APIPrefix                      string
+	StorageVersion                 string
+	CloudProvider                  string
+	CloudConfigFile                string
+	EventTTL                       time.Duration
+	BasicAuthFile                  string
+	ClientCAFile                   string
+	TokenAuthFile                  string
+	TokenWebhookConfigFile         string
+	TokenWebhookCacheTTL           time.Duration
+	ServiceAccountKeyFile          string
+	ServiceAccountLookup           bool
+	OIDCIssuerURL                  string
+	OIDCClientID                   string
+	OIDCCAFile                     string
+	OIDCJWKSFile                   string
+	OIDCUsernameClaim              string
+	OIDCGroupsClaim                string
+	AuthorizationMode              string
+	AuthorizationPolicyFile        string
+	AuthorizationWebhookConfigFile string
+	AuthorizationWebhookCacheTTL   time.Duration
+	AuditLogPath                   string
+	AuditLogMaxSize                int
+	AuditLogMaxBackups             int
+	AuditPolicyFile                string
+	EncryptionKeyFile              string
+	EncryptedResources             string
+	AdmissionControl               string
+	AdmissionControlConfigFile     string
+	EtcdServerList                 util.StringList
+	EtcdConfigFile                 string
+	CorsAllowedOriginList          util.StringList
+	AllowPrivileged                bool
+	PortalNet                      util.IPNet // TODO: make this a list
+	ServiceNodePorts               util.PortRange
+	EnableLogsSupport              bool
+	MasterServiceNamespace         string
+	RuntimeConfig                  util.ConfigurationMap
+	KubeletConfig                  client.KubeletConfig
+	ClusterName                    string
+	EnableProfiling                bool
 }
 
 // NewAPIServer creates a new APIServer object with default parameters
 func NewAPIServer() *APIServer {
 	s := APIServer{
-		WideOpenPort:           8080,
-		Address:                util.IP(net.ParseIP("127.0.0.1")),
-		PublicAddressOverride:  util.IP(net.ParseIP("")),
-		ReadOnlyPort:           7080,
-		APIRate:                10.0,
-		APIBurst:               200,
-		SecurePort:             6443,
-		APIPrefix:              "/api",
-		EventTTL:               1 * time.Hour,
-		AuthorizationMode:      "AlwaysAllow",
-		AdmissionControl:       "AlwaysAdmit",
-		EnableLogsSupport:      true,
-		MasterServiceNamespace: api.NamespaceDefault,
-		ClusterName:            "kubernetes",
+		WideOpenPort:                 8080,
+		Address:                      util.IP(net.ParseIP("127.0.0.1")),
+		PublicAddressOverride:        util.IP(net.ParseIP("")),
+		ReadOnlyPort:                 7080,
+		APIRate:                      10.0,
+		APIBurst:                     200,
+		SecurePort:                   6443,
+		APIPrefix:                    "/api",
+		EventTTL:                     1 * time.Hour,
+		AuthorizationMode:            "AlwaysAllow",
+		TokenWebhookCacheTTL:         2 * time.Minute,
+		OIDCUsernameClaim:            "sub",
+		AuthorizationWebhookCacheTTL: 2 * time.Minute,
+		AuditLogMaxSize:              100,
+		AuditLogMaxBackups:           5,
+		EncryptedResources:           "secrets",
+		AdmissionControl:             "AlwaysAdmit",
+		EnableLogsSupport:            true,
+		MasterServiceNamespace:       api.NamespaceDefault,
+		ClusterName:                  "kubernetes",
 
 		RuntimeConfig: make(util.ConfigurationMap),
 		KubeletConfig: client.KubeletConfig{
@@ -139,9 +170,25 @@ func (s *APIServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CloudProvider, "cloud_provider", s.CloudProvider, "The provider for cloud services.  Empty string for no provider.")
 	fs.StringVar(&s.CloudConfigFile, "cloud_config", s.CloudConfigFile, "The path to the cloud provider configuration file.  Empty string for no configuration file.")
 	fs.DurationVar(&s.EventTTL, "event_ttl", s.EventTTL, "Amount of time to retain events. Default 1 hour.")
+	fs.StringVar(&s.BasicAuthFile, "basic_auth_file", s.BasicAuthFile, "If set, the file that will be used to admit requests to the secure port of the API server via http basic authentication.")
+	fs.StringVar(&s.ClientCAFile, "client_ca_file", s.ClientCAFile, "If set, any request presenting a client certificate signed by one of the authorities in the --client_ca_file is authenticated with an identity corresponding to the CommonName of the client certificate, and with groups corresponding to its Organizations.")
 	fs.StringVar(&s.TokenAuthFile, "token_auth_file", s.TokenAuthFile, "If set, the file that will be used to secure the secure port of the API server via token authentication.")
+	fs.StringVar(&s.TokenWebhookConfigFile, "authentication_token_webhook_config_file", s.TokenWebhookConfigFile, "File with webhook configuration for token authentication in kubeconfig format. The API server will query the remote service to determine authentication for bearer tokens.")
+	fs.DurationVar(&s.TokenWebhookCacheTTL, "authentication_token_webhook_cache_ttl", s.TokenWebhookCacheTTL, "The duration to cache responses from the webhook token authenticator. Default 2 minutes.")
+	fs.StringVar(&s.ServiceAccountKeyFile, "service_account_key_file", s.ServiceAccountKeyFile, "File containing PEM-encoded x509 RSA private or public key, used to verify ServiceAccount tokens. If unspecified, --tls_private_key_file is used.")
+	fs.BoolVar(&s.ServiceAccountLookup, "service_account_lookup", s.ServiceAccountLookup, "If true, validate ServiceAccount tokens exist in etcd as part of authentication.")
+	fs.StringVar(&s.OIDCIssuerURL, "oidc_issuer_url", s.OIDCIssuerURL, "If set, OpenID Connect ID tokens issued by this URL are accepted as bearer tokens. Must use https.")
+	fs.StringVar(&s.OIDCClientID, "oidc_client_id", s.OIDCClientID, "The client ID that OpenID Connect ID tokens must be issued for. Required if --oidc_issuer_url is set.")
+	fs.StringVar(&s.OIDCCAFile, "oidc_ca_file", s.OIDCCAFile, "If set, the certificate authority used to verify the OpenID Connect provider during discovery. Otherwise the host's root CAs are used.")
+	fs.StringVar(&s.OIDCJWKSFile, "oidc_jwks_file", s.OIDCJWKSFile, "If set, a JSON Web Key Set file holding the OpenID Connect provider's signing keys. Otherwise the keys are found by discovery from --oidc_issuer_url.")
+	fs.StringVar(&s.OIDCUsernameClaim, "oidc_username_claim", s.OIDCUsernameClaim, "The ID token claim used as the user name.")
+	fs.StringVar(&s.OIDCGroupsClaim, "oidc_groups_claim", s.OIDCGroupsClaim, "If set, the ID token claim holding the user's groups, as a string or a list of strings.")
 	fs.StringVar(&s.AuthorizationMode, "authorization_mode", s.AuthorizationMode, "Selects how to do authorization on the secure port.  One of: "+strings.Join(apiserver.AuthorizationModeChoices, ","))
 	fs.StringVar(&s.AuthorizationPolicyFile, "authorization_policy_file", s.AuthorizationPolicyFile, "File with authorization policy in csv format, used with --authorization_mode=ABAC, on the secure port.")
+	fs.StringVar(&s.AuthorizationWebhookConfigFile, "authorization_webhook_config_file", s.AuthorizationWebhookConfigFile, "File with webhook configuration in kubeconfig format, used with --authorization_mode=Webhook. The API server will query the remote service to determine access on the secure port.")
+	fs.DurationVar(&s.AuthorizationWebhookCacheTTL, "authorization_webhook_cache_ttl", s.AuthorizationWebhookCacheTTL, "The duration to cache responses from the webhook authorizer. Default 2 minutes.")
+	fs.StringVar(&s.EncryptionKeyFile, "encryption_key_file", s.EncryptionKeyFile, "If set, the resources named by --encrypted_resources are encrypted in etcd with the keys in this file. Each line has the form name,base64-encoded AES key; the first key encrypts new data.")
+	fs.StringVar(&s.EncryptedResources, "encrypted_resources", s.EncryptedResources, "Comma-delimited list of resources to encrypt in etcd when --encryption_key_file is set, such as secrets,serviceaccounts. Default secrets.")
 	fs.StringVar(&s.AdmissionControl, "admission_control", s.AdmissionControl, "Ordered list of plug-ins to do admission control of resources into cluster. Comma-delimited list of: "+strings.Join(admission.GetPlugins(), ", "))
 	fs.StringVar(&s.AdmissionControlConfigFile, "admission_control_config_file", s.AdmissionControlConfigFile, "File with admission control configuration.")
 	fs.Var(&s.EtcdServerList, "etcd_servers", "List of etcd servers to watch (http://ip:port), comma separated. Mutually exclusive with -etcd_config")
@@ -149,6 +196,7 @@ func (s *APIServer) AddFlags(fs *pflag.FlagSet) {
 	fs.Var(&s.CorsAllowedOriginList, "cors_allowed_origins", "List of allowed origins for CORS, comma separated.  An allowed origin can be a regular expression to support subdomain matching.  If this list is empty CORS will not be enabled.")
 	fs.BoolVar(&s.AllowPrivileged, "allow_privileged", s.AllowPrivileged, "If true, allow privileged containers.")
 	fs.Var(&s.PortalNet, "portal_net", "A CIDR notation IP range from which to assign portal IPs. This must not overlap with any IP ranges assigned to nodes for pods.")
+	fs.Var(&s.ServiceNodePorts, "service_node_port_range", "A port range to reserve for services with NodePort visibility.  Example: '30000-32767'.  Inclusive at both ends of the range.")
 	fs.StringVar(&s.MasterServiceNamespace, "master_service_namespace", s.MasterServiceNamespace, "The namespace from which the kubernetes master services should be injected into pods")
 	fs.Var(&s.RuntimeConfig, "runtime_config", "A set of key=value pairs that describe runtime configuration that may be passed to the apiserver.")
 	client.BindKubeletClientConfigFlags(fs, &s.KubeletConfig)
@@ -218,16 +266,65 @@ func (s *APIServer) Run(_ []string) error {
 
 	n := net.IPNet(s.PortalNet)
 
-	authenticator, err := apiserver.NewAuthenticatorFromTokenFile(s.TokenAuthFile)
+	// Default to the private server key for service account token signing
+	if len(s.ServiceAccountKeyFile) == 0 && len(s.TLSPrivateKeyFile) > 0 {
+		s.ServiceAccountKeyFile = s.TLSPrivateKeyFile
+	}
+	authenticator, err := apiserver.NewAuthenticator(apiserver.AuthenticatorConfig{
+		BasicAuthFile:             s.BasicAuthFile,
+		ClientCAFile:              s.ClientCAFile,
+		TokenAuthFile:             s.TokenAuthFile,
+		TokenWebhookConfigFile:    s.TokenWebhookConfigFile,
+		TokenWebhookCacheTTL:      s.TokenWebhookCacheTTL,
+		ServiceAccountKeyFile:     s.ServiceAccountKeyFile,
+		ServiceAccountLookup:      s.ServiceAccountLookup,
+		ServiceAccountTokenGetter: serviceaccount.NewGetterFromClient(client),
+		OIDCIssuerURL:             s.OIDCIssuerURL,
+		OIDCClientID:              s.OIDCClientID,
+		OIDCCAFile:                s.OIDCCAFile,
+		OIDCJWKSFile:              s.OIDCJWKSFile,
+		OIDCUsernameClaim:         s.OIDCUsernameClaim,
+		OIDCGroupsClaim:           s.OIDCGroupsClaim,
+	})
 	if err != nil {
 		glog.Fatalf("Invalid Authentication Config: %v", err)
 	}
 
-	authorizer, err := apiserver.NewAuthorizerFromAuthorizationConfig(s.AuthorizationMode, s.AuthorizationPolicyFile)
+	authorizer, err := apiserver.NewAuthorizerFromAuthorizationConfig(s.AuthorizationMode, s.AuthorizationPolicyFile, s.AuthorizationWebhookConfigFile, s.AuthorizationWebhookCacheTTL, client)
 	if err != nil {
 		glog.Fatalf("Invalid Authorization Config: %v", err)
 	}
 
+	var auditWriter io.Writer
+	auditPolicy := &apiserver.AuditPolicy{}
+	if len(s.AuditLogPath) > 0 {
+		auditWriter, err = logrotate.New(s.AuditLogPath, int64(s.AuditLogMaxSize)*1024*1024, s.AuditLogMaxBackups)
+		if err != nil {
+			glog.Fatalf("Unable to open audit log: %v", err)
+		}
+		if len(s.AuditPolicyFile) > 0 {
+			auditPolicy, err = apiserver.NewAuditPolicyFromFile(s.AuditPolicyFile)
+			if err != nil {
+				glog.Fatalf("Invalid Audit Policy: %v", err)
+			}
+		}
+	}
+
+	storageTransformers := map[string]tools.ValueTransformer{}
+	if len(s.EncryptionKeyFile) > 0 {
+		transformer, err := encryption.NewAESGCMTransformerFromFile(s.EncryptionKeyFile)
+		if err != nil {
+			glog.Fatalf("Invalid Encryption Config: %v", err)
+		}
+		resources, err := master.ParseStorageResources(s.EncryptedResources)
+		if err != nil {
+			glog.Fatalf("Invalid --encrypted_resources: %v", err)
+		}
+		for _, resource := range resources {
+			storageTransformers[resource] = transformer
+		}
+	}
+
 	admissionControlPluginNames := strings.Split(s.AdmissionControl, ",")
 	admissionController := admission.NewFromPlugins(client, admissionControlPluginNames, s.AdmissionControlConfigFile)
 
@@ -261,6 +358,7 @@ func (s *APIServer) Run(_ []string) error {
 		EventTTL:               s.EventTTL,
 		KubeletClient:          kubeletClient,
 		PortalNet:              &n,
+		ServiceNodePorts:       s.ServiceNodePorts,
 		EnableLogsSupport:      s.EnableLogsSupport,
 		EnableUISupport:        true,
 		EnableSwaggerSupport:   true,
@@ -274,6 +372,9 @@ func (s *APIServer) Run(_ []string) error {
 		Authenticator:          authenticator,
 		Authorizer:             authorizer,
 		AdmissionControl:       admissionController,
+		AuditWriter:            auditWriter,
+		AuditPolicy:            *auditPolicy,
+		StorageTransformers:    storageTransformers,
 		EnableV1Beta3:          v1beta3,
 		MasterServiceNamespace: s.MasterServiceNamespace,
 		ClusterName:            s.ClusterName,
@@ -20,6 +20,7 @@ limitations under the License.
 package app
 
 import (
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -29,15 +30,22 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/leaderelection"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/record"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/cloudprovider"
 	nodeControllerPkg "github.com/GoogleCloudPlatform/kubernetes/pkg/cloudprovider/controller"
 	replicationControllerPkg "github.com/GoogleCloudPlatform/kubernetes/pkg/controller"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/daemon"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/deployment"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/job"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/master/ports"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/namespace"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/podautoscaler"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/resourcequota"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/service"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/serviceaccount"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/volumeclaimbinder"
 
 	"github.com/golang/glog"
 	"github.com/spf13/pflag"
@@ -45,20 +53,27 @@ import (
 
 // CMServer is the main context object for the controller manager.
 type CMServer struct {
-	Port                    int
-	Address                 util.IP
-	ClientConfig            client.Config
-	CloudProvider           string
-	CloudConfigFile         string
-	MinionRegexp            string
-	NodeSyncPeriod          time.Duration
-	ResourceQuotaSyncPeriod time.Duration
-	NamespaceSyncPeriod     time.Duration
-	RegisterRetryCount      int
-	MachineList             util.StringList
-	SyncNodeList            bool
-	SyncNodeStatus          bool
-	PodEvictionTimeout      time.Duration
+	Port                              int
+	Address                           util.IP
+	ClientConfig                      client.Config
+	CloudProvider                     string
+	CloudConfigFile                   string
+	MinionRegexp                      string
+	NodeSyncPeriod                    time.Duration
+	ResourceQuotaSyncPeriod           time.Duration
+	NamespaceSyncPeriod               time.Duration
+	PVClaimBinderSyncPeriod           time.Duration
+	DeploymentSyncPeriod              time.Duration
+	JobSyncPeriod                     time.Duration
+	DaemonSetSyncPeriod               time.Duration
+	HorizontalPodAutoscalerSyncPeriod time.Duration
+	ServiceAccountSyncPeriod          time.Duration
+	ServiceAccountKeyFile             string
+	RegisterRetryCount                int
+	MachineList                       util.StringList
+	SyncNodeList                      bool
+	SyncNodeStatus                    bool
+	PodEvictionTimeout                time.Duration
 
 	// TODO: Discover these by pinging the host machines, and rip out these params.
 	NodeMilliCPU int64
@@ -66,27 +81,35 @@ type CMServer struct {
 
 	KubeletConfig   client.KubeletConfig
 	EnableProfiling bool
+	LeaderElection  leaderelection.LeaderElectionConfiguration
 }
 
 // NewCMServer creates a new CMServer with a default config.
 func NewCMServer() *CMServer {
 	s := CMServer{
-		Port:                    ports.ControllerManagerPort,
-		Address:                 util.IP(net.ParseIP("127.0.0.1")),
-		NodeSyncPeriod:          10 * time.Second,
-		ResourceQuotaSyncPeriod: 10 * time.Second,
-		NamespaceSyncPeriod:     1 * time.Minute,
-		RegisterRetryCount:      10,
-		PodEvictionTimeout:      5 * time.Minute,
-		NodeMilliCPU:            1000,
-		NodeMemory:              resource.MustParse("3Gi"),
-		SyncNodeList:            true,
-		SyncNodeStatus:          false,
+		Port:                              ports.ControllerManagerPort,
+		Address:                           util.IP(net.ParseIP("127.0.0.1")),
+		NodeSyncPeriod:                    10 * time.Second,
+		ResourceQuotaSyncPeriod:           10 * time.Second,
+		NamespaceSyncPeriod:               1 * time.Minute,
+		PVClaimBinderSyncPeriod:           10 * time.Second,
+		DeploymentSyncPeriod:              10 * time.Second,
+		JobSyncPeriod:                     10 * time.Second,
+		DaemonSetSyncPeriod:               30 * time.Second,
+		HorizontalPodAutoscalerSyncPeriod: 30 * time.Second,
+		ServiceAccountSyncPeriod:          30 * time.Second,
+		RegisterRetryCount:                10,
+		PodEvictionTimeout:                5 * time.Minute,
+		NodeMilliCPU:                      1000,
+		NodeMemory:                        resource.MustParse("3Gi"),
+		SyncNodeList:                      true,
+		SyncNodeStatus:                    false,
 		KubeletConfig: client.KubeletConfig{
 			Port:        ports.KubeletPort,
 			EnableHttps: false,
 			HTTPTimeout: time.Duration(5) * time.Second,
 		},
+		LeaderElection: leaderelection.DefaultLeaderElectionConfiguration(),
 	}
 	return &s
 }
@@ -102,8 +125,15 @@ func (s *CMServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&s.NodeSyncPeriod, "node_sync_period", s.NodeSyncPeriod, ""+
 		"The period for syncing nodes from cloudprovider. Longer periods will result in "+
 		"fewer calls to cloud provider, but may delay addition of new nodes to cluster.")
-	fs.DurationVar(&s.ResourceQuotaSyncPeriod, "resource_quota_sync_period", s.ResourceQuotaSyncPeriod, "The period for syncing quota usage status in the system")
+	fs.DurationVar(&s.ResourceQuotaSyncPeriod, "resource_quota_sync_period", s.ResourceQuotaSyncPeriod, "The period for recomputing the usage of every quota.  Usage is also recomputed whenever objects in a namespace change")
 	fs.DurationVar(&s.NamespaceSyncPeriod, "namespace_sync_period", s.NamespaceSyncPeriod, "The period for syncing namespace life-cycle updates")
+	fs.DurationVar(&s.PVClaimBinderSyncPeriod, "pvclaimbinder_sync_period", s.PVClaimBinderSyncPeriod, "The period for syncing persistent volumes and persistent volume claims")
+	fs.DurationVar(&s.DeploymentSyncPeriod, "deployment_sync_period", s.DeploymentSyncPeriod, "The period for syncing deployments. Each sync advances a rollout by one step")
+	fs.DurationVar(&s.JobSyncPeriod, "job_sync_period", s.JobSyncPeriod, "The period for syncing jobs with their pods")
+	fs.DurationVar(&s.DaemonSetSyncPeriod, "daemonset_sync_period", s.DaemonSetSyncPeriod, "The period for syncing daemon sets with their pods. Changes to nodes trigger a sync immediately")
+	fs.DurationVar(&s.HorizontalPodAutoscalerSyncPeriod, "horizontal_pod_autoscaler_sync_period", s.HorizontalPodAutoscalerSyncPeriod, "The period for syncing the number of pods in horizontal pod autoscaler")
+	fs.DurationVar(&s.ServiceAccountSyncPeriod, "service_account_sync_period", s.ServiceAccountSyncPeriod, "The period for syncing service accounts and their API tokens")
+	fs.StringVar(&s.ServiceAccountKeyFile, "service_account_private_key_file", s.ServiceAccountKeyFile, "Filename containing a PEM-encoded private RSA key used to sign service account tokens. If unset, no API tokens are generated.")
 	fs.DurationVar(&s.PodEvictionTimeout, "pod_eviction_timeout", s.PodEvictionTimeout, "The grace peroid for deleting pods on failed nodes.")
 	fs.IntVar(&s.RegisterRetryCount, "register_retry_count", s.RegisterRetryCount, ""+
 		"The number of retries for initial node registration.  Retry interval equals node_sync_period.")
@@ -118,6 +148,7 @@ func (s *CMServer) AddFlags(fs *pflag.FlagSet) {
 	fs.Var(resource.NewQuantityFlagValue(&s.NodeMemory), "node_memory", "The amount of memory (in bytes) provisioned on each node")
 	client.BindKubeletClientConfigFlags(fs, &s.KubeletConfig)
 	fs.BoolVar(&s.EnableProfiling, "profiling", false, "Enable profiling via web interface host:port/debug/pprof/")
+	leaderelection.BindFlags(&s.LeaderElection, fs)
 }
 
 func (s *CMServer) verifyMinionFlags() {
@@ -158,6 +189,39 @@ func (s *CMServer) Run(_ []string) error {
 		http.ListenAndServe(net.JoinHostPort(s.Address.String(), strconv.Itoa(s.Port)), nil)
 	}()
 
+	if !s.LeaderElection.LeaderElect {
+		s.runControllers(kubeClient)
+		select {}
+	}
+
+	le, err := leaderelection.NewLeaderElector(leaderelection.Config{
+		Client:        kubeClient,
+		Namespace:     api.NamespaceDefault,
+		Name:          "kube-controller-manager",
+		Identity:      leaderelection.DefaultIdentity(),
+		LeaseDuration: s.LeaderElection.LeaseDuration,
+		RenewDeadline: s.LeaderElection.RenewDeadline,
+		RetryPeriod:   s.LeaderElection.RetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(_ <-chan struct{}) {
+				s.runControllers(kubeClient)
+			},
+			OnStoppedLeading: func() {
+				glog.Errorf("Lost leader election lease")
+			},
+		},
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create leader elector: %v", err)
+	}
+	// Run returns once the lease is lost, the controllers must not keep running.
+	le.Run()
+	return fmt.Errorf("lost leader election lease, exiting")
+}
+
+// runControllers starts all control loops.  The loops run in the background
+// until the process exits.
+func (s *CMServer) runControllers(kubeClient *client.Client) {
 	endpoints := service.NewEndpointController(kubeClient)
 	go util.Forever(func() { endpoints.SyncServiceEndpoints() }, time.Second*10)
 
@@ -188,6 +252,39 @@ func (s *CMServer) Run(_ []string) error {
 	namespaceManager := namespace.NewNamespaceManager(kubeClient)
 	namespaceManager.Run(s.NamespaceSyncPeriod)
 
-	select {}
-	return nil
+	pvclaimBinder, err := volumeclaimbinder.NewPersistentVolumeClaimBinder(kubeClient, ProbePersistentVolumePlugins())
+	if err != nil {
+		glog.Fatalf("Failure to start persistent volume claim binder: %v", err)
+	}
+	pvclaimBinder.Run(s.PVClaimBinderSyncPeriod)
+
+	deploymentController := deployment.NewDeploymentController(kubeClient)
+	deploymentController.Run(s.DeploymentSyncPeriod)
+
+	jobController := job.NewJobController(kubeClient)
+	jobController.Run(s.JobSyncPeriod)
+
+	daemonSetController := daemon.NewDaemonSetController(kubeClient)
+	daemonSetController.Run(s.DaemonSetSyncPeriod)
+
+	containerInfoGetter := &client.HTTPContainerInfoGetter{
+		Client: &http.Client{Timeout: s.KubeletConfig.HTTPTimeout},
+		Port:   int(s.KubeletConfig.Port),
+	}
+	metricsClient := podautoscaler.NewCadvisorMetricsClient(kubeClient, containerInfoGetter)
+	horizontalController := podautoscaler.NewHorizontalController(kubeClient, metricsClient)
+	horizontalController.Run(s.HorizontalPodAutoscalerSyncPeriod)
+
+	serviceAccountController := serviceaccount.NewServiceAccountsController(kubeClient)
+	serviceAccountController.Run(s.ServiceAccountSyncPeriod)
+
+	if len(s.ServiceAccountKeyFile) > 0 {
+		privateKey, err := serviceaccount.ReadPrivateKey(s.ServiceAccountKeyFile)
+		if err != nil {
+			glog.Errorf("Error reading key for service account token controller: %v", err)
+		} else {
+			tokensController := serviceaccount.NewTokensController(kubeClient, serviceaccount.JWTTokenGenerator(privateKey))
+			tokensController.Run(s.ServiceAccountSyncPeriod)
+		}
+	}
 }
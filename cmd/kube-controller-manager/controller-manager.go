@@ -49,7 +49,7 @@ func main() {
 	verflag.PrintAndExitIfRequested()
 
 	if err := s.Run(pflag.CommandLine.Args()); err != nil {
-		fmt.Fprint(os.Stderr, err.Error)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
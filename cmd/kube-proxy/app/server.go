@@ -28,6 +28,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/proxy"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/proxy/config"
+	iptablesproxy "github.com/GoogleCloudPlatform/kubernetes/pkg/proxy/iptables"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/exec"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/iptables"
@@ -43,8 +44,15 @@ type ProxyServer struct {
 	HealthzPort        int
 	HealthzBindAddress util.IP
 	OOMScoreAdj        int
+	ProxyMode          string
 }
 
+// The modes ProxyServer can run in.
+const (
+	proxyModeUserspace = "userspace"
+	proxyModeIptables  = "iptables"
+)
+
 // NewProxyServer creates a new ProxyServer object with default parameters
 func NewProxyServer() *ProxyServer {
 	return &ProxyServer{
@@ -52,6 +60,7 @@ func NewProxyServer() *ProxyServer {
 		HealthzPort:        10249,
 		HealthzBindAddress: util.IP(net.ParseIP("127.0.0.1")),
 		OOMScoreAdj:        -899,
+		ProxyMode:          proxyModeUserspace,
 	}
 }
 
@@ -62,6 +71,7 @@ func (s *ProxyServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.HealthzPort, "healthz_port", s.HealthzPort, "The port to bind the health check server. Use 0 to disable.")
 	fs.Var(&s.HealthzBindAddress, "healthz_bind_address", "The IP address for the health check server to serve on, defaulting to 127.0.0.1 (set to 0.0.0.0 for all interfaces)")
 	fs.IntVar(&s.OOMScoreAdj, "oom_score_adj", s.OOMScoreAdj, "The oom_score_adj value for kube-proxy process. Values must be within the range [-1000, 1000]")
+	fs.StringVar(&s.ProxyMode, "proxy_mode", s.ProxyMode, "Which proxy mode to use: 'userspace' (copies traffic through the proxy process) or 'iptables' (DNATs traffic directly to endpoints with iptables rules)")
 }
 
 // Run runs the specified ProxyServer.  This should never exit.
@@ -77,16 +87,35 @@ func (s *ProxyServer) Run(_ []string) error {
 	if net.IP(s.BindAddress).To4() == nil {
 		protocol = iptables.ProtocolIpv6
 	}
-	loadBalancer := proxy.NewLoadBalancerRR()
-	proxier := proxy.NewProxier(loadBalancer, net.IP(s.BindAddress), iptables.New(exec.New(), protocol))
-	if proxier == nil {
-		glog.Fatalf("failed to create proxier, aborting")
-	}
+	ipt := iptables.New(exec.New(), protocol)
+
+	var syncLoop func()
+	switch s.ProxyMode {
+	case proxyModeUserspace:
+		loadBalancer := proxy.NewLoadBalancerRR()
+		proxier := proxy.NewProxier(loadBalancer, net.IP(s.BindAddress), ipt)
+		if proxier == nil {
+			glog.Fatalf("failed to create proxier, aborting")
+		}
 
-	// Wire proxier to handle changes to services
-	serviceConfig.RegisterHandler(proxier)
-	// And wire loadBalancer to handle changes to endpoints to services
-	endpointsConfig.RegisterHandler(loadBalancer)
+		// Wire proxier to handle changes to services
+		serviceConfig.RegisterHandler(proxier)
+		// And wire loadBalancer to handle changes to endpoints to services
+		endpointsConfig.RegisterHandler(loadBalancer)
+		syncLoop = proxier.SyncLoop
+	case proxyModeIptables:
+		proxier, err := iptablesproxy.NewProxier(ipt, 30*time.Second)
+		if err != nil {
+			glog.Fatalf("failed to create proxier, aborting: %v", err)
+		}
+
+		// Wire proxier to handle changes to both services and endpoints
+		serviceConfig.RegisterHandler(config.ServiceConfigHandlerFunc(proxier.OnServiceUpdate))
+		endpointsConfig.RegisterHandler(config.EndpointsConfigHandlerFunc(proxier.OnEndpointsUpdate))
+		syncLoop = proxier.SyncLoop
+	default:
+		glog.Fatalf("unknown proxy mode %q", s.ProxyMode)
+	}
 
 	// Note: RegisterHandler() calls need to happen before creation of Sources because sources
 	// only notify on changes, and the initial update (on process start) may be lost if no handlers
@@ -118,6 +147,6 @@ func (s *ProxyServer) Run(_ []string) error {
 	}
 
 	// Just loop forever for now...
-	proxier.SyncLoop()
+	syncLoop()
 	return nil
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kube-reencrypt rewrites the values stored in etcd for a set of resources
+// with the current key from an encryption key file.  Run it after adding a new
+// key to the top of the file given to kube-apiserver with
+// --encryption_key_file, and after restarting the apiservers, so that the
+// old key can be removed.
+package main
+
+import (
+	"path"
+	"runtime"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/master"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/encryption"
+
+	"github.com/coreos/go-etcd/etcd"
+	"github.com/golang/glog"
+	flag "github.com/spf13/pflag"
+)
+
+var (
+	etcdServerList util.StringList
+	etcdConfigFile = flag.String("etcd_config", "", "The config file for the etcd client. Mutually exclusive with --etcd_servers.")
+	keyFile        = flag.String("encryption_key_file", "", "The encryption key file given to kube-apiserver. Values are re-encrypted with the first key in the file.")
+	resources      = flag.String("resources", "secrets", "Comma-delimited list of resources to re-encrypt, named as in the apiserver's --encrypted_resources.")
+	prefix         = flag.String("etcd_prefix", "/registry", "The etcd key under which the apiserver stores resources.")
+)
+
+func init() {
+	flag.Var(&etcdServerList, "etcd_servers", "List of etcd servers to connect to (http://ip:port), comma separated. Mutually exclusive with --etcd_config.")
+}
+
+func main() {
+	runtime.GOMAXPROCS(runtime.NumCPU())
+	util.InitFlags()
+	util.InitLogs()
+	defer util.FlushLogs()
+
+	if (*etcdConfigFile != "" && len(etcdServerList) != 0) || (*etcdConfigFile == "" && len(etcdServerList) == 0) {
+		glog.Fatalf("specify either --etcd_servers or --etcd_config")
+	}
+	if len(*keyFile) == 0 {
+		glog.Fatalf("--encryption_key_file is required")
+	}
+
+	names, err := master.ParseStorageResources(*resources)
+	if err != nil {
+		glog.Fatalf("Invalid --resources: %v", err)
+	}
+
+	transformer, err := encryption.NewAESGCMTransformerFromFile(*keyFile)
+	if err != nil {
+		glog.Fatalf("Invalid encryption key file: %v", err)
+	}
+
+	var client tools.EtcdGetSet
+	if *etcdConfigFile != "" {
+		client, err = etcd.NewClientFromFile(*etcdConfigFile)
+		if err != nil {
+			glog.Fatalf("Unable to create etcd client: %v", err)
+		}
+	} else {
+		client = etcd.NewClient(etcdServerList)
+	}
+	helper := tools.NewEtcdHelper(client, latest.Codec)
+	helper.Transformer = transformer
+
+	for _, resource := range names {
+		key := path.Join(*prefix, master.StorageResourceKey(resource))
+		count, err := helper.RewriteList(key)
+		if err != nil {
+			glog.Fatalf("Failed to re-encrypt %s after %d values: %v", key, count, err)
+		}
+		glog.Infof("Re-encrypted %d values under %s", count, key)
+	}
+}
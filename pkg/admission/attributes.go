@@ -17,6 +17,7 @@ limitations under the License.
 package admission
 
 import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 )
 
@@ -25,14 +26,16 @@ type attributesRecord struct {
 	resource  string
 	operation string
 	object    runtime.Object
+	userInfo  user.Info
 }
 
-func NewAttributesRecord(object runtime.Object, namespace, resource, operation string) Attributes {
+func NewAttributesRecord(object runtime.Object, namespace, resource, operation string, userInfo user.Info) Attributes {
 	return &attributesRecord{
 		namespace: namespace,
 		resource:  resource,
 		operation: operation,
 		object:    object,
+		userInfo:  userInfo,
 	}
 }
 
@@ -51,3 +54,7 @@ func (record *attributesRecord) GetOperation() string {
 func (record *attributesRecord) GetObject() runtime.Object {
 	return record.object
 }
+
+func (record *attributesRecord) GetUserInfo() user.Info {
+	return record.userInfo
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package admission
 
 import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 )
 
@@ -27,6 +28,8 @@ type Attributes interface {
 	GetResource() string
 	GetOperation() string
 	GetObject() runtime.Object
+	// GetUserInfo returns the user making the request, or nil if it is not known.
+	GetUserInfo() user.Info
 }
 
 // Interface is an abstract, pluggable interface for Admission Control decisions.
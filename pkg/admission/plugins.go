@@ -78,10 +78,9 @@ func GetPlugin(name string, client client.Interface, config io.Reader) (Interfac
 
 // InitPlugin creates an instance of the named interface.
 func InitPlugin(name string, client client.Interface, configFilePath string) Interface {
-	var (
-		config *os.File
-		err    error
-	)
+	// config must stay a nil interface when there is no file, so that
+	// plugins can tell that no configuration was given.
+	var config io.Reader
 
 	if name == "" {
 		glog.Info("No admission plugin specified.")
@@ -89,13 +88,14 @@ func InitPlugin(name string, client client.Interface, configFilePath string) Int
 	}
 
 	if configFilePath != "" {
-		config, err = os.Open(configFilePath)
+		file, err := os.Open(configFilePath)
 		if err != nil {
 			glog.Fatalf("Couldn't open admission plugin configuration %s: %#v",
 				configFilePath, err)
 		}
 
-		defer config.Close()
+		defer file.Close()
+		config = file
 	}
 
 	plugin, err := GetPlugin(name, client, config)
@@ -97,9 +97,15 @@ func (set addressSet) Insert(addr *api.EndpointAddress) {
 
 func hashAddresses(addrs addressSet) string {
 	// Flatten the list of addresses into a string so it can be used as a
-	// map key.
+	// map key.  DeepHashObject can not order pointer map keys, so the set
+	// is collapsed into a sorted slice before hashing.
+	slice := []api.EndpointAddress{}
+	for k := range addrs {
+		slice = append(slice, *k)
+	}
+	sort.Sort(addrsByIP(slice))
 	hasher := md5.New()
-	util.DeepHashObject(hasher, addrs)
+	util.DeepHashObject(hasher, slice)
 	return hex.EncodeToString(hasher.Sum(nil)[0:])
 }
 
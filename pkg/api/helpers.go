@@ -80,12 +80,47 @@ var standardResources = util.NewStringSet(
 	string(ResourceQuotas),
 	string(ResourceServices),
 	string(ResourceReplicationControllers),
+	string(ResourceSecrets),
+	string(ResourcePersistentVolumeClaims),
+	string(ResourceRequestsStorage),
 	string(ResourceStorage))
 
 func IsStandardResourceName(str string) bool {
 	return standardResources.Has(str)
 }
 
+// countableResources are the namespaced resources whose objects a quota can
+// count with a ResourceCountPrefix name, e.g. "count/deployments".  The quota
+// controller must know how to list and watch every one of them.
+var countableResources = util.NewStringSet(
+	"pods",
+	"services",
+	"replicationcontrollers",
+	"resourcequotas",
+	"secrets",
+	"persistentvolumeclaims",
+	"endpoints",
+	"limitranges",
+	"serviceaccounts",
+	"deployments",
+	"jobs",
+	"daemonsets",
+	"horizontalpodautoscalers",
+	"roles",
+	"rolebindings")
+
+// IsCountableResourceName returns true if objects of the resource, named by
+// the lowercase plural used in API paths, can be counted by a quota.
+func IsCountableResourceName(str string) bool {
+	return countableResources.Has(str)
+}
+
+// CountableResourceNames returns the resources accepted by
+// IsCountableResourceName, sorted.
+func CountableResourceNames() []string {
+	return countableResources.List()
+}
+
 // NewDeleteOptions returns a DeleteOptions indicating the resource should
 // be deleted within the specified grace period. Use zero to indicate
 // immediate deletion. If you would prefer to use the default grace period,
@@ -78,6 +78,8 @@ func TestIsStandardResource(t *testing.T) {
 		{"disk", false},
 		{"blah", false},
 		{"x.y.z", false},
+		{"secrets", true},
+		{"requests.storage", true},
 	}
 	for i, tc := range testCases {
 		if IsStandardResourceName(tc.input) != tc.output {
@@ -118,10 +118,12 @@ func init() {
 	// the list of kinds that are scoped at the root of the api hierarchy
 	// if a kind is not enumerated here, it is assumed to have a namespace scope
 	kindToRootScope := map[string]bool{
-		"Node":             true,
-		"Minion":           true,
-		"Namespace":        true,
-		"PersistentVolume": true,
+		"Node":               true,
+		"Minion":             true,
+		"Namespace":          true,
+		"PersistentVolume":   true,
+		"ClusterRole":        true,
+		"ClusterRoleBinding": true,
 	}
 
 	// these kinds should be excluded from the list of resources
@@ -173,7 +173,7 @@ func FuzzerFor(t *testing.T, version string, src rand.Source) *fuzz.Fuzzer {
 		func(vs *api.VolumeSource, c fuzz.Continue) {
 			// Exactly one of the fields should be set.
 			//FIXME: the fuzz can still end up nil.  What if fuzz allowed me to say that?
-			fuzzOneOf(c, &vs.HostPath, &vs.EmptyDir, &vs.GCEPersistentDisk, &vs.GitRepo, &vs.Secret, &vs.NFS)
+			fuzzOneOf(c, &vs.HostPath, &vs.EmptyDir, &vs.GCEPersistentDisk, &vs.GitRepo, &vs.Secret, &vs.NFS, &vs.PersistentVolumeClaimVolumeSource)
 		},
 		func(d *api.DNSPolicy, c fuzz.Continue) {
 			policies := []api.DNSPolicy{api.DNSClusterFirst, api.DNSDefault}
@@ -216,11 +216,49 @@ func FuzzerFor(t *testing.T, version string, src rand.Source) *fuzz.Fuzzer {
 		},
 		func(ss *api.ServiceSpec, c fuzz.Continue) {
 			c.FuzzNoCustom(ss) // fuzz self without calling this function again
-			switch ss.TargetPort.Kind {
+			types := []api.ServiceType{api.ServiceTypeClusterIP, api.ServiceTypeNodePort}
+			ss.Type = types[c.Rand.Intn(len(types))]
+		},
+		func(sp *api.ServicePort, c fuzz.Continue) {
+			c.FuzzNoCustom(sp) // fuzz self without calling this function again
+			// Protocol must be non-empty.
+			sp.Protocol = api.Protocol("x" + string(sp.Protocol))
+			switch sp.TargetPort.Kind {
 			case util.IntstrInt:
-				ss.TargetPort.IntVal = 1 + ss.TargetPort.IntVal%65535 // non-zero
+				sp.TargetPort.IntVal = 1 + sp.TargetPort.IntVal%65535 // non-zero
 			case util.IntstrString:
-				ss.TargetPort.StrVal = "x" + ss.TargetPort.StrVal // non-empty
+				sp.TargetPort.StrVal = "x" + sp.TargetPort.StrVal // non-empty
+			}
+		},
+		func(j *api.JobSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(j) // fuzz self without calling this function again
+			// Parallelism, completions and the selector are defaulted.
+			parallelism := c.Rand.Intn(10)
+			completions := c.Rand.Intn(10)
+			j.Parallelism = &parallelism
+			j.Completions = &completions
+			j.Selector = map[string]string{c.RandString(): c.RandString()}
+		},
+		func(d *api.DaemonSetSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(d) // fuzz self without calling this function again
+			// The selector is defaulted.
+			d.Selector = map[string]string{c.RandString(): c.RandString()}
+		},
+		func(s *api.HorizontalPodAutoscalerSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(s) // fuzz self without calling this function again
+			// The minimum replicas and the target utilization are defaulted.
+			minReplicas := c.Rand.Intn(10)
+			s.MinReplicas = &minReplicas
+			s.CPUUtilization = &api.CPUTargetUtilization{TargetPercentage: 1 + c.Rand.Intn(100)}
+		},
+		func(ds *api.DeploymentStrategy, c fuzz.Continue) {
+			c.FuzzNoCustom(ds) // fuzz self without calling this function again
+			// Type and the rolling update parameters are defaulted.
+			types := []api.DeploymentStrategyType{api.RecreateDeploymentStrategyType, api.RollingUpdateDeploymentStrategyType}
+			ds.Type = types[c.Rand.Intn(len(types))]
+			if ds.Type == api.RollingUpdateDeploymentStrategyType && ds.RollingUpdate == nil {
+				ds.RollingUpdate = &api.RollingUpdateDeployment{}
+				c.Fuzz(ds.RollingUpdate)
 			}
 		},
 		func(n *api.Node, c fuzz.Continue) {
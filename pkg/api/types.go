@@ -195,6 +195,8 @@ type VolumeSource struct {
 	Secret *SecretVolumeSource `json:"secret"`
 	// NFS represents an NFS mount on the host that shares a pod's lifetime
 	NFS *NFSVolumeSource `json:"nfs"`
+	// PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace
+	PersistentVolumeClaimVolumeSource *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
 }
 
 // Similar to VolumeSource but meant for the administrator who creates PVs.
@@ -302,6 +304,16 @@ const (
 	VolumeReleased PersistentVolumePhase = "Released"
 )
 
+// PersistentVolumeClaimVolumeSource references the user's PersistentVolumeClaim
+// in the same namespace.  The volume bound to the claim is mounted in the pod.
+type PersistentVolumeClaimVolumeSource struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume
+	ClaimName string `json:"claimName,omitempty"`
+	// Optional: Defaults to false (read/write).  ReadOnly here
+	// will force the ReadOnly setting in VolumeMounts
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
 type PersistentVolumeClaimPhase string
 
 const (
@@ -490,6 +502,32 @@ type Capabilities struct {
 	Drop []CapabilityType `json:"drop,omitempty"`
 }
 
+// SecurityContext holds security configuration that is applied to a container.
+// Fields set on a container override those set on its pod.
+type SecurityContext struct {
+	// Optional: The UID to run the container process as.  Defaults to the
+	// user specified in the image.
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// Optional: If true, the container must run as a user other than root.
+	RunAsNonRoot bool `json:"runAsNonRoot,omitempty"`
+	// Optional: If true, the container's root filesystem is mounted read-only.
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+	// Optional: The SELinux context to apply to the container.
+	SELinuxOptions *SELinuxOptions `json:"seLinuxOptions,omitempty"`
+}
+
+// SELinuxOptions are the labels applied to a container.
+type SELinuxOptions struct {
+	// SELinux user label
+	User string `json:"user,omitempty"`
+	// SELinux role label
+	Role string `json:"role,omitempty"`
+	// SELinux type label
+	Type string `json:"type,omitempty"`
+	// SELinux level label
+	Level string `json:"level,omitempty"`
+}
+
 // ResourceRequirements describes the compute resource requirements.
 type ResourceRequirements struct {
 	// Limits describes the maximum amount of compute resources required.
@@ -525,6 +563,12 @@ type Container struct {
 	ImagePullPolicy PullPolicy `json:"imagePullPolicy"`
 	// Optional: Capabilities for container.
 	Capabilities Capabilities `json:"capabilities,omitempty"`
+	// Optional: Security options for the container.
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+	// Optional: Default to false.  Keeps stdin open, so that it can be attached to.
+	Stdin bool `json:"stdin,omitempty"`
+	// Optional: Default to false.  Allocates a TTY for the container; requires Stdin.
+	TTY bool `json:"tty,omitempty"`
 }
 
 // Handler defines a specific action that should be taken
@@ -711,6 +755,13 @@ type PodSpec struct {
 	// used must be specified.
 	// Optional: Default to false.
 	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// ServiceAccount is the name of the ServiceAccount to use to run this pod.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// SecurityContext holds pod-level security options.  They apply to every
+	// container, unless overridden by the container's SecurityContext.
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
 }
 
 // PodStatus represents information about the status of a pod. Status may trail the actual
@@ -833,6 +884,318 @@ type ReplicationControllerList struct {
 	Items []ReplicationController `json:"items"`
 }
 
+// DeploymentSpec is the specification of the desired behavior of a Deployment.
+type DeploymentSpec struct {
+	// Replicas is the number of desired pods.
+	Replicas int `json:"replicas"`
+
+	// Selector is a label query over pods that are managed by this deployment.
+	// The replication controllers owned by the deployment are found through it.
+	Selector map[string]string `json:"selector"`
+
+	// Template describes the pods that will be created.
+	Template *PodTemplateSpec `json:"template,omitempty"`
+
+	// Strategy is the deployment strategy used to replace existing pods with new ones.
+	Strategy DeploymentStrategy `json:"strategy,omitempty"`
+
+	// RevisionHistoryLimit is the number of old replication controllers to
+	// retain to allow rollback.  If nil, all of them are kept.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+
+	// Paused indicates that the deployment is paused and that the deployment
+	// controller will not roll it out.
+	Paused bool `json:"paused,omitempty"`
+
+	// RollbackTo is the revision this deployment is rolling back to.  It is
+	// cleared by the deployment controller once the rollback has been started.
+	RollbackTo *RollbackConfig `json:"rollbackTo,omitempty"`
+}
+
+// RollbackConfig names the revision of a deployment to roll back to.
+type RollbackConfig struct {
+	// Revision is the revision to roll back to.  If 0, the deployment rolls
+	// back to the last revision before the current one.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// DeploymentStrategy describes how to replace existing pods with new ones.
+type DeploymentStrategy struct {
+	// Type of deployment.  Can be "Recreate" or "RollingUpdate".
+	Type DeploymentStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate holds the parameters of a rolling update.  It is only set
+	// when Type is RollingUpdate.
+	RollingUpdate *RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+}
+
+type DeploymentStrategyType string
+
+const (
+	// RecreateDeploymentStrategyType kills all existing pods before creating new ones.
+	RecreateDeploymentStrategyType DeploymentStrategyType = "Recreate"
+
+	// RollingUpdateDeploymentStrategyType replaces the old replication
+	// controller by the new one gradually, scaling down the old one and
+	// scaling up the new one.
+	RollingUpdateDeploymentStrategyType DeploymentStrategyType = "RollingUpdate"
+)
+
+// RollingUpdateDeployment bounds the progress of a rolling update.
+type RollingUpdateDeployment struct {
+	// MaxUnavailable is the maximum number of pods that can be unavailable
+	// during the update.  It is either an absolute number or a percentage of
+	// the desired pods, like "10%".  Percentages are rounded down.
+	MaxUnavailable util.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the maximum number of pods that can be scheduled above the
+	// desired number of pods.  It is either an absolute number or a
+	// percentage of the desired pods, like "10%".  Percentages are rounded up.
+	MaxSurge util.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// DeploymentStatus is the most recently observed status of a Deployment.
+type DeploymentStatus struct {
+	// Replicas is the total number of pods targeted by this deployment.
+	Replicas int `json:"replicas,omitempty"`
+
+	// UpdatedReplicas is the number of pods that have the desired template.
+	UpdatedReplicas int `json:"updatedReplicas,omitempty"`
+
+	// Revision is the revision of the replication controller that holds
+	// the desired template.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// Deployment enables declarative, server-side updates of pods through
+// replication controllers.
+type Deployment struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired behavior of this deployment.
+	Spec DeploymentSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of this deployment.
+	Status DeploymentStatus `json:"status,omitempty"`
+}
+
+// DeploymentList is a collection of deployments.
+type DeploymentList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []Deployment `json:"items"`
+}
+
+// JobSpec describes how a job execution will look.
+type JobSpec struct {
+	// Parallelism is the maximum number of pods the job should run at any
+	// given time.
+	Parallelism *int `json:"parallelism,omitempty"`
+
+	// Completions is the number of successfully finished pods the job
+	// should run to.
+	Completions *int `json:"completions,omitempty"`
+
+	// Selector is a label query over pods that are managed by this job.
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// Template describes the pods that will be created.  Its RestartPolicy
+	// must be OnFailure or Never.
+	Template *PodTemplateSpec `json:"template,omitempty"`
+}
+
+// JobStatus represents the current state of a Job.
+type JobStatus struct {
+	// Conditions represent the latest available observations of the job's state.
+	Conditions []JobCondition `json:"conditions,omitempty"`
+
+	// StartTime is the time the job was first observed by the job controller.
+	StartTime *util.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the job was observed to be complete.
+	CompletionTime *util.Time `json:"completionTime,omitempty"`
+
+	// Active is the number of pending and running pods.
+	Active int `json:"active,omitempty"`
+
+	// Succeeded is the number of pods which reached phase Succeeded.
+	Succeeded int `json:"succeeded,omitempty"`
+
+	// Failed is the number of pods which reached phase Failed.
+	Failed int `json:"failed,omitempty"`
+}
+
+type JobConditionType string
+
+const (
+	// JobComplete means the job has reached its completions target.
+	JobComplete JobConditionType = "Complete"
+)
+
+// JobCondition describes the current state of a job.
+type JobCondition struct {
+	// Type of the job condition, currently only Complete.
+	Type JobConditionType `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status ConditionStatus `json:"status"`
+
+	// LastProbeTime is the last time the condition was checked.
+	LastProbeTime util.Time `json:"lastProbeTime,omitempty"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime util.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message indicating details about the last transition.
+	Message string `json:"message,omitempty"`
+}
+
+// Job represents the configuration of a single job, which runs pods to completion.
+type Job struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired behavior of this job.
+	Spec JobSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of this job.
+	Status JobStatus `json:"status,omitempty"`
+}
+
+// JobList is a collection of jobs.
+type JobList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []Job `json:"items"`
+}
+
+// DaemonSetSpec is the specification of a daemon set.
+type DaemonSetSpec struct {
+	// Selector is a label query over the pods that are managed by the daemon set.
+	// Defaults to the labels on the pod template.
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// Template describes the pod that will be run on every node that matches
+	// the node selector of the template. Nodes are matched through
+	// Template.Spec.NodeSelector; an empty node selector matches every node.
+	Template *PodTemplateSpec `json:"template,omitempty"`
+}
+
+// DaemonSetStatus represents the current status of a daemon set.
+type DaemonSetStatus struct {
+	// CurrentNumberScheduled is the number of nodes that are running a daemon pod
+	// and are supposed to run it.
+	CurrentNumberScheduled int `json:"currentNumberScheduled"`
+
+	// NumberMisscheduled is the number of nodes that are running a daemon pod
+	// but are not supposed to run it.
+	NumberMisscheduled int `json:"numberMisscheduled"`
+
+	// DesiredNumberScheduled is the number of nodes that should be running a daemon pod.
+	DesiredNumberScheduled int `json:"desiredNumberScheduled"`
+}
+
+// DaemonSet represents the configuration of a daemon set, which runs one pod on
+// every node that matches its template's node selector.
+type DaemonSet struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired behavior of this daemon set.
+	Spec DaemonSetSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of this daemon set.
+	Status DaemonSetStatus `json:"status,omitempty"`
+}
+
+// DaemonSetList is a collection of daemon sets.
+type DaemonSetList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []DaemonSet `json:"items"`
+}
+
+// CPUTargetUtilization is the target average CPU utilization of the pods of
+// an autoscaled replication controller.
+type CPUTargetUtilization struct {
+	// TargetPercentage is the target average CPU utilization of all pods, as a
+	// percentage of the CPU requested by their containers.
+	TargetPercentage int `json:"targetPercentage"`
+}
+
+// HorizontalPodAutoscalerSpec is the specification of a horizontal pod autoscaler.
+type HorizontalPodAutoscalerSpec struct {
+	// ScaleRef is a reference to the replication controller that is scaled.
+	ScaleRef ObjectReference `json:"scaleRef"`
+
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	MinReplicas *int `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas. It cannot be
+	// smaller than MinReplicas.
+	MaxReplicas int `json:"maxReplicas"`
+
+	// CPUUtilization is the target average CPU utilization over all pods.
+	// Defaults to 80 percent.
+	CPUUtilization *CPUTargetUtilization `json:"cpuUtilization,omitempty"`
+}
+
+// HorizontalPodAutoscalerStatus is the current status of a horizontal pod autoscaler.
+type HorizontalPodAutoscalerStatus struct {
+	// CurrentReplicas is the number of replicas last seen by the autoscaler.
+	CurrentReplicas int `json:"currentReplicas"`
+
+	// DesiredReplicas is the number of replicas last computed by the autoscaler.
+	DesiredReplicas int `json:"desiredReplicas"`
+
+	// CurrentCPUUtilizationPercentage is the average CPU utilization over all
+	// pods, as a percentage of the requested CPU, or nil if it is unknown.
+	CurrentCPUUtilizationPercentage *int `json:"currentCPUUtilizationPercentage,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the number of replicas.
+	LastScaleTime *util.Time `json:"lastScaleTime,omitempty"`
+}
+
+// HorizontalPodAutoscaler represents the configuration of an autoscaler,
+// which adjusts the replica count of a replication controller to the CPU
+// utilization of its pods.
+type HorizontalPodAutoscaler struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the behavior of this autoscaler.
+	Spec HorizontalPodAutoscalerSpec `json:"spec,omitempty"`
+
+	// Status is the most recently observed status of this autoscaler.
+	Status HorizontalPodAutoscalerStatus `json:"status,omitempty"`
+}
+
+// HorizontalPodAutoscalerList is a collection of horizontal pod autoscalers.
+type HorizontalPodAutoscalerList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []HorizontalPodAutoscaler `json:"items"`
+}
+
+const (
+	// DeploymentRevisionAnnotation is set on the replication controllers of
+	// a deployment to record the revision of their template.
+	DeploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// DeploymentPodTemplateHashLabel is added to the selector and pod
+	// template of the replication controllers of a deployment, so that
+	// controllers holding different templates never select the same pods.
+	DeploymentPodTemplateHashLabel = "deployment.kubernetes.io/podTemplateHash"
+)
+
 const (
 	// PortalIPNone - do not assign a portal IP
 	// no proxying required and no environment variables should be created for pods
@@ -858,17 +1221,26 @@ const (
 	AffinityTypeNone AffinityType = "None"
 )
 
+// Service Type string describes how a service is exposed.
+type ServiceType string
+
+const (
+	// ServiceTypeClusterIP means a service will only be reachable through its
+	// portal IP, from inside the cluster.
+	ServiceTypeClusterIP ServiceType = "ClusterIP"
+
+	// ServiceTypeNodePort means a service will additionally be exposed on a
+	// port of every node, allocated by the master unless one is requested.
+	ServiceTypeNodePort ServiceType = "NodePort"
+)
+
 // ServiceStatus represents the current status of a service
 type ServiceStatus struct{}
 
 // ServiceSpec describes the attributes that a user creates on a service
 type ServiceSpec struct {
-	// Port is the TCP or UDP port that will be made available to each pod for connecting to the pods
-	// proxied by this service.
-	Port int `json:"port"`
-
-	// Required: Supports "TCP" and "UDP".
-	Protocol Protocol `json:"protocol,omitempty"`
+	// Required: The list of ports that are exposed by this service.
+	Ports []ServicePort `json:"ports"`
 
 	// This service will route traffic to pods having labels matching this selector. If empty or not present,
 	// the service is assumed to have endpoints set by an external process and Kubernetes will not modify
@@ -891,15 +1263,37 @@ type ServiceSpec struct {
 	// For hostnames, the user will use a CNAME record (instead of using an A record with the IP)
 	PublicIPs []string `json:"publicIPs,omitempty"`
 
-	// TargetPort is the name or number of the port on the container to direct traffic to.
-	// This is useful if the containers the service points to have multiple open ports.
-	// Optional: If unspecified, the first port on the container will be used.
-	// As of v1beta3 this field will become required in the internal API,
-	// and the versioned APIs must provide a default value.
-	TargetPort util.IntOrString `json:"targetPort,omitempty"`
-
 	// Required: Supports "ClientIP" and "None".  Used to maintain session affinity.
 	SessionAffinity AffinityType `json:"sessionAffinity,omitempty"`
+
+	// Required: Supports "ClusterIP" and "NodePort".  Determines how the
+	// service is exposed.
+	Type ServiceType `json:"type,omitempty"`
+}
+
+type ServicePort struct {
+	// Optional if only one ServicePort is defined on this service: The
+	// name of this port within the service.  This must be a DNS_LABEL.
+	// All ports within a ServiceSpec must have unique names.  This maps to
+	// the 'Name' field in EndpointPort objects.
+	Name string `json:"name"`
+
+	// The IP protocol for this port.  Supports "TCP" and "UDP".
+	Protocol Protocol `json:"protocol"`
+
+	// The port that will be exposed on the service.
+	Port int `json:"port"`
+
+	// Optional: The target port on pods selected by this service.  If this
+	// is a string, it will be looked up as a named port in the target
+	// Pod's container ports.  If this is not specified, the first port
+	// with the same protocol on the container will be used.
+	TargetPort util.IntOrString `json:"targetPort"`
+
+	// Optional: The port on each node on which this service is exposed,
+	// when the service type is NodePort.  If this is not specified, a port
+	// will be allocated by the master.
+	NodePort int `json:"nodePort"`
 }
 
 // Service is a named abstraction of software service (for example, mysql) consisting of local port
@@ -1203,6 +1597,20 @@ type DeleteOptions struct {
 	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
 }
 
+// PatchType is the Content-Type of a PATCH request, which selects how the patch is applied.
+type PatchType string
+
+const (
+	// JSONPatchType is a list of operations, as defined by RFC 6902.
+	JSONPatchType PatchType = "application/json-patch+json"
+	// MergePatchType is a partial object that is merged into the object, as defined by
+	// RFC 7386. Lists in the patch replace the lists of the object.
+	MergePatchType PatchType = "application/merge-patch+json"
+	// StrategicMergePatchType is like MergePatchType, except that lists of objects whose
+	// fields are tagged with a patchMergeKey are merged by that key.
+	StrategicMergePatchType PatchType = "application/strategic-merge-patch+json"
+)
+
 // ListOptions is the query options to a standard REST list call, and has future support for
 // watch calls.
 type ListOptions struct {
@@ -1581,8 +1989,19 @@ const (
 	ResourceReplicationControllers ResourceName = "replicationcontrollers"
 	// ResourceQuotas, number
 	ResourceQuotas ResourceName = "resourcequotas"
+	// Secrets, number
+	ResourceSecrets ResourceName = "secrets"
+	// PersistentVolumeClaims, number
+	ResourcePersistentVolumeClaims ResourceName = "persistentvolumeclaims"
+	// Storage requested by all persistent volume claims, in bytes
+	ResourceRequestsStorage ResourceName = "requests.storage"
 )
 
+// ResourceCountPrefix is prepended to the plural name of a namespaced kind,
+// e.g. "count/deployments", to form a ResourceName that counts those objects.
+// Only the kinds the quota controller knows how to list can be counted.
+const ResourceCountPrefix = "count/"
+
 // ResourceQuotaSpec defines the desired hard limits to enforce for Quota
 type ResourceQuotaSpec struct {
 	// Hard is the set of desired hard limits for each named resource
@@ -1639,6 +2058,21 @@ type SecretType string
 
 const (
 	SecretTypeOpaque SecretType = "Opaque" // Default; arbitrary user-defined data
+
+	// SecretTypeServiceAccountToken contains a token that identifies a service account to the API
+	//
+	// Required fields:
+	// - Secret.Annotations["kubernetes.io/service-account.name"] - the name of the ServiceAccount the token identifies
+	// - Secret.Annotations["kubernetes.io/service-account.uid"] - the UID of the ServiceAccount the token identifies
+	// - Secret.Data["token"] - a token that identifies the service account to the API
+	SecretTypeServiceAccountToken SecretType = "kubernetes.io/service-account-token"
+
+	// ServiceAccountNameKey is the key of the required annotation for SecretTypeServiceAccountToken secrets
+	ServiceAccountNameKey = "kubernetes.io/service-account.name"
+	// ServiceAccountUIDKey is the key of the required annotation for SecretTypeServiceAccountToken secrets
+	ServiceAccountUIDKey = "kubernetes.io/service-account.uid"
+	// ServiceAccountTokenKey is the key of the required data for SecretTypeServiceAccountToken secrets
+	ServiceAccountTokenKey = "token"
 )
 
 type SecretList struct {
@@ -1648,6 +2082,137 @@ type SecretList struct {
 	Items []Secret `json:"items"`
 }
 
+// ServiceAccount binds together:
+// * a name, understood by users, and perhaps by peripheral systems, for an identity
+// * a principal that can be authenticated and authorized
+// * a set of secrets
+type ServiceAccount struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Secrets is the list of secrets allowed to be used by pods running using this ServiceAccount
+	Secrets []ObjectReference `json:"secrets"`
+}
+
+// ServiceAccountList is a list of ServiceAccount objects
+type ServiceAccountList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceAccount `json:"items"`
+}
+
+// Authorization verbs and resources
+const (
+	// VerbAll matches every verb in a PolicyRule
+	VerbAll = "*"
+	// ResourceAll matches every resource in a PolicyRule, including requests that do not address a resource
+	ResourceAll = "*"
+)
+
+// Kinds of subjects a RoleBinding or ClusterRoleBinding can grant a role to
+const (
+	UserKind           = "User"
+	GroupKind          = "Group"
+	ServiceAccountKind = "ServiceAccount"
+)
+
+// PolicyRule holds information that describes a policy rule, but does not contain information
+// about who the rule applies to or which namespace the rule applies to.
+type PolicyRule struct {
+	// Verbs is a list of verbs that apply to all of the listed resources, e.g. get, list, watch, create, update, delete.
+	// VerbAll represents all verbs.
+	Verbs []string `json:"verbs"`
+	// Resources is a list of resources this rule applies to.  ResourceAll represents all resources.
+	Resources []string `json:"resources"`
+}
+
+// Subject contains a reference to the user, group or service account a role is granted to.
+type Subject struct {
+	// Kind of the subject: User, Group or ServiceAccount.
+	Kind string `json:"kind"`
+	// Name of the subject.
+	Name string `json:"name"`
+	// Namespace of a ServiceAccount subject.  Defaults to the namespace of the RoleBinding.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Role is a namespaced, logical grouping of PolicyRules that can be referenced as a unit by a RoleBinding.
+type Role struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Rules holds all the PolicyRules for this role
+	Rules []PolicyRule `json:"rules"`
+}
+
+// RoleList is a collection of Roles
+type RoleList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []Role `json:"items"`
+}
+
+// ClusterRole is a cluster level, logical grouping of PolicyRules that can be referenced as a unit by a
+// RoleBinding or ClusterRoleBinding.
+type ClusterRole struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Rules holds all the PolicyRules for this role
+	Rules []PolicyRule `json:"rules"`
+}
+
+// ClusterRoleList is a collection of ClusterRoles
+type ClusterRoleList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterRole `json:"items"`
+}
+
+// RoleBinding grants the permissions of a Role or ClusterRole to subjects within the namespace of the binding.
+type RoleBinding struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Subjects holds references to the objects the role applies to.
+	Subjects []Subject `json:"subjects"`
+
+	// RoleRef references the role being granted.
+	RoleRef ObjectReference `json:"roleRef"`
+}
+
+// RoleBindingList is a collection of RoleBindings
+type RoleBindingList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []RoleBinding `json:"items"`
+}
+
+// ClusterRoleBinding grants the permissions of a ClusterRole to subjects in every namespace and on
+// cluster-scoped resources.
+type ClusterRoleBinding struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty"`
+
+	// Subjects holds references to the objects the role applies to.
+	Subjects []Subject `json:"subjects"`
+
+	// RoleRef references the role being granted.
+	RoleRef ObjectReference `json:"roleRef"`
+}
+
+// ClusterRoleBindingList is a collection of ClusterRoleBindings
+type ClusterRoleBindingList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterRoleBinding `json:"items"`
+}
+
 // These constants are for remote command execution and port forwarding and are
 // used by both the client side and server side components.
 //
@@ -67,6 +67,62 @@ func init() {
 			if obj.SessionAffinity == "" {
 				obj.SessionAffinity = AffinityTypeNone
 			}
+			if obj.Type == "" {
+				obj.Type = ServiceTypeClusterIP
+			}
+			if len(obj.Ports) == 0 && obj.Port != 0 {
+				// Must be a legacy-style object - populate
+				// Ports from the older fields.
+				obj.Ports = []ServicePort{{
+					Protocol:      obj.Protocol,
+					Port:          obj.Port,
+					ContainerPort: obj.ContainerPort,
+				}}
+			}
+			for i := range obj.Ports {
+				sp := &obj.Ports[i]
+				if sp.Protocol == "" {
+					sp.Protocol = ProtocolTCP
+				}
+			}
+		},
+		func(obj *Deployment) {
+			if obj.Spec.Strategy.Type == "" {
+				obj.Spec.Strategy.Type = RollingUpdateDeploymentStrategyType
+			}
+			if obj.Spec.Strategy.Type == RollingUpdateDeploymentStrategyType && obj.Spec.Strategy.RollingUpdate == nil {
+				obj.Spec.Strategy.RollingUpdate = &RollingUpdateDeployment{
+					MaxUnavailable: util.NewIntOrStringFromInt(1),
+					MaxSurge:       util.NewIntOrStringFromInt(1),
+				}
+			}
+		},
+		func(obj *Job) {
+			if obj.Spec.Parallelism == nil {
+				parallelism := 1
+				obj.Spec.Parallelism = &parallelism
+			}
+			if obj.Spec.Completions == nil {
+				completions := 1
+				obj.Spec.Completions = &completions
+			}
+			if len(obj.Spec.Selector) == 0 && obj.Spec.Template != nil {
+				obj.Spec.Selector = obj.Spec.Template.Labels
+			}
+		},
+		func(obj *DaemonSet) {
+			if len(obj.Spec.Selector) == 0 && obj.Spec.Template != nil {
+				obj.Spec.Selector = obj.Spec.Template.Labels
+			}
+		},
+		func(obj *HorizontalPodAutoscaler) {
+			if obj.Spec.MinReplicas == nil {
+				minReplicas := 1
+				obj.Spec.MinReplicas = &minReplicas
+			}
+			if obj.Spec.CPUUtilization == nil {
+				obj.Spec.CPUUtilization = &CPUTargetUtilization{TargetPercentage: 80}
+			}
 		},
 		func(obj *PodSpec) {
 			if obj.DNSPolicy == "" {
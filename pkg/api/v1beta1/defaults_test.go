@@ -49,6 +49,9 @@ func TestSetDefaultService(t *testing.T) {
 	if svc2.SessionAffinity != current.AffinityTypeNone {
 		t.Errorf("Expected default sesseion affinity type:%s, got: %s", current.AffinityTypeNone, svc2.SessionAffinity)
 	}
+	if svc2.Type != current.ServiceTypeClusterIP {
+		t.Errorf("Expected default type:%s, got: %s", current.ServiceTypeClusterIP, svc2.Type)
+	}
 }
 
 func TestSetDefaultSecret(t *testing.T) {
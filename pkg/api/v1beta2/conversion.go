@@ -363,6 +363,11 @@ func init() {
 			if err := s.Convert(&in.Capabilities, &out.Capabilities, 0); err != nil {
 				return err
 			}
+			if err := s.Convert(&in.SecurityContext, &out.SecurityContext, 0); err != nil {
+				return err
+			}
+			out.Stdin = in.Stdin
+			out.TTY = in.TTY
 			return nil
 		},
 		// Internal API does not support CPU to be specified via an explicit field.
@@ -448,6 +453,11 @@ func init() {
 			if err := s.Convert(&in.Capabilities, &out.Capabilities, 0); err != nil {
 				return err
 			}
+			if err := s.Convert(&in.SecurityContext, &out.SecurityContext, 0); err != nil {
+				return err
+			}
+			out.Stdin = in.Stdin
+			out.TTY = in.TTY
 			return nil
 		},
 		func(in *newer.PodSpec, out *ContainerManifest, s conversion.Scope) error {
@@ -463,6 +473,10 @@ func init() {
 			out.DNSPolicy = DNSPolicy(in.DNSPolicy)
 			out.Version = "v1beta2"
 			out.HostNetwork = in.HostNetwork
+			out.ServiceAccount = in.ServiceAccount
+			if err := s.Convert(&in.SecurityContext, &out.SecurityContext, 0); err != nil {
+				return err
+			}
 			return nil
 		},
 		func(in *ContainerManifest, out *newer.PodSpec, s conversion.Scope) error {
@@ -477,6 +491,10 @@ func init() {
 			}
 			out.DNSPolicy = newer.DNSPolicy(in.DNSPolicy)
 			out.HostNetwork = in.HostNetwork
+			out.ServiceAccount = in.ServiceAccount
+			if err := s.Convert(&in.SecurityContext, &out.SecurityContext, 0); err != nil {
+				return err
+			}
 			return nil
 		},
 
@@ -635,18 +653,25 @@ func init() {
 				return err
 			}
 
-			out.Port = in.Spec.Port
-			out.Protocol = Protocol(in.Spec.Protocol)
+			if err := s.Convert(&in.Spec.Ports, &out.Ports, 0); err != nil {
+				return err
+			}
+			// Produce back-compat fields from the first port.
+			if len(in.Spec.Ports) > 0 {
+				out.Port = in.Spec.Ports[0].Port
+				out.Protocol = Protocol(in.Spec.Ports[0].Protocol)
+				out.ContainerPort = in.Spec.Ports[0].TargetPort
+			}
 			if err := s.Convert(&in.Spec.Selector, &out.Selector, 0); err != nil {
 				return err
 			}
 			out.CreateExternalLoadBalancer = in.Spec.CreateExternalLoadBalancer
 			out.PublicIPs = in.Spec.PublicIPs
-			out.ContainerPort = in.Spec.TargetPort
 			out.PortalIP = in.Spec.PortalIP
 			if err := s.Convert(&in.Spec.SessionAffinity, &out.SessionAffinity, 0); err != nil {
 				return err
 			}
+			out.Type = ServiceType(in.Spec.Type)
 
 			return nil
 		},
@@ -661,21 +686,39 @@ func init() {
 				return err
 			}
 
-			out.Spec.Port = in.Port
-			out.Spec.Protocol = newer.Protocol(in.Protocol)
+			// Back-compat fields are handled in the defaulting phase.
+			if err := s.Convert(&in.Ports, &out.Spec.Ports, 0); err != nil {
+				return err
+			}
 			if err := s.Convert(&in.Selector, &out.Spec.Selector, 0); err != nil {
 				return err
 			}
 			out.Spec.CreateExternalLoadBalancer = in.CreateExternalLoadBalancer
 			out.Spec.PublicIPs = in.PublicIPs
-			out.Spec.TargetPort = in.ContainerPort
 			out.Spec.PortalIP = in.PortalIP
 			if err := s.Convert(&in.SessionAffinity, &out.Spec.SessionAffinity, 0); err != nil {
 				return err
 			}
+			out.Spec.Type = newer.ServiceType(in.Type)
 
 			return nil
 		},
+		func(in *newer.ServicePort, out *ServicePort, s conversion.Scope) error {
+			out.Name = in.Name
+			out.Protocol = Protocol(in.Protocol)
+			out.Port = in.Port
+			out.ContainerPort = in.TargetPort
+			out.NodePort = in.NodePort
+			return nil
+		},
+		func(in *ServicePort, out *newer.ServicePort, s conversion.Scope) error {
+			out.Name = in.Name
+			out.Protocol = newer.Protocol(in.Protocol)
+			out.Port = in.Port
+			out.TargetPort = in.ContainerPort
+			out.NodePort = in.NodePort
+			return nil
+		},
 
 		func(in *newer.Node, out *Minion, s conversion.Scope) error {
 			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
@@ -743,6 +786,436 @@ func init() {
 			return s.Convert(&in.NodeResources.Capacity, &out.Status.Capacity, 0)
 		},
 
+		func(in *newer.Deployment, out *Deployment, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *Deployment, out *newer.Deployment, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+
+		// The pod template of a deployment uses the older PodTemplate.
+		func(in *newer.DeploymentSpec, out *DeploymentSpec, s conversion.Scope) error {
+			out.Replicas = in.Replicas
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			if in.Template != nil {
+				out.Template = &PodTemplate{}
+				if err := s.Convert(in.Template, out.Template, 0); err != nil {
+					return err
+				}
+			} else {
+				out.Template = nil
+			}
+			if err := s.Convert(&in.Strategy, &out.Strategy, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.RevisionHistoryLimit, &out.RevisionHistoryLimit, 0); err != nil {
+				return err
+			}
+			out.Paused = in.Paused
+			if err := s.Convert(&in.RollbackTo, &out.RollbackTo, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *DeploymentSpec, out *newer.DeploymentSpec, s conversion.Scope) error {
+			out.Replicas = in.Replicas
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			if in.Template != nil {
+				out.Template = &newer.PodTemplateSpec{}
+				if err := s.Convert(in.Template, out.Template, 0); err != nil {
+					return err
+				}
+			} else {
+				out.Template = nil
+			}
+			if err := s.Convert(&in.Strategy, &out.Strategy, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.RevisionHistoryLimit, &out.RevisionHistoryLimit, 0); err != nil {
+				return err
+			}
+			out.Paused = in.Paused
+			if err := s.Convert(&in.RollbackTo, &out.RollbackTo, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+
+		func(in *newer.Job, out *Job, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *Job, out *newer.Job, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *newer.JobSpec, out *JobSpec, s conversion.Scope) error {
+			if err := s.Convert(&in.Parallelism, &out.Parallelism, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Completions, &out.Completions, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			if in.Template != nil {
+				out.Template = &PodTemplate{}
+				if err := s.Convert(in.Template, out.Template, 0); err != nil {
+					return err
+				}
+			} else {
+				out.Template = nil
+			}
+			return nil
+		},
+		func(in *JobSpec, out *newer.JobSpec, s conversion.Scope) error {
+			if err := s.Convert(&in.Parallelism, &out.Parallelism, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Completions, &out.Completions, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			if in.Template != nil {
+				out.Template = &newer.PodTemplateSpec{}
+				if err := s.Convert(in.Template, out.Template, 0); err != nil {
+					return err
+				}
+			} else {
+				out.Template = nil
+			}
+			return nil
+		},
+		func(in *newer.DaemonSet, out *DaemonSet, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *DaemonSet, out *newer.DaemonSet, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *newer.DaemonSetSpec, out *DaemonSetSpec, s conversion.Scope) error {
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			if in.Template != nil {
+				out.Template = &PodTemplate{}
+				if err := s.Convert(in.Template, out.Template, 0); err != nil {
+					return err
+				}
+			} else {
+				out.Template = nil
+			}
+			return nil
+		},
+		func(in *DaemonSetSpec, out *newer.DaemonSetSpec, s conversion.Scope) error {
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			if in.Template != nil {
+				out.Template = &newer.PodTemplateSpec{}
+				if err := s.Convert(in.Template, out.Template, 0); err != nil {
+					return err
+				}
+			} else {
+				out.Template = nil
+			}
+			return nil
+		},
+		func(in *newer.HorizontalPodAutoscaler, out *HorizontalPodAutoscaler, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *HorizontalPodAutoscaler, out *newer.HorizontalPodAutoscaler, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Spec, &out.Spec, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Status, &out.Status, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+
+		func(in *newer.ServiceAccount, out *ServiceAccount, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Secrets, &out.Secrets, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *ServiceAccount, out *newer.ServiceAccount, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Secrets, &out.Secrets, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+
+		func(in *newer.Role, out *Role, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Rules, &out.Rules, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *Role, out *newer.Role, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Rules, &out.Rules, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *newer.ClusterRole, out *ClusterRole, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Rules, &out.Rules, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *ClusterRole, out *newer.ClusterRole, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Rules, &out.Rules, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *newer.RoleBinding, out *RoleBinding, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Subjects, &out.Subjects, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.RoleRef, &out.RoleRef, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *RoleBinding, out *newer.RoleBinding, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Subjects, &out.Subjects, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.RoleRef, &out.RoleRef, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *newer.ClusterRoleBinding, out *ClusterRoleBinding, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.ObjectMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Subjects, &out.Subjects, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.RoleRef, &out.RoleRef, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+		func(in *ClusterRoleBinding, out *newer.ClusterRoleBinding, s conversion.Scope) error {
+			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.TypeMeta, &out.ObjectMeta, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Labels, &out.Labels, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Subjects, &out.Subjects, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.RoleRef, &out.RoleRef, 0); err != nil {
+				return err
+			}
+			return nil
+		},
+
 		func(in *newer.LimitRange, out *LimitRange, s conversion.Scope) error {
 			if err := s.Convert(&in.TypeMeta, &out.TypeMeta, 0); err != nil {
 				return err
@@ -1061,6 +1534,9 @@ func init() {
 			if err := s.Convert(&in.NFS, &out.NFS, 0); err != nil {
 				return err
 			}
+			if err := s.Convert(&in.PersistentVolumeClaimVolumeSource, &out.PersistentVolumeClaimVolumeSource, 0); err != nil {
+				return err
+			}
 			return nil
 		},
 		func(in *VolumeSource, out *newer.VolumeSource, s conversion.Scope) error {
@@ -1082,6 +1558,9 @@ func init() {
 			if err := s.Convert(&in.NFS, &out.NFS, 0); err != nil {
 				return err
 			}
+			if err := s.Convert(&in.PersistentVolumeClaimVolumeSource, &out.PersistentVolumeClaimVolumeSource, 0); err != nil {
+				return err
+			}
 			return nil
 		},
 
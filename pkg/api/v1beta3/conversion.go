@@ -20,11 +20,58 @@ import (
 	"fmt"
 
 	newer "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/conversion"
 )
 
 func init() {
+	err := newer.Scheme.AddConversionFuncs(
+		func(in *newer.ServiceSpec, out *ServiceSpec, s conversion.Scope) error {
+			if err := s.Convert(&in.Ports, &out.Ports, 0); err != nil {
+				return err
+			}
+			// Produce back-compat fields from the first port.
+			if len(in.Ports) > 0 {
+				out.Port = in.Ports[0].Port
+				out.Protocol = Protocol(in.Ports[0].Protocol)
+				out.TargetPort = in.Ports[0].TargetPort
+			}
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			out.PortalIP = in.PortalIP
+			out.CreateExternalLoadBalancer = in.CreateExternalLoadBalancer
+			if err := s.Convert(&in.PublicIPs, &out.PublicIPs, 0); err != nil {
+				return err
+			}
+			out.SessionAffinity = AffinityType(in.SessionAffinity)
+			out.Type = ServiceType(in.Type)
+			return nil
+		},
+		func(in *ServiceSpec, out *newer.ServiceSpec, s conversion.Scope) error {
+			// Back-compat fields are handled in the defaulting phase.
+			if err := s.Convert(&in.Ports, &out.Ports, 0); err != nil {
+				return err
+			}
+			if err := s.Convert(&in.Selector, &out.Selector, 0); err != nil {
+				return err
+			}
+			out.PortalIP = in.PortalIP
+			out.CreateExternalLoadBalancer = in.CreateExternalLoadBalancer
+			if err := s.Convert(&in.PublicIPs, &out.PublicIPs, 0); err != nil {
+				return err
+			}
+			out.SessionAffinity = newer.AffinityType(in.SessionAffinity)
+			out.Type = newer.ServiceType(in.Type)
+			return nil
+		},
+	)
+	if err != nil {
+		// If one of the conversion functions is malformed, detect it immediately.
+		panic(err)
+	}
+
 	// Add field conversion funcs.
-	err := newer.Scheme.AddFieldLabelConversionFunc("v1beta3", "Pod",
+	err = newer.Scheme.AddFieldLabelConversionFunc("v1beta3", "Pod",
 		func(label, value string) (string, string, error) {
 			switch label {
 			case "name",
@@ -59,6 +59,47 @@ func init() {
 			if obj.Spec.SessionAffinity == "" {
 				obj.Spec.SessionAffinity = AffinityTypeNone
 			}
+			if obj.Spec.Type == "" {
+				obj.Spec.Type = ServiceTypeClusterIP
+			}
+		},
+		func(obj *Deployment) {
+			if obj.Spec.Strategy.Type == "" {
+				obj.Spec.Strategy.Type = RollingUpdateDeploymentStrategyType
+			}
+			if obj.Spec.Strategy.Type == RollingUpdateDeploymentStrategyType && obj.Spec.Strategy.RollingUpdate == nil {
+				obj.Spec.Strategy.RollingUpdate = &RollingUpdateDeployment{
+					MaxUnavailable: util.NewIntOrStringFromInt(1),
+					MaxSurge:       util.NewIntOrStringFromInt(1),
+				}
+			}
+		},
+		func(obj *Job) {
+			if obj.Spec.Parallelism == nil {
+				parallelism := 1
+				obj.Spec.Parallelism = &parallelism
+			}
+			if obj.Spec.Completions == nil {
+				completions := 1
+				obj.Spec.Completions = &completions
+			}
+			if len(obj.Spec.Selector) == 0 && obj.Spec.Template != nil {
+				obj.Spec.Selector = obj.Spec.Template.Labels
+			}
+		},
+		func(obj *DaemonSet) {
+			if len(obj.Spec.Selector) == 0 && obj.Spec.Template != nil {
+				obj.Spec.Selector = obj.Spec.Template.Labels
+			}
+		},
+		func(obj *HorizontalPodAutoscaler) {
+			if obj.Spec.MinReplicas == nil {
+				minReplicas := 1
+				obj.Spec.MinReplicas = &minReplicas
+			}
+			if obj.Spec.CPUUtilization == nil {
+				obj.Spec.CPUUtilization = &CPUTargetUtilization{TargetPercentage: 80}
+			}
 		},
 		func(obj *PodSpec) {
 			if obj.DNSPolicy == "" {
@@ -102,6 +143,25 @@ func init() {
 				obj.TargetPort.Kind == util.IntstrString && obj.TargetPort.StrVal == "" {
 				obj.TargetPort = util.NewIntOrStringFromInt(obj.Port)
 			}
+			if len(obj.Ports) == 0 && obj.Port != 0 {
+				// Must be a legacy-style object - populate
+				// Ports from the older fields.
+				obj.Ports = []ServicePort{{
+					Protocol:   obj.Protocol,
+					Port:       obj.Port,
+					TargetPort: obj.TargetPort,
+				}}
+			}
+			for i := range obj.Ports {
+				sp := &obj.Ports[i]
+				if sp.Protocol == "" {
+					sp.Protocol = ProtocolTCP
+				}
+				if sp.TargetPort.Kind == util.IntstrInt && sp.TargetPort.IntVal == 0 ||
+					sp.TargetPort.Kind == util.IntstrString && sp.TargetPort.StrVal == "" {
+					sp.TargetPort = util.NewIntOrStringFromInt(sp.Port)
+				}
+			}
 		},
 		func(obj *NamespaceStatus) {
 			if obj.Phase == "" {
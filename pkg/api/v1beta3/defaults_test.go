@@ -50,6 +50,9 @@ func TestSetDefaultService(t *testing.T) {
 	if svc2.Spec.SessionAffinity != current.AffinityTypeNone {
 		t.Errorf("Expected default sesseion affinity type:%s, got: %s", current.AffinityTypeNone, svc2.Spec.SessionAffinity)
 	}
+	if svc2.Spec.Type != current.ServiceTypeClusterIP {
+		t.Errorf("Expected default type:%s, got: %s", current.ServiceTypeClusterIP, svc2.Spec.Type)
+	}
 }
 
 func TestSetDefaultSecret(t *testing.T) {
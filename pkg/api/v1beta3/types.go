@@ -212,6 +212,8 @@ type VolumeSource struct {
 	Secret *SecretVolumeSource `json:"secret" description:"secret to populate volume"`
 	// NFS represents an NFS mount on the host that shares a pod's lifetime
 	NFS *NFSVolumeSource `json:"nfs" description:"NFS volume that will be mounted in the host machine"`
+	// PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace
+	PersistentVolumeClaimVolumeSource *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty" description:"a reference to a PersistentVolumeClaim in the same namespace"`
 }
 
 // Similar to VolumeSource but meant for the administrator who creates PVs.
@@ -319,6 +321,16 @@ const (
 	VolumeReleased PersistentVolumePhase = "Released"
 )
 
+// PersistentVolumeClaimVolumeSource references the user's PersistentVolumeClaim
+// in the same namespace.  The volume bound to the claim is mounted in the pod.
+type PersistentVolumeClaimVolumeSource struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the same namespace as the pod using this volume
+	ClaimName string `json:"claimName,omitempty" description:"the name of the claim in the same namespace to be mounted as a volume"`
+	// Optional: Defaults to false (read/write).  ReadOnly here
+	// will force the ReadOnly setting in VolumeMounts
+	ReadOnly bool `json:"readOnly,omitempty" description:"mount volume as read-only when true; default false"`
+}
+
 type PersistentVolumeClaimPhase string
 
 const (
@@ -500,6 +512,32 @@ type Capabilities struct {
 	Drop []CapabilityType `json:"drop,omitempty" description:"droped capabilities"`
 }
 
+// SecurityContext holds security configuration that is applied to a container.
+// Fields set on a container override those set on its pod.
+type SecurityContext struct {
+	// Optional: The UID to run the container process as.  Defaults to the
+	// user specified in the image.
+	RunAsUser *int64 `json:"runAsUser,omitempty" description:"the UID to run the entrypoint of the container process; defaults to the user specified in image metadata"`
+	// Optional: If true, the container must run as a user other than root.
+	RunAsNonRoot bool `json:"runAsNonRoot,omitempty" description:"whether the container must run as a non-root user; the kubelet refuses to start it otherwise"`
+	// Optional: If true, the container's root filesystem is mounted read-only.
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty" description:"whether the container has a read-only root filesystem"`
+	// Optional: The SELinux context to apply to the container.
+	SELinuxOptions *SELinuxOptions `json:"seLinuxOptions,omitempty" description:"the SELinux context to be applied to the container"`
+}
+
+// SELinuxOptions are the labels applied to a container.
+type SELinuxOptions struct {
+	// SELinux user label
+	User string `json:"user,omitempty" description:"the user label to apply to the container"`
+	// SELinux role label
+	Role string `json:"role,omitempty" description:"the role label to apply to the container"`
+	// SELinux type label
+	Type string `json:"type,omitempty" description:"the type label to apply to the container"`
+	// SELinux level label
+	Level string `json:"level,omitempty" description:"the level label to apply to the container"`
+}
+
 // ResourceRequirements describes the compute resource requirements.
 type ResourceRequirements struct {
 	// Limits describes the maximum amount of compute resources required.
@@ -524,10 +562,10 @@ type Container struct {
 	Command []string `json:"command,omitempty" description:"command argv array; not executed within a shell; defaults to entrypoint or command in the image; cannot be updated"`
 	// Optional: Defaults to Docker's default.
 	WorkingDir     string               `json:"workingDir,omitempty" description:"container's working directory; defaults to image's default; cannot be updated"`
-	Ports          []ContainerPort      `json:"ports,omitempty" description:"list of ports to expose from the container; cannot be updated"`
-	Env            []EnvVar             `json:"env,omitempty" description:"list of environment variables to set in the container; cannot be updated"`
+	Ports          []ContainerPort      `json:"ports,omitempty" patchStrategy:"merge" patchMergeKey:"containerPort" description:"list of ports to expose from the container; cannot be updated"`
+	Env            []EnvVar             `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name" description:"list of environment variables to set in the container; cannot be updated"`
 	Resources      ResourceRequirements `json:"resources,omitempty" description:"Compute Resources required by this container; cannot be updated"`
-	VolumeMounts   []VolumeMount        `json:"volumeMounts,omitempty" description:"pod volumes to mount into the container's filesyste; cannot be updated"`
+	VolumeMounts   []VolumeMount        `json:"volumeMounts,omitempty" patchStrategy:"merge" patchMergeKey:"mountPath" description:"pod volumes to mount into the container's filesyste; cannot be updated"`
 	LivenessProbe  *Probe               `json:"livenessProbe,omitempty" description:"periodic probe of container liveness; container will be restarted if the probe fails; cannot be updated"`
 	ReadinessProbe *Probe               `json:"readinessProbe,omitempty" description:"periodic probe of container service readiness; container will be removed from service endpoints if the probe fails; cannot be updated"`
 	Lifecycle      *Lifecycle           `json:"lifecycle,omitempty" description:"actions that the management system should take in response to container lifecycle events; cannot be updated"`
@@ -539,6 +577,12 @@ type Container struct {
 	ImagePullPolicy PullPolicy `json:"imagePullPolicy" description:"image pull policy; one of PullAlways, PullNever, PullIfNotPresent; defaults to PullAlways if :latest tag is specified, or PullIfNotPresent otherwise; cannot be updated"`
 	// Optional: Capabilities for container.
 	Capabilities Capabilities `json:"capabilities,omitempty" description:"capabilities for container; cannot be updated"`
+	// Optional: Security options for the container.
+	SecurityContext *SecurityContext `json:"securityContext,omitempty" description:"security options the container should be run with; cannot be updated"`
+	// Optional: Default to false.
+	Stdin bool `json:"stdin,omitempty" description:"whether the container keeps stdin open, so that it can be attached to; defaults to false; cannot be updated"`
+	// Optional: Default to false.
+	TTY bool `json:"tty,omitempty" description:"whether the container is allocated a TTY, which requires stdin; defaults to false; cannot be updated"`
 }
 
 // Handler defines a specific action that should be taken
@@ -693,9 +737,9 @@ const (
 
 // PodSpec is a description of a pod
 type PodSpec struct {
-	Volumes []Volume `json:"volumes" description:"list of volumes that can be mounted by containers belonging to the pod"`
+	Volumes []Volume `json:"volumes" patchStrategy:"merge" patchMergeKey:"name" description:"list of volumes that can be mounted by containers belonging to the pod"`
 	// Required: there must be at least one container in a pod.
-	Containers    []Container   `json:"containers" description:"list of containers belonging to the pod; cannot be updated; containers cannot currently be added or removed; there must be at least one container in a Pod"`
+	Containers    []Container   `json:"containers" patchStrategy:"merge" patchMergeKey:"name" description:"list of containers belonging to the pod; cannot be updated; containers cannot currently be added or removed; there must be at least one container in a Pod"`
 	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty" description:"restart policy for all containers within the pod; one of RestartPolicyAlways, RestartPolicyOnFailure, RestartPolicyNever"`
 	// Optional: Set DNS policy.  Defaults to "ClusterFirst"
 	DNSPolicy DNSPolicy `json:"dnsPolicy,omitempty" description:"DNS policy for containers within the pod; one of 'ClusterFirst' or 'Default'"`
@@ -710,6 +754,13 @@ type PodSpec struct {
 	// used must be specified.
 	// Optional: Default to false.
 	HostNetwork bool `json:"hostNetwork,omitempty" description:"host networking requested for this pod"`
+
+	// ServiceAccount is the name of the ServiceAccount to use to run this pod.
+	ServiceAccount string `json:"serviceAccount,omitempty" description:"name of the ServiceAccount to use to run this pod"`
+
+	// SecurityContext holds pod-level security options.  They apply to every
+	// container, unless overridden by the container's SecurityContext.
+	SecurityContext *SecurityContext `json:"securityContext,omitempty" description:"pod-level security options, which apply to every container unless overridden by the container; cannot be updated"`
 }
 
 // PodStatus represents information about the status of a pod. Status may trail the actual
@@ -837,6 +888,301 @@ type ReplicationControllerList struct {
 	Items []ReplicationController `json:"items" description:"list of replication controllers"`
 }
 
+// DeploymentSpec is the specification of the desired behavior of a Deployment.
+type DeploymentSpec struct {
+	// Replicas is the number of desired pods.
+	Replicas int `json:"replicas" description:"number of replicas desired"`
+
+	// Selector is a label query over pods that are managed by this deployment.
+	Selector map[string]string `json:"selector,omitempty" description:"label keys and values that must match in order to be managed by this deployment"`
+
+	// Template describes the pods that will be created.
+	Template *PodTemplateSpec `json:"template,omitempty" description:"object that describes the pods that will be created"`
+
+	// Strategy is the deployment strategy used to replace existing pods with new ones.
+	Strategy DeploymentStrategy `json:"strategy,omitempty" description:"the deployment strategy used to replace existing pods with new ones"`
+
+	// RevisionHistoryLimit is the number of old replication controllers to
+	// retain to allow rollback.  If unset, all of them are kept.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty" description:"number of old replication controllers to retain to allow rollback; all are kept if unset"`
+
+	// Paused indicates that the deployment is paused and that the deployment
+	// controller will not roll it out.
+	Paused bool `json:"paused,omitempty" description:"indicates that the deployment is paused and will not be rolled out"`
+
+	// RollbackTo is the revision this deployment is rolling back to.
+	RollbackTo *RollbackConfig `json:"rollbackTo,omitempty" description:"the revision this deployment is rolling back to; cleared once the rollback has been started"`
+}
+
+// RollbackConfig names the revision of a deployment to roll back to.
+type RollbackConfig struct {
+	// Revision is the revision to roll back to.  If 0, the deployment rolls
+	// back to the last revision before the current one.
+	Revision int64 `json:"revision,omitempty" description:"the revision to roll back to; if 0, rolls back to the last revision"`
+}
+
+// DeploymentStrategy describes how to replace existing pods with new ones.
+type DeploymentStrategy struct {
+	// Type of deployment.  Can be "Recreate" or "RollingUpdate".
+	Type DeploymentStrategyType `json:"type,omitempty" description:"type of deployment; one of Recreate or RollingUpdate; defaults to RollingUpdate"`
+
+	// RollingUpdate holds the parameters of a rolling update.
+	RollingUpdate *RollingUpdateDeployment `json:"rollingUpdate,omitempty" description:"rolling update parameters; present only if type is RollingUpdate"`
+}
+
+type DeploymentStrategyType string
+
+const (
+	// RecreateDeploymentStrategyType kills all existing pods before creating new ones.
+	RecreateDeploymentStrategyType DeploymentStrategyType = "Recreate"
+
+	// RollingUpdateDeploymentStrategyType replaces the old replication
+	// controller by the new one gradually.
+	RollingUpdateDeploymentStrategyType DeploymentStrategyType = "RollingUpdate"
+)
+
+// RollingUpdateDeployment bounds the progress of a rolling update.
+type RollingUpdateDeployment struct {
+	// MaxUnavailable is the maximum number of pods that can be unavailable
+	// during the update.
+	MaxUnavailable util.IntOrString `json:"maxUnavailable,omitempty" description:"maximum number of pods that can be unavailable during the update; an absolute number or a percentage of desired pods, like 10%"`
+
+	// MaxSurge is the maximum number of pods that can be scheduled above the
+	// desired number of pods.
+	MaxSurge util.IntOrString `json:"maxSurge,omitempty" description:"maximum number of pods that can be scheduled above the desired number of pods; an absolute number or a percentage of desired pods, like 10%"`
+}
+
+// DeploymentStatus is the most recently observed status of a Deployment.
+type DeploymentStatus struct {
+	// Replicas is the total number of pods targeted by this deployment.
+	Replicas int `json:"replicas,omitempty" description:"total number of pods targeted by this deployment"`
+
+	// UpdatedReplicas is the number of pods that have the desired template.
+	UpdatedReplicas int `json:"updatedReplicas,omitempty" description:"number of pods that have the desired template"`
+
+	// Revision is the revision of the replication controller that holds
+	// the desired template.
+	Revision int64 `json:"revision,omitempty" description:"revision of the replication controller holding the desired template"`
+}
+
+// Deployment enables declarative, server-side updates of pods through
+// replication controllers.
+type Deployment struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Spec defines the desired behavior of this deployment.
+	Spec DeploymentSpec `json:"spec,omitempty" description:"specification of the desired behavior of the deployment; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+
+	// Status is the most recently observed status of this deployment.
+	Status DeploymentStatus `json:"status,omitempty" description:"most recently observed status of the deployment; populated by the system, read-only; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+}
+
+// DeploymentList is a collection of deployments.
+type DeploymentList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []Deployment `json:"items" description:"list of deployments"`
+}
+
+// JobSpec describes how a job execution will look.
+type JobSpec struct {
+	// Parallelism is the maximum number of pods the job should run at any
+	// given time.
+	Parallelism *int `json:"parallelism,omitempty" description:"maximum number of pods the job should run in parallel; defaults to 1"`
+
+	// Completions is the number of successfully finished pods the job
+	// should run to.
+	Completions *int `json:"completions,omitempty" description:"number of successfully finished pods the job should be run with; defaults to 1"`
+
+	// Selector is a label query over pods that are managed by this job.
+	Selector map[string]string `json:"selector,omitempty" description:"label keys and values that must match in order to be managed by this job; defaults to the labels of the pod template"`
+
+	// Template describes the pods that will be created.  Its RestartPolicy
+	// must be OnFailure or Never.
+	Template *PodTemplateSpec `json:"template,omitempty" description:"object that describes the pods that will be created"`
+}
+
+// JobStatus represents the current state of a Job.
+type JobStatus struct {
+	// Conditions represent the latest available observations of the job's state.
+	Conditions []JobCondition `json:"conditions,omitempty" description:"latest available observations of the job's state"`
+
+	// StartTime is the time the job was first observed by the job controller.
+	StartTime *util.Time `json:"startTime,omitempty" description:"time the job was first observed by the job controller"`
+
+	// CompletionTime is the time the job was observed to be complete.
+	CompletionTime *util.Time `json:"completionTime,omitempty" description:"time the job was observed to be complete"`
+
+	// Active is the number of pending and running pods.
+	Active int `json:"active,omitempty" description:"number of pending and running pods"`
+
+	// Succeeded is the number of pods which reached phase Succeeded.
+	Succeeded int `json:"succeeded,omitempty" description:"number of pods which reached phase Succeeded"`
+
+	// Failed is the number of pods which reached phase Failed.
+	Failed int `json:"failed,omitempty" description:"number of pods which reached phase Failed"`
+}
+
+type JobConditionType string
+
+const (
+	// JobComplete means the job has reached its completions target.
+	JobComplete JobConditionType = "Complete"
+)
+
+// JobCondition describes the current state of a job.
+type JobCondition struct {
+	// Type of the job condition, currently only Complete.
+	Type JobConditionType `json:"type" description:"type of job condition, currently only Complete"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status ConditionStatus `json:"status" description:"status of the condition, one of True, False, Unknown"`
+
+	// LastProbeTime is the last time the condition was checked.
+	LastProbeTime util.Time `json:"lastProbeTime,omitempty" description:"last time the condition was checked"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime util.Time `json:"lastTransitionTime,omitempty" description:"last time the condition transitioned from one status to another"`
+
+	// Reason is a brief reason for the condition's last transition.
+	Reason string `json:"reason,omitempty" description:"one-word CamelCase reason for the condition's last transition"`
+
+	// Message is a human readable message indicating details about the last transition.
+	Message string `json:"message,omitempty" description:"human-readable message indicating details about the last transition"`
+}
+
+// Job represents the configuration of a single job, which runs pods to completion.
+type Job struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Spec defines the desired behavior of this job.
+	Spec JobSpec `json:"spec,omitempty" description:"specification of the desired behavior of the job; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+
+	// Status is the most recently observed status of this job.
+	Status JobStatus `json:"status,omitempty" description:"most recently observed status of the job; populated by the system, read-only; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+}
+
+// JobList is a collection of jobs.
+type JobList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []Job `json:"items" description:"list of jobs"`
+}
+
+// DaemonSetSpec is the specification of a daemon set.
+type DaemonSetSpec struct {
+	// Selector is a label query over the pods that are managed by the daemon set.
+	// Defaults to the labels on the pod template.
+	Selector map[string]string `json:"selector,omitempty" description:"label selector for the pods run by the daemon set; defaults to the labels of the pod template"`
+
+	// Template describes the pod that will be run on every node that matches
+	// the node selector of the template. Nodes are matched through
+	// Template.Spec.NodeSelector; an empty node selector matches every node.
+	Template *PodTemplateSpec `json:"template,omitempty" description:"pod template that is run on every matching node"`
+}
+
+// DaemonSetStatus represents the current status of a daemon set.
+type DaemonSetStatus struct {
+	// CurrentNumberScheduled is the number of nodes that are running a daemon pod
+	// and are supposed to run it.
+	CurrentNumberScheduled int `json:"currentNumberScheduled" description:"number of nodes that are running a daemon pod and are supposed to run it"`
+
+	// NumberMisscheduled is the number of nodes that are running a daemon pod
+	// but are not supposed to run it.
+	NumberMisscheduled int `json:"numberMisscheduled" description:"number of nodes that are running a daemon pod but are not supposed to run it"`
+
+	// DesiredNumberScheduled is the number of nodes that should be running a daemon pod.
+	DesiredNumberScheduled int `json:"desiredNumberScheduled" description:"number of nodes that should be running a daemon pod"`
+}
+
+// DaemonSet represents the configuration of a daemon set, which runs one pod on
+// every node that matches its template's node selector.
+type DaemonSet struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Spec defines the desired behavior of this daemon set.
+	Spec DaemonSetSpec `json:"spec,omitempty" description:"specification of the desired behavior of the daemon set; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+
+	// Status is the most recently observed status of this daemon set.
+	Status DaemonSetStatus `json:"status,omitempty" description:"most recently observed status of the daemon set; populated by the system, read-only; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+}
+
+// DaemonSetList is a collection of daemon sets.
+type DaemonSetList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []DaemonSet `json:"items" description:"list of daemon sets"`
+}
+
+// CPUTargetUtilization is the target average CPU utilization of the pods of
+// an autoscaled replication controller.
+type CPUTargetUtilization struct {
+	// TargetPercentage is the target average CPU utilization of all pods, as a
+	// percentage of the CPU requested by their containers.
+	TargetPercentage int `json:"targetPercentage" description:"target average CPU utilization of all pods, as a percentage of the requested CPU"`
+}
+
+// HorizontalPodAutoscalerSpec is the specification of a horizontal pod autoscaler.
+type HorizontalPodAutoscalerSpec struct {
+	// ScaleRef is a reference to the replication controller that is scaled.
+	ScaleRef ObjectReference `json:"scaleRef" description:"reference to the replication controller that is scaled"`
+
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	MinReplicas *int `json:"minReplicas,omitempty" description:"lower limit for the number of replicas; defaults to 1"`
+
+	// MaxReplicas is the upper limit for the number of replicas. It cannot be
+	// smaller than MinReplicas.
+	MaxReplicas int `json:"maxReplicas" description:"upper limit for the number of replicas; cannot be smaller than minReplicas"`
+
+	// CPUUtilization is the target average CPU utilization over all pods.
+	// Defaults to 80 percent.
+	CPUUtilization *CPUTargetUtilization `json:"cpuUtilization,omitempty" description:"target average CPU utilization over all pods; defaults to 80 percent"`
+}
+
+// HorizontalPodAutoscalerStatus is the current status of a horizontal pod autoscaler.
+type HorizontalPodAutoscalerStatus struct {
+	// CurrentReplicas is the number of replicas last seen by the autoscaler.
+	CurrentReplicas int `json:"currentReplicas" description:"number of replicas last seen by the autoscaler"`
+
+	// DesiredReplicas is the number of replicas last computed by the autoscaler.
+	DesiredReplicas int `json:"desiredReplicas" description:"number of replicas last computed by the autoscaler"`
+
+	// CurrentCPUUtilizationPercentage is the average CPU utilization over all
+	// pods, as a percentage of the requested CPU, or nil if it is unknown.
+	CurrentCPUUtilizationPercentage *int `json:"currentCPUUtilizationPercentage,omitempty" description:"average CPU utilization over all pods, as a percentage of the requested CPU"`
+
+	// LastScaleTime is the last time the autoscaler changed the number of replicas.
+	LastScaleTime *util.Time `json:"lastScaleTime,omitempty" description:"last time the autoscaler changed the number of replicas"`
+}
+
+// HorizontalPodAutoscaler represents the configuration of an autoscaler,
+// which adjusts the replica count of a replication controller to the CPU
+// utilization of its pods.
+type HorizontalPodAutoscaler struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Spec defines the behavior of this autoscaler.
+	Spec HorizontalPodAutoscalerSpec `json:"spec,omitempty" description:"specification of the behavior of the autoscaler; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+
+	// Status is the most recently observed status of this autoscaler.
+	Status HorizontalPodAutoscalerStatus `json:"status,omitempty" description:"most recently observed status of the autoscaler; populated by the system, read-only; https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#spec-and-status"`
+}
+
+// HorizontalPodAutoscalerList is a collection of horizontal pod autoscalers.
+type HorizontalPodAutoscalerList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []HorizontalPodAutoscaler `json:"items" description:"list of horizontal pod autoscalers"`
+}
+
 // Session Affinity Type string
 type AffinityType string
 
@@ -848,6 +1194,19 @@ const (
 	AffinityTypeNone AffinityType = "None"
 )
 
+// Service Type string describes how a service is exposed.
+type ServiceType string
+
+const (
+	// ServiceTypeClusterIP means a service will only be reachable through its
+	// portal IP, from inside the cluster.
+	ServiceTypeClusterIP ServiceType = "ClusterIP"
+
+	// ServiceTypeNodePort means a service will additionally be exposed on a
+	// port of every node, allocated by the master unless one is requested.
+	ServiceTypeNodePort ServiceType = "NodePort"
+)
+
 // ServiceStatus represents the current status of a service
 type ServiceStatus struct{}
 
@@ -884,6 +1243,39 @@ type ServiceSpec struct {
 
 	// Optional: Supports "ClientIP" and "None".  Used to maintain session affinity.
 	SessionAffinity AffinityType `json:"sessionAffinity,omitempty" description:"enable client IP based session affinity; must be ClientIP or None; defaults to None"`
+
+	// Required: The list of ports that are exposed by this service.  If
+	// empty, a single port is built from the Port, Protocol and TargetPort fields.
+	Ports []ServicePort `json:"ports,omitempty" patchStrategy:"merge" patchMergeKey:"port" description:"ports exposed by the service; if empty, a single port is built from the legacy port fields"`
+
+	// Optional: Supports "ClusterIP" and "NodePort".  Determines how the
+	// service is exposed.  Defaults to "ClusterIP".
+	Type ServiceType `json:"type,omitempty" description:"how the service is exposed; must be ClusterIP or NodePort; defaults to ClusterIP"`
+}
+
+type ServicePort struct {
+	// Optional if only one ServicePort is defined on this service: The
+	// name of this port within the service.  This must be a DNS_LABEL.
+	// All ports within a service must have unique names.  This maps to
+	// the 'Name' field in EndpointPort objects.
+	Name string `json:"name,omitempty" description:"the name of this port; optional if only one port is defined"`
+
+	// Optional: The IP protocol for this port.  Supports "TCP" and "UDP",
+	// default is TCP.
+	Protocol Protocol `json:"protocol,omitempty" description:"the protocol used by this port; must be UDP or TCP; TCP if unspecified"`
+
+	// Required: The port that will be exposed on the service.
+	Port int `json:"port" description:"the port number that is exposed"`
+
+	// Optional: The target port on pods selected by this service.  If this
+	// is a string, it will be looked up as a named port in the target
+	// Pod's container ports.  If this is not specified, the default value
+	// is the same as the port.
+	TargetPort util.IntOrString `json:"targetPort,omitempty" description:"the port to access on the pods targeted by the service; defaults to the service port"`
+
+	// Optional: The port on each node on which this service is exposed,
+	// when the service type is NodePort.
+	NodePort int `json:"nodePort,omitempty" description:"the port on each node on which this service is exposed when type is NodePort; allocated by the system if unspecified"`
 }
 
 // Service is a named abstraction of software service (for example, mysql) consisting of local port
@@ -1486,8 +1878,19 @@ const (
 	ResourceReplicationControllers ResourceName = "replicationcontrollers"
 	// ResourceQuotas, number
 	ResourceQuotas ResourceName = "resourcequotas"
+	// Secrets, number
+	ResourceSecrets ResourceName = "secrets"
+	// PersistentVolumeClaims, number
+	ResourcePersistentVolumeClaims ResourceName = "persistentvolumeclaims"
+	// Storage requested by all persistent volume claims, in bytes
+	ResourceRequestsStorage ResourceName = "requests.storage"
 )
 
+// ResourceCountPrefix is prepended to the plural name of a namespaced kind,
+// e.g. "count/deployments", to form a ResourceName that counts those objects.
+// Only the kinds the quota controller knows how to list can be counted.
+const ResourceCountPrefix = "count/"
+
 // ResourceQuotaSpec defines the desired hard limits to enforce for Quota
 type ResourceQuotaSpec struct {
 	// Hard is the set of desired hard limits for each named resource
@@ -1544,6 +1947,9 @@ type SecretType string
 
 const (
 	SecretTypeOpaque SecretType = "Opaque" // Default; arbitrary user-defined data
+
+	// SecretTypeServiceAccountToken contains a token that identifies a service account to the API
+	SecretTypeServiceAccountToken SecretType = "kubernetes.io/service-account-token"
 )
 
 type SecretList struct {
@@ -1552,3 +1958,134 @@ type SecretList struct {
 
 	Items []Secret `json:"items" description:"items is a list of secret objects"`
 }
+
+// ServiceAccount binds together:
+// * a name, understood by users, and perhaps by peripheral systems, for an identity
+// * a principal that can be authenticated and authorized
+// * a set of secrets
+type ServiceAccount struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Secrets is the list of secrets allowed to be used by pods running using this ServiceAccount
+	Secrets []ObjectReference `json:"secrets" description:"list of secrets that can be used by pods running as this service account"`
+}
+
+// ServiceAccountList is a list of ServiceAccount objects
+type ServiceAccountList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []ServiceAccount `json:"items" description:"list of ServiceAccounts"`
+}
+
+// Authorization verbs and resources
+const (
+	// VerbAll matches every verb in a PolicyRule
+	VerbAll = "*"
+	// ResourceAll matches every resource in a PolicyRule, including requests that do not address a resource
+	ResourceAll = "*"
+)
+
+// Kinds of subjects a RoleBinding or ClusterRoleBinding can grant a role to
+const (
+	UserKind           = "User"
+	GroupKind          = "Group"
+	ServiceAccountKind = "ServiceAccount"
+)
+
+// PolicyRule holds information that describes a policy rule, but does not contain information
+// about who the rule applies to or which namespace the rule applies to.
+type PolicyRule struct {
+	// Verbs is a list of verbs that apply to all of the listed resources, e.g. get, list, watch, create, update, delete.
+	// VerbAll represents all verbs.
+	Verbs []string `json:"verbs" description:"list of verbs that apply to all of the listed resources; '*' represents all verbs"`
+	// Resources is a list of resources this rule applies to.  ResourceAll represents all resources.
+	Resources []string `json:"resources" description:"list of resources this rule applies to; '*' represents all resources"`
+}
+
+// Subject contains a reference to the user, group or service account a role is granted to.
+type Subject struct {
+	// Kind of the subject: User, Group or ServiceAccount.
+	Kind string `json:"kind" description:"kind of the subject; one of User, Group or ServiceAccount"`
+	// Name of the subject.
+	Name string `json:"name" description:"name of the subject"`
+	// Namespace of a ServiceAccount subject.  Defaults to the namespace of the RoleBinding.
+	Namespace string `json:"namespace,omitempty" description:"namespace of a ServiceAccount subject; defaults to the namespace of the binding"`
+}
+
+// Role is a namespaced, logical grouping of PolicyRules that can be referenced as a unit by a RoleBinding.
+type Role struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Rules holds all the PolicyRules for this role
+	Rules []PolicyRule `json:"rules" description:"all the policy rules for this role"`
+}
+
+// RoleList is a collection of Roles
+type RoleList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []Role `json:"items" description:"list of Roles"`
+}
+
+// ClusterRole is a cluster level, logical grouping of PolicyRules that can be referenced as a unit by a
+// RoleBinding or ClusterRoleBinding.
+type ClusterRole struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Rules holds all the PolicyRules for this role
+	Rules []PolicyRule `json:"rules" description:"all the policy rules for this role"`
+}
+
+// ClusterRoleList is a collection of ClusterRoles
+type ClusterRoleList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []ClusterRole `json:"items" description:"list of ClusterRoles"`
+}
+
+// RoleBinding grants the permissions of a Role or ClusterRole to subjects within the namespace of the binding.
+type RoleBinding struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Subjects holds references to the objects the role applies to.
+	Subjects []Subject `json:"subjects" description:"references to the users, groups and service accounts the role is granted to"`
+
+	// RoleRef references the role being granted.
+	RoleRef ObjectReference `json:"roleRef" description:"reference to a Role or ClusterRole in the namespace of the binding; the namespace of a ClusterRole reference is ignored"`
+}
+
+// RoleBindingList is a collection of RoleBindings
+type RoleBindingList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []RoleBinding `json:"items" description:"list of RoleBindings"`
+}
+
+// ClusterRoleBinding grants the permissions of a ClusterRole to subjects in every namespace and on
+// cluster-scoped resources.
+type ClusterRoleBinding struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata,omitempty" description:"standard object metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	// Subjects holds references to the objects the role applies to.
+	Subjects []Subject `json:"subjects" description:"references to the users, groups and service accounts the role is granted to"`
+
+	// RoleRef references the role being granted.
+	RoleRef ObjectReference `json:"roleRef" description:"reference to a ClusterRole"`
+}
+
+// ClusterRoleBindingList is a collection of ClusterRoleBindings
+type ClusterRoleBindingList struct {
+	TypeMeta `json:",inline"`
+	ListMeta `json:"metadata,omitempty" description:"standard list metadata; see https://github.com/GoogleCloudPlatform/kubernetes/blob/master/docs/api-conventions.md#metadata"`
+
+	Items []ClusterRoleBinding `json:"items" description:"list of ClusterRoleBindings"`
+}
@@ -151,6 +151,13 @@ func ValidateSecretName(name string, prefix bool) (bool, string) {
 	return nameIsDNSSubdomain(name, prefix)
 }
 
+// ValidateServiceAccountName can be used to check whether the given service account name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+func ValidateServiceAccountName(name string, prefix bool) (bool, string) {
+	return nameIsDNSSubdomain(name, prefix)
+}
+
 // ValidateEndpointsName can be used to check whether the given endpoints name is valid.
 // Prefix indicates this name will be used as part of generation, in which case
 // trailing dashes are allowed.
@@ -305,6 +312,10 @@ func validateSource(source *api.VolumeSource) errs.ValidationErrorList {
 		numVolumes++
 		allErrs = append(allErrs, validateNFS(source.NFS).Prefix("nfs")...)
 	}
+	if source.PersistentVolumeClaimVolumeSource != nil {
+		numVolumes++
+		allErrs = append(allErrs, validatePersistentClaimVolumeSource(source.PersistentVolumeClaimVolumeSource).Prefix("persistentVolumeClaim")...)
+	}
 	if numVolumes != 1 {
 		allErrs = append(allErrs, errs.NewFieldInvalid("", source, "exactly 1 volume type is required"))
 	}
@@ -363,7 +374,22 @@ func validateNFS(nfs *api.NFSVolumeSource) errs.ValidationErrorList {
 	return allErrs
 }
 
+func validatePersistentClaimVolumeSource(claim *api.PersistentVolumeClaimVolumeSource) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if claim.ClaimName == "" {
+		allErrs = append(allErrs, errs.NewFieldRequired("claimName"))
+	}
+	return allErrs
+}
+
+// ValidatePersistentVolumeName can be used to check whether the given persistent
+// volume or claim name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
 func ValidatePersistentVolumeName(name string, prefix bool) (bool, string) {
+	if prefix {
+		name = maskTrailingDash(name)
+	}
 	return util.IsDNS1123Label(name), name
 }
 
@@ -404,6 +430,48 @@ func ValidatePersistentVolumeClaim(pvc *api.PersistentVolumeClaim) errs.Validati
 	return allErrs
 }
 
+// ValidatePersistentVolumeUpdate tests to see if the update is legal for an end user to make.
+// newPv is updated with fields that cannot be changed.
+func ValidatePersistentVolumeUpdate(newPv, oldPv *api.PersistentVolume) errs.ValidationErrorList {
+	allErrs := ValidatePersistentVolume(newPv)
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldPv.ObjectMeta, &newPv.ObjectMeta).Prefix("metadata")...)
+	newPv.Status = oldPv.Status
+	return allErrs
+}
+
+// ValidatePersistentVolumeStatusUpdate tests to see if the status update is legal for an end user to make.
+// newPv is updated with fields that cannot be changed.
+func ValidatePersistentVolumeStatusUpdate(newPv, oldPv *api.PersistentVolume) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldPv.ObjectMeta, &newPv.ObjectMeta).Prefix("metadata")...)
+	if newPv.ResourceVersion == "" {
+		allErrs = append(allErrs, errs.NewFieldRequired("resourceVersion"))
+	}
+	newPv.Spec = oldPv.Spec
+	return allErrs
+}
+
+// ValidatePersistentVolumeClaimUpdate tests to see if the update is legal for an end user to make.
+// newPvc is updated with fields that cannot be changed.
+func ValidatePersistentVolumeClaimUpdate(newPvc, oldPvc *api.PersistentVolumeClaim) errs.ValidationErrorList {
+	allErrs := ValidatePersistentVolumeClaim(newPvc)
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldPvc.ObjectMeta, &newPvc.ObjectMeta).Prefix("metadata")...)
+	newPvc.Status = oldPvc.Status
+	return allErrs
+}
+
+// ValidatePersistentVolumeClaimStatusUpdate tests to see if the status update is legal for an end user to make.
+// newPvc is updated with fields that cannot be changed.
+func ValidatePersistentVolumeClaimStatusUpdate(newPvc, oldPvc *api.PersistentVolumeClaim) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldPvc.ObjectMeta, &newPvc.ObjectMeta).Prefix("metadata")...)
+	if newPvc.ResourceVersion == "" {
+		allErrs = append(allErrs, errs.NewFieldRequired("resourceVersion"))
+	}
+	newPvc.Spec = oldPvc.Spec
+	return allErrs
+}
+
 var supportedPortProtocols = util.NewStringSet(string(api.ProtocolTCP), string(api.ProtocolUDP))
 
 func validatePorts(ports []api.ContainerPort) errs.ValidationErrorList {
@@ -622,6 +690,9 @@ func validateContainers(containers []api.Container, volumes util.StringSet) errs
 		if len(ctr.Image) == 0 {
 			cErrs = append(cErrs, errs.NewFieldRequired("image"))
 		}
+		if ctr.TTY && !ctr.Stdin {
+			cErrs = append(cErrs, errs.NewFieldInvalid("tty", ctr.TTY, "requires stdin"))
+		}
 		if ctr.Lifecycle != nil {
 			cErrs = append(cErrs, validateLifecycle(ctr.Lifecycle).Prefix("lifecycle")...)
 		}
@@ -632,6 +703,7 @@ func validateContainers(containers []api.Container, volumes util.StringSet) errs
 		cErrs = append(cErrs, validateVolumeMounts(ctr.VolumeMounts, volumes).Prefix("volumeMounts")...)
 		cErrs = append(cErrs, validatePullPolicy(&ctr).Prefix("pullPolicy")...)
 		cErrs = append(cErrs, validateResourceRequirements(&ctr).Prefix("resources")...)
+		cErrs = append(cErrs, validateSecurityContext(ctr.SecurityContext).Prefix("securityContext")...)
 		allErrs = append(allErrs, cErrs.PrefixIndex(i)...)
 	}
 	// Check for colliding ports across all containers.
@@ -644,6 +716,22 @@ func validateContainers(containers []api.Container, volumes util.StringSet) errs
 	return allErrs
 }
 
+// validateSecurityContext tests that the security options of a pod or container are consistent.
+func validateSecurityContext(sc *api.SecurityContext) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if sc == nil {
+		return allErrs
+	}
+	if sc.RunAsUser != nil {
+		if *sc.RunAsUser < 0 {
+			allErrs = append(allErrs, errs.NewFieldInvalid("runAsUser", *sc.RunAsUser, "runAsUser cannot be negative"))
+		} else if *sc.RunAsUser == 0 && sc.RunAsNonRoot {
+			allErrs = append(allErrs, errs.NewFieldInvalid("runAsUser", *sc.RunAsUser, "runAsUser cannot be 0 (root) if runAsNonRoot is set"))
+		}
+	}
+	return allErrs
+}
+
 var supportedManifestVersions = util.NewStringSet("v1beta1", "v1beta2")
 
 // ValidateManifest tests that the specified ContainerManifest has valid data.
@@ -731,6 +819,12 @@ func ValidatePodSpec(spec *api.PodSpec) errs.ValidationErrorList {
 	allErrs = append(allErrs, validateDNSPolicy(&spec.DNSPolicy).Prefix("dnsPolicy")...)
 	allErrs = append(allErrs, ValidateLabels(spec.NodeSelector, "nodeSelector")...)
 	allErrs = append(allErrs, validateHostNetwork(spec.HostNetwork, spec.Containers).Prefix("hostNetwork")...)
+	if len(spec.ServiceAccount) > 0 {
+		if ok, msg := ValidateServiceAccountName(spec.ServiceAccount, false); !ok {
+			allErrs = append(allErrs, errs.NewFieldInvalid("serviceAccount", spec.ServiceAccount, msg))
+		}
+	}
+	allErrs = append(allErrs, validateSecurityContext(spec.SecurityContext).Prefix("securityContext")...)
 	return allErrs
 }
 
@@ -780,24 +874,19 @@ func ValidatePodStatusUpdate(newPod, oldPod *api.Pod) errs.ValidationErrorList {
 }
 
 var supportedSessionAffinityType = util.NewStringSet(string(api.AffinityTypeClientIP), string(api.AffinityTypeNone))
+var supportedServiceType = util.NewStringSet(string(api.ServiceTypeClusterIP), string(api.ServiceTypeNodePort))
 
 // ValidateService tests if required fields in the service are set.
 func ValidateService(service *api.Service) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 	allErrs = append(allErrs, ValidateObjectMeta(&service.ObjectMeta, true, ValidateServiceName).Prefix("metadata")...)
 
-	if !util.IsValidPortNum(service.Spec.Port) {
-		allErrs = append(allErrs, errs.NewFieldInvalid("spec.port", service.Spec.Port, portRangeErrorMsg))
+	if len(service.Spec.Ports) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("spec.ports"))
 	}
-	if len(service.Spec.Protocol) == 0 {
-		allErrs = append(allErrs, errs.NewFieldRequired("spec.protocol"))
-	} else if !supportedPortProtocols.Has(strings.ToUpper(string(service.Spec.Protocol))) {
-		allErrs = append(allErrs, errs.NewFieldNotSupported("spec.protocol", service.Spec.Protocol))
-	}
-	if service.Spec.TargetPort.Kind == util.IntstrInt && service.Spec.TargetPort.IntVal != 0 && !util.IsValidPortNum(service.Spec.TargetPort.IntVal) {
-		allErrs = append(allErrs, errs.NewFieldInvalid("spec.containerPort", service.Spec.Port, portRangeErrorMsg))
-	} else if service.Spec.TargetPort.Kind == util.IntstrString && len(service.Spec.TargetPort.StrVal) == 0 {
-		allErrs = append(allErrs, errs.NewFieldRequired("spec.containerPort"))
+	allPortNames := util.StringSet{}
+	for i := range service.Spec.Ports {
+		allErrs = append(allErrs, validateServicePort(&service.Spec.Ports[i], len(service.Spec.Ports) > 1, allPortNames).PrefixIndex(i).Prefix("spec.ports")...)
 	}
 
 	if service.Spec.Selector != nil {
@@ -824,6 +913,70 @@ func ValidateService(service *api.Service) errs.ValidationErrorList {
 		}
 	}
 
+	if service.Spec.Type != "" && !supportedServiceType.Has(string(service.Spec.Type)) {
+		allErrs = append(allErrs, errs.NewFieldNotSupported("spec.type", service.Spec.Type))
+	}
+
+	// Node ports may only be requested by NodePort services, and a given
+	// node port can only be used once per protocol.
+	nodePorts := map[api.ServicePort]bool{}
+	for i := range service.Spec.Ports {
+		sp := &service.Spec.Ports[i]
+		if sp.NodePort == 0 {
+			continue
+		}
+		field := fmt.Sprintf("spec.ports[%d].nodePort", i)
+		if service.Spec.Type != api.ServiceTypeNodePort {
+			allErrs = append(allErrs, errs.NewFieldInvalid(field, sp.NodePort, "may not be specified unless type is NodePort"))
+			continue
+		}
+		if !util.IsValidPortNum(sp.NodePort) {
+			allErrs = append(allErrs, errs.NewFieldInvalid(field, sp.NodePort, portRangeErrorMsg))
+			continue
+		}
+		key := api.ServicePort{Protocol: sp.Protocol, NodePort: sp.NodePort}
+		if nodePorts[key] {
+			allErrs = append(allErrs, errs.NewFieldDuplicate(field, sp.NodePort))
+		}
+		nodePorts[key] = true
+	}
+
+	return allErrs
+}
+
+// validateServicePort tests a single port of a service.  Names are required
+// when a service has more than one port and must be unique within allNames.
+func validateServicePort(sp *api.ServicePort, requireName bool, allNames util.StringSet) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+
+	if requireName && len(sp.Name) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("name"))
+	} else if len(sp.Name) > 0 {
+		if len(sp.Name) > util.DNS1123LabelMaxLength || !util.IsDNS1123Label(sp.Name) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("name", sp.Name, dns1123LabelErrorMsg))
+		} else if allNames.Has(sp.Name) {
+			allErrs = append(allErrs, errs.NewFieldDuplicate("name", sp.Name))
+		} else {
+			allNames.Insert(sp.Name)
+		}
+	}
+
+	if !util.IsValidPortNum(sp.Port) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("port", sp.Port, portRangeErrorMsg))
+	}
+
+	if len(sp.Protocol) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("protocol"))
+	} else if !supportedPortProtocols.Has(strings.ToUpper(string(sp.Protocol))) {
+		allErrs = append(allErrs, errs.NewFieldNotSupported("protocol", sp.Protocol))
+	}
+
+	if sp.TargetPort.Kind == util.IntstrInt && sp.TargetPort.IntVal != 0 && !util.IsValidPortNum(sp.TargetPort.IntVal) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("targetPort", sp.TargetPort, portRangeErrorMsg))
+	} else if sp.TargetPort.Kind == util.IntstrString && len(sp.TargetPort.StrVal) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("targetPort"))
+	}
+
 	return allErrs
 }
 
@@ -910,6 +1063,360 @@ func ValidateReadOnlyPersistentDisks(volumes []api.Volume) errs.ValidationErrorL
 	return allErrs
 }
 
+// ValidateDeploymentName can be used to check whether the given deployment
+// name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+func ValidateDeploymentName(name string, prefix bool) (bool, string) {
+	return nameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateDeployment tests if required fields in the deployment are set.
+func ValidateDeployment(deployment *api.Deployment) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&deployment.ObjectMeta, true, ValidateDeploymentName).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateDeploymentSpec(&deployment.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+// ValidateDeploymentUpdate tests to see if the update is legal for an end user to make.
+// deployment is updated with fields that cannot be changed.
+func ValidateDeploymentUpdate(oldDeployment, deployment *api.Deployment) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldDeployment.ObjectMeta, &deployment.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateDeploymentSpec(&deployment.Spec).Prefix("spec")...)
+	deployment.Status = oldDeployment.Status
+	return allErrs
+}
+
+// ValidateDeploymentStatusUpdate tests to see if the status update is legal for an end user to make.
+// deployment is updated with fields that cannot be changed.
+func ValidateDeploymentStatusUpdate(oldDeployment, deployment *api.Deployment) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldDeployment.ObjectMeta, &deployment.ObjectMeta).Prefix("metadata")...)
+	if deployment.Status.Replicas < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.replicas", deployment.Status.Replicas, isNegativeErrorMsg))
+	}
+	if deployment.Status.UpdatedReplicas < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.updatedReplicas", deployment.Status.UpdatedReplicas, isNegativeErrorMsg))
+	}
+	if deployment.Status.Revision < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.revision", deployment.Status.Revision, isNegativeErrorMsg))
+	}
+	deployment.Spec = oldDeployment.Spec
+	return allErrs
+}
+
+// ValidateDeploymentSpec tests if required fields in the deployment spec are set.
+func ValidateDeploymentSpec(spec *api.DeploymentSpec) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+
+	selector := labels.Set(spec.Selector).AsSelector()
+	if selector.Empty() {
+		allErrs = append(allErrs, errs.NewFieldRequired("selector"))
+	}
+	if spec.Replicas < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("replicas", spec.Replicas, isNegativeErrorMsg))
+	}
+	if spec.Template == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("template"))
+	} else {
+		if !selector.Matches(labels.Set(spec.Template.Labels)) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("template.labels", spec.Template.Labels, "selector does not match template"))
+		}
+		allErrs = append(allErrs, ValidatePodTemplateSpec(spec.Template, spec.Replicas).Prefix("template")...)
+		if spec.Template.Spec.RestartPolicy != api.RestartPolicyAlways {
+			allErrs = append(allErrs, errs.NewFieldNotSupported("template.restartPolicy", spec.Template.Spec.RestartPolicy))
+		}
+	}
+	allErrs = append(allErrs, validateDeploymentStrategy(&spec.Strategy).Prefix("strategy")...)
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("revisionHistoryLimit", *spec.RevisionHistoryLimit, isNegativeErrorMsg))
+	}
+	if spec.RollbackTo != nil && spec.RollbackTo.Revision < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("rollbackTo.revision", spec.RollbackTo.Revision, isNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func validateDeploymentStrategy(strategy *api.DeploymentStrategy) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	switch strategy.Type {
+	case api.RecreateDeploymentStrategyType:
+		if strategy.RollingUpdate != nil {
+			allErrs = append(allErrs, errs.NewFieldForbidden("rollingUpdate", strategy.RollingUpdate))
+		}
+	case api.RollingUpdateDeploymentStrategyType:
+		if strategy.RollingUpdate == nil {
+			allErrs = append(allErrs, errs.NewFieldRequired("rollingUpdate"))
+			break
+		}
+		allErrs = append(allErrs, validateIntOrPercent(&strategy.RollingUpdate.MaxUnavailable, "rollingUpdate.maxUnavailable")...)
+		allErrs = append(allErrs, validateIntOrPercent(&strategy.RollingUpdate.MaxSurge, "rollingUpdate.maxSurge")...)
+		if isZeroIntOrPercent(&strategy.RollingUpdate.MaxUnavailable) && isZeroIntOrPercent(&strategy.RollingUpdate.MaxSurge) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("rollingUpdate.maxUnavailable", strategy.RollingUpdate.MaxUnavailable.String(), "may not be 0 when maxSurge is 0"))
+		}
+	case "":
+		allErrs = append(allErrs, errs.NewFieldRequired("type"))
+	default:
+		allErrs = append(allErrs, errs.NewFieldNotSupported("type", strategy.Type))
+	}
+	return allErrs
+}
+
+// validateIntOrPercent checks that value is a non-negative int or a percentage
+// between 0% and 100%.
+func validateIntOrPercent(value *util.IntOrString, field string) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	switch value.Kind {
+	case util.IntstrInt:
+		if value.IntVal < 0 {
+			allErrs = append(allErrs, errs.NewFieldInvalid(field, value.IntVal, isNegativeErrorMsg))
+		}
+	case util.IntstrString:
+		percent, err := util.GetScaledValueFromIntOrPercent(value, 100, false)
+		if err != nil || percent < 0 || percent > 100 {
+			allErrs = append(allErrs, errs.NewFieldInvalid(field, value.StrVal, "must be an integer or a percentage between 0% and 100%"))
+		}
+	default:
+		allErrs = append(allErrs, errs.NewFieldInvalid(field, value, "must be an integer or a percentage"))
+	}
+	return allErrs
+}
+
+func isZeroIntOrPercent(value *util.IntOrString) bool {
+	v, err := util.GetScaledValueFromIntOrPercent(value, 100, false)
+	return err == nil && v == 0
+}
+
+// ValidateJobName can be used to check whether the given job name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+func ValidateJobName(name string, prefix bool) (bool, string) {
+	return nameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateJob tests if required fields in the job are set.
+func ValidateJob(job *api.Job) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&job.ObjectMeta, true, ValidateJobName).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateJobSpec(&job.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+// ValidateJobSpec tests if required fields in the job spec are set.
+func ValidateJobSpec(spec *api.JobSpec) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+
+	if spec.Parallelism == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("parallelism"))
+	} else if *spec.Parallelism < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("parallelism", *spec.Parallelism, isNegativeErrorMsg))
+	}
+	if spec.Completions == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("completions"))
+	} else if *spec.Completions < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("completions", *spec.Completions, isNegativeErrorMsg))
+	}
+
+	selector := labels.Set(spec.Selector).AsSelector()
+	if selector.Empty() {
+		allErrs = append(allErrs, errs.NewFieldRequired("selector"))
+	}
+	if spec.Template == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("template"))
+	} else {
+		if !selector.Matches(labels.Set(spec.Template.Labels)) {
+			allErrs = append(allErrs, errs.NewFieldInvalid("template.labels", spec.Template.Labels, "selector does not match template"))
+		}
+		parallelism := 0
+		if spec.Parallelism != nil {
+			parallelism = *spec.Parallelism
+		}
+		allErrs = append(allErrs, ValidatePodTemplateSpec(spec.Template, parallelism).Prefix("template")...)
+		if spec.Template.Spec.RestartPolicy != api.RestartPolicyOnFailure &&
+			spec.Template.Spec.RestartPolicy != api.RestartPolicyNever {
+			allErrs = append(allErrs, errs.NewFieldNotSupported("template.spec.restartPolicy", spec.Template.Spec.RestartPolicy))
+		}
+	}
+	return allErrs
+}
+
+// ValidateJobUpdate tests to see if the update is legal for an end user to make.
+// Only the parallelism of a job may change. job is updated with fields that
+// cannot be changed.
+func ValidateJobUpdate(oldJob, job *api.Job) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldJob.ObjectMeta, &job.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateJobSpec(&job.Spec).Prefix("spec")...)
+
+	// Ignore the parallelism when comparing the old and new spec.
+	oldSpec := oldJob.Spec
+	oldSpec.Parallelism = job.Spec.Parallelism
+	if !api.Semantic.DeepEqual(oldSpec, job.Spec) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("spec", job.Spec, "may not update fields other than spec.parallelism"))
+	}
+	job.Status = oldJob.Status
+	return allErrs
+}
+
+// ValidateJobStatusUpdate tests to see if the status update is legal for an end user to make.
+// job is updated with fields that cannot be changed.
+func ValidateJobStatusUpdate(oldJob, job *api.Job) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldJob.ObjectMeta, &job.ObjectMeta).Prefix("metadata")...)
+	if job.Status.Active < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.active", job.Status.Active, isNegativeErrorMsg))
+	}
+	if job.Status.Succeeded < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.succeeded", job.Status.Succeeded, isNegativeErrorMsg))
+	}
+	if job.Status.Failed < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.failed", job.Status.Failed, isNegativeErrorMsg))
+	}
+	job.Spec = oldJob.Spec
+	return allErrs
+}
+
+// ValidateDaemonSetName can be used to check whether the given daemon set
+// name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+func ValidateDaemonSetName(name string, prefix bool) (bool, string) {
+	return nameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateDaemonSet tests if required fields in the daemon set are set.
+func ValidateDaemonSet(ds *api.DaemonSet) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&ds.ObjectMeta, true, ValidateDaemonSetName).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateDaemonSetSpec(&ds.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+// ValidateDaemonSetSpec tests if required fields in the daemon set spec are set.
+func ValidateDaemonSetSpec(spec *api.DaemonSetSpec) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+
+	selector := labels.Set(spec.Selector).AsSelector()
+	if selector.Empty() {
+		allErrs = append(allErrs, errs.NewFieldRequired("selector"))
+	}
+	if spec.Template == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("template"))
+		return allErrs
+	}
+	if !selector.Matches(labels.Set(spec.Template.Labels)) {
+		allErrs = append(allErrs, errs.NewFieldInvalid("template.labels", spec.Template.Labels, "selector does not match template"))
+	}
+	allErrs = append(allErrs, ValidatePodTemplateSpec(spec.Template, 0).Prefix("template")...)
+	// The same template is run on every node, so disks must be shared read-only.
+	allErrs = append(allErrs, ValidateReadOnlyPersistentDisks(spec.Template.Spec.Volumes).Prefix("template.spec.volumes")...)
+	if spec.Template.Spec.RestartPolicy != api.RestartPolicyAlways {
+		allErrs = append(allErrs, errs.NewFieldNotSupported("template.spec.restartPolicy", spec.Template.Spec.RestartPolicy))
+	}
+	if len(spec.Template.Spec.Host) != 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("template.spec.host", spec.Template.Spec.Host, "daemon pods are bound to their node by the daemon set controller"))
+	}
+	return allErrs
+}
+
+// ValidateDaemonSetUpdate tests to see if the update is legal for an end user to make.
+// ds is updated with fields that cannot be changed.
+func ValidateDaemonSetUpdate(oldDS, ds *api.DaemonSet) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldDS.ObjectMeta, &ds.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateDaemonSetSpec(&ds.Spec).Prefix("spec")...)
+	ds.Status = oldDS.Status
+	return allErrs
+}
+
+// ValidateDaemonSetStatusUpdate tests to see if the status update is legal for an end user to make.
+// ds is updated with fields that cannot be changed.
+func ValidateDaemonSetStatusUpdate(oldDS, ds *api.DaemonSet) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldDS.ObjectMeta, &ds.ObjectMeta).Prefix("metadata")...)
+	if ds.Status.CurrentNumberScheduled < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.currentNumberScheduled", ds.Status.CurrentNumberScheduled, isNegativeErrorMsg))
+	}
+	if ds.Status.NumberMisscheduled < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.numberMisscheduled", ds.Status.NumberMisscheduled, isNegativeErrorMsg))
+	}
+	if ds.Status.DesiredNumberScheduled < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.desiredNumberScheduled", ds.Status.DesiredNumberScheduled, isNegativeErrorMsg))
+	}
+	ds.Spec = oldDS.Spec
+	return allErrs
+}
+
+// ValidateHorizontalPodAutoscalerName can be used to check whether the given
+// autoscaler name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+func ValidateHorizontalPodAutoscalerName(name string, prefix bool) (bool, string) {
+	return nameIsDNSSubdomain(name, prefix)
+}
+
+// ValidateHorizontalPodAutoscaler tests if required fields in the autoscaler are set.
+func ValidateHorizontalPodAutoscaler(autoscaler *api.HorizontalPodAutoscaler) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&autoscaler.ObjectMeta, true, ValidateHorizontalPodAutoscalerName).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateHorizontalPodAutoscalerSpec(&autoscaler.Spec).Prefix("spec")...)
+	return allErrs
+}
+
+// ValidateHorizontalPodAutoscalerSpec tests if required fields in the autoscaler spec are set.
+func ValidateHorizontalPodAutoscalerSpec(spec *api.HorizontalPodAutoscalerSpec) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if spec.ScaleRef.Kind != "ReplicationController" {
+		allErrs = append(allErrs, errs.NewFieldNotSupported("scaleRef.kind", spec.ScaleRef.Kind))
+	}
+	if len(spec.ScaleRef.Name) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("scaleRef.name"))
+	} else if ok, msg := ValidateReplicationControllerName(spec.ScaleRef.Name, false); !ok {
+		allErrs = append(allErrs, errs.NewFieldInvalid("scaleRef.name", spec.ScaleRef.Name, msg))
+	}
+	if spec.MinReplicas == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("minReplicas"))
+	} else if *spec.MinReplicas < 1 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("minReplicas", *spec.MinReplicas, "must be greater than 0"))
+	}
+	if spec.MinReplicas != nil && spec.MaxReplicas < *spec.MinReplicas {
+		allErrs = append(allErrs, errs.NewFieldInvalid("maxReplicas", spec.MaxReplicas, "must be greater than or equal to minReplicas"))
+	}
+	if spec.CPUUtilization == nil {
+		allErrs = append(allErrs, errs.NewFieldRequired("cpuUtilization"))
+	} else if spec.CPUUtilization.TargetPercentage < 1 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("cpuUtilization.targetPercentage", spec.CPUUtilization.TargetPercentage, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+// ValidateHorizontalPodAutoscalerUpdate tests to see if the update is legal for an end user to make.
+// autoscaler is updated with fields that cannot be changed.
+func ValidateHorizontalPodAutoscalerUpdate(oldAutoscaler, autoscaler *api.HorizontalPodAutoscaler) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldAutoscaler.ObjectMeta, &autoscaler.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateHorizontalPodAutoscalerSpec(&autoscaler.Spec).Prefix("spec")...)
+	autoscaler.Status = oldAutoscaler.Status
+	return allErrs
+}
+
+// ValidateHorizontalPodAutoscalerStatusUpdate tests to see if the status update is legal for an end user to make.
+// autoscaler is updated with fields that cannot be changed.
+func ValidateHorizontalPodAutoscalerStatusUpdate(oldAutoscaler, autoscaler *api.HorizontalPodAutoscaler) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldAutoscaler.ObjectMeta, &autoscaler.ObjectMeta).Prefix("metadata")...)
+	if autoscaler.Status.CurrentReplicas < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.currentReplicas", autoscaler.Status.CurrentReplicas, isNegativeErrorMsg))
+	}
+	if autoscaler.Status.DesiredReplicas < 0 {
+		allErrs = append(allErrs, errs.NewFieldInvalid("status.desiredReplicas", autoscaler.Status.DesiredReplicas, isNegativeErrorMsg))
+	}
+	autoscaler.Spec = oldAutoscaler.Spec
+	return allErrs
+}
+
 // ValidateMinion tests if required fields in the node are set.
 func ValidateMinion(node *api.Node) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
@@ -1010,6 +1517,179 @@ func ValidateSecret(secret *api.Secret) errs.ValidationErrorList {
 		allErrs = append(allErrs, errs.NewFieldForbidden("data", "Maximum secret size exceeded"))
 	}
 
+	switch secret.Type {
+	case api.SecretTypeServiceAccountToken:
+		// Only require Annotations[kubernetes.io/service-account.name]
+		// Additional fields (like Annotations[kubernetes.io/service-account.uid] and Data[token]) might be contributed later by a controller loop
+		if value := secret.Annotations[api.ServiceAccountNameKey]; len(value) == 0 {
+			allErrs = append(allErrs, errs.NewFieldRequired(fmt.Sprintf("metadata.annotations[%s]", api.ServiceAccountNameKey)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateServiceAccount tests if required fields in the ServiceAccount are set.
+func ValidateServiceAccount(serviceAccount *api.ServiceAccount) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&serviceAccount.ObjectMeta, true, ValidateServiceAccountName).Prefix("metadata")...)
+	return allErrs
+}
+
+// ValidateServiceAccountUpdate tests if required fields in the ServiceAccount are set.
+func ValidateServiceAccountUpdate(oldServiceAccount, newServiceAccount *api.ServiceAccount) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldServiceAccount.ObjectMeta, &newServiceAccount.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateServiceAccount(newServiceAccount)...)
+	return allErrs
+}
+
+// ValidateRoleName can be used to check whether the given role, cluster role, role binding or
+// cluster role binding name is valid.
+// Prefix indicates this name will be used as part of generation, in which case
+// trailing dashes are allowed.
+func ValidateRoleName(name string, prefix bool) (bool, string) {
+	return nameIsDNSSubdomain(name, prefix)
+}
+
+func validatePolicyRules(rules []api.PolicyRule) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	for i, rule := range rules {
+		ruleErrs := errs.ValidationErrorList{}
+		if len(rule.Verbs) == 0 {
+			ruleErrs = append(ruleErrs, errs.NewFieldRequired("verbs"))
+		}
+		for j, verb := range rule.Verbs {
+			if len(verb) == 0 {
+				ruleErrs = append(ruleErrs, errs.NewFieldRequired(fmt.Sprintf("verbs[%d]", j)))
+			}
+		}
+		if len(rule.Resources) == 0 {
+			ruleErrs = append(ruleErrs, errs.NewFieldRequired("resources"))
+		}
+		for j, resource := range rule.Resources {
+			if len(resource) == 0 {
+				ruleErrs = append(ruleErrs, errs.NewFieldRequired(fmt.Sprintf("resources[%d]", j)))
+			}
+		}
+		allErrs = append(allErrs, ruleErrs.PrefixIndex(i)...)
+	}
+	return allErrs
+}
+
+// ValidateRole tests if required fields in the Role are set.
+func ValidateRole(role *api.Role) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&role.ObjectMeta, true, ValidateRoleName).Prefix("metadata")...)
+	allErrs = append(allErrs, validatePolicyRules(role.Rules).Prefix("rules")...)
+	return allErrs
+}
+
+// ValidateRoleUpdate tests if an update to a Role is valid.
+func ValidateRoleUpdate(oldRole, role *api.Role) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldRole.ObjectMeta, &role.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateRole(role)...)
+	return allErrs
+}
+
+// ValidateClusterRole tests if required fields in the ClusterRole are set.
+func ValidateClusterRole(role *api.ClusterRole) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&role.ObjectMeta, false, ValidateRoleName).Prefix("metadata")...)
+	allErrs = append(allErrs, validatePolicyRules(role.Rules).Prefix("rules")...)
+	return allErrs
+}
+
+// ValidateClusterRoleUpdate tests if an update to a ClusterRole is valid.
+func ValidateClusterRoleUpdate(oldRole, role *api.ClusterRole) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldRole.ObjectMeta, &role.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateClusterRole(role)...)
+	return allErrs
+}
+
+func validateSubjects(subjects []api.Subject) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	for i, subject := range subjects {
+		subjectErrs := errs.ValidationErrorList{}
+		if len(subject.Name) == 0 {
+			subjectErrs = append(subjectErrs, errs.NewFieldRequired("name"))
+		}
+		switch subject.Kind {
+		case api.ServiceAccountKind:
+			if len(subject.Name) > 0 {
+				if ok, msg := ValidateServiceAccountName(subject.Name, false); !ok {
+					subjectErrs = append(subjectErrs, errs.NewFieldInvalid("name", subject.Name, msg))
+				}
+			}
+			if len(subject.Namespace) > 0 {
+				if ok, msg := ValidateNamespaceName(subject.Namespace, false); !ok {
+					subjectErrs = append(subjectErrs, errs.NewFieldInvalid("namespace", subject.Namespace, msg))
+				}
+			}
+		case api.UserKind, api.GroupKind:
+			if len(subject.Namespace) > 0 {
+				subjectErrs = append(subjectErrs, errs.NewFieldForbidden("namespace", subject.Namespace))
+			}
+		default:
+			subjectErrs = append(subjectErrs, errs.NewFieldNotSupported("kind", subject.Kind))
+		}
+		allErrs = append(allErrs, subjectErrs.PrefixIndex(i)...)
+	}
+	return allErrs
+}
+
+func validateRoleRef(roleRef *api.ObjectReference, allowedKinds ...string) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	if !util.NewStringSet(allowedKinds...).Has(roleRef.Kind) {
+		allErrs = append(allErrs, errs.NewFieldNotSupported("kind", roleRef.Kind))
+	}
+	if len(roleRef.Name) == 0 {
+		allErrs = append(allErrs, errs.NewFieldRequired("name"))
+	} else if ok, msg := ValidateRoleName(roleRef.Name, false); !ok {
+		allErrs = append(allErrs, errs.NewFieldInvalid("name", roleRef.Name, msg))
+	}
+	return allErrs
+}
+
+// ValidateRoleBinding tests if required fields in the RoleBinding are set.
+func ValidateRoleBinding(binding *api.RoleBinding) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&binding.ObjectMeta, true, ValidateRoleName).Prefix("metadata")...)
+	allErrs = append(allErrs, validateSubjects(binding.Subjects).Prefix("subjects")...)
+	allErrs = append(allErrs, validateRoleRef(&binding.RoleRef, "Role", "ClusterRole").Prefix("roleRef")...)
+	return allErrs
+}
+
+// ValidateRoleBindingUpdate tests if an update to a RoleBinding is valid.  The granted role cannot change.
+func ValidateRoleBindingUpdate(oldBinding, binding *api.RoleBinding) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldBinding.ObjectMeta, &binding.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateRoleBinding(binding)...)
+	if oldBinding.RoleRef.Kind != binding.RoleRef.Kind || oldBinding.RoleRef.Name != binding.RoleRef.Name {
+		allErrs = append(allErrs, errs.NewFieldInvalid("roleRef", binding.RoleRef, "cannot change roleRef"))
+	}
+	return allErrs
+}
+
+// ValidateClusterRoleBinding tests if required fields in the ClusterRoleBinding are set.
+func ValidateClusterRoleBinding(binding *api.ClusterRoleBinding) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMeta(&binding.ObjectMeta, false, ValidateRoleName).Prefix("metadata")...)
+	allErrs = append(allErrs, validateSubjects(binding.Subjects).Prefix("subjects")...)
+	allErrs = append(allErrs, validateRoleRef(&binding.RoleRef, "ClusterRole").Prefix("roleRef")...)
+	return allErrs
+}
+
+// ValidateClusterRoleBindingUpdate tests if an update to a ClusterRoleBinding is valid.  The granted role cannot change.
+func ValidateClusterRoleBindingUpdate(oldBinding, binding *api.ClusterRoleBinding) errs.ValidationErrorList {
+	allErrs := errs.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldBinding.ObjectMeta, &binding.ObjectMeta).Prefix("metadata")...)
+	allErrs = append(allErrs, ValidateClusterRoleBinding(binding)...)
+	if oldBinding.RoleRef.Kind != binding.RoleRef.Kind || oldBinding.RoleRef.Name != binding.RoleRef.Name {
+		allErrs = append(allErrs, errs.NewFieldInvalid("roleRef", binding.RoleRef, "cannot change roleRef"))
+	}
 	return allErrs
 }
 
@@ -1035,13 +1715,26 @@ func validateResourceRequirements(container *api.Container) errs.ValidationError
 	return allErrs
 }
 
+// validateQuotaResourceName validates a resource name a quota can be set
+// for.  Object counts are only tracked for the countable resources.
+func validateQuotaResourceName(value string, field string) errs.ValidationErrorList {
+	allErrs := validateResourceName(value, field)
+	if strings.HasPrefix(value, api.ResourceCountPrefix) {
+		resource := strings.TrimPrefix(value, api.ResourceCountPrefix)
+		if !api.IsCountableResourceName(resource) {
+			allErrs = append(allErrs, errs.NewFieldInvalid(field, value, "objects of this resource cannot be counted, must be "+api.ResourceCountPrefix+"<resource> with one of: "+strings.Join(api.CountableResourceNames(), ", ")))
+		}
+	}
+	return allErrs
+}
+
 // ValidateResourceQuota tests if required fields in the ResourceQuota are set.
 func ValidateResourceQuota(resourceQuota *api.ResourceQuota) errs.ValidationErrorList {
 	allErrs := errs.ValidationErrorList{}
 	allErrs = append(allErrs, ValidateObjectMeta(&resourceQuota.ObjectMeta, true, ValidateResourceQuotaName).Prefix("metadata")...)
 
 	for k := range resourceQuota.Spec.Hard {
-		allErrs = append(allErrs, validateResourceName(string(k), string(resourceQuota.TypeMeta.Kind))...)
+		allErrs = append(allErrs, validateQuotaResourceName(string(k), string(resourceQuota.TypeMeta.Kind))...)
 	}
 	for k := range resourceQuota.Status.Hard {
 		allErrs = append(allErrs, validateResourceName(string(k), string(resourceQuota.TypeMeta.Kind))...)
@@ -1058,7 +1751,7 @@ func ValidateResourceQuotaUpdate(newResourceQuota, oldResourceQuota *api.Resourc
 	allErrs := errs.ValidationErrorList{}
 	allErrs = append(allErrs, ValidateObjectMetaUpdate(&oldResourceQuota.ObjectMeta, &newResourceQuota.ObjectMeta).Prefix("metadata")...)
 	for k := range newResourceQuota.Spec.Hard {
-		allErrs = append(allErrs, validateResourceName(string(k), string(newResourceQuota.TypeMeta.Kind))...)
+		allErrs = append(allErrs, validateQuotaResourceName(string(k), string(newResourceQuota.TypeMeta.Kind))...)
 	}
 	newResourceQuota.Status = oldResourceQuota.Status
 	return allErrs
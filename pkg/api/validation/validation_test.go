@@ -366,6 +366,70 @@ func TestValidatePersistentVolumeClaim(t *testing.T) {
 	}
 }
 
+func TestValidatePersistentVolumeStatusUpdate(t *testing.T) {
+	oldVolume := testVolume("foo", "", api.PersistentVolumeSpec{
+		Capacity: api.ResourceList{
+			api.ResourceName(api.ResourceStorage): resource.MustParse("10G"),
+		},
+		PersistentVolumeSource: api.PersistentVolumeSource{
+			HostPath: &api.HostPathVolumeSource{Path: "/foo"},
+		},
+	})
+	oldVolume.ResourceVersion = "1"
+	newVolume := testVolume("foo", "", api.PersistentVolumeSpec{
+		Capacity: api.ResourceList{
+			api.ResourceName(api.ResourceStorage): resource.MustParse("20G"),
+		},
+	})
+	newVolume.ResourceVersion = "1"
+	newVolume.Status.Phase = api.VolumeBound
+
+	if errs := ValidatePersistentVolumeStatusUpdate(newVolume, oldVolume); len(errs) != 0 {
+		t.Errorf("Unexpected failure: %v", errs)
+	}
+	if newVolume.Spec.HostPath == nil || newVolume.Spec.HostPath.Path != "/foo" {
+		t.Errorf("Expected spec to be preserved on status update, got %#v", newVolume.Spec)
+	}
+	if newVolume.Status.Phase != api.VolumeBound {
+		t.Errorf("Expected status to be updated, got %#v", newVolume.Status)
+	}
+
+	newVolume.ResourceVersion = ""
+	if errs := ValidatePersistentVolumeStatusUpdate(newVolume, oldVolume); len(errs) == 0 {
+		t.Errorf("Expected failure for missing resourceVersion")
+	}
+}
+
+func TestValidatePersistentVolumeClaimUpdate(t *testing.T) {
+	spec := api.PersistentVolumeClaimSpec{
+		AccessModes: []api.AccessModeType{api.ReadWriteOnce},
+		Resources: api.ResourceRequirements{
+			Requests: api.ResourceList{
+				api.ResourceName(api.ResourceStorage): resource.MustParse("10G"),
+			},
+		},
+	}
+	oldClaim := testVolumeClaim("foo", "ns", spec)
+	oldClaim.ResourceVersion = "1"
+	oldClaim.Status.Phase = api.ClaimBound
+	newClaim := testVolumeClaim("foo", "ns", spec)
+	newClaim.ResourceVersion = "1"
+	newClaim.Status.Phase = api.ClaimPending
+
+	if errs := ValidatePersistentVolumeClaimUpdate(newClaim, oldClaim); len(errs) != 0 {
+		t.Errorf("Unexpected failure: %v", errs)
+	}
+	if newClaim.Status.Phase != api.ClaimBound {
+		t.Errorf("Expected status to be preserved on update, got %#v", newClaim.Status)
+	}
+
+	renamed := testVolumeClaim("bar", "ns", spec)
+	renamed.ResourceVersion = "1"
+	if errs := ValidatePersistentVolumeClaimUpdate(renamed, oldClaim); len(errs) == 0 {
+		t.Errorf("Expected failure for a renamed claim")
+	}
+}
+
 func TestValidateVolumes(t *testing.T) {
 	successCase := []api.Volume{
 		{Name: "abc", VolumeSource: api.VolumeSource{HostPath: &api.HostPathVolumeSource{"/mnt/path1"}}},
@@ -375,12 +439,13 @@ func TestValidateVolumes(t *testing.T) {
 		{Name: "gcepd", VolumeSource: api.VolumeSource{GCEPersistentDisk: &api.GCEPersistentDiskVolumeSource{"my-PD", "ext4", 1, false}}},
 		{Name: "gitrepo", VolumeSource: api.VolumeSource{GitRepo: &api.GitRepoVolumeSource{"my-repo", "hashstring"}}},
 		{Name: "secret", VolumeSource: api.VolumeSource{Secret: &api.SecretVolumeSource{"my-secret"}}},
+		{Name: "claim", VolumeSource: api.VolumeSource{PersistentVolumeClaimVolumeSource: &api.PersistentVolumeClaimVolumeSource{ClaimName: "my-claim"}}},
 	}
 	names, errs := validateVolumes(successCase)
 	if len(errs) != 0 {
 		t.Errorf("expected success: %v", errs)
 	}
-	if len(names) != len(successCase) || !names.HasAll("abc", "123", "abc-123", "empty", "gcepd", "gitrepo", "secret") {
+	if len(names) != len(successCase) || !names.HasAll("abc", "123", "abc-123", "empty", "gcepd", "gitrepo", "secret", "claim") {
 		t.Errorf("wrong names result: %v", names)
 	}
 	emptyVS := api.VolumeSource{EmptyDir: &api.EmptyDirVolumeSource{}}
@@ -393,6 +458,7 @@ func TestValidateVolumes(t *testing.T) {
 		"name > 63 characters": {[]api.Volume{{Name: strings.Repeat("a", 64), VolumeSource: emptyVS}}, errors.ValidationErrorTypeInvalid, "[0].name"},
 		"name not a DNS label": {[]api.Volume{{Name: "a.b.c", VolumeSource: emptyVS}}, errors.ValidationErrorTypeInvalid, "[0].name"},
 		"name not unique":      {[]api.Volume{{Name: "abc", VolumeSource: emptyVS}, {Name: "abc", VolumeSource: emptyVS}}, errors.ValidationErrorTypeDuplicate, "[1].name"},
+		"claim without name":   {[]api.Volume{{Name: "claim", VolumeSource: api.VolumeSource{PersistentVolumeClaimVolumeSource: &api.PersistentVolumeClaimVolumeSource{}}}}, errors.ValidationErrorTypeRequired, "[0].source.persistentVolumeClaim.claimName"},
 	}
 	for k, v := range errorCases {
 		_, errs := validateVolumes(v.V)
@@ -630,6 +696,12 @@ func getResourceLimits(cpu, memory string) api.ResourceList {
 	return res
 }
 
+var (
+	uid0        int64 = 0
+	uid1000     int64 = 1000
+	uidNegative int64 = -1
+)
+
 func TestValidateContainers(t *testing.T) {
 	volumes := util.StringSet{}
 	capabilities.SetForTests(capabilities.Capabilities{
@@ -663,6 +735,18 @@ func TestValidateContainers(t *testing.T) {
 			ImagePullPolicy: "IfNotPresent",
 		},
 		{Name: "abc-1234", Image: "image", Privileged: true, ImagePullPolicy: "IfNotPresent"},
+		{
+			Name:            "security-context",
+			Image:           "image",
+			ImagePullPolicy: "IfNotPresent",
+			SecurityContext: &api.SecurityContext{
+				RunAsUser:              &uid1000,
+				RunAsNonRoot:           true,
+				ReadOnlyRootFilesystem: true,
+				SELinuxOptions:         &api.SELinuxOptions{User: "user_u", Role: "role_r", Type: "type_t", Level: "s0:c1,c2"},
+			},
+		},
+		{Name: "interactive", Image: "image", ImagePullPolicy: "IfNotPresent", Stdin: true, TTY: true},
 	}
 	if errs := validateContainers(successCase, volumes); len(errs) != 0 {
 		t.Errorf("expected success: %v", errs)
@@ -680,6 +764,13 @@ func TestValidateContainers(t *testing.T) {
 			{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent"},
 		},
 		"zero-length image": {{Name: "abc", Image: "", ImagePullPolicy: "IfNotPresent"}},
+		"tty without stdin": {{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent", TTY: true}},
+		"negative runAsUser": {
+			{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent", SecurityContext: &api.SecurityContext{RunAsUser: &uidNegative}},
+		},
+		"runAsUser root with runAsNonRoot": {
+			{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent", SecurityContext: &api.SecurityContext{RunAsUser: &uid0, RunAsNonRoot: true}},
+		},
 		"host port not unique": {
 			{Name: "abc", Image: "image", Ports: []api.ContainerPort{{ContainerPort: 80, HostPort: 80, Protocol: "TCP"}},
 				ImagePullPolicy: "IfNotPresent"},
@@ -885,6 +976,18 @@ func TestValidatePodSpec(t *testing.T) {
 			RestartPolicy: api.RestartPolicyAlways,
 			DNSPolicy:     api.DNSClusterFirst,
 		},
+		{ // Populate ServiceAccount.
+			Containers:     []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			ServiceAccount: "acct",
+			RestartPolicy:  api.RestartPolicyAlways,
+			DNSPolicy:      api.DNSClusterFirst,
+		},
+		{ // Populate SecurityContext.
+			Containers:      []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			SecurityContext: &api.SecurityContext{RunAsUser: &uid1000, RunAsNonRoot: true},
+			RestartPolicy:   api.RestartPolicyAlways,
+			DNSPolicy:       api.DNSClusterFirst,
+		},
 	}
 	for i := range successCases {
 		if errs := ValidatePodSpec(&successCases[i]); len(errs) != 0 {
@@ -928,6 +1031,18 @@ func TestValidatePodSpec(t *testing.T) {
 			RestartPolicy: api.RestartPolicyAlways,
 			DNSPolicy:     api.DNSClusterFirst,
 		},
+		"bad service account name": {
+			Containers:     []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			ServiceAccount: "invalid_name",
+			RestartPolicy:  api.RestartPolicyAlways,
+			DNSPolicy:      api.DNSClusterFirst,
+		},
+		"bad security context": {
+			Containers:      []api.Container{{Name: "ctr", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+			SecurityContext: &api.SecurityContext{RunAsUser: &uid0, RunAsNonRoot: true},
+			RestartPolicy:   api.RestartPolicyAlways,
+			DNSPolicy:       api.DNSClusterFirst,
+		},
 	}
 	for k, v := range failureCases {
 		if errs := ValidatePodSpec(&v); len(errs) == 0 {
@@ -1309,14 +1424,49 @@ func TestValidateService(t *testing.T) {
 		{
 			name: "missing protocol",
 			makeSvc: func(s *api.Service) {
-				s.Spec.Protocol = ""
+				s.Spec.Ports[0].Protocol = ""
 			},
 			numErrs: 1,
 		},
 		{
 			name: "invalid protocol",
 			makeSvc: func(s *api.Service) {
-				s.Spec.Protocol = "INVALID"
+				s.Spec.Ports[0].Protocol = "INVALID"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "missing ports",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Ports = nil
+			},
+			numErrs: 1,
+		},
+		{
+			name: "empty port[0] name",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Ports[0].Name = ""
+			},
+			numErrs: 0,
+		},
+		{
+			name: "empty port[1] name",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Ports = append(s.Spec.Ports, api.ServicePort{Name: "", Protocol: "TCP", Port: 12345})
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid port name",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Ports[0].Name = "INVALID"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "dup port name",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Ports = append(s.Spec.Ports, api.ServicePort{Name: "p", Protocol: "TCP", Port: 12345})
 			},
 			numErrs: 1,
 		},
@@ -1330,28 +1480,28 @@ func TestValidateService(t *testing.T) {
 		{
 			name: "missing port",
 			makeSvc: func(s *api.Service) {
-				s.Spec.Port = 0
+				s.Spec.Ports[0].Port = 0
 			},
 			numErrs: 1,
 		},
 		{
 			name: "invalid port",
 			makeSvc: func(s *api.Service) {
-				s.Spec.Port = 65536
+				s.Spec.Ports[0].Port = 65536
 			},
 			numErrs: 1,
 		},
 		{
 			name: "missing targetPort string",
 			makeSvc: func(s *api.Service) {
-				s.Spec.TargetPort = util.NewIntOrStringFromString("")
+				s.Spec.Ports[0].TargetPort = util.NewIntOrStringFromString("")
 			},
 			numErrs: 1,
 		},
 		{
 			name: "invalid targetPort int",
 			makeSvc: func(s *api.Service) {
-				s.Spec.TargetPort = util.NewIntOrStringFromInt(65536)
+				s.Spec.Ports[0].TargetPort = util.NewIntOrStringFromInt(65536)
 			},
 			numErrs: 1,
 		},
@@ -1393,15 +1543,78 @@ func TestValidateService(t *testing.T) {
 		{
 			name: "valid 2",
 			makeSvc: func(s *api.Service) {
-				s.Spec.Protocol = "UDP"
-				s.Spec.TargetPort = util.NewIntOrStringFromInt(12345)
+				s.Spec.Ports[0].Protocol = "UDP"
+				s.Spec.Ports[0].TargetPort = util.NewIntOrStringFromInt(12345)
 			},
 			numErrs: 0,
 		},
 		{
 			name: "valid 3",
 			makeSvc: func(s *api.Service) {
-				s.Spec.TargetPort = util.NewIntOrStringFromString("http")
+				s.Spec.Ports[0].TargetPort = util.NewIntOrStringFromString("http")
+			},
+			numErrs: 0,
+		},
+		{
+			name: "valid 4",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Ports = append(s.Spec.Ports, api.ServicePort{Name: "q", Protocol: "UDP", Port: 12345, TargetPort: util.NewIntOrStringFromString("dns")})
+			},
+			numErrs: 0,
+		},
+		{
+			name: "invalid type",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = "InvalidType"
+			},
+			numErrs: 1,
+		},
+		{
+			name: "nodePort on ClusterIP service",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeClusterIP
+				s.Spec.Ports[0].NodePort = 30001
+			},
+			numErrs: 1,
+		},
+		{
+			name: "invalid nodePort",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.Ports[0].NodePort = 65536
+			},
+			numErrs: 1,
+		},
+		{
+			name: "dup nodePort",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.Ports[0].NodePort = 30001
+				s.Spec.Ports = append(s.Spec.Ports, api.ServicePort{Name: "q", Protocol: "TCP", Port: 12345, NodePort: 30001})
+			},
+			numErrs: 1,
+		},
+		{
+			name: "valid nodePort",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.Ports[0].NodePort = 30001
+			},
+			numErrs: 0,
+		},
+		{
+			name: "valid nodePort reused across protocols",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
+				s.Spec.Ports[0].NodePort = 30001
+				s.Spec.Ports = append(s.Spec.Ports, api.ServicePort{Name: "q", Protocol: "UDP", Port: 12345, NodePort: 30001})
+			},
+			numErrs: 0,
+		},
+		{
+			name: "valid NodePort type without nodePort",
+			makeSvc: func(s *api.Service) {
+				s.Spec.Type = api.ServiceTypeNodePort
 			},
 			numErrs: 0,
 		},
@@ -1432,8 +1645,7 @@ func TestValidateService(t *testing.T) {
 			Spec: api.ServiceSpec{
 				Selector:        map[string]string{"key": "val"},
 				SessionAffinity: "None",
-				Port:            8675,
-				Protocol:        "TCP",
+				Ports:           []api.ServicePort{{Name: "p", Protocol: "TCP", Port: 8675}},
 			},
 		}
 		tc.makeSvc(&svc)
@@ -1813,6 +2025,636 @@ func TestValidateReplicationController(t *testing.T) {
 	}
 }
 
+func TestValidateDeployment(t *testing.T) {
+	validSelector := map[string]string{"a": "b"}
+	validPodTemplate := api.PodTemplateSpec{
+		ObjectMeta: api.ObjectMeta{
+			Labels: validSelector,
+		},
+		Spec: api.PodSpec{
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+			Containers:    []api.Container{{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+		},
+	}
+	validStrategy := api.DeploymentStrategy{
+		Type: api.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &api.RollingUpdateDeployment{
+			MaxUnavailable: util.NewIntOrStringFromInt(1),
+			MaxSurge:       util.NewIntOrStringFromString("25%"),
+		},
+	}
+	validDeployment := func() api.Deployment {
+		template := validPodTemplate
+		strategy := validStrategy
+		return api.Deployment{
+			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
+			Spec: api.DeploymentSpec{
+				Replicas: 3,
+				Selector: validSelector,
+				Template: &template,
+				Strategy: strategy,
+			},
+		}
+	}
+	negative := -1
+
+	successCases := []api.Deployment{validDeployment()}
+	recreate := validDeployment()
+	recreate.Spec.Strategy = api.DeploymentStrategy{Type: api.RecreateDeploymentStrategyType}
+	successCases = append(successCases, recreate)
+	zeroSurge := validDeployment()
+	zeroSurge.Spec.Strategy.RollingUpdate = &api.RollingUpdateDeployment{
+		MaxUnavailable: util.NewIntOrStringFromString("100%"),
+		MaxSurge:       util.NewIntOrStringFromInt(0),
+	}
+	successCases = append(successCases, zeroSurge)
+	rollback := validDeployment()
+	rollback.Spec.Paused = true
+	rollback.Spec.RollbackTo = &api.RollbackConfig{Revision: 2}
+	successCases = append(successCases, rollback)
+	for _, successCase := range successCases {
+		if errs := ValidateDeployment(&successCase); len(errs) != 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]api.Deployment{}
+	d := validDeployment()
+	d.Namespace = ""
+	errorCases["metadata.namespace"] = d
+	d = validDeployment()
+	d.Spec.Selector = nil
+	errorCases["spec.selector"] = d
+	d = validDeployment()
+	d.Spec.Selector = map[string]string{"foo": "bar"}
+	errorCases["spec.template.labels"] = d
+	d = validDeployment()
+	d.Spec.Template = nil
+	errorCases["spec.template"] = d
+	d = validDeployment()
+	d.Spec.Replicas = -1
+	errorCases["spec.replicas"] = d
+	d = validDeployment()
+	d.Spec.Strategy.Type = ""
+	errorCases["spec.strategy.type"] = d
+	d = validDeployment()
+	d.Spec.Strategy.Type = "Bogus"
+	errorCases["spec.strategy.type unsupported"] = d
+	d = validDeployment()
+	d.Spec.Strategy.RollingUpdate = nil
+	errorCases["spec.strategy.rollingUpdate"] = d
+	d = validDeployment()
+	d.Spec.Strategy = api.DeploymentStrategy{Type: api.RecreateDeploymentStrategyType, RollingUpdate: validStrategy.RollingUpdate}
+	errorCases["spec.strategy.rollingUpdate forbidden"] = d
+	d = validDeployment()
+	d.Spec.Strategy.RollingUpdate = &api.RollingUpdateDeployment{MaxUnavailable: util.NewIntOrStringFromInt(-1), MaxSurge: util.NewIntOrStringFromInt(1)}
+	errorCases["spec.strategy.rollingUpdate.maxUnavailable"] = d
+	d = validDeployment()
+	d.Spec.Strategy.RollingUpdate = &api.RollingUpdateDeployment{MaxUnavailable: util.NewIntOrStringFromInt(1), MaxSurge: util.NewIntOrStringFromString("150%")}
+	errorCases["spec.strategy.rollingUpdate.maxSurge"] = d
+	d = validDeployment()
+	d.Spec.Strategy.RollingUpdate = &api.RollingUpdateDeployment{MaxUnavailable: util.NewIntOrStringFromInt(1), MaxSurge: util.NewIntOrStringFromString("ten")}
+	errorCases["spec.strategy.rollingUpdate.maxSurge not a percentage"] = d
+	d = validDeployment()
+	d.Spec.Strategy.RollingUpdate = &api.RollingUpdateDeployment{MaxUnavailable: util.NewIntOrStringFromString("0%"), MaxSurge: util.NewIntOrStringFromInt(0)}
+	errorCases["spec.strategy.rollingUpdate.maxUnavailable both zero"] = d
+	d = validDeployment()
+	d.Spec.RevisionHistoryLimit = &negative
+	errorCases["spec.revisionHistoryLimit"] = d
+	d = validDeployment()
+	d.Spec.RollbackTo = &api.RollbackConfig{Revision: -1}
+	errorCases["spec.rollbackTo.revision"] = d
+
+	for k, v := range errorCases {
+		errs := ValidateDeployment(&v)
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+			continue
+		}
+		field := strings.Split(k, " ")[0]
+		found := false
+		for i := range errs {
+			if errs[i].(*errors.ValidationError).Field == field {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected an error for field %s, got %v", k, field, errs)
+		}
+	}
+}
+
+func TestValidateDeploymentStatusUpdate(t *testing.T) {
+	old := api.Deployment{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		Spec:       api.DeploymentSpec{Replicas: 3},
+	}
+	update := old
+	update.Spec.Replicas = 5
+	update.Status = api.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, Revision: 1}
+	if errs := ValidateDeploymentStatusUpdate(&old, &update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	if update.Spec.Replicas != 3 {
+		t.Errorf("expected spec to be reset on status update, got %#v", update.Spec)
+	}
+
+	update.Status.UpdatedReplicas = -1
+	if errs := ValidateDeploymentStatusUpdate(&old, &update); len(errs) == 0 {
+		t.Errorf("expected failure for negative updatedReplicas")
+	}
+}
+
+func TestValidateJob(t *testing.T) {
+	validSelector := map[string]string{"a": "b"}
+	validJob := func() api.Job {
+		one := 1
+		completions := 5
+		return api.Job{
+			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
+			Spec: api.JobSpec{
+				Parallelism: &one,
+				Completions: &completions,
+				Selector:    validSelector,
+				Template: &api.PodTemplateSpec{
+					ObjectMeta: api.ObjectMeta{Labels: validSelector},
+					Spec: api.PodSpec{
+						RestartPolicy: api.RestartPolicyOnFailure,
+						DNSPolicy:     api.DNSClusterFirst,
+						Containers:    []api.Container{{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+					},
+				},
+			},
+		}
+	}
+	negative := -1
+
+	successCases := []api.Job{validJob()}
+	never := validJob()
+	never.Spec.Template.Spec.RestartPolicy = api.RestartPolicyNever
+	successCases = append(successCases, never)
+	for _, successCase := range successCases {
+		if errs := ValidateJob(&successCase); len(errs) != 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]api.Job{}
+	j := validJob()
+	j.Namespace = ""
+	errorCases["metadata.namespace"] = j
+	j = validJob()
+	j.Spec.Parallelism = &negative
+	errorCases["spec.parallelism"] = j
+	j = validJob()
+	j.Spec.Completions = &negative
+	errorCases["spec.completions"] = j
+	j = validJob()
+	j.Spec.Completions = nil
+	errorCases["spec.completions missing"] = j
+	j = validJob()
+	j.Spec.Selector = nil
+	errorCases["spec.selector"] = j
+	j = validJob()
+	j.Spec.Selector = map[string]string{"foo": "bar"}
+	errorCases["spec.template.labels"] = j
+	j = validJob()
+	j.Spec.Template = nil
+	errorCases["spec.template"] = j
+	j = validJob()
+	j.Spec.Template.Spec.RestartPolicy = api.RestartPolicyAlways
+	errorCases["spec.template.spec.restartPolicy"] = j
+
+	for k, v := range errorCases {
+		errs := ValidateJob(&v)
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+			continue
+		}
+		field := strings.Split(k, " ")[0]
+		found := false
+		for i := range errs {
+			if errs[i].(*errors.ValidationError).Field == field {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected an error for field %s, got %v", k, field, errs)
+		}
+	}
+}
+
+func TestValidateJobUpdate(t *testing.T) {
+	one, two, five := 1, 2, 5
+	old := api.Job{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		Spec: api.JobSpec{
+			Parallelism: &one,
+			Completions: &five,
+			Selector:    map[string]string{"a": "b"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{Labels: map[string]string{"a": "b"}},
+				Spec: api.PodSpec{
+					RestartPolicy: api.RestartPolicyNever,
+					DNSPolicy:     api.DNSClusterFirst,
+					Containers:    []api.Container{{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+				},
+			},
+		},
+		Status: api.JobStatus{Succeeded: 2},
+	}
+
+	update := old
+	update.Spec.Parallelism = &two
+	update.Status = api.JobStatus{}
+	if errs := ValidateJobUpdate(&old, &update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	if update.Status.Succeeded != 2 {
+		t.Errorf("expected status to be preserved on update, got %#v", update.Status)
+	}
+
+	update = old
+	update.Spec.Completions = &two
+	if errs := ValidateJobUpdate(&old, &update); len(errs) == 0 {
+		t.Errorf("expected failure when updating completions")
+	}
+
+	update = old
+	update.Status.Failed = -1
+	if errs := ValidateJobStatusUpdate(&old, &update); len(errs) == 0 {
+		t.Errorf("expected failure for negative failed count")
+	}
+}
+
+func TestValidateDaemonSet(t *testing.T) {
+	validSelector := map[string]string{"a": "b"}
+	validDaemonSet := func() api.DaemonSet {
+		return api.DaemonSet{
+			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: api.NamespaceDefault},
+			Spec: api.DaemonSetSpec{
+				Selector: validSelector,
+				Template: &api.PodTemplateSpec{
+					ObjectMeta: api.ObjectMeta{Labels: validSelector},
+					Spec: api.PodSpec{
+						RestartPolicy: api.RestartPolicyAlways,
+						DNSPolicy:     api.DNSClusterFirst,
+						NodeSelector:  map[string]string{"role": "logging"},
+						Containers:    []api.Container{{Name: "abc", Image: "image", ImagePullPolicy: "IfNotPresent"}},
+					},
+				},
+			},
+		}
+	}
+
+	successCase := validDaemonSet()
+	if errs := ValidateDaemonSet(&successCase); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	errorCases := map[string]api.DaemonSet{}
+	ds := validDaemonSet()
+	ds.Namespace = ""
+	errorCases["metadata.namespace"] = ds
+	ds = validDaemonSet()
+	ds.Spec.Selector = nil
+	errorCases["spec.selector"] = ds
+	ds = validDaemonSet()
+	ds.Spec.Selector = map[string]string{"foo": "bar"}
+	errorCases["spec.template.labels"] = ds
+	ds = validDaemonSet()
+	ds.Spec.Template = nil
+	errorCases["spec.template"] = ds
+	ds = validDaemonSet()
+	ds.Spec.Template.Spec.RestartPolicy = api.RestartPolicyNever
+	errorCases["spec.template.spec.restartPolicy"] = ds
+	ds = validDaemonSet()
+	ds.Spec.Template.Spec.Host = "node-1"
+	errorCases["spec.template.spec.host"] = ds
+	ds = validDaemonSet()
+	ds.Spec.Template.Spec.Volumes = []api.Volume{{Name: "disk", VolumeSource: api.VolumeSource{GCEPersistentDisk: &api.GCEPersistentDiskVolumeSource{PDName: "my-PD", FSType: "ext4"}}}}
+	errorCases["spec.template.spec.volumes.GCEPersistentDisk.ReadOnly"] = ds
+
+	for k, v := range errorCases {
+		errs := ValidateDaemonSet(&v)
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+			continue
+		}
+		found := false
+		for i := range errs {
+			if errs[i].(*errors.ValidationError).Field == k {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected an error for field %s, got %v", k, k, errs)
+		}
+	}
+
+	old := validDaemonSet()
+	old.ResourceVersion = "1"
+	old.Status = api.DaemonSetStatus{CurrentNumberScheduled: 2, DesiredNumberScheduled: 3}
+	update := old
+	update.Spec.Template = &api.PodTemplateSpec{}
+	*update.Spec.Template = *old.Spec.Template
+	update.Spec.Template.Spec.NodeSelector = map[string]string{"role": "monitoring"}
+	update.Status = api.DaemonSetStatus{}
+	if errs := ValidateDaemonSetUpdate(&old, &update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	if update.Status.DesiredNumberScheduled != 3 {
+		t.Errorf("expected status to be preserved on update, got %#v", update.Status)
+	}
+	update = old
+	update.Status.NumberMisscheduled = -1
+	if errs := ValidateDaemonSetStatusUpdate(&old, &update); len(errs) == 0 {
+		t.Errorf("expected failure for negative misscheduled count")
+	}
+}
+
+func TestValidateHorizontalPodAutoscaler(t *testing.T) {
+	validAutoscaler := func() api.HorizontalPodAutoscaler {
+		one := 1
+		return api.HorizontalPodAutoscaler{
+			ObjectMeta: api.ObjectMeta{Name: "frontend", Namespace: api.NamespaceDefault},
+			Spec: api.HorizontalPodAutoscalerSpec{
+				ScaleRef:       api.ObjectReference{Kind: "ReplicationController", Name: "frontend"},
+				MinReplicas:    &one,
+				MaxReplicas:    5,
+				CPUUtilization: &api.CPUTargetUtilization{TargetPercentage: 70},
+			},
+		}
+	}
+
+	successCase := validAutoscaler()
+	if errs := ValidateHorizontalPodAutoscaler(&successCase); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	zero := 0
+	errorCases := map[string]api.HorizontalPodAutoscaler{}
+	a := validAutoscaler()
+	a.Namespace = ""
+	errorCases["metadata.namespace"] = a
+	a = validAutoscaler()
+	a.Spec.ScaleRef.Kind = "Pod"
+	errorCases["spec.scaleRef.kind"] = a
+	a = validAutoscaler()
+	a.Spec.ScaleRef.Name = ""
+	errorCases["spec.scaleRef.name"] = a
+	a = validAutoscaler()
+	a.Spec.MinReplicas = &zero
+	errorCases["spec.minReplicas"] = a
+	a = validAutoscaler()
+	a.Spec.MaxReplicas = 0
+	errorCases["spec.maxReplicas"] = a
+	a = validAutoscaler()
+	a.Spec.CPUUtilization = nil
+	errorCases["spec.cpuUtilization"] = a
+	a = validAutoscaler()
+	a.Spec.CPUUtilization.TargetPercentage = 0
+	errorCases["spec.cpuUtilization.targetPercentage"] = a
+
+	for k, v := range errorCases {
+		errs := ValidateHorizontalPodAutoscaler(&v)
+		if len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+			continue
+		}
+		found := false
+		for i := range errs {
+			if errs[i].(*errors.ValidationError).Field == k {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected an error for field %s, got %v", k, k, errs)
+		}
+	}
+
+	old := validAutoscaler()
+	old.ResourceVersion = "1"
+	old.Status = api.HorizontalPodAutoscalerStatus{CurrentReplicas: 2, DesiredReplicas: 3}
+	update := old
+	update.Spec.MaxReplicas = 10
+	update.Status = api.HorizontalPodAutoscalerStatus{}
+	if errs := ValidateHorizontalPodAutoscalerUpdate(&old, &update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	if update.Status.DesiredReplicas != 3 {
+		t.Errorf("expected status to be preserved on update, got %#v", update.Status)
+	}
+	update = old
+	update.Status.DesiredReplicas = -1
+	if errs := ValidateHorizontalPodAutoscalerStatusUpdate(&old, &update); len(errs) == 0 {
+		t.Errorf("expected failure for negative desired replicas")
+	}
+}
+
+func TestValidateServiceAccount(t *testing.T) {
+	successCases := []api.ServiceAccount{
+		{ObjectMeta: api.ObjectMeta{Name: "default", Namespace: api.NamespaceDefault}},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "builder", Namespace: api.NamespaceDefault},
+			Secrets:    []api.ObjectReference{{Name: "builder-token-abcde"}},
+		},
+	}
+	for _, successCase := range successCases {
+		if errs := ValidateServiceAccount(&successCase); len(errs) != 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]api.ServiceAccount{
+		"empty name":        {ObjectMeta: api.ObjectMeta{Name: "", Namespace: api.NamespaceDefault}},
+		"invalid name":      {ObjectMeta: api.ObjectMeta{Name: "Invalid_Name", Namespace: api.NamespaceDefault}},
+		"empty namespace":   {ObjectMeta: api.ObjectMeta{Name: "default"}},
+		"invalid namespace": {ObjectMeta: api.ObjectMeta{Name: "default", Namespace: "Invalid_Namespace"}},
+	}
+	for k, v := range errorCases {
+		if errs := ValidateServiceAccount(&v); len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		}
+	}
+}
+
+func TestValidateServiceAccountUpdate(t *testing.T) {
+	old := api.ServiceAccount{ObjectMeta: api.ObjectMeta{Name: "default", Namespace: api.NamespaceDefault, ResourceVersion: "1"}}
+
+	update := old
+	update.Secrets = []api.ObjectReference{{Name: "default-token-abcde"}}
+	if errs := ValidateServiceAccountUpdate(&old, &update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	rename := old
+	rename.Name = "other"
+	if errs := ValidateServiceAccountUpdate(&old, &rename); len(errs) == 0 {
+		t.Errorf("expected failure when changing the name")
+	}
+}
+
+func TestValidateRole(t *testing.T) {
+	validRules := []api.PolicyRule{
+		{Verbs: []string{"get", "list", "watch"}, Resources: []string{"pods"}},
+		{Verbs: []string{api.VerbAll}, Resources: []string{api.ResourceAll}},
+	}
+	successCases := []api.Role{
+		{ObjectMeta: api.ObjectMeta{Name: "viewer", Namespace: api.NamespaceDefault}, Rules: validRules},
+		{ObjectMeta: api.ObjectMeta{Name: "nothing", Namespace: api.NamespaceDefault}},
+	}
+	for _, successCase := range successCases {
+		if errs := ValidateRole(&successCase); len(errs) != 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]api.Role{
+		"empty name":      {ObjectMeta: api.ObjectMeta{Namespace: api.NamespaceDefault}, Rules: validRules},
+		"empty namespace": {ObjectMeta: api.ObjectMeta{Name: "viewer"}, Rules: validRules},
+		"no verbs": {
+			ObjectMeta: api.ObjectMeta{Name: "viewer", Namespace: api.NamespaceDefault},
+			Rules:      []api.PolicyRule{{Resources: []string{"pods"}}},
+		},
+		"no resources": {
+			ObjectMeta: api.ObjectMeta{Name: "viewer", Namespace: api.NamespaceDefault},
+			Rules:      []api.PolicyRule{{Verbs: []string{"get"}}},
+		},
+		"empty verb": {
+			ObjectMeta: api.ObjectMeta{Name: "viewer", Namespace: api.NamespaceDefault},
+			Rules:      []api.PolicyRule{{Verbs: []string{""}, Resources: []string{"pods"}}},
+		},
+	}
+	for k, v := range errorCases {
+		if errs := ValidateRole(&v); len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		}
+	}
+}
+
+func TestValidateClusterRole(t *testing.T) {
+	rules := []api.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"nodes"}}}
+	role := api.ClusterRole{ObjectMeta: api.ObjectMeta{Name: "node-reader"}, Rules: rules}
+	if errs := ValidateClusterRole(&role); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	namespaced := api.ClusterRole{ObjectMeta: api.ObjectMeta{Name: "node-reader", Namespace: api.NamespaceDefault}, Rules: rules}
+	if errs := ValidateClusterRole(&namespaced); len(errs) == 0 {
+		t.Errorf("expected failure for a namespaced cluster role")
+	}
+}
+
+func TestValidateRoleBinding(t *testing.T) {
+	validSubjects := []api.Subject{
+		{Kind: api.UserKind, Name: "alice@example.com"},
+		{Kind: api.GroupKind, Name: "system:authenticated"},
+		{Kind: api.ServiceAccountKind, Name: "default"},
+		{Kind: api.ServiceAccountKind, Name: "builder", Namespace: "other"},
+	}
+	successCases := []api.RoleBinding{
+		{
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			Subjects:   validSubjects,
+			RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "admins", Namespace: api.NamespaceDefault},
+			Subjects:   validSubjects,
+			RoleRef:    api.ObjectReference{Kind: "ClusterRole", Name: "admin"},
+		},
+	}
+	for _, successCase := range successCases {
+		if errs := ValidateRoleBinding(&successCase); len(errs) != 0 {
+			t.Errorf("expected success: %v", errs)
+		}
+	}
+
+	errorCases := map[string]api.RoleBinding{
+		"empty namespace": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers"},
+			RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+		},
+		"unknown role kind": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			RoleRef:    api.ObjectReference{Kind: "Pod", Name: "viewer"},
+		},
+		"missing role name": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			RoleRef:    api.ObjectReference{Kind: "Role"},
+		},
+		"unknown subject kind": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			Subjects:   []api.Subject{{Kind: "Robot", Name: "r2d2"}},
+			RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+		},
+		"missing subject name": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			Subjects:   []api.Subject{{Kind: api.UserKind}},
+			RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+		},
+		"namespaced user": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			Subjects:   []api.Subject{{Kind: api.UserKind, Name: "alice", Namespace: "default"}},
+			RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+		},
+		"invalid service account": {
+			ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault},
+			Subjects:   []api.Subject{{Kind: api.ServiceAccountKind, Name: "Invalid_Name"}},
+			RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+		},
+	}
+	for k, v := range errorCases {
+		if errs := ValidateRoleBinding(&v); len(errs) == 0 {
+			t.Errorf("expected failure for %s", k)
+		}
+	}
+}
+
+func TestValidateRoleBindingUpdate(t *testing.T) {
+	old := api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "viewers", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		RoleRef:    api.ObjectReference{Kind: "Role", Name: "viewer"},
+	}
+
+	update := old
+	update.Subjects = []api.Subject{{Kind: api.UserKind, Name: "alice"}}
+	if errs := ValidateRoleBindingUpdate(&old, &update); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	changedRole := old
+	changedRole.RoleRef = api.ObjectReference{Kind: "ClusterRole", Name: "admin"}
+	if errs := ValidateRoleBindingUpdate(&old, &changedRole); len(errs) == 0 {
+		t.Errorf("expected failure when changing the role")
+	}
+}
+
+func TestValidateClusterRoleBinding(t *testing.T) {
+	binding := api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "admins"},
+		Subjects:   []api.Subject{{Kind: api.GroupKind, Name: "admins"}},
+		RoleRef:    api.ObjectReference{Kind: "ClusterRole", Name: "admin"},
+	}
+	if errs := ValidateClusterRoleBinding(&binding); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	roleRef := binding
+	roleRef.RoleRef = api.ObjectReference{Kind: "Role", Name: "admin"}
+	if errs := ValidateClusterRoleBinding(&roleRef); len(errs) == 0 {
+		t.Errorf("expected failure for a cluster role binding to a namespaced role")
+	}
+
+	changedRole := binding
+	changedRole.RoleRef = api.ObjectReference{Kind: "ClusterRole", Name: "viewer"}
+	if errs := ValidateClusterRoleBindingUpdate(&binding, &changedRole); len(errs) == 0 {
+		t.Errorf("expected failure when changing the role")
+	}
+}
+
 func TestValidateMinion(t *testing.T) {
 	validSelector := map[string]string{"a": "b"}
 	invalidSelector := map[string]string{"NoUppercaseOrSpecialCharsLike=Equals": "b"}
@@ -2385,6 +3227,28 @@ func TestValidateResourceQuota(t *testing.T) {
 		}
 	}
 
+	countSpec := api.ResourceQuotaSpec{
+		Hard: api.ResourceList{
+			"count/deployments": resource.MustParse("10"),
+			"count/secrets":     resource.MustParse("10"),
+		},
+	}
+	if errs := ValidateResourceQuota(&api.ResourceQuota{ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: "foo"}, Spec: countSpec}); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	for _, name := range []api.ResourceName{"count/foo", "count/nodes", "count/Deployments"} {
+		quota := &api.ResourceQuota{
+			ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: "foo"},
+			Spec:       api.ResourceQuotaSpec{Hard: api.ResourceList{name: resource.MustParse("10")}},
+		}
+		if errs := ValidateResourceQuota(quota); len(errs) == 0 {
+			t.Errorf("%s: expected failure", name)
+		}
+		if errs := ValidateResourceQuotaUpdate(quota, &api.ResourceQuota{ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: "foo"}}); len(errs) == 0 {
+			t.Errorf("%s: expected update failure", name)
+		}
+	}
+
 	errorCases := map[string]struct {
 		R api.ResourceQuota
 		D string
@@ -2642,6 +3506,26 @@ func TestValidateSecret(t *testing.T) {
 		invalidNs   = validSecret()
 		overMaxSize = validSecret()
 		invalidKey  = validSecret()
+
+		validServiceAccountTokenSecret = func() api.Secret {
+			return api.Secret{
+				ObjectMeta: api.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+					Annotations: map[string]string{
+						api.ServiceAccountNameKey: "foo",
+					},
+				},
+				Type: api.SecretTypeServiceAccountToken,
+				Data: map[string][]byte{
+					"data-1": []byte("bar"),
+				},
+			}
+		}
+
+		emptyTokenAnnotation    = validServiceAccountTokenSecret()
+		missingTokenAnnotation  = validServiceAccountTokenSecret()
+		missingTokenAnnotations = validServiceAccountTokenSecret()
 	)
 
 	emptyName.Name = ""
@@ -2653,6 +3537,10 @@ func TestValidateSecret(t *testing.T) {
 	}
 	invalidKey.Data["a..b"] = []byte("whoops")
 
+	emptyTokenAnnotation.Annotations[api.ServiceAccountNameKey] = ""
+	delete(missingTokenAnnotation.Annotations, api.ServiceAccountNameKey)
+	missingTokenAnnotations.Annotations = nil
+
 	tests := map[string]struct {
 		secret api.Secret
 		valid  bool
@@ -2664,6 +3552,11 @@ func TestValidateSecret(t *testing.T) {
 		"invalid namespace": {invalidNs, false},
 		"over max size":     {overMaxSize, false},
 		"invalid key":       {invalidKey, false},
+
+		"valid service-account-token secret":        {validServiceAccountTokenSecret(), true},
+		"empty service-account-token annotation":    {emptyTokenAnnotation, false},
+		"missing service-account-token annotation":  {missingTokenAnnotation, false},
+		"missing service-account-token annotations": {missingTokenAnnotations, false},
 	}
 
 	for name, tc := range tests {
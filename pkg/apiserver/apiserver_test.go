@@ -1172,6 +1172,127 @@ func TestPatch(t *testing.T) {
 	}
 }
 
+func TestPatchTypes(t *testing.T) {
+	testCases := map[string]struct {
+		contentType string
+		patch       string
+		expectCode  int
+		expectOther string
+	}{
+		"merge patch": {
+			contentType: string(api.MergePatchType),
+			patch:       `{"other":"baz"}`,
+			expectCode:  http.StatusOK,
+			expectOther: "baz",
+		},
+		"json patch": {
+			contentType: string(api.JSONPatchType),
+			patch:       `[{"op":"replace","path":"/other","value":"baz"}]`,
+			expectCode:  http.StatusOK,
+			expectOther: "baz",
+		},
+		"strategic merge patch": {
+			contentType: string(api.StrategicMergePatchType) + "; charset=UTF-8",
+			patch:       `{"other":"baz"}`,
+			expectCode:  http.StatusOK,
+			expectOther: "baz",
+		},
+		"invalid json patch": {
+			contentType: string(api.JSONPatchType),
+			patch:       `{"other":"baz"}`,
+			expectCode:  http.StatusBadRequest,
+		},
+		"failed json patch test": {
+			contentType: string(api.JSONPatchType),
+			patch:       `[{"op":"test","path":"/other","value":"qux"},{"op":"replace","path":"/other","value":"baz"}]`,
+			expectCode:  http.StatusBadRequest,
+		},
+	}
+	for k, testCase := range testCases {
+		storage := map[string]rest.Storage{}
+		ID := "id"
+		simpleStorage := SimpleRESTStorage{item: Simple{ObjectMeta: api.ObjectMeta{Name: ID}, Other: "bar"}}
+		storage["simple"] = &simpleStorage
+		handler := handle(storage)
+		server := httptest.NewServer(handler)
+
+		request, _ := http.NewRequest("PATCH", server.URL+"/api/version/simple/"+ID, bytes.NewReader([]byte(testCase.patch)))
+		request.Header.Set("Content-Type", testCase.contentType)
+		response, err := http.DefaultClient.Do(request)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if response.StatusCode != testCase.expectCode {
+			t.Errorf("%s: expected status %d, got %d", k, testCase.expectCode, response.StatusCode)
+		}
+		if testCase.expectCode != http.StatusOK {
+			if simpleStorage.updated != nil {
+				t.Errorf("%s: unexpected update: %#v", k, simpleStorage.updated)
+			}
+			continue
+		}
+		if simpleStorage.updated == nil || simpleStorage.updated.Other != testCase.expectOther {
+			t.Errorf("%s: unexpected update: %#v", k, simpleStorage.updated)
+		}
+	}
+}
+
+// objectAdmission records the objects it is asked to admit, and rejects any
+// whose Other field is "forbidden".
+type objectAdmission struct {
+	objects []runtime.Object
+}
+
+func (a *objectAdmission) Admit(attributes admission.Attributes) error {
+	obj := attributes.GetObject()
+	a.objects = append(a.objects, obj)
+	if simple, ok := obj.(*Simple); ok && simple.Other == "forbidden" {
+		return apierrs.NewForbidden(attributes.GetResource(), simple.Name, fmt.Errorf("forbidden value"))
+	}
+	return nil
+}
+
+func TestPatchAdmitsPatchedObject(t *testing.T) {
+	testCases := map[string]struct {
+		patch      string
+		expectCode int
+	}{
+		"allowed":   {`{"other":"baz"}`, http.StatusOK},
+		"forbidden": {`{"other":"forbidden"}`, http.StatusForbidden},
+	}
+	for k, testCase := range testCases {
+		ID := "id"
+		simpleStorage := SimpleRESTStorage{item: Simple{ObjectMeta: api.ObjectMeta{Name: ID}, Other: "bar"}}
+		admit := &objectAdmission{}
+		handler := handleInternal(true, map[string]rest.Storage{"simple": &simpleStorage}, admit, selfLinker)
+		server := httptest.NewServer(handler)
+
+		request, _ := http.NewRequest("PATCH", server.URL+"/api/version/simple/"+ID, bytes.NewReader([]byte(testCase.patch)))
+		response, err := http.DefaultClient.Do(request)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if response.StatusCode != testCase.expectCode {
+			t.Errorf("%s: expected status %d, got %d", k, testCase.expectCode, response.StatusCode)
+		}
+		if len(admit.objects) != 1 {
+			t.Errorf("%s: expected one admission call, got %d", k, len(admit.objects))
+			continue
+		}
+		admitted, ok := admit.objects[0].(*Simple)
+		if !ok || admitted.Name != ID || admitted.Other == "bar" {
+			t.Errorf("%s: expected the patched object to be admitted, got %#v", k, admit.objects[0])
+		}
+		if testCase.expectCode != http.StatusOK && simpleStorage.updated != nil {
+			t.Errorf("%s: unexpected update: %#v", k, simpleStorage.updated)
+		}
+	}
+}
+
 func TestPatchRequiresMatchingName(t *testing.T) {
 	storage := map[string]rest.Storage{}
 	ID := "id"
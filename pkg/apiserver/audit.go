@@ -0,0 +1,198 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/meta"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/golang/glog"
+)
+
+// AuditPolicy controls which requests are written to the audit log and how
+// much of each request is recorded.
+type AuditPolicy struct {
+	// IncludeReadOnly also records read-only (GET) requests.  By default only
+	// requests which may mutate state are recorded.
+	IncludeReadOnly bool `json:"includeReadOnly,omitempty"`
+	// RequestBodyResources lists the resources (for example "pods") whose
+	// request bodies are included in the log.  "*" matches every resource.
+	RequestBodyResources []string `json:"requestBodyResources,omitempty"`
+}
+
+// NewAuditPolicyFromFile reads a JSON encoded AuditPolicy from path.
+func NewAuditPolicyFromFile(path string) (*AuditPolicy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &AuditPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("unable to parse audit policy %s: %v", path, err)
+	}
+	return policy, nil
+}
+
+// AuditEvent is a single line of the audit log.
+type AuditEvent struct {
+	Timestamp   util.Time       `json:"timestamp"`
+	User        string          `json:"user"`
+	Groups      []string        `json:"groups,omitempty"`
+	Verb        string          `json:"verb,omitempty"`
+	Resource    string          `json:"resource,omitempty"`
+	Namespace   string          `json:"namespace,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	SourceIP    string          `json:"sourceIP"`
+	Method      string          `json:"method"`
+	URI         string          `json:"uri"`
+	Code        int             `json:"code"`
+	Latency     string          `json:"latency"`
+	RequestBody json.RawMessage `json:"requestBody,omitempty"`
+}
+
+// WithAudit writes an AuditEvent to out for each request selected by policy.
+// It must be installed inside the authenticating handler so that the user is
+// present in the request context.
+func WithAudit(handler http.Handler, requestContextMapper api.RequestContextMapper, restMapper meta.RESTMapper, out io.Writer, policy AuditPolicy, apiRoots ...string) http.Handler {
+	resolver := &APIRequestInfoResolver{util.NewStringSet(apiRoots...), restMapper}
+	bodyResources := util.NewStringSet(policy.RequestBodyResources...)
+	encoder := json.NewEncoder(out)
+	lock := sync.Mutex{}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !policy.IncludeReadOnly && IsReadOnlyReq(*req) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		start := time.Now()
+		event := AuditEvent{
+			Timestamp: util.NewTime(start),
+			SourceIP:  sourceIP(req),
+			Method:    req.Method,
+			URI:       req.RequestURI,
+		}
+		if ctx, ok := requestContextMapper.Get(req); ok {
+			if user, ok := api.UserFrom(ctx); ok {
+				event.User = user.GetName()
+				event.Groups = user.GetGroups()
+			}
+		}
+		requestInfo, _ := resolver.GetAPIRequestInfo(req)
+		event.Verb = requestInfo.Verb
+		event.Resource = requestInfo.Resource
+		event.Namespace = requestInfo.Namespace
+		event.Name = requestInfo.Name
+
+		if req.Body != nil && (bodyResources.Has("*") || bodyResources.Has(requestInfo.Resource)) {
+			body, err := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				glog.Errorf("Unable to read request body for audit: %v", err)
+			}
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			// Only well formed JSON can be embedded in the event.
+			var raw json.RawMessage
+			if err := json.Unmarshal(body, &raw); err == nil {
+				event.RequestBody = raw
+			}
+		}
+
+		auditWriter := &auditResponseWriter{ResponseWriter: w}
+		handler.ServeHTTP(auditWriter, req)
+
+		event.Code = auditWriter.code
+		if event.Code == 0 {
+			event.Code = http.StatusOK
+		}
+		event.Latency = time.Since(start).String()
+
+		lock.Lock()
+		defer lock.Unlock()
+		if err := encoder.Encode(&event); err != nil {
+			glog.Errorf("Unable to write audit event: %v", err)
+		}
+	})
+}
+
+// sourceIP returns the address of the client that sent req, without the port.
+func sourceIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// auditResponseWriter records the status code of a response.  It passes
+// through flushing and hijacking so that watches and streaming requests keep
+// working.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *auditResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *auditResponseWriter) CloseNotify() <-chan bool {
+	if notifier, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	// The client is never reported as gone.
+	return make(chan bool)
+}
+
+func (w *auditResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("ResponseWriter does not implement http.Hijacker")
+	}
+	if w.code == 0 {
+		w.code = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
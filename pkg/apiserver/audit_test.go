@@ -0,0 +1,206 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+)
+
+// withUser installs a request context carrying u around handler.
+func withUser(mapper api.RequestContextMapper, u user.Info, handler http.Handler) http.Handler {
+	userHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if u != nil {
+			ctx, _ := mapper.Get(req)
+			mapper.Update(req, api.WithUser(ctx, u))
+		}
+		handler.ServeHTTP(w, req)
+	})
+	filter, _ := api.NewRequestContextFilter(mapper, userHandler)
+	return filter
+}
+
+func decodeAuditEvents(t *testing.T, data []byte) []AuditEvent {
+	events := []AuditEvent{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		event := AuditEvent{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("unexpected error decoding %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestAudit(t *testing.T) {
+	body := `{"kind":"Pod","metadata":{"name":"foo"}}`
+	testCases := map[string]struct {
+		policy   AuditPolicy
+		method   string
+		path     string
+		body     string
+		user     user.Info
+		code     int
+		expected []AuditEvent
+	}{
+		"create": {
+			method: "POST",
+			path:   "/api/v1beta3/namespaces/other/pods",
+			body:   body,
+			user:   &user.DefaultInfo{Name: "alice", Groups: []string{"admins"}},
+			code:   http.StatusCreated,
+			expected: []AuditEvent{{
+				User: "alice", Groups: []string{"admins"},
+				Verb: "create", Resource: "pods", Namespace: "other",
+				SourceIP: "10.0.0.1", Method: "POST", URI: "/api/v1beta3/namespaces/other/pods", Code: http.StatusCreated,
+			}},
+		},
+		"delete forbidden": {
+			method: "DELETE",
+			path:   "/api/v1beta3/namespaces/other/pods/foo",
+			user:   &user.DefaultInfo{Name: "bob"},
+			code:   http.StatusForbidden,
+			expected: []AuditEvent{{
+				User: "bob", Verb: "delete", Resource: "pods", Namespace: "other", Name: "foo",
+				SourceIP: "10.0.0.1", Method: "DELETE", URI: "/api/v1beta3/namespaces/other/pods/foo", Code: http.StatusForbidden,
+			}},
+		},
+		"read only skipped": {
+			method:   "GET",
+			path:     "/api/v1beta3/namespaces/other/pods/foo",
+			user:     &user.DefaultInfo{Name: "alice"},
+			code:     http.StatusOK,
+			expected: []AuditEvent{},
+		},
+		"read only included": {
+			policy: AuditPolicy{IncludeReadOnly: true},
+			method: "GET",
+			path:   "/api/v1beta3/namespaces/other/pods/foo",
+			code:   http.StatusOK,
+			expected: []AuditEvent{{
+				Verb: "get", Resource: "pods", Namespace: "other", Name: "foo",
+				SourceIP: "10.0.0.1", Method: "GET", URI: "/api/v1beta3/namespaces/other/pods/foo", Code: http.StatusOK,
+			}},
+		},
+		"request body": {
+			policy: AuditPolicy{RequestBodyResources: []string{"pods"}},
+			method: "PUT",
+			path:   "/api/v1beta3/namespaces/other/pods/foo",
+			body:   body,
+			user:   &user.DefaultInfo{Name: "alice"},
+			code:   http.StatusOK,
+			expected: []AuditEvent{{
+				User: "alice", Verb: "update", Resource: "pods", Namespace: "other", Name: "foo",
+				SourceIP: "10.0.0.1", Method: "PUT", URI: "/api/v1beta3/namespaces/other/pods/foo", Code: http.StatusOK,
+				RequestBody: json.RawMessage(body),
+			}},
+		},
+		"request body not json": {
+			policy: AuditPolicy{RequestBodyResources: []string{"pods"}},
+			method: "PUT",
+			path:   "/api/v1beta3/namespaces/other/pods/foo",
+			body:   "kind: Pod",
+			user:   &user.DefaultInfo{Name: "alice"},
+			code:   http.StatusOK,
+			expected: []AuditEvent{{
+				User: "alice", Verb: "update", Resource: "pods", Namespace: "other", Name: "foo",
+				SourceIP: "10.0.0.1", Method: "PUT", URI: "/api/v1beta3/namespaces/other/pods/foo", Code: http.StatusOK,
+			}},
+		},
+		"request body for other resource": {
+			policy: AuditPolicy{RequestBodyResources: []string{"secrets"}},
+			method: "PUT",
+			path:   "/api/v1beta3/namespaces/other/pods/foo",
+			body:   body,
+			user:   &user.DefaultInfo{Name: "alice"},
+			code:   http.StatusOK,
+			expected: []AuditEvent{{
+				User: "alice", Verb: "update", Resource: "pods", Namespace: "other", Name: "foo",
+				SourceIP: "10.0.0.1", Method: "PUT", URI: "/api/v1beta3/namespaces/other/pods/foo", Code: http.StatusOK,
+			}},
+		},
+	}
+
+	for k, testCase := range testCases {
+		out := &bytes.Buffer{}
+		mapper := api.NewRequestContextMapper()
+		var received string
+		handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			data, _ := ioutil.ReadAll(req.Body)
+			received = string(data)
+			w.WriteHeader(testCase.code)
+		})
+		audit := withUser(mapper, testCase.user, WithAudit(handler, mapper, latest.RESTMapper, out, testCase.policy, "api"))
+
+		req, _ := http.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.body))
+		req.RemoteAddr = "10.0.0.1:34567"
+		req.RequestURI = testCase.path
+		w := httptest.NewRecorder()
+		audit.ServeHTTP(w, req)
+
+		if w.Code != testCase.code {
+			t.Errorf("%s: expected code %d, got %d", k, testCase.code, w.Code)
+		}
+		if received != testCase.body {
+			t.Errorf("%s: expected handler to receive %q, got %q", k, testCase.body, received)
+		}
+		events := decodeAuditEvents(t, out.Bytes())
+		for i := range events {
+			if events[i].Timestamp.IsZero() || len(events[i].Latency) == 0 {
+				t.Errorf("%s: expected timing information, got %#v", k, events[i])
+			}
+			events[i].Timestamp = testCase.expected[i].Timestamp
+			events[i].Latency = testCase.expected[i].Latency
+		}
+		if !reflect.DeepEqual(testCase.expected, events) {
+			t.Errorf("%s: expected\n%#v\ngot\n%#v", k, testCase.expected, events)
+		}
+	}
+}
+
+func TestNewAuditPolicyFromFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "audit_policy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(`{"includeReadOnly":true,"requestBodyResources":["pods","secrets"]}`)
+	f.Close()
+
+	policy, err := NewAuditPolicyFromFile(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &AuditPolicy{IncludeReadOnly: true, RequestBodyResources: []string{"pods", "secrets"}}
+	if !reflect.DeepEqual(expected, policy) {
+		t.Errorf("expected %#v, got %#v", expected, policy)
+	}
+}
@@ -17,20 +17,179 @@ limitations under the License.
 package apiserver
 
 import (
+	"crypto/rsa"
+	"time"
+
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authenticator"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authenticator/bearertoken"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/serviceaccount"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/password/passwordfile"
+	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/request/basicauth"
+	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/request/union"
+	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/request/x509"
+	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/token/oidc"
 	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/token/tokenfile"
+	"github.com/GoogleCloudPlatform/kubernetes/plugin/pkg/auth/authenticator/token/webhook"
 )
 
-// NewAuthenticatorFromTokenFile returns an authenticator.Request or an error
-func NewAuthenticatorFromTokenFile(tokenAuthFile string) (authenticator.Request, error) {
-	var authenticator authenticator.Request
-	if len(tokenAuthFile) != 0 {
-		tokenAuthenticator, err := tokenfile.NewCSV(tokenAuthFile)
+// AuthenticatorConfig selects the methods used to authenticate requests.  Methods
+// whose file is empty are disabled.
+type AuthenticatorConfig struct {
+	BasicAuthFile string
+	ClientCAFile  string
+	TokenAuthFile string
+
+	// TokenWebhookConfigFile is a kubeconfig file describing a remote service
+	// that reviews bearer tokens.  Its answers are cached for TokenWebhookCacheTTL.
+	TokenWebhookConfigFile string
+	TokenWebhookCacheTTL   time.Duration
+
+	ServiceAccountKeyFile     string
+	ServiceAccountLookup      bool
+	ServiceAccountTokenGetter serviceaccount.ServiceAccountTokenGetter
+
+	// OIDCIssuerURL, if set, enables OpenID Connect ID tokens from that issuer.
+	OIDCIssuerURL     string
+	OIDCClientID      string
+	OIDCCAFile        string
+	OIDCJWKSFile      string
+	OIDCUsernameClaim string
+	OIDCGroupsClaim   string
+}
+
+// NewAuthenticator returns an authenticator.Request or an error.  A request is
+// authenticated by the first of the configured methods that recognizes it.
+func NewAuthenticator(config AuthenticatorConfig) (authenticator.Request, error) {
+	var authenticators []authenticator.Request
+
+	if len(config.BasicAuthFile) > 0 {
+		basicAuth, err := newAuthenticatorFromBasicAuthFile(config.BasicAuthFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, basicAuth)
+	}
+
+	if len(config.ClientCAFile) > 0 {
+		certAuth, err := newAuthenticatorFromClientCAFile(config.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, certAuth)
+	}
+
+	if len(config.TokenAuthFile) > 0 {
+		tokenAuth, err := newAuthenticatorFromTokenFile(config.TokenAuthFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, tokenAuth)
+	}
+
+	if len(config.ServiceAccountKeyFile) > 0 {
+		serviceAccountAuth, err := newServiceAccountAuthenticator(config.ServiceAccountKeyFile, config.ServiceAccountLookup, config.ServiceAccountTokenGetter)
 		if err != nil {
 			return nil, err
 		}
-		authenticator = bearertoken.New(tokenAuthenticator)
+		authenticators = append(authenticators, serviceAccountAuth)
 	}
-	return authenticator, nil
+
+	if len(config.OIDCIssuerURL) > 0 {
+		oidcAuth, err := newOIDCAuthenticator(oidc.Options{
+			IssuerURL:     config.OIDCIssuerURL,
+			ClientID:      config.OIDCClientID,
+			CAFile:        config.OIDCCAFile,
+			JWKSFile:      config.OIDCJWKSFile,
+			UsernameClaim: config.OIDCUsernameClaim,
+			GroupsClaim:   config.OIDCGroupsClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, oidcAuth)
+	}
+
+	// The webhook is consulted last so that tokens recognized locally never
+	// leave the apiserver.
+	if len(config.TokenWebhookConfigFile) > 0 {
+		webhookAuth, err := newWebhookTokenAuthenticator(config.TokenWebhookConfigFile, config.TokenWebhookCacheTTL)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, webhookAuth)
+	}
+
+	switch len(authenticators) {
+	case 0:
+		return nil, nil
+	case 1:
+		return authenticators[0], nil
+	default:
+		return union.New(authenticators...), nil
+	}
+}
+
+// newAuthenticatorFromBasicAuthFile returns an authenticator.Request or an error
+func newAuthenticatorFromBasicAuthFile(basicAuthFile string) (authenticator.Request, error) {
+	basicAuthenticator, err := passwordfile.NewCSV(basicAuthFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return basicauth.New(basicAuthenticator), nil
+}
+
+// newAuthenticatorFromClientCAFile returns an authenticator.Request or an error
+func newAuthenticatorFromClientCAFile(clientCAFile string) (authenticator.Request, error) {
+	roots, err := util.CertPoolFromFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := x509.DefaultVerifyOptions()
+	opts.Roots = roots
+
+	return x509.New(opts, x509.CommonNameUserConversion), nil
+}
+
+// newAuthenticatorFromTokenFile returns an authenticator.Request or an error
+func newAuthenticatorFromTokenFile(tokenAuthFile string) (authenticator.Request, error) {
+	tokenAuthenticator, err := tokenfile.NewCSV(tokenAuthFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return bearertoken.New(tokenAuthenticator), nil
+}
+
+// newServiceAccountAuthenticator returns an authenticator.Request or an error
+func newServiceAccountAuthenticator(keyfile string, lookup bool, serviceAccountGetter serviceaccount.ServiceAccountTokenGetter) (authenticator.Request, error) {
+	publicKey, err := serviceaccount.ReadPublicKey(keyfile)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAuthenticator := serviceaccount.JWTTokenAuthenticator([]*rsa.PublicKey{publicKey}, lookup, serviceAccountGetter)
+	return bearertoken.New(tokenAuthenticator), nil
+}
+
+// newOIDCAuthenticator returns an authenticator.Request or an error
+func newOIDCAuthenticator(opts oidc.Options) (authenticator.Request, error) {
+	tokenAuthenticator, err := oidc.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return bearertoken.New(tokenAuthenticator), nil
+}
+
+// newWebhookTokenAuthenticator returns an authenticator.Request or an error
+func newWebhookTokenAuthenticator(kubeConfigFile string, cacheTTL time.Duration) (authenticator.Request, error) {
+	tokenAuthenticator, err := webhook.New(kubeConfigFile, cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return bearertoken.New(tokenAuthenticator), nil
 }
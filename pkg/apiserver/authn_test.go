@@ -0,0 +1,170 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeTempFile(t *testing.T, contents []byte) string {
+	f, err := ioutil.TempFile("", "authn_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating temp file: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(contents); err != nil {
+		t.Fatalf("unexpected error writing temp file: %v", err)
+	}
+	return f.Name()
+}
+
+// newClientCert returns a PEM-encoded CA certificate and a client certificate signed by it.
+func newClientCert(t *testing.T, commonName string, organizations []string) ([]byte, *x509.Certificate) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clientKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clientTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName, Organization: organizations},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	clientDER, err := x509.CreateCertificate(rand.Reader, clientTemplate, ca, &clientKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := x509.ParseCertificate(clientDER)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), client
+}
+
+func TestNewAuthenticatorNoneConfigured(t *testing.T) {
+	auth, err := NewAuthenticator(AuthenticatorConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if auth != nil {
+		t.Errorf("expected no authenticator, got %#v", auth)
+	}
+}
+
+func TestNewAuthenticatorUnion(t *testing.T) {
+	caPEM, clientCert := newClientCert(t, "alice", []string{"admins", "developers"})
+	caFile := writeTempFile(t, caPEM)
+	defer os.Remove(caFile)
+	basicAuthFile := writeTempFile(t, []byte("password1,bob,uid1\n"))
+	defer os.Remove(basicAuthFile)
+	tokenFile := writeTempFile(t, []byte("token1,carol,uid2\n"))
+	defer os.Remove(tokenFile)
+
+	auth, err := NewAuthenticator(AuthenticatorConfig{
+		BasicAuthFile: basicAuthFile,
+		ClientCAFile:  caFile,
+		TokenAuthFile: tokenFile,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	certReq, _ := http.NewRequest("GET", "/", nil)
+	certReq.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}
+	basicReq, _ := http.NewRequest("GET", "/", nil)
+	basicReq.SetBasicAuth("bob", "password1")
+	badBasicReq, _ := http.NewRequest("GET", "/", nil)
+	badBasicReq.SetBasicAuth("bob", "password2")
+	tokenReq, _ := http.NewRequest("GET", "/", nil)
+	tokenReq.Header.Set("Authorization", "Bearer token1")
+	anonymousReq, _ := http.NewRequest("GET", "/", nil)
+
+	testCases := map[string]struct {
+		Request      *http.Request
+		ExpectOK     bool
+		ExpectName   string
+		ExpectGroups []string
+	}{
+		"client certificate": {Request: certReq, ExpectOK: true, ExpectName: "alice", ExpectGroups: []string{"admins", "developers"}},
+		"basic auth":         {Request: basicReq, ExpectOK: true, ExpectName: "bob"},
+		"bad basic auth":     {Request: badBasicReq},
+		"token":              {Request: tokenReq, ExpectOK: true, ExpectName: "carol"},
+		"anonymous":          {Request: anonymousReq},
+	}
+	for k, testCase := range testCases {
+		user, ok, _ := auth.AuthenticateRequest(testCase.Request)
+		if ok != testCase.ExpectOK {
+			t.Errorf("%s: expected ok=%v, got %v", k, testCase.ExpectOK, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if user.GetName() != testCase.ExpectName {
+			t.Errorf("%s: expected name %q, got %q", k, testCase.ExpectName, user.GetName())
+		}
+		if len(testCase.ExpectGroups) > 0 && !reflect.DeepEqual(user.GetGroups(), testCase.ExpectGroups) {
+			t.Errorf("%s: expected groups %v, got %v", k, testCase.ExpectGroups, user.GetGroups())
+		}
+	}
+}
+
+func TestNewAuthenticatorInvalidClientCAFile(t *testing.T) {
+	caFile := writeTempFile(t, []byte("not a certificate"))
+	defer os.Remove(caFile)
+
+	if _, err := NewAuthenticator(AuthenticatorConfig{ClientCAFile: caFile}); err == nil {
+		t.Errorf("expected an error for a client CA file without certificates")
+	}
+}
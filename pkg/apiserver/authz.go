@@ -18,9 +18,13 @@ package apiserver
 
 import (
 	"errors"
+	"time"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer/abac"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer/rbac"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer/webhook"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 )
 
 // Attributes implements authorizer.Attributes interface.
@@ -58,17 +62,23 @@ const (
 	ModeAlwaysAllow string = "AlwaysAllow"
 	ModeAlwaysDeny  string = "AlwaysDeny"
 	ModeABAC        string = "ABAC"
+	ModeRBAC        string = "RBAC"
+	ModeWebhook     string = "Webhook"
 )
 
 // Keep this list in sync with constant list above.
-var AuthorizationModeChoices = []string{ModeAlwaysAllow, ModeAlwaysDeny, ModeABAC}
+var AuthorizationModeChoices = []string{ModeAlwaysAllow, ModeAlwaysDeny, ModeABAC, ModeRBAC, ModeWebhook}
 
 // NewAuthorizerFromAuthorizationConfig returns the right sort of authorizer.Authorizer
 // based on the authorizationMode xor an error.  authorizationMode should be one of AuthorizationModeChoices.
-func NewAuthorizerFromAuthorizationConfig(authorizationMode string, authorizationPolicyFile string) (authorizer.Authorizer, error) {
-	if authorizationPolicyFile != "" && authorizationMode != "ABAC" {
+// The client is used by ModeRBAC to watch the roles and bindings stored in the apiserver.
+func NewAuthorizerFromAuthorizationConfig(authorizationMode string, authorizationPolicyFile string, authorizationWebhookConfigFile string, authorizationWebhookCacheTTL time.Duration, kubeClient client.Interface) (authorizer.Authorizer, error) {
+	if authorizationPolicyFile != "" && authorizationMode != ModeABAC {
 		return nil, errors.New("Cannot specify --authorization_policy_file without mode ABAC")
 	}
+	if authorizationWebhookConfigFile != "" && authorizationMode != ModeWebhook {
+		return nil, errors.New("Cannot specify --authorization_webhook_config_file without mode Webhook")
+	}
 	// Keep cases in sync with constant list above.
 	switch authorizationMode {
 	case ModeAlwaysAllow:
@@ -77,6 +87,13 @@ func NewAuthorizerFromAuthorizationConfig(authorizationMode string, authorizatio
 		return NewAlwaysDenyAuthorizer(), nil
 	case ModeABAC:
 		return abac.NewFromFile(authorizationPolicyFile)
+	case ModeRBAC:
+		return rbac.NewFromClient(kubeClient), nil
+	case ModeWebhook:
+		if authorizationWebhookConfigFile == "" {
+			return nil, errors.New("Mode Webhook requires --authorization_webhook_config_file")
+		}
+		return webhook.New(authorizationWebhookConfigFile, authorizationWebhookCacheTTL)
 	default:
 		return nil, errors.New("Unknown authorization mode")
 	}
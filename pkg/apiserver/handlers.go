@@ -188,6 +188,9 @@ func (r *requestAttributeGetter) GetAttribs(req *http.Request) authorizer.Attrib
 	// in empty (does not understand defaulting rules.)
 	attribs.Namespace = apiRequestInfo.Namespace
 
+	// The verb is only meaningful for requests against the REST object store.
+	attribs.Verb = apiRequestInfo.Verb
+
 	return &attribs
 }
 
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/url"
 	gpath "path"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
@@ -28,6 +29,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/strategicpatch"
 
 	"github.com/emicklei/go-restful"
 	"github.com/evanphx/json-patch"
@@ -181,7 +183,8 @@ func CreateResource(r rest.Creater, scope RequestScope, typer runtime.ObjectType
 			return
 		}
 
-		err = admit.Admit(admission.NewAttributesRecord(obj, namespace, scope.Resource, "CREATE"))
+		userInfo, _ := api.UserFrom(ctx)
+		err = admit.Admit(admission.NewAttributesRecord(obj, namespace, scope.Resource, "CREATE", userInfo))
 		if err != nil {
 			errorJSON(err, scope.Codec, w)
 			return
@@ -223,14 +226,6 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 			return
 		}
 
-		obj := r.New()
-		// PATCH requires same permission as UPDATE
-		err = admit.Admit(admission.NewAttributesRecord(obj, namespace, scope.Resource, "UPDATE"))
-		if err != nil {
-			errorJSON(err, scope.Codec, w)
-			return
-		}
-
 		ctx := scope.ContextFunc(req)
 		ctx = api.WithNamespace(ctx, namespace)
 
@@ -250,12 +245,23 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 			errorJSON(err, scope.Codec, w)
 			return
 		}
-		patchedObjJs, err := jsonpatch.MergePatch(originalObjJs, patchJs)
+		contentType := req.HeaderParameter("Content-Type")
+		// Remove "; charset=" if included in header.
+		if idx := strings.Index(contentType, ";"); idx > 0 {
+			contentType = contentType[:idx]
+		}
+		versionedObj, err := scope.Creater.New(scope.APIVersion, scope.Kind)
 		if err != nil {
 			errorJSON(err, scope.Codec, w)
 			return
 		}
+		patchedObjJs, err := getPatchedJS(api.PatchType(contentType), originalObjJs, patchJs, versionedObj)
+		if err != nil {
+			errorJSON(errors.NewBadRequest(err.Error()), scope.Codec, w)
+			return
+		}
 
+		obj := r.New()
 		if err := scope.Codec.DecodeInto(patchedObjJs, obj); err != nil {
 			errorJSON(err, scope.Codec, w)
 			return
@@ -265,6 +271,15 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 			return
 		}
 
+		// PATCH requires same permission as UPDATE, and is admitted with the
+		// object as it will be stored.
+		userInfo, _ := api.UserFrom(ctx)
+		err = admit.Admit(admission.NewAttributesRecord(obj, namespace, scope.Resource, "UPDATE", userInfo))
+		if err != nil {
+			errorJSON(err, scope.Codec, w)
+			return
+		}
+
 		result, err := finishRequest(timeout, func() (runtime.Object, error) {
 			// update should never create as previous get would fail
 			obj, _, err := r.Update(ctx, obj)
@@ -284,6 +299,24 @@ func PatchResource(r rest.Patcher, scope RequestScope, typer runtime.ObjectTyper
 	}
 }
 
+// getPatchedJS applies patchJS to originalJS as selected by patchType.  obj is
+// an instance of the versioned type originalJS encodes.
+func getPatchedJS(patchType api.PatchType, originalJS, patchJS []byte, obj runtime.Object) ([]byte, error) {
+	switch patchType {
+	case api.JSONPatchType:
+		patchObj, err := jsonpatch.DecodePatch(patchJS)
+		if err != nil {
+			return nil, err
+		}
+		return patchObj.Apply(originalJS)
+	case api.StrategicMergePatchType:
+		return strategicpatch.StrategicMergePatch(originalJS, patchJS, obj)
+	default:
+		// MergePatchType, and clients that do not set a patch type
+		return jsonpatch.MergePatch(originalJS, patchJS)
+	}
+}
+
 // UpdateResource returns a function that will handle a resource update
 func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectTyper, admit admission.Interface) restful.RouteFunction {
 	return func(req *restful.Request, res *restful.Response) {
@@ -318,7 +351,8 @@ func UpdateResource(r rest.Updater, scope RequestScope, typer runtime.ObjectType
 			return
 		}
 
-		err = admit.Admit(admission.NewAttributesRecord(obj, namespace, scope.Resource, "UPDATE"))
+		userInfo, _ := api.UserFrom(ctx)
+		err = admit.Admit(admission.NewAttributesRecord(obj, namespace, scope.Resource, "UPDATE", userInfo))
 		if err != nil {
 			errorJSON(err, scope.Codec, w)
 			return
@@ -379,7 +413,8 @@ func DeleteResource(r rest.GracefulDeleter, checkBody bool, scope RequestScope,
 			}
 		}
 
-		err = admit.Admit(admission.NewAttributesRecord(nil, namespace, scope.Resource, "DELETE"))
+		userInfo, _ := api.UserFrom(ctx)
+		err = admit.Admit(admission.NewAttributesRecord(nil, namespace, scope.Resource, "DELETE", userInfo))
 		if err != nil {
 			errorJSON(err, scope.Codec, w)
 			return
@@ -41,6 +41,10 @@ type Attributes interface {
 
 	// The kind of object, if a request is for a REST object.
 	GetResource() string
+
+	// The kube verb associated with the request, e.g. get, list, watch,
+	// create, update or delete.  Empty if the request is not for a REST object.
+	GetVerb() string
 }
 
 // Authorizer makes an authorization decision based on information gained by making
@@ -56,6 +60,7 @@ type AttributesRecord struct {
 	ReadOnly  bool
 	Namespace string
 	Resource  string
+	Verb      string
 }
 
 func (a AttributesRecord) GetUserName() string {
@@ -77,3 +82,7 @@ func (a AttributesRecord) GetNamespace() string {
 func (a AttributesRecord) GetResource() string {
 	return a.Resource
 }
+
+func (a AttributesRecord) GetVerb() string {
+	return a.Verb
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbac implements an authorizer.Authorizer that grants access based on
+// Role, ClusterRole, RoleBinding and ClusterRoleBinding objects stored in the API.
+package rbac
+
+import (
+	"errors"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/serviceaccount"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// RBACAuthorizer authorizes requests against the rules of every role bound to
+// the requesting user, either cluster-wide by a ClusterRoleBinding or within the
+// request namespace by a RoleBinding.
+type RBACAuthorizer struct {
+	roles               cache.Store
+	clusterRoles        cache.Store
+	roleBindings        cache.Indexer
+	clusterRoleBindings cache.Store
+}
+
+// New returns an authorizer that reads its policy from the given stores.  Roles
+// and role bindings must be keyed by cache.MetaNamespaceKeyFunc, and role bindings
+// must be indexed by namespace under the name "namespace".
+func New(roles, clusterRoles cache.Store, roleBindings cache.Indexer, clusterRoleBindings cache.Store) *RBACAuthorizer {
+	return &RBACAuthorizer{
+		roles:               roles,
+		clusterRoles:        clusterRoles,
+		roleBindings:        roleBindings,
+		clusterRoleBindings: clusterRoleBindings,
+	}
+}
+
+// NewFromClient returns an authorizer whose policy is kept in sync with the
+// roles and bindings stored in the apiserver, so that changes to them take
+// effect without a restart.
+func NewFromClient(c client.Interface) *RBACAuthorizer {
+	roles := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return c.Roles(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return c.Roles(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.Role{},
+		roles,
+		0,
+	).Run()
+
+	clusterRoles := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return c.ClusterRoles().List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return c.ClusterRoles().Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.ClusterRole{},
+		clusterRoles,
+		0,
+	).Run()
+
+	roleBindings, reflector := cache.NewNamespaceKeyedIndexerAndReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return c.RoleBindings(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return c.RoleBindings(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.RoleBinding{},
+		0,
+	)
+	reflector.Run()
+
+	clusterRoleBindings := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return c.ClusterRoleBindings().List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return c.ClusterRoleBindings().Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.ClusterRoleBinding{},
+		clusterRoleBindings,
+		0,
+	).Run()
+
+	return New(roles, clusterRoles, roleBindings, clusterRoleBindings)
+}
+
+// Authorize implements authorizer.Authorizer.  A request is allowed if any rule
+// of any role bound to the user matches its verb and resource.
+func (r *RBACAuthorizer) Authorize(a authorizer.Attributes) error {
+	for _, obj := range r.clusterRoleBindings.List() {
+		binding := obj.(*api.ClusterRoleBinding)
+		if !subjectsMatch(binding.Subjects, "", a) {
+			continue
+		}
+		if rulesAllow(r.clusterRoleRules(binding.RoleRef.Name), a) {
+			return nil
+		}
+	}
+
+	namespace := a.GetNamespace()
+	if len(namespace) == 0 {
+		return errors.New("No policy matched.")
+	}
+	key := &api.RoleBinding{ObjectMeta: api.ObjectMeta{Namespace: namespace}}
+	items, err := r.roleBindings.Index("namespace", key)
+	if err != nil {
+		return err
+	}
+	for _, obj := range items {
+		binding := obj.(*api.RoleBinding)
+		if !subjectsMatch(binding.Subjects, binding.Namespace, a) {
+			continue
+		}
+		var rules []api.PolicyRule
+		switch binding.RoleRef.Kind {
+		case "Role":
+			rules = r.roleRules(binding.Namespace, binding.RoleRef.Name)
+		case "ClusterRole":
+			rules = r.clusterRoleRules(binding.RoleRef.Name)
+		}
+		if rulesAllow(rules, a) {
+			return nil
+		}
+	}
+	return errors.New("No policy matched.")
+}
+
+// roleRules returns the rules of the named role, or nil if it does not exist.
+func (r *RBACAuthorizer) roleRules(namespace, name string) []api.PolicyRule {
+	obj, exists, err := r.roles.GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*api.Role).Rules
+}
+
+// clusterRoleRules returns the rules of the named cluster role, or nil if it
+// does not exist.
+func (r *RBACAuthorizer) clusterRoleRules(name string) []api.PolicyRule {
+	obj, exists, err := r.clusterRoles.GetByKey(name)
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*api.ClusterRole).Rules
+}
+
+// subjectsMatch returns true if the requesting user is one of the subjects.
+// Service account subjects without a namespace default to bindingNamespace.
+func subjectsMatch(subjects []api.Subject, bindingNamespace string, a authorizer.Attributes) bool {
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case api.UserKind:
+			if subject.Name == a.GetUserName() {
+				return true
+			}
+		case api.GroupKind:
+			for _, group := range a.GetGroups() {
+				if subject.Name == group {
+					return true
+				}
+			}
+		case api.ServiceAccountKind:
+			namespace := subject.Namespace
+			if len(namespace) == 0 {
+				namespace = bindingNamespace
+			}
+			if len(namespace) > 0 && serviceaccount.MakeUsername(namespace, subject.Name) == a.GetUserName() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesAllow returns true if any rule covers the verb and resource of the
+// request.  Requests that are not for a REST object are only covered by rules
+// using the "*" wildcard.
+func rulesAllow(rules []api.PolicyRule, a authorizer.Attributes) bool {
+	for _, rule := range rules {
+		if contains(rule.Verbs, api.VerbAll, a.GetVerb()) && contains(rule.Resources, api.ResourceAll, a.GetResource()) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns true if values holds the wildcard or a non-empty value.
+func contains(values []string, wildcard, value string) bool {
+	for _, v := range values {
+		if v == wildcard || (len(value) > 0 && v == value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+)
+
+type stores struct {
+	roles               cache.Store
+	clusterRoles        cache.Store
+	roleBindings        cache.Indexer
+	clusterRoleBindings cache.Store
+}
+
+func newStores() *stores {
+	return &stores{
+		roles:               cache.NewStore(cache.MetaNamespaceKeyFunc),
+		clusterRoles:        cache.NewStore(cache.MetaNamespaceKeyFunc),
+		roleBindings:        cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{"namespace": cache.MetaNamespaceIndexFunc}),
+		clusterRoleBindings: cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+}
+
+func (s *stores) authorizer() *RBACAuthorizer {
+	return New(s.roles, s.clusterRoles, s.roleBindings, s.clusterRoleBindings)
+}
+
+func TestAuthorize(t *testing.T) {
+	s := newStores()
+	s.clusterRoles.Add(&api.ClusterRole{
+		ObjectMeta: api.ObjectMeta{Name: "admin"},
+		Rules:      []api.PolicyRule{{Verbs: []string{api.VerbAll}, Resources: []string{api.ResourceAll}}},
+	})
+	s.clusterRoles.Add(&api.ClusterRole{
+		ObjectMeta: api.ObjectMeta{Name: "pod-reader"},
+		Rules:      []api.PolicyRule{{Verbs: []string{"get", "list", "watch"}, Resources: []string{"pods"}}},
+	})
+	s.roles.Add(&api.Role{
+		ObjectMeta: api.ObjectMeta{Name: "editor", Namespace: "ns1"},
+		Rules: []api.PolicyRule{
+			{Verbs: []string{"get", "list"}, Resources: []string{api.ResourceAll}},
+			{Verbs: []string{"create", "update", "delete"}, Resources: []string{"pods", "services"}},
+		},
+	})
+	s.clusterRoleBindings.Add(&api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "admins"},
+		Subjects:   []api.Subject{{Kind: api.GroupKind, Name: "system:masters"}},
+		RoleRef:    api.ObjectReference{Kind: "ClusterRole", Name: "admin"},
+	})
+	s.clusterRoleBindings.Add(&api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "scheduler"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "scheduler"}},
+		RoleRef:    api.ObjectReference{Kind: "ClusterRole", Name: "pod-reader"},
+	})
+	s.roleBindings.Add(&api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "editors", Namespace: "ns1"},
+		Subjects: []api.Subject{
+			{Kind: api.UserKind, Name: "alice"},
+			{Kind: api.ServiceAccountKind, Name: "builder"},
+		},
+		RoleRef: api.ObjectReference{Kind: "Role", Name: "editor"},
+	})
+	s.roleBindings.Add(&api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "readers", Namespace: "ns2"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "bob"}},
+		RoleRef:    api.ObjectReference{Kind: "ClusterRole", Name: "pod-reader"},
+	})
+	s.roleBindings.Add(&api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "dangling", Namespace: "ns2"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "chuck"}},
+		RoleRef:    api.ObjectReference{Kind: "Role", Name: "missing"},
+	})
+	a := s.authorizer()
+
+	uAdmin := user.DefaultInfo{Name: "root", Groups: []string{"system:masters"}}
+	uScheduler := user.DefaultInfo{Name: "scheduler"}
+	uAlice := user.DefaultInfo{Name: "alice"}
+	uBob := user.DefaultInfo{Name: "bob"}
+	uChuck := user.DefaultInfo{Name: "chuck"}
+	uBuilder := user.DefaultInfo{Name: "system:serviceaccount:ns1:builder"}
+	uOtherBuilder := user.DefaultInfo{Name: "system:serviceaccount:ns2:builder"}
+
+	testCases := []struct {
+		User        user.DefaultInfo
+		Verb        string
+		Resource    string
+		NS          string
+		ExpectAllow bool
+	}{
+		// Members of system:masters can do anything, anywhere.
+		{User: uAdmin, Verb: "delete", Resource: "nodes", NS: "", ExpectAllow: true},
+		{User: uAdmin, Verb: "create", Resource: "pods", NS: "ns1", ExpectAllow: true},
+		{User: uAdmin, Verb: "", Resource: "", NS: "", ExpectAllow: true},
+
+		// The scheduler can read pods in every namespace but not write them.
+		{User: uScheduler, Verb: "list", Resource: "pods", NS: "", ExpectAllow: true},
+		{User: uScheduler, Verb: "watch", Resource: "pods", NS: "ns2", ExpectAllow: true},
+		{User: uScheduler, Verb: "delete", Resource: "pods", NS: "ns1", ExpectAllow: false},
+		{User: uScheduler, Verb: "get", Resource: "services", NS: "ns1", ExpectAllow: false},
+
+		// Alice can read anything and edit pods and services in ns1 only.
+		{User: uAlice, Verb: "get", Resource: "secrets", NS: "ns1", ExpectAllow: true},
+		{User: uAlice, Verb: "create", Resource: "pods", NS: "ns1", ExpectAllow: true},
+		{User: uAlice, Verb: "delete", Resource: "secrets", NS: "ns1", ExpectAllow: false},
+		{User: uAlice, Verb: "get", Resource: "pods", NS: "ns2", ExpectAllow: false},
+		{User: uAlice, Verb: "list", Resource: "pods", NS: "", ExpectAllow: false},
+		// Requests not for a REST object are only allowed by wildcard rules.
+		{User: uAlice, Verb: "", Resource: "", NS: "ns1", ExpectAllow: false},
+
+		// Bob is granted a cluster role, but only within ns2.
+		{User: uBob, Verb: "get", Resource: "pods", NS: "ns2", ExpectAllow: true},
+		{User: uBob, Verb: "get", Resource: "pods", NS: "ns1", ExpectAllow: false},
+
+		// Bindings to roles that do not exist grant nothing.
+		{User: uChuck, Verb: "get", Resource: "pods", NS: "ns2", ExpectAllow: false},
+
+		// Service account subjects default to the namespace of the binding.
+		{User: uBuilder, Verb: "create", Resource: "services", NS: "ns1", ExpectAllow: true},
+		{User: uOtherBuilder, Verb: "create", Resource: "services", NS: "ns1", ExpectAllow: false},
+	}
+	for _, tc := range testCases {
+		attr := authorizer.AttributesRecord{
+			User:      &tc.User,
+			Verb:      tc.Verb,
+			Resource:  tc.Resource,
+			Namespace: tc.NS,
+		}
+		err := a.Authorize(attr)
+		actualAllow := bool(err == nil)
+		if tc.ExpectAllow != actualAllow {
+			t.Errorf("Expected allowed=%v but actually allowed=%v, for case %v",
+				tc.ExpectAllow, actualAllow, tc)
+		}
+	}
+}
+
+func TestAuthorizeFollowsPolicyChanges(t *testing.T) {
+	s := newStores()
+	a := s.authorizer()
+	attr := authorizer.AttributesRecord{
+		User:      &user.DefaultInfo{Name: "alice"},
+		Verb:      "get",
+		Resource:  "pods",
+		Namespace: "ns1",
+	}
+	if err := a.Authorize(attr); err == nil {
+		t.Fatalf("Expected request to be denied without any policy")
+	}
+
+	role := &api.Role{
+		ObjectMeta: api.ObjectMeta{Name: "reader", Namespace: "ns1"},
+		Rules:      []api.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+	}
+	binding := &api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "readers", Namespace: "ns1"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "alice"}},
+		RoleRef:    api.ObjectReference{Kind: "Role", Name: "reader"},
+	}
+	s.roles.Add(role)
+	s.roleBindings.Add(binding)
+	if err := a.Authorize(attr); err != nil {
+		t.Errorf("Expected request to be allowed after binding, got %v", err)
+	}
+
+	role.Rules = []api.PolicyRule{{Verbs: []string{"list"}, Resources: []string{"pods"}}}
+	s.roles.Update(role)
+	if err := a.Authorize(attr); err == nil {
+		t.Errorf("Expected request to be denied after the role was narrowed")
+	}
+
+	s.roles.Delete(role)
+	s.roleBindings.Delete(binding)
+	if err := a.Authorize(attr); err == nil {
+		t.Errorf("Expected request to be denied after the binding was removed")
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements an authorizer.Authorizer that asks a remote
+// service to review each request.
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/webhook"
+)
+
+// SubjectAccessReview is posted to the remote service to ask whether a
+// request is allowed.  The service fills in the status.
+type SubjectAccessReview struct {
+	Spec   SubjectAccessReviewSpec   `json:"spec"`
+	Status SubjectAccessReviewStatus `json:"status"`
+}
+
+// SubjectAccessReviewSpec holds the attributes of the request being reviewed.
+type SubjectAccessReviewSpec struct {
+	User      string   `json:"user"`
+	Groups    []string `json:"groups"`
+	ReadOnly  bool     `json:"readonly"`
+	Verb      string   `json:"verb"`
+	Resource  string   `json:"resource"`
+	Namespace string   `json:"namespace"`
+}
+
+// SubjectAccessReviewStatus is the remote service's answer.
+type SubjectAccessReviewStatus struct {
+	// Allowed is true if the request may proceed.
+	Allowed bool `json:"allowed"`
+	// Reason optionally explains why a request was denied.
+	Reason string `json:"reason,omitempty"`
+}
+
+// WebhookAuthorizer authorizes requests by posting a SubjectAccessReview to
+// a remote service.  Answers are cached for a fixed time.
+type WebhookAuthorizer struct {
+	webhook *webhook.GenericWebhook
+	cache   *webhook.Cache
+}
+
+// New creates an authorizer for the service described by the given
+// kubeconfig file.  Reviews are cached for cacheTTL.
+func New(kubeConfigFile string, cacheTTL time.Duration) (*WebhookAuthorizer, error) {
+	w, err := webhook.NewGenericWebhook(kubeConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookAuthorizer{w, webhook.NewCache(cacheTTL)}, nil
+}
+
+// Authorize implements authorizer.Authorizer.
+func (a *WebhookAuthorizer) Authorize(attr authorizer.Attributes) error {
+	review := &SubjectAccessReview{
+		Spec: SubjectAccessReviewSpec{
+			User:      attr.GetUserName(),
+			Groups:    attr.GetGroups(),
+			ReadOnly:  attr.IsReadOnly(),
+			Verb:      attr.GetVerb(),
+			Resource:  attr.GetResource(),
+			Namespace: attr.GetNamespace(),
+		},
+	}
+	key, err := json.Marshal(review.Spec)
+	if err != nil {
+		return err
+	}
+
+	var status SubjectAccessReviewStatus
+	if cached, ok := a.cache.Get(string(key)); ok {
+		status = cached.(SubjectAccessReviewStatus)
+	} else {
+		if err := a.webhook.Post(review, review); err != nil {
+			return err
+		}
+		status = review.Status
+		a.cache.Set(string(key), status)
+	}
+
+	if status.Allowed {
+		return nil
+	}
+	if len(status.Reason) > 0 {
+		return errors.New(status.Reason)
+	}
+	return errors.New("Denied by authorization webhook.")
+}
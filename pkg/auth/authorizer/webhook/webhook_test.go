@@ -0,0 +1,169 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authorizer"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+)
+
+func newWithServer(t *testing.T, server *httptest.Server, cacheTTL time.Duration) *WebhookAuthorizer {
+	f, err := ioutil.TempFile("", "webhook_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating kubeconfig: %v", err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	kubeConfig := fmt.Sprintf(`{
+  "current-context": "webhook",
+  "contexts": {"webhook": {"cluster": "webhook"}},
+  "clusters": {"webhook": {"server": %q, "insecure-skip-tls-verify": true}}
+}`, server.URL)
+	if err := ioutil.WriteFile(f.Name(), []byte(kubeConfig), 0600); err != nil {
+		t.Fatalf("unexpected error writing kubeconfig: %v", err)
+	}
+
+	a, err := New(f.Name(), cacheTTL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return a
+}
+
+// fakePolicyService allows admins to do anything and everyone else to read
+// pods.  It records the reviews it is asked for.
+type fakePolicyService struct {
+	fail    bool
+	reviews []SubjectAccessReviewSpec
+}
+
+func (s *fakePolicyService) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if s.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var review SubjectAccessReview
+	if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.reviews = append(s.reviews, review.Spec)
+	for _, group := range review.Spec.Groups {
+		if group == "admins" {
+			review.Status.Allowed = true
+		}
+	}
+	if review.Spec.ReadOnly && review.Spec.Resource == "pods" {
+		review.Status.Allowed = true
+	}
+	if !review.Status.Allowed && review.Spec.User == "mallory" {
+		review.Status.Reason = "mallory is banned"
+	}
+	json.NewEncoder(w).Encode(review)
+}
+
+func TestAuthorize(t *testing.T) {
+	service := &fakePolicyService{}
+	server := httptest.NewTLSServer(service)
+	defer server.Close()
+	a := newWithServer(t, server, time.Minute)
+
+	uAdmin := user.DefaultInfo{Name: "alice", Groups: []string{"admins"}}
+	uBob := user.DefaultInfo{Name: "bob"}
+	uMallory := user.DefaultInfo{Name: "mallory"}
+
+	testCases := []struct {
+		User        user.DefaultInfo
+		RO          bool
+		Verb        string
+		Resource    string
+		NS          string
+		ExpectAllow bool
+	}{
+		{User: uAdmin, RO: false, Verb: "delete", Resource: "nodes", NS: "", ExpectAllow: true},
+		{User: uBob, RO: true, Verb: "get", Resource: "pods", NS: "ns1", ExpectAllow: true},
+		{User: uBob, RO: false, Verb: "create", Resource: "pods", NS: "ns1", ExpectAllow: false},
+		{User: uBob, RO: true, Verb: "get", Resource: "secrets", NS: "ns1", ExpectAllow: false},
+		{User: uMallory, RO: false, Verb: "delete", Resource: "pods", NS: "ns1", ExpectAllow: false},
+	}
+	for _, tc := range testCases {
+		attr := authorizer.AttributesRecord{
+			User:      &tc.User,
+			ReadOnly:  tc.RO,
+			Verb:      tc.Verb,
+			Resource:  tc.Resource,
+			Namespace: tc.NS,
+		}
+		err := a.Authorize(attr)
+		actualAllow := bool(err == nil)
+		if tc.ExpectAllow != actualAllow {
+			t.Errorf("Expected allowed=%v but actually allowed=%v, for case %v",
+				tc.ExpectAllow, actualAllow, tc)
+		}
+	}
+
+	last := service.reviews[len(service.reviews)-1]
+	expected := SubjectAccessReviewSpec{User: "mallory", Verb: "delete", Resource: "pods", Namespace: "ns1"}
+	if fmt.Sprintf("%#v", last) != fmt.Sprintf("%#v", expected) {
+		t.Errorf("expected review %#v, got %#v", expected, last)
+	}
+	attr := authorizer.AttributesRecord{User: &uMallory, Verb: "delete", Resource: "pods", Namespace: "ns1"}
+	if err := a.Authorize(attr); err == nil || err.Error() != "mallory is banned" {
+		t.Errorf("expected the reason from the webhook, got %v", err)
+	}
+}
+
+func TestAuthorizeCachesReviews(t *testing.T) {
+	service := &fakePolicyService{}
+	server := httptest.NewTLSServer(service)
+	defer server.Close()
+	a := newWithServer(t, server, time.Minute)
+
+	allowed := authorizer.AttributesRecord{User: &user.DefaultInfo{Name: "bob"}, ReadOnly: true, Verb: "get", Resource: "pods"}
+	denied := authorizer.AttributesRecord{User: &user.DefaultInfo{Name: "bob"}, Verb: "delete", Resource: "pods"}
+	for i := 0; i < 3; i++ {
+		if err := a.Authorize(allowed); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := a.Authorize(denied); err == nil {
+			t.Fatalf("expected request to be denied")
+		}
+	}
+	if len(service.reviews) != 2 {
+		t.Errorf("expected 2 reviews, got %d", len(service.reviews))
+	}
+
+	// Requests are denied if the service cannot be reached and nothing is cached.
+	service.fail = true
+	if err := a.Authorize(allowed); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	other := authorizer.AttributesRecord{User: &user.DefaultInfo{Name: "bob"}, ReadOnly: true, Verb: "list", Resource: "pods"}
+	if err := a.Authorize(other); err == nil {
+		t.Errorf("expected request to be denied when the service fails")
+	}
+}
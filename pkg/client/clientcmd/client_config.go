@@ -54,21 +54,24 @@ type DirectClientConfig struct {
 	contextName    string
 	overrides      *ConfigOverrides
 	fallbackReader io.Reader
+	// loadingRules, if set, are the rules config was loaded with.  Auth providers
+	// save refreshed credentials to the files they name.
+	loadingRules *ClientConfigLoadingRules
 }
 
 // NewDefaultClientConfig creates a DirectClientConfig using the config.CurrentContext as the context name
 func NewDefaultClientConfig(config clientcmdapi.Config, overrides *ConfigOverrides) ClientConfig {
-	return DirectClientConfig{config, config.CurrentContext, overrides, nil}
+	return DirectClientConfig{config, config.CurrentContext, overrides, nil, nil}
 }
 
 // NewNonInteractiveClientConfig creates a DirectClientConfig using the passed context name and does not have a fallback reader for auth information
 func NewNonInteractiveClientConfig(config clientcmdapi.Config, contextName string, overrides *ConfigOverrides) ClientConfig {
-	return DirectClientConfig{config, contextName, overrides, nil}
+	return DirectClientConfig{config, contextName, overrides, nil, nil}
 }
 
 // NewInteractiveClientConfig creates a DirectClientConfig using the passed context name and a reader in case auth information is not provided via files or flags
 func NewInteractiveClientConfig(config clientcmdapi.Config, contextName string, overrides *ConfigOverrides, fallbackReader io.Reader) ClientConfig {
-	return DirectClientConfig{config, contextName, overrides, fallbackReader}
+	return DirectClientConfig{config, contextName, overrides, fallbackReader, nil}
 }
 
 func (config DirectClientConfig) RawConfig() (clientcmdapi.Config, error) {
@@ -104,6 +107,18 @@ func (config DirectClientConfig) ClientConfig() (*client.Config, error) {
 			return nil, err
 		}
 		mergo.Merge(clientConfig, serverAuthPartialConfig)
+
+		if configAuthInfo.AuthProvider != nil {
+			var persister AuthProviderConfigPersister
+			if config.loadingRules != nil {
+				persister = &kubeConfigFilePersister{config.loadingRules, config.getAuthInfoName()}
+			}
+			provider, err := newAuthProvider(configAuthInfo.AuthProvider, persister)
+			if err != nil {
+				return nil, err
+			}
+			clientConfig.WrapTransport = provider.WrapTransport
+		}
 	}
 
 	return clientConfig, nil
@@ -182,7 +197,7 @@ func getUserIdentificationPartialConfig(configAuthInfo clientcmdapi.AuthInfo, fa
 	}
 
 	// if there isn't sufficient information to authenticate the user to the server, merge in ~/.kubernetes_auth.
-	if !canIdentifyUser(*mergedConfig) {
+	if !canIdentifyUser(*mergedConfig) && configAuthInfo.AuthProvider == nil {
 		defaultAuthPathInfo, err := NewDefaultAuthLoader().LoadAuth(os.Getenv("HOME") + "/.kubernetes_auth")
 		// if the error is anything besides a does not exist, then fail.  Not existing is ok
 		if err != nil && !os.IsNotExist(err) {
@@ -198,7 +213,7 @@ func getUserIdentificationPartialConfig(configAuthInfo clientcmdapi.AuthInfo, fa
 	}
 
 	// if there still isn't enough information to authenticate the user, try prompting
-	if !canIdentifyUser(*mergedConfig) && (fallbackReader != nil) {
+	if !canIdentifyUser(*mergedConfig) && configAuthInfo.AuthProvider == nil && (fallbackReader != nil) {
 		prompter := NewPromptingAuthLoader(fallbackReader)
 		promptedAuthInfo := prompter.Prompt()
 
@@ -50,14 +50,7 @@ func (config DeferredLoadingClientConfig) createClientConfig() (ClientConfig, er
 		return nil, err
 	}
 
-	var mergedClientConfig ClientConfig
-	if config.fallbackReader != nil {
-		mergedClientConfig = NewInteractiveClientConfig(*mergedConfig, config.overrides.CurrentContext, config.overrides, config.fallbackReader)
-	} else {
-		mergedClientConfig = NewNonInteractiveClientConfig(*mergedConfig, config.overrides.CurrentContext, config.overrides)
-	}
-
-	return mergedClientConfig, nil
+	return DirectClientConfig{*mergedConfig, config.overrides.CurrentContext, config.overrides, config.fallbackReader, config.loadingRules}, nil
 }
 
 func (config DeferredLoadingClientConfig) RawConfig() (clientcmdapi.Config, error) {
@@ -0,0 +1,287 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientcmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+)
+
+const (
+	// OIDCAuthProviderName is the name of the OpenID Connect auth provider.
+	OIDCAuthProviderName = "oidc"
+
+	// Keys of the oidc auth provider's config.
+	oidcIssuerURL            = "idp-issuer-url"
+	oidcCertificateAuthority = "idp-certificate-authority"
+	oidcClientID             = "client-id"
+	oidcClientSecret         = "client-secret"
+	oidcIDToken              = "id-token"
+	oidcRefreshToken         = "refresh-token"
+
+	// oidcExpiryMargin is how long before it expires an ID token is refreshed,
+	// so that it does not expire while a request is in flight.
+	oidcExpiryMargin = 10 * time.Second
+)
+
+// AuthProviderConfigPersister saves the configuration of an auth provider,
+// e.g. after it has refreshed its tokens.
+type AuthProviderConfigPersister interface {
+	Persist(config map[string]string) error
+}
+
+// oidcAuthProvider authenticates requests with an OpenID Connect ID token.
+// When the token expires, it is refreshed from the provider's token endpoint
+// with the refresh token, and the new tokens are persisted.
+type oidcAuthProvider struct {
+	client    *http.Client
+	persister AuthProviderConfigPersister
+	clock     util.Clock
+
+	lock   sync.Mutex
+	config map[string]string
+}
+
+// newAuthProvider returns the auth provider described by config.  persister
+// may be nil, in which case refreshed tokens are only kept in memory.
+func newAuthProvider(config *clientcmdapi.AuthProviderConfig, persister AuthProviderConfigPersister) (*oidcAuthProvider, error) {
+	if config.Name != OIDCAuthProviderName {
+		return nil, fmt.Errorf("unknown auth provider %q", config.Name)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if caFile := config.Config[oidcCertificateAuthority]; len(caFile) > 0 {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+			Proxy:           http.ProxyFromEnvironment,
+		}
+	}
+	copied := map[string]string{}
+	for k, v := range config.Config {
+		copied[k] = v
+	}
+	return &oidcAuthProvider{
+		client:    client,
+		persister: persister,
+		clock:     util.RealClock{},
+		config:    copied,
+	}, nil
+}
+
+// WrapTransport returns a round tripper that adds the ID token to requests.
+func (p *oidcAuthProvider) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &oidcRoundTripper{p, rt}
+}
+
+type oidcRoundTripper struct {
+	provider *oidcAuthProvider
+	rt       http.RoundTripper
+}
+
+func (rt *oidcRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := rt.provider.idToken()
+	if err != nil {
+		return nil, err
+	}
+	// shallow copy of the struct, deep copy of the headers, since round
+	// trippers must not modify the request
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header)
+	for k, s := range req.Header {
+		r.Header[k] = s
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	return rt.rt.RoundTrip(r)
+}
+
+// idToken returns an unexpired ID token, refreshing it if necessary.
+func (p *oidcAuthProvider) idToken() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	token := p.config[oidcIDToken]
+	if len(token) > 0 && !p.expired(token) {
+		return token, nil
+	}
+	if len(p.config[oidcRefreshToken]) == 0 {
+		if len(token) == 0 {
+			return "", errors.New("no id-token or refresh-token is configured")
+		}
+		return "", errors.New("the id-token has expired and there is no refresh-token to renew it")
+	}
+	if err := p.refresh(); err != nil {
+		return "", fmt.Errorf("unable to refresh the id-token: %v", err)
+	}
+	return p.config[oidcIDToken], nil
+}
+
+// expired returns true if a token expires within oidcExpiryMargin.  Tokens
+// that cannot be parsed are left to the server to judge.
+func (p *oidcAuthProvider) expired(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	segment := parts[1]
+	if l := len(segment) % 4; l > 0 {
+		segment += strings.Repeat("=", 4-l)
+	}
+	data, err := base64.URLEncoding.DecodeString(segment)
+	if err != nil {
+		return false
+	}
+	claims := struct {
+		Expiry int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(data, &claims); err != nil || claims.Expiry == 0 {
+		return false
+	}
+	return !p.clock.Now().Add(oidcExpiryMargin).Before(time.Unix(claims.Expiry, 0))
+}
+
+// refresh exchanges the refresh token for new tokens at the token endpoint
+// found by discovery from the issuer.  p.lock must be held.
+func (p *oidcAuthProvider) refresh() error {
+	issuer := p.config[oidcIssuerURL]
+	if len(issuer) == 0 {
+		return fmt.Errorf("%s is not configured", oidcIssuerURL)
+	}
+	discovery := struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}{}
+	if err := p.getJSON(strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return err
+	}
+	if len(discovery.TokenEndpoint) == 0 {
+		return errors.New("the provider has no token_endpoint")
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {p.config[oidcRefreshToken]},
+	}
+	req, err := http.NewRequest("POST", discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.config[oidcClientID]), url.QueryEscape(p.config[oidcClientSecret]))
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from the token endpoint: %s", resp.Status)
+	}
+	tokens := struct {
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return err
+	}
+	if len(tokens.IDToken) == 0 {
+		return errors.New("the token endpoint did not return an id_token")
+	}
+
+	p.config[oidcIDToken] = tokens.IDToken
+	// Providers may rotate the refresh token.
+	if len(tokens.RefreshToken) > 0 {
+		p.config[oidcRefreshToken] = tokens.RefreshToken
+	}
+	if p.persister != nil {
+		persisted := map[string]string{}
+		for k, v := range p.config {
+			persisted[k] = v
+		}
+		if err := p.persister.Persist(persisted); err != nil {
+			glog.Warningf("Unable to save refreshed OpenID Connect tokens: %v", err)
+		}
+	}
+	return nil
+}
+
+func (p *oidcAuthProvider) getJSON(location string, obj interface{}) error {
+	resp, err := p.client.Get(location)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from %s: %s", location, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
+
+// kubeConfigFilePersister saves the auth provider config of a user to the
+// first kubeconfig file of a set of loading rules that defines the user,
+// which is the file whose definition is in effect.
+type kubeConfigFilePersister struct {
+	loadingRules *ClientConfigLoadingRules
+	authInfoName string
+}
+
+func (p *kubeConfigFilePersister) Persist(config map[string]string) error {
+	filenames := append([]string{p.loadingRules.ExplicitPath}, p.loadingRules.Precedence...)
+	for _, filename := range filenames {
+		if len(filename) == 0 {
+			continue
+		}
+		kubeConfig, err := LoadFromFile(filename)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		authInfo, ok := kubeConfig.AuthInfos[p.authInfoName]
+		if !ok {
+			continue
+		}
+		if authInfo.AuthProvider == nil {
+			return fmt.Errorf("user %q in %s has no auth provider", p.authInfoName, filename)
+		}
+		authInfo.AuthProvider.Config = config
+		kubeConfig.AuthInfos[p.authInfoName] = authInfo
+		return WriteToFile(*kubeConfig, filename)
+	}
+	return fmt.Errorf("user %q was not found in any kubeconfig file", p.authInfoName)
+}
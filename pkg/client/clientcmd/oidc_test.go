@@ -0,0 +1,254 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientcmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	clientcmdapi "github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// fakeIDToken returns an unsigned token that expires at expiry.  The client
+// never verifies the signature.
+func fakeIDToken(subject string, expiry time.Time) string {
+	claims, _ := json.Marshal(map[string]interface{}{"sub": subject, "exp": expiry.Unix()})
+	return "eyJhbGciOiJSUzI1NiJ9." + strings.TrimRight(base64.URLEncoding.EncodeToString(claims), "=") + ".c2lnbmF0dXJl"
+}
+
+// fakeOIDCProvider serves discovery and a token endpoint that exchanges
+// refreshToken for idToken and nextRefreshToken.
+type fakeOIDCProvider struct {
+	server           *httptest.Server
+	clientID         string
+	clientSecret     string
+	refreshToken     string
+	idToken          string
+	nextRefreshToken string
+	refreshes        int
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	p := &fakeOIDCProvider{clientID: "kubectl", clientSecret: "secret"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"issuer":%q,"token_endpoint":%q}`, p.server.URL, p.server.URL+"/token")
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		id, secret, ok := req.BasicAuth()
+		if !ok || id != p.clientID || secret != p.clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if grantType := req.FormValue("grant_type"); grantType != "refresh_token" {
+			t.Errorf("unexpected grant type %q", grantType)
+		}
+		if req.FormValue("refresh_token") != p.refreshToken {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		p.refreshes++
+		fmt.Fprintf(w, `{"id_token":%q,"refresh_token":%q,"token_type":"Bearer"}`, p.idToken, p.nextRefreshToken)
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+type fakePersister struct {
+	config map[string]string
+}
+
+func (p *fakePersister) Persist(config map[string]string) error {
+	p.config = config
+	return nil
+}
+
+func TestOIDCAuthProviderRefresh(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	defer provider.server.Close()
+	now := time.Now()
+	provider.refreshToken = "refresh-1"
+	provider.idToken = fakeIDToken("jane", now.Add(time.Hour))
+	provider.nextRefreshToken = "refresh-2"
+
+	testCases := map[string]struct {
+		config        map[string]string
+		expectToken   string
+		expectRefresh bool
+		expectErr     bool
+	}{
+		"valid token": {
+			config:      map[string]string{oidcIDToken: fakeIDToken("jane", now.Add(time.Minute)), oidcRefreshToken: "refresh-1"},
+			expectToken: fakeIDToken("jane", now.Add(time.Minute)),
+		},
+		"opaque token is not refreshed": {
+			config:      map[string]string{oidcIDToken: "opaque"},
+			expectToken: "opaque",
+		},
+		"expired token": {
+			config:        map[string]string{oidcIDToken: fakeIDToken("jane", now.Add(-time.Minute)), oidcRefreshToken: "refresh-1"},
+			expectToken:   provider.idToken,
+			expectRefresh: true,
+		},
+		"token about to expire": {
+			config:        map[string]string{oidcIDToken: fakeIDToken("jane", now.Add(oidcExpiryMargin/2)), oidcRefreshToken: "refresh-1"},
+			expectToken:   provider.idToken,
+			expectRefresh: true,
+		},
+		"no token": {
+			config:        map[string]string{oidcRefreshToken: "refresh-1"},
+			expectToken:   provider.idToken,
+			expectRefresh: true,
+		},
+		"expired token without refresh token": {
+			config:    map[string]string{oidcIDToken: fakeIDToken("jane", now.Add(-time.Minute))},
+			expectErr: true,
+		},
+		"rejected refresh token": {
+			config:    map[string]string{oidcRefreshToken: "revoked"},
+			expectErr: true,
+		},
+	}
+	for k, testCase := range testCases {
+		provider.refreshes = 0
+		config := map[string]string{
+			oidcIssuerURL:    provider.server.URL,
+			oidcClientID:     provider.clientID,
+			oidcClientSecret: provider.clientSecret,
+		}
+		for key, value := range testCase.config {
+			config[key] = value
+		}
+		persister := &fakePersister{}
+		authProvider, err := newAuthProvider(&clientcmdapi.AuthProviderConfig{Name: OIDCAuthProviderName, Config: config}, persister)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", k, err)
+		}
+		authProvider.clock = &util.FakeClock{Time: now}
+
+		token, err := authProvider.idToken()
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error", k)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if token != testCase.expectToken {
+			t.Errorf("%s: expected token %q, got %q", k, testCase.expectToken, token)
+		}
+		if (provider.refreshes > 0) != testCase.expectRefresh {
+			t.Errorf("%s: expected refresh %t, got %d refreshes", k, testCase.expectRefresh, provider.refreshes)
+		}
+		if testCase.expectRefresh {
+			if persister.config[oidcIDToken] != provider.idToken || persister.config[oidcRefreshToken] != provider.nextRefreshToken {
+				t.Errorf("%s: refreshed tokens were not persisted: %v", k, persister.config)
+			}
+			if persister.config[oidcClientSecret] != provider.clientSecret {
+				t.Errorf("%s: expected the rest of the config to be persisted: %v", k, persister.config)
+			}
+		} else if persister.config != nil {
+			t.Errorf("%s: unexpected persist: %v", k, persister.config)
+		}
+	}
+}
+
+func TestOIDCAuthProviderClientConfig(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	defer provider.server.Close()
+	provider.refreshToken = "refresh-1"
+	provider.idToken = fakeIDToken("jane", time.Now().Add(time.Hour))
+	provider.nextRefreshToken = "refresh-2"
+
+	// credentials are only sent to secure servers
+	var authorization string
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+	}))
+	defer apiServer.Close()
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = clientcmdapi.Cluster{Server: apiServer.URL, InsecureSkipTLSVerify: true}
+	config.AuthInfos["jane"] = clientcmdapi.AuthInfo{
+		AuthProvider: &clientcmdapi.AuthProviderConfig{
+			Name: OIDCAuthProviderName,
+			Config: map[string]string{
+				oidcIssuerURL:    provider.server.URL,
+				oidcClientID:     provider.clientID,
+				oidcClientSecret: provider.clientSecret,
+				oidcIDToken:      fakeIDToken("jane", time.Now().Add(-time.Hour)),
+				oidcRefreshToken: "refresh-1",
+			},
+		},
+	}
+	config.Contexts["context"] = clientcmdapi.Context{Cluster: "cluster", AuthInfo: "jane"}
+	config.CurrentContext = "context"
+
+	file, err := ioutil.TempFile("", "kubeconfig")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(file.Name())
+	if err := WriteToFile(*config, file.Name()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the fallback reader must not be consulted for users with an auth provider
+	loadingRules := &ClientConfigLoadingRules{ExplicitPath: file.Name()}
+	clientConfig, err := NewInteractiveDeferredLoadingClientConfig(loadingRules, &ConfigOverrides{}, strings.NewReader("")).ClientConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transport, err := client.TransportFor(clientConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(apiServer.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if expected := "Bearer " + provider.idToken; authorization != expected {
+		t.Errorf("expected authorization %q, got %q", expected, authorization)
+	}
+	persisted, err := LoadFromFile(file.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	persistedConfig := persisted.AuthInfos["jane"].AuthProvider.Config
+	if persistedConfig[oidcIDToken] != provider.idToken || persistedConfig[oidcRefreshToken] != provider.nextRefreshToken {
+		t.Errorf("refreshed tokens were not saved to the kubeconfig file: %v", persistedConfig)
+	}
+	if persistedConfig[oidcIssuerURL] != provider.server.URL {
+		t.Errorf("expected the rest of the provider config to be kept: %v", persistedConfig)
+	}
+}
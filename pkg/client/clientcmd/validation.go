@@ -148,6 +148,11 @@ func validateAuthInfo(authInfoName string, authInfo clientcmdapi.AuthInfo) []err
 		}
 	}
 
+	if authInfo.AuthProvider != nil {
+		methods = append(methods, "authProvider")
+		validationErrors = append(validationErrors, validateAuthProvider(authInfoName, authInfo.AuthProvider)...)
+	}
+
 	if len(authInfo.ClientCertificate) != 0 || len(authInfo.ClientCertificateData) != 0 {
 		// Make sure cert data and file aren't both specified
 		if len(authInfo.ClientCertificate) != 0 && len(authInfo.ClientCertificateData) != 0 {
@@ -186,6 +191,22 @@ func validateAuthInfo(authInfoName string, authInfo clientcmdapi.AuthInfo) []err
 	return validationErrors
 }
 
+// validateAuthProvider looks for errors in the configuration of an auth provider
+func validateAuthProvider(authInfoName string, provider *clientcmdapi.AuthProviderConfig) []error {
+	switch provider.Name {
+	case OIDCAuthProviderName:
+		config := provider.Config
+		if len(config[oidcIDToken]) == 0 && len(config[oidcRefreshToken]) == 0 {
+			return []error{fmt.Errorf("%s or %s must be specified for %v to use the %s auth provider", oidcIDToken, oidcRefreshToken, authInfoName, provider.Name)}
+		}
+		if len(config[oidcRefreshToken]) != 0 && (len(config[oidcIssuerURL]) == 0 || len(config[oidcClientID]) == 0) {
+			return []error{fmt.Errorf("%s and %s must be specified for %v to refresh tokens", oidcIssuerURL, oidcClientID, authInfoName)}
+		}
+		return nil
+	}
+	return []error{fmt.Errorf("unknown auth provider %q for %v", provider.Name, authInfoName)}
+}
+
 // validateContext looks for errors in the context.  It is not transitive, so errors in the reference authInfo or cluster configs are not included in this return
 func validateContext(contextName string, context clientcmdapi.Context, config clientcmdapi.Config) []error {
 	validationErrors := make([]error, 0)
@@ -322,6 +322,71 @@ func TestValidateMultipleMethodsAuthInfo(t *testing.T) {
 	test.testConfig(t)
 }
 
+func TestValidateAuthProviderAuthInfo(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.AuthInfos["clean"] = clientcmdapi.AuthInfo{
+		AuthProvider: &clientcmdapi.AuthProviderConfig{
+			Name: "oidc",
+			Config: map[string]string{
+				"idp-issuer-url": "https://accounts.example.com",
+				"client-id":      "kubectl",
+				"refresh-token":  "refresh",
+			},
+		},
+	}
+	test := configValidationTest{
+		config: config,
+	}
+
+	test.testAuthInfo("clean", t)
+	test.testConfig(t)
+}
+func TestValidateAuthProviderErrorsAuthInfo(t *testing.T) {
+	testCases := map[string]struct {
+		provider               clientcmdapi.AuthProviderConfig
+		expectedErrorSubstring []string
+	}{
+		"unknown provider": {
+			provider:               clientcmdapi.AuthProviderConfig{Name: "unknown", Config: map[string]string{"id-token": "token"}},
+			expectedErrorSubstring: []string{"unknown auth provider"},
+		},
+		"no tokens": {
+			provider:               clientcmdapi.AuthProviderConfig{Name: "oidc"},
+			expectedErrorSubstring: []string{"id-token or refresh-token must be specified"},
+		},
+		"refresh without issuer": {
+			provider:               clientcmdapi.AuthProviderConfig{Name: "oidc", Config: map[string]string{"refresh-token": "refresh", "client-id": "kubectl"}},
+			expectedErrorSubstring: []string{"idp-issuer-url and client-id must be specified"},
+		},
+	}
+	for _, testCase := range testCases {
+		provider := testCase.provider
+		config := clientcmdapi.NewConfig()
+		config.AuthInfos["error"] = clientcmdapi.AuthInfo{AuthProvider: &provider}
+		test := configValidationTest{
+			config:                 config,
+			expectedErrorSubstring: testCase.expectedErrorSubstring,
+		}
+
+		test.testAuthInfo("error", t)
+		test.testConfig(t)
+	}
+}
+func TestValidateAuthProviderAndTokenAuthInfo(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.AuthInfos["error"] = clientcmdapi.AuthInfo{
+		Token:        "token",
+		AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc", Config: map[string]string{"id-token": "token"}},
+	}
+	test := configValidationTest{
+		config:                 config,
+		expectedErrorSubstring: []string{"more than one authentication method", "token", "authProvider"},
+	}
+
+	test.testAuthInfo("error", t)
+	test.testConfig(t)
+}
+
 type configValidationTest struct {
 	config                 *clientcmdapi.Config
 	expectedErrorSubstring []string
@@ -0,0 +1,104 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// ClusterRoleBindingsInterface has methods to work with ClusterRoleBinding resources.
+type ClusterRoleBindingsInterface interface {
+	ClusterRoleBindings() ClusterRoleBindingInterface
+}
+
+// ClusterRoleBindingInterface has methods to work with ClusterRoleBinding resources.
+type ClusterRoleBindingInterface interface {
+	List(label labels.Selector, field fields.Selector) (*api.ClusterRoleBindingList, error)
+	Get(name string) (*api.ClusterRoleBinding, error)
+	Create(clusterRoleBinding *api.ClusterRoleBinding) (*api.ClusterRoleBinding, error)
+	Update(clusterRoleBinding *api.ClusterRoleBinding) (*api.ClusterRoleBinding, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// clusterRoleBindings implements ClusterRoleBindingsInterface interface
+type clusterRoleBindings struct {
+	client *Client
+}
+
+// newClusterRoleBindings returns a clusterRoleBindings
+func newClusterRoleBindings(c *Client) *clusterRoleBindings {
+	return &clusterRoleBindings{c}
+}
+
+// List takes a selector, and returns the list of cluster role bindings that match that selector.
+func (c *clusterRoleBindings) List(label labels.Selector, field fields.Selector) (result *api.ClusterRoleBindingList, err error) {
+	result = &api.ClusterRoleBindingList{}
+	err = c.client.Get().
+		Resource("clusterRoleBindings").
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get takes the name of the cluster role binding, and returns the corresponding ClusterRoleBinding object, and an error if it occurs
+func (c *clusterRoleBindings) Get(name string) (result *api.ClusterRoleBinding, err error) {
+	result = &api.ClusterRoleBinding{}
+	err = c.client.Get().Resource("clusterRoleBindings").Name(name).Do().Into(result)
+	return
+}
+
+// Create takes the representation of a cluster role binding.  Returns the server's representation of the cluster role binding, and an error, if it occurs.
+func (c *clusterRoleBindings) Create(clusterRoleBinding *api.ClusterRoleBinding) (result *api.ClusterRoleBinding, err error) {
+	result = &api.ClusterRoleBinding{}
+	err = c.client.Post().Resource("clusterRoleBindings").Body(clusterRoleBinding).Do().Into(result)
+	return
+}
+
+// Update takes the representation of a cluster role binding to update.  Returns the server's representation of the cluster role binding, and an error, if it occurs.
+func (c *clusterRoleBindings) Update(clusterRoleBinding *api.ClusterRoleBinding) (result *api.ClusterRoleBinding, err error) {
+	result = &api.ClusterRoleBinding{}
+	if len(clusterRoleBinding.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", clusterRoleBinding)
+		return
+	}
+	err = c.client.Put().Resource("clusterRoleBindings").Name(clusterRoleBinding.Name).Body(clusterRoleBinding).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the cluster role binding, and returns an error if one occurs
+func (c *clusterRoleBindings) Delete(name string) error {
+	return c.client.Delete().Resource("clusterRoleBindings").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested cluster role bindings.
+func (c *clusterRoleBindings) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Resource("clusterRoleBindings").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Watch()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+)
+
+func TestClusterRoleBindingCreate(t *testing.T) {
+	clusterRoleBinding := &api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "abc"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "alice"}},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "POST",
+			Path:   testapi.ResourcePath("clusterRoleBindings", "", ""),
+			Query:  buildQueryValues("", nil),
+			Body:   clusterRoleBinding,
+		},
+		Response: Response{StatusCode: 200, Body: clusterRoleBinding},
+	}
+	response, err := c.Setup().ClusterRoleBindings().Create(clusterRoleBinding)
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleBindingGet(t *testing.T) {
+	clusterRoleBinding := &api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "abc"},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("clusterRoleBindings", "", "abc"),
+			Query:  buildQueryValues("", nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: clusterRoleBinding},
+	}
+	response, err := c.Setup().ClusterRoleBindings().Get("abc")
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleBindingList(t *testing.T) {
+	clusterRoleBindingList := &api.ClusterRoleBindingList{
+		Items: []api.ClusterRoleBinding{
+			{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+		},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("clusterRoleBindings", "", ""),
+			Query:  buildQueryValues("", nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: clusterRoleBindingList},
+	}
+	response, err := c.Setup().ClusterRoleBindings().List(labels.Everything(), fields.Everything())
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleBindingUpdate(t *testing.T) {
+	clusterRoleBinding := &api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "abc", ResourceVersion: "1"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "alice"}},
+	}
+	c := &testClient{
+		Request:  testRequest{Method: "PUT", Path: testapi.ResourcePath("clusterRoleBindings", "", "abc"), Query: buildQueryValues("", nil)},
+		Response: Response{StatusCode: 200, Body: clusterRoleBinding},
+	}
+	response, err := c.Setup().ClusterRoleBindings().Update(clusterRoleBinding)
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleBindingDelete(t *testing.T) {
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath("clusterRoleBindings", "", "foo"), Query: buildQueryValues("", nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().ClusterRoleBindings().Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestClusterRoleBindingWatch(t *testing.T) {
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   "/api/" + testapi.Version() + "/watch/clusterRoleBindings",
+			Query:  url.Values{"resourceVersion": []string{}}},
+		Response: Response{StatusCode: 200},
+	}
+	_, err := c.Setup().ClusterRoleBindings().Watch(labels.Everything(), fields.Everything(), "")
+	c.Validate(t, nil, err)
+}
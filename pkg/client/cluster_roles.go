@@ -0,0 +1,104 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// ClusterRolesInterface has methods to work with ClusterRole resources.
+type ClusterRolesInterface interface {
+	ClusterRoles() ClusterRoleInterface
+}
+
+// ClusterRoleInterface has methods to work with ClusterRole resources.
+type ClusterRoleInterface interface {
+	List(label labels.Selector, field fields.Selector) (*api.ClusterRoleList, error)
+	Get(name string) (*api.ClusterRole, error)
+	Create(clusterRole *api.ClusterRole) (*api.ClusterRole, error)
+	Update(clusterRole *api.ClusterRole) (*api.ClusterRole, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// clusterRoles implements ClusterRolesInterface interface
+type clusterRoles struct {
+	client *Client
+}
+
+// newClusterRoles returns a clusterRoles
+func newClusterRoles(c *Client) *clusterRoles {
+	return &clusterRoles{c}
+}
+
+// List takes a selector, and returns the list of cluster roles that match that selector.
+func (c *clusterRoles) List(label labels.Selector, field fields.Selector) (result *api.ClusterRoleList, err error) {
+	result = &api.ClusterRoleList{}
+	err = c.client.Get().
+		Resource("clusterRoles").
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get takes the name of the cluster role, and returns the corresponding ClusterRole object, and an error if it occurs
+func (c *clusterRoles) Get(name string) (result *api.ClusterRole, err error) {
+	result = &api.ClusterRole{}
+	err = c.client.Get().Resource("clusterRoles").Name(name).Do().Into(result)
+	return
+}
+
+// Create takes the representation of a cluster role.  Returns the server's representation of the cluster role, and an error, if it occurs.
+func (c *clusterRoles) Create(clusterRole *api.ClusterRole) (result *api.ClusterRole, err error) {
+	result = &api.ClusterRole{}
+	err = c.client.Post().Resource("clusterRoles").Body(clusterRole).Do().Into(result)
+	return
+}
+
+// Update takes the representation of a cluster role to update.  Returns the server's representation of the cluster role, and an error, if it occurs.
+func (c *clusterRoles) Update(clusterRole *api.ClusterRole) (result *api.ClusterRole, err error) {
+	result = &api.ClusterRole{}
+	if len(clusterRole.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", clusterRole)
+		return
+	}
+	err = c.client.Put().Resource("clusterRoles").Name(clusterRole.Name).Body(clusterRole).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the cluster role, and returns an error if one occurs
+func (c *clusterRoles) Delete(name string) error {
+	return c.client.Delete().Resource("clusterRoles").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested cluster roles.
+func (c *clusterRoles) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Resource("clusterRoles").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Watch()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+)
+
+func TestClusterRoleCreate(t *testing.T) {
+	clusterRole := &api.ClusterRole{
+		ObjectMeta: api.ObjectMeta{Name: "abc"},
+		Rules:      []api.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "POST",
+			Path:   testapi.ResourcePath("clusterRoles", "", ""),
+			Query:  buildQueryValues("", nil),
+			Body:   clusterRole,
+		},
+		Response: Response{StatusCode: 200, Body: clusterRole},
+	}
+	response, err := c.Setup().ClusterRoles().Create(clusterRole)
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleGet(t *testing.T) {
+	clusterRole := &api.ClusterRole{
+		ObjectMeta: api.ObjectMeta{Name: "abc"},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("clusterRoles", "", "abc"),
+			Query:  buildQueryValues("", nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: clusterRole},
+	}
+	response, err := c.Setup().ClusterRoles().Get("abc")
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleList(t *testing.T) {
+	clusterRoleList := &api.ClusterRoleList{
+		Items: []api.ClusterRole{
+			{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+		},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("clusterRoles", "", ""),
+			Query:  buildQueryValues("", nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: clusterRoleList},
+	}
+	response, err := c.Setup().ClusterRoles().List(labels.Everything(), fields.Everything())
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleUpdate(t *testing.T) {
+	clusterRole := &api.ClusterRole{
+		ObjectMeta: api.ObjectMeta{Name: "abc", ResourceVersion: "1"},
+		Rules:      []api.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+	}
+	c := &testClient{
+		Request:  testRequest{Method: "PUT", Path: testapi.ResourcePath("clusterRoles", "", "abc"), Query: buildQueryValues("", nil)},
+		Response: Response{StatusCode: 200, Body: clusterRole},
+	}
+	response, err := c.Setup().ClusterRoles().Update(clusterRole)
+	c.Validate(t, response, err)
+}
+
+func TestClusterRoleDelete(t *testing.T) {
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath("clusterRoles", "", "foo"), Query: buildQueryValues("", nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().ClusterRoles().Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestClusterRoleWatch(t *testing.T) {
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   "/api/" + testapi.Version() + "/watch/clusterRoles",
+			Query:  url.Values{"resourceVersion": []string{}}},
+		Response: Response{StatusCode: 200},
+	}
+	_, err := c.Setup().ClusterRoles().Watch(labels.Everything(), fields.Everything(), "")
+	c.Validate(t, nil, err)
+}
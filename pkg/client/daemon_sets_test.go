@@ -0,0 +1,137 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+)
+
+func TestDaemonSetCreate(t *testing.T) {
+	ns := api.NamespaceDefault
+	daemonSet := &api.DaemonSet{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+		Spec:       api.DaemonSetSpec{Selector: map[string]string{"name": "fluentd"}},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "POST",
+			Path:   testapi.ResourcePath("daemonsets", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   daemonSet,
+		},
+		Response: Response{StatusCode: 200, Body: daemonSet},
+	}
+	response, err := c.Setup().DaemonSets(ns).Create(daemonSet)
+	c.Validate(t, response, err)
+}
+
+func TestDaemonSetGet(t *testing.T) {
+	ns := api.NamespaceDefault
+	daemonSet := &api.DaemonSet{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("daemonsets", ns, "abc"),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: daemonSet},
+	}
+	response, err := c.Setup().DaemonSets(ns).Get("abc")
+	c.Validate(t, response, err)
+}
+
+func TestDaemonSetList(t *testing.T) {
+	ns := api.NamespaceDefault
+	daemonSetList := &api.DaemonSetList{
+		Items: []api.DaemonSet{
+			{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+		},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("daemonsets", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: daemonSetList},
+	}
+	response, err := c.Setup().DaemonSets(ns).List(labels.Everything(), fields.Everything())
+	c.Validate(t, response, err)
+}
+
+func TestDaemonSetUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	daemonSet := &api.DaemonSet{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Spec:       api.DaemonSetSpec{Selector: map[string]string{"name": "fluentd"}},
+	}
+	c := &testClient{
+		Request:  testRequest{Method: "PUT", Path: testapi.ResourcePath("daemonsets", ns, "abc"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: daemonSet},
+	}
+	response, err := c.Setup().DaemonSets(ns).Update(daemonSet)
+	c.Validate(t, response, err)
+}
+
+func TestDaemonSetStatusUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	daemonSet := &api.DaemonSet{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Status:     api.DaemonSetStatus{CurrentNumberScheduled: 1, DesiredNumberScheduled: 2},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "PUT",
+			Path:   testapi.ResourcePath("daemonsets", ns, "abc") + "/status",
+			Query:  buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: daemonSet},
+	}
+	response, err := c.Setup().DaemonSets(ns).UpdateStatus(daemonSet)
+	c.Validate(t, response, err)
+}
+
+func TestDaemonSetDelete(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath("daemonsets", ns, "foo"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().DaemonSets(ns).Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestDaemonSetWatch(t *testing.T) {
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   "/api/" + testapi.Version() + "/watch/daemonsets",
+			Query:  url.Values{"resourceVersion": []string{}}},
+		Response: Response{StatusCode: 200},
+	}
+	_, err := c.Setup().DaemonSets(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), "")
+	c.Validate(t, nil, err)
+}
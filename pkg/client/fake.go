@@ -51,6 +51,35 @@ type Fake struct {
 	Secret              api.Secret
 	Err                 error
 	Watch               watch.Interface
+
+	PersistentVolume          api.PersistentVolume
+	PersistentVolumesList     api.PersistentVolumeList
+	PersistentVolumeClaim     api.PersistentVolumeClaim
+	PersistentVolumeClaimList api.PersistentVolumeClaimList
+
+	Deployment     api.Deployment
+	DeploymentList api.DeploymentList
+
+	Job     api.Job
+	JobList api.JobList
+
+	DaemonSet     api.DaemonSet
+	DaemonSetList api.DaemonSetList
+
+	HorizontalPodAutoscaler     api.HorizontalPodAutoscaler
+	HorizontalPodAutoscalerList api.HorizontalPodAutoscalerList
+
+	ServiceAccount     api.ServiceAccount
+	ServiceAccountList api.ServiceAccountList
+
+	Role                   api.Role
+	RoleList               api.RoleList
+	ClusterRole            api.ClusterRole
+	ClusterRoleList        api.ClusterRoleList
+	RoleBinding            api.RoleBinding
+	RoleBindingList        api.RoleBindingList
+	ClusterRoleBinding     api.ClusterRoleBinding
+	ClusterRoleBindingList api.ClusterRoleBindingList
 }
 
 func (c *Fake) LimitRanges(namespace string) LimitRangeInterface {
@@ -89,6 +118,50 @@ func (c *Fake) Secrets(namespace string) SecretsInterface {
 	return &FakeSecrets{Fake: c, Namespace: namespace}
 }
 
+func (c *Fake) PersistentVolumes() PersistentVolumeInterface {
+	return &FakePersistentVolumes{Fake: c}
+}
+
+func (c *Fake) PersistentVolumeClaims(namespace string) PersistentVolumeClaimInterface {
+	return &FakePersistentVolumeClaims{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) Deployments(namespace string) DeploymentInterface {
+	return &FakeDeployments{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) Jobs(namespace string) JobInterface {
+	return &FakeJobs{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) DaemonSets(namespace string) DaemonSetInterface {
+	return &FakeDaemonSets{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) HorizontalPodAutoscalers(namespace string) HorizontalPodAutoscalerInterface {
+	return &FakeHorizontalPodAutoscalers{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) ServiceAccounts(namespace string) ServiceAccountInterface {
+	return &FakeServiceAccounts{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) Roles(namespace string) RoleInterface {
+	return &FakeRoles{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) ClusterRoles() ClusterRoleInterface {
+	return &FakeClusterRoles{Fake: c}
+}
+
+func (c *Fake) RoleBindings(namespace string) RoleBindingInterface {
+	return &FakeRoleBindings{Fake: c, Namespace: namespace}
+}
+
+func (c *Fake) ClusterRoleBindings() ClusterRoleBindingInterface {
+	return &FakeClusterRoleBindings{Fake: c}
+}
+
 func (c *Fake) Namespaces() NamespaceInterface {
 	return &FakeNamespaces{Fake: c}
 }
@@ -128,6 +201,10 @@ func (c *FakeRESTClient) Put() *Request {
 	return NewRequest(c, "PUT", &url.URL{Host: "localhost"}, testapi.Version(), c.Codec, c.Legacy, c.Legacy)
 }
 
+func (c *FakeRESTClient) Patch() *Request {
+	return NewRequest(c, "PATCH", &url.URL{Host: "localhost"}, testapi.Version(), c.Codec, c.Legacy, c.Legacy)
+}
+
 func (c *FakeRESTClient) Post() *Request {
 	return NewRequest(c, "POST", &url.URL{Host: "localhost"}, testapi.Version(), c.Codec, c.Legacy, c.Legacy)
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// FakeClusterRoleBindings implements ClusterRoleBindingInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the methods you want to test easier.
+type FakeClusterRoleBindings struct {
+	Fake *Fake
+}
+
+func (c *FakeClusterRoleBindings) List(label labels.Selector, field fields.Selector) (*api.ClusterRoleBindingList, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "list-clusterRoleBindings"})
+	return api.Scheme.CopyOrDie(&c.Fake.ClusterRoleBindingList).(*api.ClusterRoleBindingList), c.Fake.Err
+}
+
+func (c *FakeClusterRoleBindings) Get(name string) (*api.ClusterRoleBinding, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "get-clusterRoleBinding", Value: name})
+	return api.Scheme.CopyOrDie(&c.Fake.ClusterRoleBinding).(*api.ClusterRoleBinding), c.Fake.Err
+}
+
+func (c *FakeClusterRoleBindings) Create(clusterRoleBinding *api.ClusterRoleBinding) (*api.ClusterRoleBinding, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "create-clusterRoleBinding", Value: clusterRoleBinding})
+	return &api.ClusterRoleBinding{}, c.Fake.Err
+}
+
+func (c *FakeClusterRoleBindings) Update(clusterRoleBinding *api.ClusterRoleBinding) (*api.ClusterRoleBinding, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "update-clusterRoleBinding", Value: clusterRoleBinding})
+	return clusterRoleBinding, c.Fake.Err
+}
+
+func (c *FakeClusterRoleBindings) Delete(name string) error {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "delete-clusterRoleBinding", Value: name})
+	return c.Fake.Err
+}
+
+func (c *FakeClusterRoleBindings) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "watch-clusterRoleBindings", Value: resourceVersion})
+	return c.Fake.Watch, c.Fake.Err
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// FakeRoleBindings implements RoleBindingInterface. Meant to be embedded into a struct to get a default
+// implementation. This makes faking out just the methods you want to test easier.
+type FakeRoleBindings struct {
+	Fake      *Fake
+	Namespace string
+}
+
+func (c *FakeRoleBindings) List(label labels.Selector, field fields.Selector) (*api.RoleBindingList, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "list-roleBindings"})
+	return api.Scheme.CopyOrDie(&c.Fake.RoleBindingList).(*api.RoleBindingList), c.Fake.Err
+}
+
+func (c *FakeRoleBindings) Get(name string) (*api.RoleBinding, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "get-roleBinding", Value: name})
+	return api.Scheme.CopyOrDie(&c.Fake.RoleBinding).(*api.RoleBinding), c.Fake.Err
+}
+
+func (c *FakeRoleBindings) Create(roleBinding *api.RoleBinding) (*api.RoleBinding, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "create-roleBinding", Value: roleBinding})
+	return &api.RoleBinding{}, c.Fake.Err
+}
+
+func (c *FakeRoleBindings) Update(roleBinding *api.RoleBinding) (*api.RoleBinding, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "update-roleBinding", Value: roleBinding})
+	return roleBinding, c.Fake.Err
+}
+
+func (c *FakeRoleBindings) Delete(name string) error {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "delete-roleBinding", Value: name})
+	return c.Fake.Err
+}
+
+func (c *FakeRoleBindings) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	c.Fake.Actions = append(c.Fake.Actions, FakeAction{Action: "watch-roleBindings", Value: resourceVersion})
+	return c.Fake.Watch, c.Fake.Err
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// HorizontalPodAutoscalersNamespacer has methods to work with HorizontalPodAutoscaler resources in a namespace
+type HorizontalPodAutoscalersNamespacer interface {
+	HorizontalPodAutoscalers(namespace string) HorizontalPodAutoscalerInterface
+}
+
+// HorizontalPodAutoscalerInterface has methods to work with HorizontalPodAutoscaler resources.
+type HorizontalPodAutoscalerInterface interface {
+	List(label labels.Selector, field fields.Selector) (*api.HorizontalPodAutoscalerList, error)
+	Get(name string) (*api.HorizontalPodAutoscaler, error)
+	Create(autoscaler *api.HorizontalPodAutoscaler) (*api.HorizontalPodAutoscaler, error)
+	Update(autoscaler *api.HorizontalPodAutoscaler) (*api.HorizontalPodAutoscaler, error)
+	UpdateStatus(autoscaler *api.HorizontalPodAutoscaler) (*api.HorizontalPodAutoscaler, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// horizontalPodAutoscalers implements HorizontalPodAutoscalersNamespacer interface
+type horizontalPodAutoscalers struct {
+	client    *Client
+	namespace string
+}
+
+// newHorizontalPodAutoscalers returns a horizontalPodAutoscalers
+func newHorizontalPodAutoscalers(c *Client, namespace string) *horizontalPodAutoscalers {
+	return &horizontalPodAutoscalers{c, namespace}
+}
+
+// List takes a selector, and returns the list of horizontal pod autoscalers that match that selector.
+func (c *horizontalPodAutoscalers) List(label labels.Selector, field fields.Selector) (result *api.HorizontalPodAutoscalerList, err error) {
+	result = &api.HorizontalPodAutoscalerList{}
+	err = c.client.Get().
+		Namespace(c.namespace).
+		Resource("horizontalpodautoscalers").
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get takes the name of the autoscaler, and returns the corresponding HorizontalPodAutoscaler object, and an error if it occurs
+func (c *horizontalPodAutoscalers) Get(name string) (result *api.HorizontalPodAutoscaler, err error) {
+	result = &api.HorizontalPodAutoscaler{}
+	err = c.client.Get().Namespace(c.namespace).Resource("horizontalpodautoscalers").Name(name).Do().Into(result)
+	return
+}
+
+// Create takes the representation of an autoscaler.  Returns the server's representation of the autoscaler, and an error, if it occurs.
+func (c *horizontalPodAutoscalers) Create(autoscaler *api.HorizontalPodAutoscaler) (result *api.HorizontalPodAutoscaler, err error) {
+	result = &api.HorizontalPodAutoscaler{}
+	err = c.client.Post().Namespace(c.namespace).Resource("horizontalpodautoscalers").Body(autoscaler).Do().Into(result)
+	return
+}
+
+// Update takes the representation of an autoscaler to update spec.  Returns the server's representation of the autoscaler, and an error, if it occurs.
+func (c *horizontalPodAutoscalers) Update(autoscaler *api.HorizontalPodAutoscaler) (result *api.HorizontalPodAutoscaler, err error) {
+	result = &api.HorizontalPodAutoscaler{}
+	if len(autoscaler.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", autoscaler)
+		return
+	}
+	err = c.client.Put().Namespace(c.namespace).Resource("horizontalpodautoscalers").Name(autoscaler.Name).Body(autoscaler).Do().Into(result)
+	return
+}
+
+// UpdateStatus takes the representation of an autoscaler to update status.  Returns the server's representation of the autoscaler, and an error, if it occurs.
+func (c *horizontalPodAutoscalers) UpdateStatus(autoscaler *api.HorizontalPodAutoscaler) (result *api.HorizontalPodAutoscaler, err error) {
+	result = &api.HorizontalPodAutoscaler{}
+	err = c.client.Put().Namespace(c.namespace).Resource("horizontalpodautoscalers").Name(autoscaler.Name).SubResource("status").Body(autoscaler).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the autoscaler, and returns an error if one occurs
+func (c *horizontalPodAutoscalers) Delete(name string) error {
+	return c.client.Delete().Namespace(c.namespace).Resource("horizontalpodautoscalers").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested horizontal pod autoscalers.
+func (c *horizontalPodAutoscalers) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Namespace(c.namespace).
+		Resource("horizontalpodautoscalers").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Watch()
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+)
+
+func TestHorizontalPodAutoscalerCreate(t *testing.T) {
+	ns := api.NamespaceDefault
+	autoscaler := &api.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+		Spec:       api.HorizontalPodAutoscalerSpec{ScaleRef: api.ObjectReference{Kind: "ReplicationController", Name: "frontend"}, MaxReplicas: 5},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "POST",
+			Path:   testapi.ResourcePath("horizontalpodautoscalers", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   autoscaler,
+		},
+		Response: Response{StatusCode: 200, Body: autoscaler},
+	}
+	response, err := c.Setup().HorizontalPodAutoscalers(ns).Create(autoscaler)
+	c.Validate(t, response, err)
+}
+
+func TestHorizontalPodAutoscalerGet(t *testing.T) {
+	ns := api.NamespaceDefault
+	autoscaler := &api.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("horizontalpodautoscalers", ns, "abc"),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: autoscaler},
+	}
+	response, err := c.Setup().HorizontalPodAutoscalers(ns).Get("abc")
+	c.Validate(t, response, err)
+}
+
+func TestHorizontalPodAutoscalerList(t *testing.T) {
+	ns := api.NamespaceDefault
+	autoscalerList := &api.HorizontalPodAutoscalerList{
+		Items: []api.HorizontalPodAutoscaler{
+			{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+		},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("horizontalpodautoscalers", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: autoscalerList},
+	}
+	response, err := c.Setup().HorizontalPodAutoscalers(ns).List(labels.Everything(), fields.Everything())
+	c.Validate(t, response, err)
+}
+
+func TestHorizontalPodAutoscalerUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	autoscaler := &api.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Spec:       api.HorizontalPodAutoscalerSpec{ScaleRef: api.ObjectReference{Kind: "ReplicationController", Name: "frontend"}, MaxReplicas: 5},
+	}
+	c := &testClient{
+		Request:  testRequest{Method: "PUT", Path: testapi.ResourcePath("horizontalpodautoscalers", ns, "abc"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: autoscaler},
+	}
+	response, err := c.Setup().HorizontalPodAutoscalers(ns).Update(autoscaler)
+	c.Validate(t, response, err)
+}
+
+func TestHorizontalPodAutoscalerStatusUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	autoscaler := &api.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Status:     api.HorizontalPodAutoscalerStatus{CurrentReplicas: 1, DesiredReplicas: 2},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "PUT",
+			Path:   testapi.ResourcePath("horizontalpodautoscalers", ns, "abc") + "/status",
+			Query:  buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: autoscaler},
+	}
+	response, err := c.Setup().HorizontalPodAutoscalers(ns).UpdateStatus(autoscaler)
+	c.Validate(t, response, err)
+}
+
+func TestHorizontalPodAutoscalerDelete(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath("horizontalpodautoscalers", ns, "foo"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().HorizontalPodAutoscalers(ns).Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestHorizontalPodAutoscalerWatch(t *testing.T) {
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   "/api/" + testapi.Version() + "/watch/horizontalpodautoscalers",
+			Query:  url.Values{"resourceVersion": []string{}}},
+		Response: Response{StatusCode: 200},
+	}
+	_, err := c.Setup().HorizontalPodAutoscalers(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), "")
+	c.Validate(t, nil, err)
+}
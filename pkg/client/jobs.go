@@ -0,0 +1,115 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// JobsNamespacer has methods to work with Job resources in a namespace
+type JobsNamespacer interface {
+	Jobs(namespace string) JobInterface
+}
+
+// JobInterface has methods to work with Job resources.
+type JobInterface interface {
+	List(label labels.Selector, field fields.Selector) (*api.JobList, error)
+	Get(name string) (*api.Job, error)
+	Create(job *api.Job) (*api.Job, error)
+	Update(job *api.Job) (*api.Job, error)
+	UpdateStatus(job *api.Job) (*api.Job, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// jobs implements JobsNamespacer interface
+type jobs struct {
+	client    *Client
+	namespace string
+}
+
+// newJobs returns a jobs
+func newJobs(c *Client, namespace string) *jobs {
+	return &jobs{c, namespace}
+}
+
+// List takes a selector, and returns the list of jobs that match that selector.
+func (c *jobs) List(label labels.Selector, field fields.Selector) (result *api.JobList, err error) {
+	result = &api.JobList{}
+	err = c.client.Get().
+		Namespace(c.namespace).
+		Resource("jobs").
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get takes the name of the job, and returns the corresponding Job object, and an error if it occurs
+func (c *jobs) Get(name string) (result *api.Job, err error) {
+	result = &api.Job{}
+	err = c.client.Get().Namespace(c.namespace).Resource("jobs").Name(name).Do().Into(result)
+	return
+}
+
+// Create takes the representation of a job.  Returns the server's representation of the job, and an error, if it occurs.
+func (c *jobs) Create(job *api.Job) (result *api.Job, err error) {
+	result = &api.Job{}
+	err = c.client.Post().Namespace(c.namespace).Resource("jobs").Body(job).Do().Into(result)
+	return
+}
+
+// Update takes the representation of a job to update spec.  Returns the server's representation of the job, and an error, if it occurs.
+func (c *jobs) Update(job *api.Job) (result *api.Job, err error) {
+	result = &api.Job{}
+	if len(job.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", job)
+		return
+	}
+	err = c.client.Put().Namespace(c.namespace).Resource("jobs").Name(job.Name).Body(job).Do().Into(result)
+	return
+}
+
+// UpdateStatus takes the representation of a job to update status.  Returns the server's representation of the job, and an error, if it occurs.
+func (c *jobs) UpdateStatus(job *api.Job) (result *api.Job, err error) {
+	result = &api.Job{}
+	err = c.client.Put().Namespace(c.namespace).Resource("jobs").Name(job.Name).SubResource("status").Body(job).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the job, and returns an error if one occurs
+func (c *jobs) Delete(name string) error {
+	return c.client.Delete().Namespace(c.namespace).Resource("jobs").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested jobs.
+func (c *jobs) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Namespace(c.namespace).
+		Resource("jobs").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Watch()
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+)
+
+func TestJobCreate(t *testing.T) {
+	ns := api.NamespaceDefault
+	job := &api.Job{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+		Spec:       api.JobSpec{Selector: map[string]string{"job": "pi"}},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "POST",
+			Path:   testapi.ResourcePath("jobs", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   job,
+		},
+		Response: Response{StatusCode: 200, Body: job},
+	}
+	response, err := c.Setup().Jobs(ns).Create(job)
+	c.Validate(t, response, err)
+}
+
+func TestJobGet(t *testing.T) {
+	ns := api.NamespaceDefault
+	job := &api.Job{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("jobs", ns, "abc"),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: job},
+	}
+	response, err := c.Setup().Jobs(ns).Get("abc")
+	c.Validate(t, response, err)
+}
+
+func TestJobList(t *testing.T) {
+	ns := api.NamespaceDefault
+	jobList := &api.JobList{
+		Items: []api.Job{
+			{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+		},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("jobs", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: jobList},
+	}
+	response, err := c.Setup().Jobs(ns).List(labels.Everything(), fields.Everything())
+	c.Validate(t, response, err)
+}
+
+func TestJobUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	job := &api.Job{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Spec:       api.JobSpec{Selector: map[string]string{"job": "pi"}},
+	}
+	c := &testClient{
+		Request:  testRequest{Method: "PUT", Path: testapi.ResourcePath("jobs", ns, "abc"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: job},
+	}
+	response, err := c.Setup().Jobs(ns).Update(job)
+	c.Validate(t, response, err)
+}
+
+func TestJobStatusUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	job := &api.Job{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Status:     api.JobStatus{Active: 1, Succeeded: 2},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "PUT",
+			Path:   testapi.ResourcePath("jobs", ns, "abc") + "/status",
+			Query:  buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: job},
+	}
+	response, err := c.Setup().Jobs(ns).UpdateStatus(job)
+	c.Validate(t, response, err)
+}
+
+func TestJobDelete(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath("jobs", ns, "foo"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().Jobs(ns).Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestJobWatch(t *testing.T) {
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   "/api/" + testapi.Version() + "/watch/jobs",
+			Query:  url.Values{"resourceVersion": []string{}}},
+		Response: Response{StatusCode: 200},
+	}
+	_, err := c.Setup().Jobs(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), "")
+	c.Validate(t, nil, err)
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package leaderelection implements lease based leader election for
+// components that must run as a single active instance, such as the
+// scheduler and the controller manager.
+//
+// The lease is stored as an annotation on an Endpoints object in the API.
+// Each candidate repeatedly tries to acquire the lease; the holder renews it
+// every RetryPeriod.  If the holder stops renewing, the lease expires after
+// LeaseDuration and another candidate may take it over.  Optimistic
+// concurrency on the Endpoints resourceVersion guarantees that at most one
+// candidate wins a given round.
+package leaderelection
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// LeaderElectionRecordAnnotationKey is the annotation on the lock object
+	// that holds the serialized LeaderElectionRecord.
+	LeaderElectionRecordAnnotationKey = "kubernetes.io/leader"
+
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewDeadline = 10 * time.Second
+	DefaultRetryPeriod   = 2 * time.Second
+)
+
+// LeaderElectionRecord is the record that is stored in the leader election
+// annotation.  It is used to determine who currently holds the lease.
+type LeaderElectionRecord struct {
+	HolderIdentity       string    `json:"holderIdentity"`
+	LeaseDurationSeconds int       `json:"leaseDurationSeconds"`
+	AcquireTime          util.Time `json:"acquireTime"`
+	RenewTime            util.Time `json:"renewTime"`
+}
+
+// LeaderCallbacks are invoked when the elector gains or loses the lease.
+type LeaderCallbacks struct {
+	// OnStartedLeading is called in its own goroutine once the lease is
+	// acquired.  The stop channel is closed when leadership is lost.
+	OnStartedLeading func(stop <-chan struct{})
+	// OnStoppedLeading is called when the lease could not be renewed.
+	OnStoppedLeading func()
+}
+
+// Config holds the parameters of a LeaderElector.
+type Config struct {
+	// Client is used to read and update the lock object.
+	Client client.EndpointsNamespacer
+	// Namespace and Name identify the Endpoints object used as the lock.
+	Namespace string
+	Name      string
+	// Identity uniquely identifies this candidate.
+	Identity string
+
+	// LeaseDuration is how long a non-leader waits after the last observed
+	// renewal before it attempts to take over the lease.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader keeps retrying a renewal before
+	// giving up leadership.
+	RenewDeadline time.Duration
+	// RetryPeriod is the interval between acquire and renew attempts.
+	RetryPeriod time.Duration
+
+	Callbacks LeaderCallbacks
+}
+
+// LeaderElector competes for a lease and runs callbacks on transitions.
+type LeaderElector struct {
+	config Config
+	clock  util.Clock
+
+	// observedRecord is the last record read from the lock object, and
+	// observedTime is the local time at which it last changed.
+	observedRecord LeaderElectionRecord
+	observedTime   time.Time
+}
+
+// NewLeaderElector validates the config and returns a LeaderElector.
+func NewLeaderElector(config Config) (*LeaderElector, error) {
+	if config.LeaseDuration <= config.RenewDeadline {
+		return nil, fmt.Errorf("leaseDuration must be greater than renewDeadline")
+	}
+	if config.RenewDeadline <= config.RetryPeriod {
+		return nil, fmt.Errorf("renewDeadline must be greater than retryPeriod")
+	}
+	if config.Client == nil {
+		return nil, fmt.Errorf("a client is required")
+	}
+	if len(config.Name) == 0 {
+		return nil, fmt.Errorf("a lock name is required")
+	}
+	if len(config.Identity) == 0 {
+		return nil, fmt.Errorf("an identity is required")
+	}
+	if config.Callbacks.OnStartedLeading == nil {
+		return nil, fmt.Errorf("OnStartedLeading callback must not be nil")
+	}
+	if config.Callbacks.OnStoppedLeading == nil {
+		return nil, fmt.Errorf("OnStoppedLeading callback must not be nil")
+	}
+	return &LeaderElector{
+		config: config,
+		clock:  util.RealClock{},
+	}, nil
+}
+
+// Run blocks until the lease is acquired, starts OnStartedLeading and then
+// renews the lease until a renewal fails, at which point OnStoppedLeading is
+// called and Run returns.
+func (le *LeaderElector) Run() {
+	le.acquire()
+	stop := make(chan struct{})
+	go le.config.Callbacks.OnStartedLeading(stop)
+	le.renew()
+	close(stop)
+	le.config.Callbacks.OnStoppedLeading()
+}
+
+// IsLeader returns true if the last observed record names this candidate.
+func (le *LeaderElector) IsLeader() bool {
+	return le.observedRecord.HolderIdentity == le.config.Identity
+}
+
+// acquire loops until the lease has been acquired.
+func (le *LeaderElector) acquire() {
+	stop := make(chan struct{})
+	util.Until(func() {
+		if !le.tryAcquireOrRenew() {
+			glog.V(4).Infof("Failed to acquire lease %v/%v", le.config.Namespace, le.config.Name)
+			return
+		}
+		glog.Infof("Successfully acquired lease %v/%v", le.config.Namespace, le.config.Name)
+		close(stop)
+	}, le.config.RetryPeriod, stop)
+}
+
+// renew loops until a renewal has not succeeded for RenewDeadline.
+func (le *LeaderElector) renew() {
+	lastRenew := le.clock.Now()
+	stop := make(chan struct{})
+	util.Until(func() {
+		if le.tryAcquireOrRenew() {
+			lastRenew = le.clock.Now()
+			glog.V(4).Infof("Successfully renewed lease %v/%v", le.config.Namespace, le.config.Name)
+			return
+		}
+		if le.clock.Now().Sub(lastRenew) < le.config.RenewDeadline {
+			return
+		}
+		glog.Infof("Failed to renew lease %v/%v within %v", le.config.Namespace, le.config.Name, le.config.RenewDeadline)
+		close(stop)
+	}, le.config.RetryPeriod, stop)
+}
+
+// tryAcquireOrRenew tries to acquire the lease if it is free or expired, or to
+// renew it if it is already held by this candidate.  It returns true on
+// success.
+func (le *LeaderElector) tryAcquireOrRenew() bool {
+	now := util.NewTime(le.clock.Now())
+	record := LeaderElectionRecord{
+		HolderIdentity:       le.config.Identity,
+		LeaseDurationSeconds: int(le.config.LeaseDuration / time.Second),
+		AcquireTime:          now,
+		RenewTime:            now,
+	}
+	endpoints := le.config.Client.Endpoints(le.config.Namespace)
+
+	e, err := endpoints.Get(le.config.Name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			glog.Errorf("Error retrieving lease %v/%v: %v", le.config.Namespace, le.config.Name, err)
+			return false
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			glog.Errorf("Error encoding leader election record: %v", err)
+			return false
+		}
+		_, err = endpoints.Create(&api.Endpoints{
+			ObjectMeta: api.ObjectMeta{
+				Name:      le.config.Name,
+				Namespace: le.config.Namespace,
+				Annotations: map[string]string{
+					LeaderElectionRecordAnnotationKey: string(data),
+				},
+			},
+		})
+		if err != nil {
+			glog.V(4).Infof("Error creating lease %v/%v: %v", le.config.Namespace, le.config.Name, err)
+			return false
+		}
+		le.observedRecord = record
+		le.observedTime = le.clock.Now()
+		return true
+	}
+
+	if e.Annotations == nil {
+		e.Annotations = map[string]string{}
+	}
+	var oldRecord LeaderElectionRecord
+	if value, found := e.Annotations[LeaderElectionRecordAnnotationKey]; found {
+		if err := json.Unmarshal([]byte(value), &oldRecord); err != nil {
+			glog.Errorf("Error decoding leader election record on %v/%v: %v", le.config.Namespace, le.config.Name, err)
+			return false
+		}
+		if oldRecord != le.observedRecord {
+			le.observedRecord = oldRecord
+			le.observedTime = le.clock.Now()
+		}
+		if len(oldRecord.HolderIdentity) > 0 &&
+			le.observedTime.Add(le.config.LeaseDuration).After(now.Time) &&
+			oldRecord.HolderIdentity != le.config.Identity {
+			glog.V(4).Infof("Lease %v/%v is held by %v and has not yet expired", le.config.Namespace, le.config.Name, oldRecord.HolderIdentity)
+			return false
+		}
+	}
+
+	// Keep the original acquire time when renewing our own lease.
+	if oldRecord.HolderIdentity == le.config.Identity {
+		record.AcquireTime = oldRecord.AcquireTime
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		glog.Errorf("Error encoding leader election record: %v", err)
+		return false
+	}
+	e.Annotations[LeaderElectionRecordAnnotationKey] = string(data)
+	if _, err := endpoints.Update(e); err != nil {
+		glog.V(4).Infof("Error updating lease %v/%v: %v", le.config.Namespace, le.config.Name, err)
+		return false
+	}
+	le.observedRecord = record
+	le.observedTime = le.clock.Now()
+	return true
+}
+
+// DefaultIdentity returns an identity built from the hostname and a random
+// suffix, so that several candidates on one host do not collide.
+func DefaultIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return hostname + "_" + string(util.NewUUID())
+}
+
+// LeaderElectionConfiguration holds the command line settings of a component
+// that optionally runs behind leader election.
+type LeaderElectionConfiguration struct {
+	LeaderElect   bool
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// DefaultLeaderElectionConfiguration returns a configuration with leader
+// election disabled and the default timings.
+func DefaultLeaderElectionConfiguration() LeaderElectionConfiguration {
+	return LeaderElectionConfiguration{
+		LeaderElect:   false,
+		LeaseDuration: DefaultLeaseDuration,
+		RenewDeadline: DefaultRenewDeadline,
+		RetryPeriod:   DefaultRetryPeriod,
+	}
+}
+
+// BindFlags registers the standard leader election flags.
+func BindFlags(l *LeaderElectionConfiguration, flags client.FlagSet) {
+	flags.BoolVar(&l.LeaderElect, "leader_elect", l.LeaderElect, ""+
+		"Start a leader election client and gain leadership before executing the main loop. "+
+		"Enable this when running replicated components for high availability.")
+	flags.DurationVar(&l.LeaseDuration, "leader_elect_lease_duration", l.LeaseDuration, ""+
+		"The duration that non-leader candidates will wait after observing a leadership "+
+		"renewal until attempting to acquire leadership of a led but unrenewed leader slot.")
+	flags.DurationVar(&l.RenewDeadline, "leader_elect_renew_deadline", l.RenewDeadline, ""+
+		"The interval between attempts by the acting master to renew a leadership slot "+
+		"before it stops leading. This must be less than the lease duration.")
+	flags.DurationVar(&l.RetryPeriod, "leader_elect_retry_period", l.RetryPeriod, ""+
+		"The duration the clients should wait between attempting acquisition and renewal of a leadership.")
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// fakeEndpoints stores a single Endpoints object and enforces
+// resourceVersion checks on update, like the apiserver does.
+type fakeEndpoints struct {
+	obj     *api.Endpoints
+	version int
+}
+
+func (f *fakeEndpoints) Endpoints(namespace string) client.EndpointsInterface {
+	return f
+}
+
+func (f *fakeEndpoints) Create(e *api.Endpoints) (*api.Endpoints, error) {
+	if f.obj != nil {
+		return nil, errors.NewAlreadyExists("endpoints", e.Name)
+	}
+	f.version++
+	copied := *e
+	copied.ResourceVersion = strconv.Itoa(f.version)
+	f.obj = &copied
+	return &copied, nil
+}
+
+func (f *fakeEndpoints) Get(name string) (*api.Endpoints, error) {
+	if f.obj == nil {
+		return nil, errors.NewNotFound("endpoints", name)
+	}
+	copied := *f.obj
+	copied.Annotations = map[string]string{}
+	for k, v := range f.obj.Annotations {
+		copied.Annotations[k] = v
+	}
+	return &copied, nil
+}
+
+func (f *fakeEndpoints) Update(e *api.Endpoints) (*api.Endpoints, error) {
+	if f.obj == nil {
+		return nil, errors.NewNotFound("endpoints", e.Name)
+	}
+	if e.ResourceVersion != f.obj.ResourceVersion {
+		return nil, errors.NewConflict("endpoints", e.Name, fmt.Errorf("resourceVersion mismatch"))
+	}
+	f.version++
+	copied := *e
+	copied.ResourceVersion = strconv.Itoa(f.version)
+	f.obj = &copied
+	return &copied, nil
+}
+
+func (f *fakeEndpoints) List(selector labels.Selector) (*api.EndpointsList, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeEndpoints) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func newTestElector(t *testing.T, c *fakeEndpoints, identity string, clock *util.FakeClock) *LeaderElector {
+	le, err := NewLeaderElector(Config{
+		Client:        c,
+		Namespace:     api.NamespaceDefault,
+		Name:          "test-component",
+		Identity:      identity,
+		LeaseDuration: 10 * time.Second,
+		RenewDeadline: 5 * time.Second,
+		RetryPeriod:   time.Second,
+		Callbacks: LeaderCallbacks{
+			OnStartedLeading: func(<-chan struct{}) {},
+			OnStoppedLeading: func() {},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	le.clock = clock
+	return le
+}
+
+func holder(t *testing.T, c *fakeEndpoints) string {
+	var record LeaderElectionRecord
+	if err := json.Unmarshal([]byte(c.obj.Annotations[LeaderElectionRecordAnnotationKey]), &record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return record.HolderIdentity
+}
+
+func TestTryAcquireOrRenew(t *testing.T) {
+	c := &fakeEndpoints{}
+	clock := &util.FakeClock{Time: time.Now()}
+	a := newTestElector(t, c, "a", clock)
+	b := newTestElector(t, c, "b", clock)
+
+	if !a.tryAcquireOrRenew() {
+		t.Fatalf("expected a to acquire a free lease")
+	}
+	if !a.IsLeader() || holder(t, c) != "a" {
+		t.Errorf("expected a to hold the lease, got %q", holder(t, c))
+	}
+	if b.tryAcquireOrRenew() {
+		t.Errorf("expected b not to acquire a lease held by a")
+	}
+	if b.IsLeader() {
+		t.Errorf("expected b not to be leader")
+	}
+
+	// a keeps renewing; b must not take over as long as renewals are observed.
+	for i := 0; i < 3; i++ {
+		clock.Time = clock.Time.Add(4 * time.Second)
+		if !a.tryAcquireOrRenew() {
+			t.Fatalf("expected a to renew its lease")
+		}
+		if b.tryAcquireOrRenew() {
+			t.Fatalf("expected b not to acquire a renewed lease")
+		}
+	}
+
+	// a stops renewing; once the lease has expired b takes over.
+	clock.Time = clock.Time.Add(11 * time.Second)
+	if !b.tryAcquireOrRenew() {
+		t.Fatalf("expected b to acquire an expired lease")
+	}
+	if holder(t, c) != "b" {
+		t.Errorf("expected b to hold the lease, got %q", holder(t, c))
+	}
+	if a.tryAcquireOrRenew() {
+		t.Errorf("expected a not to reacquire a lease held by b")
+	}
+}
+
+func TestNewLeaderElectorValidation(t *testing.T) {
+	callbacks := LeaderCallbacks{
+		OnStartedLeading: func(<-chan struct{}) {},
+		OnStoppedLeading: func() {},
+	}
+	valid := Config{
+		Client:        &fakeEndpoints{},
+		Name:          "test-component",
+		Identity:      "a",
+		LeaseDuration: DefaultLeaseDuration,
+		RenewDeadline: DefaultRenewDeadline,
+		RetryPeriod:   DefaultRetryPeriod,
+		Callbacks:     callbacks,
+	}
+	if _, err := NewLeaderElector(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := map[string]func(*Config){
+		"lease shorter than renew": func(c *Config) { c.LeaseDuration = c.RenewDeadline },
+		"renew shorter than retry": func(c *Config) { c.RetryPeriod = c.RenewDeadline },
+		"no name":                  func(c *Config) { c.Name = "" },
+		"no identity":              func(c *Config) { c.Identity = "" },
+		"no client":                func(c *Config) { c.Client = nil },
+		"no start callback":        func(c *Config) { c.Callbacks.OnStartedLeading = nil },
+	}
+	for name, mutate := range invalid {
+		config := valid
+		mutate(&config)
+		if _, err := NewLeaderElector(config); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -15,6 +15,6 @@ limitations under the License.
 */
 
 // Package remotecommand adds support for executing commands in containers,
-// with support for separate stdin, stdout, and stderr streams, as well as
-// TTY.
+// and for attaching to the main process of a container, with support for
+// separate stdin, stdout, and stderr streams, as well as TTY.
 package remotecommand
@@ -63,6 +63,12 @@ func New(req *client.Request, config *client.Config, command []string, stdin io.
 	}
 }
 
+// NewAttach returns an executor that attaches stdin/stdout/stderr to the streams of the
+// main process of the container addressed by req, instead of running a new command.
+func NewAttach(req *client.Request, config *client.Config, stdin io.Reader, stdout, stderr io.Writer, tty bool) *RemoteCommandExecutor {
+	return New(req, config, nil, stdin, stdout, stderr, tty)
+}
+
 // Execute sends a remote command execution request, upgrading the
 // connection and creating streams to represent stdin/stdout/stderr. Data is
 // copied between these streams and the supplied stdin/stdout/stderr parameters.
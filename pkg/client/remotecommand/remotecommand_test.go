@@ -153,6 +153,7 @@ func TestRequestExecuteRemoteCommand(t *testing.T) {
 		Stderr      string
 		Error       string
 		Tty         bool
+		Attach      bool
 		ShouldError bool
 	}{
 		{
@@ -173,6 +174,13 @@ func TestRequestExecuteRemoteCommand(t *testing.T) {
 			Stdout:   "b",
 			Stderr:   "c",
 		},
+		{
+			Upgrader: &fakeUpgrader{conn: newFakeUpgradeConnection()},
+			Stdin:    "a",
+			Stdout:   "b",
+			Stderr:   "c",
+			Attach:   true,
+		},
 		{
 			Upgrader: &fakeUpgrader{conn: newFakeUpgradeConnection()},
 			Stdin:    "a",
@@ -199,7 +207,12 @@ func TestRequestExecuteRemoteCommand(t *testing.T) {
 		if testCase.Stderr != "" {
 			localErr = &bytes.Buffer{}
 		}
-		e := New(&client.Request{}, &client.Config{}, []string{"ls", "/"}, strings.NewReader(testCase.Stdin), localOut, localErr, testCase.Tty)
+		var e *RemoteCommandExecutor
+		if testCase.Attach {
+			e = NewAttach(&client.Request{}, &client.Config{}, strings.NewReader(testCase.Stdin), localOut, localErr, testCase.Tty)
+		} else {
+			e = New(&client.Request{}, &client.Config{}, []string{"ls", "/"}, strings.NewReader(testCase.Stdin), localOut, localErr, testCase.Tty)
+		}
 		e.upgrader = testCase.Upgrader
 		err := e.Execute()
 		hasErr := err != nil
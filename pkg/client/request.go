@@ -101,6 +101,7 @@ type Request struct {
 	path    string
 	subpath string
 	params  url.Values
+	headers http.Header
 
 	// structural elements of the request that are part of the Kubernetes API conventions
 	namespace    string
@@ -343,6 +344,15 @@ func (r *Request) setParam(paramName, value string) *Request {
 	return r
 }
 
+// SetHeader sets a header of the request, e.g. the Content-Type of a patch.
+func (r *Request) SetHeader(key, value string) *Request {
+	if r.headers == nil {
+		r.headers = http.Header{}
+	}
+	r.headers.Set(key, value)
+	return r
+}
+
 // Timeout makes the request use the given duration as a timeout. Sets the "timeout"
 // parameter.
 func (r *Request) Timeout(d time.Duration) *Request {
@@ -437,6 +447,9 @@ func (r *Request) Watch() (watch.Interface, error) {
 	if err != nil {
 		return nil, err
 	}
+	for key, values := range r.headers {
+		req.Header[key] = values
+	}
 	client := r.client
 	if client == nil {
 		client = http.DefaultClient
@@ -486,6 +499,9 @@ func (r *Request) Stream() (io.ReadCloser, error) {
 	if err != nil {
 		return nil, err
 	}
+	for key, values := range r.headers {
+		req.Header[key] = values
+	}
 	client := r.client
 	if client == nil {
 		client = http.DefaultClient
@@ -561,6 +577,9 @@ func (r *Request) DoRaw() ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
+		for key, values := range r.headers {
+			r.req.Header[key] = values
+		}
 		r.resp, err = client.Do(r.req)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,107 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// RoleBindingsNamespacer has methods to work with RoleBinding resources in a namespace
+type RoleBindingsNamespacer interface {
+	RoleBindings(namespace string) RoleBindingInterface
+}
+
+// RoleBindingInterface has methods to work with RoleBinding resources.
+type RoleBindingInterface interface {
+	List(label labels.Selector, field fields.Selector) (*api.RoleBindingList, error)
+	Get(name string) (*api.RoleBinding, error)
+	Create(roleBinding *api.RoleBinding) (*api.RoleBinding, error)
+	Update(roleBinding *api.RoleBinding) (*api.RoleBinding, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// roleBindings implements RoleBindingsNamespacer interface
+type roleBindings struct {
+	client    *Client
+	namespace string
+}
+
+// newRoleBindings returns a roleBindings
+func newRoleBindings(c *Client, namespace string) *roleBindings {
+	return &roleBindings{c, namespace}
+}
+
+// List takes a selector, and returns the list of role bindings that match that selector.
+func (c *roleBindings) List(label labels.Selector, field fields.Selector) (result *api.RoleBindingList, err error) {
+	result = &api.RoleBindingList{}
+	err = c.client.Get().
+		Namespace(c.namespace).
+		Resource("roleBindings").
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get takes the name of the role binding, and returns the corresponding RoleBinding object, and an error if it occurs
+func (c *roleBindings) Get(name string) (result *api.RoleBinding, err error) {
+	result = &api.RoleBinding{}
+	err = c.client.Get().Namespace(c.namespace).Resource("roleBindings").Name(name).Do().Into(result)
+	return
+}
+
+// Create takes the representation of a role binding.  Returns the server's representation of the role binding, and an error, if it occurs.
+func (c *roleBindings) Create(roleBinding *api.RoleBinding) (result *api.RoleBinding, err error) {
+	result = &api.RoleBinding{}
+	err = c.client.Post().Namespace(c.namespace).Resource("roleBindings").Body(roleBinding).Do().Into(result)
+	return
+}
+
+// Update takes the representation of a role binding to update.  Returns the server's representation of the role binding, and an error, if it occurs.
+func (c *roleBindings) Update(roleBinding *api.RoleBinding) (result *api.RoleBinding, err error) {
+	result = &api.RoleBinding{}
+	if len(roleBinding.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", roleBinding)
+		return
+	}
+	err = c.client.Put().Namespace(c.namespace).Resource("roleBindings").Name(roleBinding.Name).Body(roleBinding).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the role binding, and returns an error if one occurs
+func (c *roleBindings) Delete(name string) error {
+	return c.client.Delete().Namespace(c.namespace).Resource("roleBindings").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested role bindings.
+func (c *roleBindings) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Namespace(c.namespace).
+		Resource("roleBindings").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Watch()
+}
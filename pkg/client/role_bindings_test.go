@@ -0,0 +1,120 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+)
+
+func TestRoleBindingCreate(t *testing.T) {
+	ns := api.NamespaceDefault
+	roleBinding := &api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "alice"}},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "POST",
+			Path:   testapi.ResourcePath("roleBindings", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   roleBinding,
+		},
+		Response: Response{StatusCode: 200, Body: roleBinding},
+	}
+	response, err := c.Setup().RoleBindings(ns).Create(roleBinding)
+	c.Validate(t, response, err)
+}
+
+func TestRoleBindingGet(t *testing.T) {
+	ns := api.NamespaceDefault
+	roleBinding := &api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("roleBindings", ns, "abc"),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: roleBinding},
+	}
+	response, err := c.Setup().RoleBindings(ns).Get("abc")
+	c.Validate(t, response, err)
+}
+
+func TestRoleBindingList(t *testing.T) {
+	ns := api.NamespaceDefault
+	roleBindingList := &api.RoleBindingList{
+		Items: []api.RoleBinding{
+			{ObjectMeta: api.ObjectMeta{Name: "foo"}},
+		},
+	}
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   testapi.ResourcePath("roleBindings", ns, ""),
+			Query:  buildQueryValues(ns, nil),
+			Body:   nil,
+		},
+		Response: Response{StatusCode: 200, Body: roleBindingList},
+	}
+	response, err := c.Setup().RoleBindings(ns).List(labels.Everything(), fields.Everything())
+	c.Validate(t, response, err)
+}
+
+func TestRoleBindingUpdate(t *testing.T) {
+	ns := api.NamespaceDefault
+	roleBinding := &api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{Name: "abc", Namespace: ns, ResourceVersion: "1"},
+		Subjects:   []api.Subject{{Kind: api.UserKind, Name: "alice"}},
+	}
+	c := &testClient{
+		Request:  testRequest{Method: "PUT", Path: testapi.ResourcePath("roleBindings", ns, "abc"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200, Body: roleBinding},
+	}
+	response, err := c.Setup().RoleBindings(ns).Update(roleBinding)
+	c.Validate(t, response, err)
+}
+
+func TestRoleBindingDelete(t *testing.T) {
+	ns := api.NamespaceDefault
+	c := &testClient{
+		Request:  testRequest{Method: "DELETE", Path: testapi.ResourcePath("roleBindings", ns, "foo"), Query: buildQueryValues(ns, nil)},
+		Response: Response{StatusCode: 200},
+	}
+	err := c.Setup().RoleBindings(ns).Delete("foo")
+	c.Validate(t, nil, err)
+}
+
+func TestRoleBindingWatch(t *testing.T) {
+	c := &testClient{
+		Request: testRequest{
+			Method: "GET",
+			Path:   "/api/" + testapi.Version() + "/watch/roleBindings",
+			Query:  url.Values{"resourceVersion": []string{}}},
+		Response: Response{StatusCode: 200},
+	}
+	_, err := c.Setup().RoleBindings(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), "")
+	c.Validate(t, nil, err)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// RolesNamespacer has methods to work with Role resources in a namespace
+type RolesNamespacer interface {
+	Roles(namespace string) RoleInterface
+}
+
+// RoleInterface has methods to work with Role resources.
+type RoleInterface interface {
+	List(label labels.Selector, field fields.Selector) (*api.RoleList, error)
+	Get(name string) (*api.Role, error)
+	Create(role *api.Role) (*api.Role, error)
+	Update(role *api.Role) (*api.Role, error)
+	Delete(name string) error
+	Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+// roles implements RolesNamespacer interface
+type roles struct {
+	client    *Client
+	namespace string
+}
+
+// newRoles returns a roles
+func newRoles(c *Client, namespace string) *roles {
+	return &roles{c, namespace}
+}
+
+// List takes a selector, and returns the list of roles that match that selector.
+func (c *roles) List(label labels.Selector, field fields.Selector) (result *api.RoleList, err error) {
+	result = &api.RoleList{}
+	err = c.client.Get().
+		Namespace(c.namespace).
+		Resource("roles").
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Do().
+		Into(result)
+	return
+}
+
+// Get takes the name of the role, and returns the corresponding Role object, and an error if it occurs
+func (c *roles) Get(name string) (result *api.Role, err error) {
+	result = &api.Role{}
+	err = c.client.Get().Namespace(c.namespace).Resource("roles").Name(name).Do().Into(result)
+	return
+}
+
+// Create takes the representation of a role.  Returns the server's representation of the role, and an error, if it occurs.
+func (c *roles) Create(role *api.Role) (result *api.Role, err error) {
+	result = &api.Role{}
+	err = c.client.Post().Namespace(c.namespace).Resource("roles").Body(role).Do().Into(result)
+	return
+}
+
+// Update takes the representation of a role to update.  Returns the server's representation of the role, and an error, if it occurs.
+func (c *roles) Update(role *api.Role) (result *api.Role, err error) {
+	result = &api.Role{}
+	if len(role.ResourceVersion) == 0 {
+		err = fmt.Errorf("invalid update object, missing resource version: %v", role)
+		return
+	}
+	err = c.client.Put().Namespace(c.namespace).Resource("roles").Name(role.Name).Body(role).Do().Into(result)
+	return
+}
+
+// Delete takes the name of the role, and returns an error if one occurs
+func (c *roles) Delete(name string) error {
+	return c.client.Delete().Namespace(c.namespace).Resource("roles").Name(name).Do().Error()
+}
+
+// Watch returns a watch.Interface that watches the requested roles.
+func (c *roles) Watch(label labels.Selector, field fields.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.client.Get().
+		Prefix("watch").
+		Namespace(c.namespace).
+		Resource("roles").
+		Param("resourceVersion", resourceVersion).
+		LabelsSelectorParam(api.LabelSelectorQueryParam(c.client.APIVersion()), label).
+		FieldsSelectorParam(api.FieldSelectorQueryParam(c.client.APIVersion()), field).
+		Watch()
+}
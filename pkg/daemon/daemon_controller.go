@@ -0,0 +1,317 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/validation"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+	"github.com/golang/glog"
+)
+
+// DaemonSetController is responsible for running exactly one pod of every
+// DaemonSet on each node that matches the node selector of its template.
+type DaemonSetController struct {
+	kubeClient client.Interface
+	podControl PodControlInterface
+
+	// nodeStore is kept up to date by a reflector watching api.Node objects.
+	nodeStore cache.StoreToNodeLister
+	// nodesChanged receives a value whenever a node is added, updated or removed,
+	// so that daemon sets are synced without waiting for the next period.
+	nodesChanged chan struct{}
+	// nodesSynced is closed once the first list of nodes has been received.
+	nodesSynced chan struct{}
+
+	// To allow injection of syncDaemonSet for testing.
+	syncHandler func(ds api.DaemonSet) error
+}
+
+// PodControlInterface is an interface that knows how to add or delete pods
+// created as an interface to allow testing.
+type PodControlInterface interface {
+	// createPod creates a new pod from the template of the daemon set, bound to the given node.
+	createPod(namespace string, ds *api.DaemonSet, nodeName string) error
+	// deletePod deletes the pod identified by podID.
+	deletePod(namespace string, podID string) error
+}
+
+// RealPodControl is the default implementation of PodControlInterface.
+type RealPodControl struct {
+	kubeClient client.Interface
+}
+
+func (r RealPodControl) createPod(namespace string, ds *api.DaemonSet, nodeName string) error {
+	desiredLabels := make(labels.Set)
+	for k, v := range ds.Spec.Template.Labels {
+		desiredLabels[k] = v
+	}
+	desiredAnnotations := make(labels.Set)
+	for k, v := range ds.Spec.Template.Annotations {
+		desiredAnnotations[k] = v
+	}
+
+	// use the dash (if the name isn't too long) to make the pod name a bit prettier
+	prefix := fmt.Sprintf("%s-", ds.Name)
+	if ok, _ := validation.ValidatePodName(prefix, true); !ok {
+		prefix = ds.Name
+	}
+
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Labels:       desiredLabels,
+			Annotations:  desiredAnnotations,
+			GenerateName: prefix,
+		},
+	}
+	if err := api.Scheme.Convert(&ds.Spec.Template.Spec, &pod.Spec); err != nil {
+		return fmt.Errorf("unable to convert pod template: %v", err)
+	}
+	// Bind the pod directly to the node, bypassing the scheduler.
+	pod.Spec.Host = nodeName
+	if labels.Set(pod.Labels).AsSelector().Empty() {
+		return fmt.Errorf("unable to create pod, no labels")
+	}
+	if _, err := r.kubeClient.Pods(namespace).Create(pod); err != nil {
+		return fmt.Errorf("unable to create pod: %v", err)
+	}
+	return nil
+}
+
+func (r RealPodControl) deletePod(namespace, podID string) error {
+	return r.kubeClient.Pods(namespace).Delete(podID)
+}
+
+// NewDaemonSetController creates a new DaemonSetController.
+func NewDaemonSetController(kubeClient client.Interface) *DaemonSetController {
+	dc := &DaemonSetController{
+		kubeClient: kubeClient,
+		podControl: RealPodControl{
+			kubeClient: kubeClient,
+		},
+		nodeStore:    cache.StoreToNodeLister{Store: cache.NewStore(cache.MetaNamespaceKeyFunc)},
+		nodesChanged: make(chan struct{}, 1),
+		nodesSynced:  make(chan struct{}),
+	}
+	dc.syncHandler = dc.syncDaemonSet
+	return dc
+}
+
+// Run begins watching nodes and syncing daemon sets every period, or sooner
+// when the set of nodes or their labels change.
+func (dc *DaemonSetController) Run(period time.Duration) {
+	cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return dc.kubeClient.Nodes().List()
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return dc.kubeClient.Nodes().Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.Node{},
+		&nodeChangeStore{Store: dc.nodeStore.Store, controller: dc},
+		0,
+	).Run()
+	go util.Forever(func() {
+		<-dc.nodesSynced
+		select {
+		case <-dc.nodesChanged:
+		case <-time.After(period):
+		}
+		dc.synchronize()
+	}, 0)
+}
+
+// nodeChangeStore passes all operations through to Store and notifies the
+// controller of every change to the set of nodes.
+type nodeChangeStore struct {
+	cache.Store
+	controller *DaemonSetController
+	once       sync.Once
+}
+
+func (s *nodeChangeStore) Add(obj interface{}) error {
+	defer s.notify()
+	return s.Store.Add(obj)
+}
+
+func (s *nodeChangeStore) Update(obj interface{}) error {
+	defer s.notify()
+	return s.Store.Update(obj)
+}
+
+func (s *nodeChangeStore) Delete(obj interface{}) error {
+	defer s.notify()
+	return s.Store.Delete(obj)
+}
+
+func (s *nodeChangeStore) Replace(list []interface{}) error {
+	defer s.once.Do(func() { close(s.controller.nodesSynced) })
+	defer s.notify()
+	return s.Store.Replace(list)
+}
+
+func (s *nodeChangeStore) notify() {
+	select {
+	case s.controller.nodesChanged <- struct{}{}:
+	default:
+		// A sync is already pending.
+	}
+}
+
+func (dc *DaemonSetController) synchronize() {
+	list, err := dc.kubeClient.DaemonSets(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		glog.Errorf("Synchronization error: %v", err)
+		return
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(list.Items))
+	for ix := range list.Items {
+		go func(ix int) {
+			defer wg.Done()
+			ds := list.Items[ix]
+			glog.V(4).Infof("periodic sync of %v/%v", ds.Namespace, ds.Name)
+			if err := dc.syncHandler(ds); err != nil {
+				glog.Errorf("Error synchronizing daemon set %v/%v: %v", ds.Namespace, ds.Name, err)
+			}
+		}(ix)
+	}
+	wg.Wait()
+}
+
+// syncDaemonSet makes sure that exactly one pod of the daemon set runs on
+// every node that should run it, deletes the pods on all other nodes, and
+// records the status of the daemon set.
+func (dc *DaemonSetController) syncDaemonSet(ds api.DaemonSet) error {
+	if ds.Spec.Template == nil {
+		return fmt.Errorf("daemon set %s/%s has no pod template", ds.Namespace, ds.Name)
+	}
+	nodes, err := dc.nodeStore.List()
+	if err != nil {
+		return err
+	}
+	pods, err := dc.kubeClient.Pods(ds.Namespace).List(labels.Set(ds.Spec.Selector).AsSelector())
+	if err != nil {
+		return err
+	}
+
+	// Group the daemon pods by the node they are bound to. Pods that have
+	// terminated are deleted so that they are replaced on the next sync.
+	var toDelete []string
+	nodeToPods := map[string][]*api.Pod{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == api.PodSucceeded || pod.Status.Phase == api.PodFailed {
+			toDelete = append(toDelete, pod.Name)
+			continue
+		}
+		nodeToPods[pod.Spec.Host] = append(nodeToPods[pod.Spec.Host], pod)
+	}
+
+	var toCreate []string
+	status := api.DaemonSetStatus{}
+	nodeSelector := labels.Set(ds.Spec.Template.Spec.NodeSelector).AsSelector()
+	for _, node := range nodes.Items {
+		daemonPods := nodeToPods[node.Name]
+		delete(nodeToPods, node.Name)
+
+		shouldRun := nodeSelector.Matches(labels.Set(node.Labels))
+		switch {
+		case shouldRun && len(daemonPods) == 0:
+			toCreate = append(toCreate, node.Name)
+		case shouldRun:
+			// Keep the oldest pod and delete any duplicates.
+			sort.Sort(byCreationTimestamp(daemonPods))
+			for _, pod := range daemonPods[1:] {
+				toDelete = append(toDelete, pod.Name)
+			}
+		default:
+			for _, pod := range daemonPods {
+				toDelete = append(toDelete, pod.Name)
+			}
+		}
+
+		if shouldRun {
+			status.DesiredNumberScheduled++
+			if len(daemonPods) > 0 {
+				status.CurrentNumberScheduled++
+			}
+		} else if len(daemonPods) > 0 {
+			status.NumberMisscheduled++
+		}
+	}
+	// Whatever is left is bound to nodes that no longer exist, or was not bound
+	// to a node at all.
+	for _, daemonPods := range nodeToPods {
+		status.NumberMisscheduled++
+		for _, pod := range daemonPods {
+			toDelete = append(toDelete, pod.Name)
+		}
+	}
+
+	var errs []error
+	if len(toCreate) > 0 {
+		glog.V(2).Infof("Creating %d pods of daemon set %s/%s", len(toCreate), ds.Namespace, ds.Name)
+	}
+	for _, nodeName := range toCreate {
+		if err := dc.podControl.createPod(ds.Namespace, &ds, nodeName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(toDelete) > 0 {
+		glog.V(2).Infof("Deleting %d pods of daemon set %s/%s", len(toDelete), ds.Namespace, ds.Name)
+	}
+	for _, podName := range toDelete {
+		if err := dc.podControl.deletePod(ds.Namespace, podName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !api.Semantic.DeepEqual(status, ds.Status) {
+		ds.Status = status
+		if _, err := dc.kubeClient.DaemonSets(ds.Namespace).UpdateStatus(&ds); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+type byCreationTimestamp []*api.Pod
+
+func (o byCreationTimestamp) Len() int      { return len(o) }
+func (o byCreationTimestamp) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+
+func (o byCreationTimestamp) Less(i, j int) bool {
+	if o[i].CreationTimestamp.Equal(o[j].CreationTimestamp.Time) {
+		return o[i].Name < o[j].Name
+	}
+	return o[i].CreationTimestamp.Before(o[j].CreationTimestamp)
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemon
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+type FakePodControl struct {
+	nodeNames     []string
+	deletePodName []string
+	lock          sync.Mutex
+}
+
+func (f *FakePodControl) createPod(namespace string, ds *api.DaemonSet, nodeName string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.nodeNames = append(f.nodeNames, nodeName)
+	return nil
+}
+
+func (f *FakePodControl) deletePod(namespace string, podName string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deletePodName = append(f.deletePodName, podName)
+	return nil
+}
+
+func newDaemonSet(nodeSelector map[string]string) api.DaemonSet {
+	return api.DaemonSet{
+		ObjectMeta: api.ObjectMeta{Name: "fluentd", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		Spec: api.DaemonSetSpec{
+			Selector: map[string]string{"name": "fluentd"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"name": "fluentd"},
+				},
+				Spec: api.PodSpec{
+					NodeSelector: nodeSelector,
+					Containers:   []api.Container{{Name: "fluentd", Image: "fluentd"}},
+				},
+			},
+		},
+	}
+}
+
+func newNode(name string, labels map[string]string) *api.Node {
+	return &api.Node{ObjectMeta: api.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newPod(name, nodeName string, phase api.PodPhase, created time.Time) api.Pod {
+	return api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Name:              name,
+			Namespace:         api.NamespaceDefault,
+			Labels:            map[string]string{"name": "fluentd"},
+			CreationTimestamp: util.NewTime(created),
+		},
+		Spec:   api.PodSpec{Host: nodeName},
+		Status: api.PodStatus{Phase: phase},
+	}
+}
+
+func newController(pods []api.Pod, nodes ...*api.Node) (*DaemonSetController, *client.Fake, *FakePodControl) {
+	fakeClient := &client.Fake{PodsList: api.PodList{Items: pods}}
+	fakePodControl := &FakePodControl{}
+	controller := NewDaemonSetController(fakeClient)
+	controller.podControl = fakePodControl
+	for _, node := range nodes {
+		controller.nodeStore.Add(node)
+	}
+	return controller, fakeClient, fakePodControl
+}
+
+func statusUpdate(fakeClient *client.Fake) *api.DaemonSetStatus {
+	var status *api.DaemonSetStatus
+	for _, action := range fakeClient.Actions {
+		if action.Action == "update-status-daemonSet" {
+			status = &action.Value.(*api.DaemonSet).Status
+		}
+	}
+	return status
+}
+
+func TestSyncDaemonSet(t *testing.T) {
+	now := time.Now()
+	logging := map[string]string{"logging": "true"}
+	tests := map[string]struct {
+		nodeSelector map[string]string
+		nodes        []*api.Node
+		pods         []api.Pod
+
+		expectedCreates []string
+		expectedDeletes []string
+		expectedStatus  api.DaemonSetStatus
+	}{
+		"every node": {
+			nodes:           []*api.Node{newNode("node-1", nil), newNode("node-2", logging)},
+			expectedCreates: []string{"node-1", "node-2"},
+			expectedStatus:  api.DaemonSetStatus{DesiredNumberScheduled: 2},
+		},
+		"only matching nodes": {
+			nodeSelector:    logging,
+			nodes:           []*api.Node{newNode("node-1", nil), newNode("node-2", logging)},
+			expectedCreates: []string{"node-2"},
+			expectedStatus:  api.DaemonSetStatus{DesiredNumberScheduled: 1},
+		},
+		"already running": {
+			nodes:          []*api.Node{newNode("node-1", nil)},
+			pods:           []api.Pod{newPod("fluentd-a", "node-1", api.PodRunning, now)},
+			expectedStatus: api.DaemonSetStatus{DesiredNumberScheduled: 1, CurrentNumberScheduled: 1},
+		},
+		"node labels changed": {
+			nodeSelector:    logging,
+			nodes:           []*api.Node{newNode("node-1", nil)},
+			pods:            []api.Pod{newPod("fluentd-a", "node-1", api.PodRunning, now)},
+			expectedDeletes: []string{"fluentd-a"},
+			expectedStatus:  api.DaemonSetStatus{NumberMisscheduled: 1},
+		},
+		"node left": {
+			nodes: []*api.Node{newNode("node-1", nil)},
+			pods: []api.Pod{
+				newPod("fluentd-a", "node-1", api.PodRunning, now),
+				newPod("fluentd-b", "node-2", api.PodRunning, now),
+			},
+			expectedDeletes: []string{"fluentd-b"},
+			expectedStatus:  api.DaemonSetStatus{DesiredNumberScheduled: 1, CurrentNumberScheduled: 1, NumberMisscheduled: 1},
+		},
+		"duplicate pods keep the oldest": {
+			nodes: []*api.Node{newNode("node-1", nil)},
+			pods: []api.Pod{
+				newPod("fluentd-new", "node-1", api.PodRunning, now),
+				newPod("fluentd-old", "node-1", api.PodRunning, now.Add(-time.Hour)),
+			},
+			expectedDeletes: []string{"fluentd-new"},
+			expectedStatus:  api.DaemonSetStatus{DesiredNumberScheduled: 1, CurrentNumberScheduled: 1},
+		},
+		"replace failed pods": {
+			nodes:           []*api.Node{newNode("node-1", nil)},
+			pods:            []api.Pod{newPod("fluentd-a", "node-1", api.PodFailed, now)},
+			expectedCreates: []string{"node-1"},
+			expectedDeletes: []string{"fluentd-a"},
+			expectedStatus:  api.DaemonSetStatus{DesiredNumberScheduled: 1},
+		},
+	}
+	for name, test := range tests {
+		controller, fakeClient, fakePodControl := newController(test.pods, test.nodes...)
+		if err := controller.syncDaemonSet(newDaemonSet(test.nodeSelector)); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		sort.Strings(fakePodControl.nodeNames)
+		if !reflect.DeepEqual(fakePodControl.nodeNames, test.expectedCreates) {
+			t.Errorf("%s: expected pods to be created on %v, got %v", name, test.expectedCreates, fakePodControl.nodeNames)
+		}
+		sort.Strings(fakePodControl.deletePodName)
+		if !reflect.DeepEqual(fakePodControl.deletePodName, test.expectedDeletes) {
+			t.Errorf("%s: expected pods %v to be deleted, got %v", name, test.expectedDeletes, fakePodControl.deletePodName)
+		}
+		status := statusUpdate(fakeClient)
+		if status == nil {
+			if test.expectedStatus != (api.DaemonSetStatus{}) {
+				t.Errorf("%s: expected a status update", name)
+			}
+			continue
+		}
+		if *status != test.expectedStatus {
+			t.Errorf("%s: expected status %#v, got %#v", name, test.expectedStatus, *status)
+		}
+	}
+}
+
+func TestSyncDaemonSetUnchangedStatus(t *testing.T) {
+	controller, fakeClient, _ := newController(
+		[]api.Pod{newPod("fluentd-a", "node-1", api.PodRunning, time.Now())},
+		newNode("node-1", nil))
+	ds := newDaemonSet(nil)
+	ds.Status = api.DaemonSetStatus{DesiredNumberScheduled: 1, CurrentNumberScheduled: 1}
+	if err := controller.syncDaemonSet(ds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := statusUpdate(fakeClient); status != nil {
+		t.Errorf("unexpected status update: %#v", status)
+	}
+}
+
+func TestNodeChangeStore(t *testing.T) {
+	controller := NewDaemonSetController(&client.Fake{})
+	store := &nodeChangeStore{Store: controller.nodeStore.Store, controller: controller}
+
+	select {
+	case <-controller.nodesSynced:
+		t.Fatalf("nodes should not be synced before the first list")
+	default:
+	}
+	if err := store.Replace([]interface{}{newNode("node-1", nil)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-controller.nodesSynced:
+	default:
+		t.Errorf("expected nodes to be synced after the first list")
+	}
+	<-controller.nodesChanged
+
+	// Several changes only queue a single sync.
+	store.Add(newNode("node-2", nil))
+	store.Delete(newNode("node-1", nil))
+	<-controller.nodesChanged
+	select {
+	case <-controller.nodesChanged:
+		t.Errorf("expected a single pending sync")
+	default:
+	}
+	if nodes, _ := controller.nodeStore.List(); len(nodes.Items) != 1 || nodes.Items[0].Name != "node-2" {
+		t.Errorf("unexpected nodes in the store: %#v", nodes)
+	}
+}
+
+func TestSynchronize(t *testing.T) {
+	fakeClient := &client.Fake{DaemonSetList: api.DaemonSetList{Items: []api.DaemonSet{newDaemonSet(nil), newDaemonSet(nil)}}}
+	controller := NewDaemonSetController(fakeClient)
+	var lock sync.Mutex
+	synced := 0
+	controller.syncHandler = func(ds api.DaemonSet) error {
+		lock.Lock()
+		defer lock.Unlock()
+		synced++
+		return fmt.Errorf("sync failed")
+	}
+	controller.synchronize()
+	if synced != 2 {
+		t.Errorf("expected both daemon sets to be synced, got %d", synced)
+	}
+}
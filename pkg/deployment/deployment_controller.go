@@ -0,0 +1,469 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+)
+
+// DeploymentController is responsible for rolling out deployments. Each sync
+// moves a deployment one step closer to its desired state, bounded by the
+// surge and unavailability limits of its strategy.
+type DeploymentController struct {
+	kubeClient client.Interface
+
+	// To allow injection of syncDeployment for testing.
+	syncHandler func(deployment api.Deployment) error
+}
+
+// NewDeploymentController creates a new DeploymentController.
+func NewDeploymentController(kubeClient client.Interface) *DeploymentController {
+	dc := &DeploymentController{
+		kubeClient: kubeClient,
+	}
+	dc.syncHandler = dc.syncDeployment
+	return dc
+}
+
+// Run begins syncing deployments every period.
+func (dc *DeploymentController) Run(period time.Duration) {
+	go util.Forever(func() { dc.synchronize() }, period)
+}
+
+func (dc *DeploymentController) synchronize() {
+	list, err := dc.kubeClient.Deployments(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		glog.Errorf("Synchronization error: %v", err)
+		return
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(list.Items))
+	for ix := range list.Items {
+		go func(ix int) {
+			defer wg.Done()
+			deployment := list.Items[ix]
+			glog.V(4).Infof("periodic sync of %v/%v", deployment.Namespace, deployment.Name)
+			if err := dc.syncHandler(deployment); err != nil {
+				glog.Errorf("Error synchronizing deployment %v/%v: %v", deployment.Namespace, deployment.Name, err)
+			}
+		}(ix)
+	}
+	wg.Wait()
+}
+
+// syncDeployment performs a single rollout step for the given deployment.
+func (dc *DeploymentController) syncDeployment(deployment api.Deployment) error {
+	controllers, err := ControllersForDeployment(dc.kubeClient, &deployment)
+	if err != nil {
+		return err
+	}
+
+	if deployment.Spec.RollbackTo != nil {
+		return dc.rollback(&deployment, controllers)
+	}
+
+	hash := PodTemplateHash(deployment.Spec.Template)
+	var newController *api.ReplicationController
+	oldControllers := []*api.ReplicationController{}
+	var maxRevision int64
+	for i := range controllers {
+		controller := &controllers[i]
+		if revision := Revision(controller); revision > maxRevision {
+			maxRevision = revision
+		}
+		if controller.Spec.Template.Labels[api.DeploymentPodTemplateHashLabel] == hash {
+			newController = controller
+		} else {
+			oldControllers = append(oldControllers, controller)
+		}
+	}
+
+	if deployment.Spec.Paused {
+		return dc.updateStatus(&deployment, newController, oldControllers)
+	}
+
+	if newController == nil {
+		newController, err = dc.createController(&deployment, hash, maxRevision+1, oldControllers)
+		if err != nil {
+			return err
+		}
+		// The new controller is scaled further on the next sync, once it has been observed.
+		return dc.updateStatus(&deployment, newController, oldControllers)
+	}
+	if Revision(newController) < maxRevision {
+		// An older template is being rolled out again; it becomes the newest revision.
+		setRevision(newController, maxRevision+1)
+		if newController, err = dc.kubeClient.ReplicationControllers(newController.Namespace).Update(newController); err != nil {
+			return err
+		}
+	}
+
+	switch deployment.Spec.Strategy.Type {
+	case api.RecreateDeploymentStrategyType:
+		err = dc.syncRecreate(&deployment, newController, oldControllers)
+	case api.RollingUpdateDeploymentStrategyType:
+		err = dc.syncRollingUpdate(&deployment, newController, oldControllers)
+	default:
+		err = fmt.Errorf("unsupported deployment strategy %q", deployment.Spec.Strategy.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := dc.cleanupOldControllers(&deployment, oldControllers); err != nil {
+		return err
+	}
+	return dc.updateStatus(&deployment, newController, oldControllers)
+}
+
+// createController creates the replication controller for the current template of
+// the deployment, starting with as many replicas as the strategy allows.
+func (dc *DeploymentController) createController(deployment *api.Deployment, hash string, revision int64, oldControllers []*api.ReplicationController) (*api.ReplicationController, error) {
+	template := &api.PodTemplateSpec{}
+	if err := api.Scheme.Convert(deployment.Spec.Template, template); err != nil {
+		return nil, err
+	}
+	template.Labels = copyLabelsWith(template.Labels, api.DeploymentPodTemplateHashLabel, hash)
+
+	replicas := 0
+	switch deployment.Spec.Strategy.Type {
+	case api.RecreateDeploymentStrategyType:
+		if totalReplicas(oldControllers) == 0 {
+			replicas = deployment.Spec.Replicas
+		}
+	case api.RollingUpdateDeploymentStrategyType:
+		maxSurge, _, err := rollingUpdateBounds(deployment)
+		if err != nil {
+			return nil, err
+		}
+		replicas = deployment.Spec.Replicas + maxSurge - totalReplicas(oldControllers)
+		if replicas > deployment.Spec.Replicas {
+			replicas = deployment.Spec.Replicas
+		}
+		if replicas < 0 {
+			replicas = 0
+		}
+	}
+
+	controller := &api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", deployment.Name, hash),
+			Namespace:   deployment.Namespace,
+			Labels:      deployment.Labels,
+			Annotations: map[string]string{api.DeploymentRevisionAnnotation: strconv.FormatInt(revision, 10)},
+		},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: replicas,
+			Selector: copyLabelsWith(deployment.Spec.Selector, api.DeploymentPodTemplateHashLabel, hash),
+			Template: template,
+		},
+	}
+	glog.V(2).Infof("Creating replication controller %s for deployment %s/%s at revision %d", controller.Name, deployment.Namespace, deployment.Name, revision)
+	return dc.kubeClient.ReplicationControllers(deployment.Namespace).Create(controller)
+}
+
+// syncRecreate scales all old controllers down to zero before scaling up the new one.
+func (dc *DeploymentController) syncRecreate(deployment *api.Deployment, newController *api.ReplicationController, oldControllers []*api.ReplicationController) error {
+	if totalReplicas(oldControllers) > 0 {
+		for _, controller := range oldControllers {
+			if err := dc.scale(controller, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return dc.scale(newController, deployment.Spec.Replicas)
+}
+
+// syncRollingUpdate scales the new controller up as far as maxSurge allows and the
+// old controllers down as far as maxUnavailable allows.
+func (dc *DeploymentController) syncRollingUpdate(deployment *api.Deployment, newController *api.ReplicationController, oldControllers []*api.ReplicationController) error {
+	maxSurge, maxUnavailable, err := rollingUpdateBounds(deployment)
+	if err != nil {
+		return err
+	}
+	desired := deployment.Spec.Replicas
+
+	switch {
+	case newController.Spec.Replicas > desired:
+		if err := dc.scale(newController, desired); err != nil {
+			return err
+		}
+	case newController.Spec.Replicas < desired:
+		total := newController.Spec.Replicas + totalReplicas(oldControllers)
+		if allowed := desired + maxSurge - total; allowed > 0 {
+			replicas := newController.Spec.Replicas + allowed
+			if replicas > desired {
+				replicas = desired
+			}
+			if err := dc.scale(newController, replicas); err != nil {
+				return err
+			}
+		}
+	}
+
+	if totalReplicas(oldControllers) == 0 {
+		return nil
+	}
+	available, err := dc.availablePods(deployment)
+	if err != nil {
+		return err
+	}
+	// Only remove as many old pods as keeps desired-maxUnavailable pods available.
+	scaleDown := available - (desired - maxUnavailable)
+	sort.Sort(byRevision(oldControllers))
+	for _, controller := range oldControllers {
+		if scaleDown <= 0 {
+			break
+		}
+		if controller.Spec.Replicas == 0 {
+			continue
+		}
+		count := controller.Spec.Replicas
+		if count > scaleDown {
+			count = scaleDown
+		}
+		if err := dc.scale(controller, controller.Spec.Replicas-count); err != nil {
+			return err
+		}
+		scaleDown -= count
+	}
+	return nil
+}
+
+// rollingUpdateBounds resolves maxSurge and maxUnavailable against the desired
+// number of replicas. At least one of them is always positive so that a rollout
+// can make progress.
+func rollingUpdateBounds(deployment *api.Deployment) (int, int, error) {
+	params := deployment.Spec.Strategy.RollingUpdate
+	if params == nil {
+		return 1, 1, nil
+	}
+	maxSurge, err := util.GetScaledValueFromIntOrPercent(&params.MaxSurge, deployment.Spec.Replicas, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxUnavailable, err := util.GetScaledValueFromIntOrPercent(&params.MaxUnavailable, deployment.Spec.Replicas, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if maxUnavailable > deployment.Spec.Replicas {
+		maxUnavailable = deployment.Spec.Replicas
+	}
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxUnavailable = 1
+	}
+	return maxSurge, maxUnavailable, nil
+}
+
+// availablePods counts the running and ready pods selected by the deployment.
+func (dc *DeploymentController) availablePods(deployment *api.Deployment) (int, error) {
+	pods, err := dc.kubeClient.Pods(deployment.Namespace).List(labels.Set(deployment.Spec.Selector).AsSelector())
+	if err != nil {
+		return 0, err
+	}
+	available := 0
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != api.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == api.PodReady && c.Status == api.ConditionTrue {
+				available++
+				break
+			}
+		}
+	}
+	return available, nil
+}
+
+// rollback replaces the template of the deployment with the template of the
+// requested revision and clears the rollback request.
+func (dc *DeploymentController) rollback(deployment *api.Deployment, controllers []api.ReplicationController) error {
+	target := findRevision(deployment, controllers, deployment.Spec.RollbackTo.Revision)
+	if target == nil {
+		glog.Warningf("Unable to find revision %d to roll back deployment %s/%s to", deployment.Spec.RollbackTo.Revision, deployment.Namespace, deployment.Name)
+	} else {
+		template := &api.PodTemplateSpec{}
+		if err := api.Scheme.Convert(target.Spec.Template, template); err != nil {
+			return err
+		}
+		delete(template.Labels, api.DeploymentPodTemplateHashLabel)
+		deployment.Spec.Template = template
+		glog.V(2).Infof("Rolling back deployment %s/%s to revision %d", deployment.Namespace, deployment.Name, Revision(target))
+	}
+	deployment.Spec.RollbackTo = nil
+	_, err := dc.kubeClient.Deployments(deployment.Namespace).Update(deployment)
+	return err
+}
+
+// findRevision returns the controller holding the given revision. Revision 0
+// refers to the revision preceding the one currently rolled out.
+func findRevision(deployment *api.Deployment, controllers []api.ReplicationController, revision int64) *api.ReplicationController {
+	if revision == 0 {
+		current := int64(-1)
+		hash := PodTemplateHash(deployment.Spec.Template)
+		for i := range controllers {
+			if controllers[i].Spec.Template.Labels[api.DeploymentPodTemplateHashLabel] == hash {
+				current = Revision(&controllers[i])
+			}
+		}
+		var previous *api.ReplicationController
+		for i := range controllers {
+			r := Revision(&controllers[i])
+			if (current < 0 || r < current) && (previous == nil || r > Revision(previous)) {
+				previous = &controllers[i]
+			}
+		}
+		return previous
+	}
+	for i := range controllers {
+		if Revision(&controllers[i]) == revision {
+			return &controllers[i]
+		}
+	}
+	return nil
+}
+
+// cleanupOldControllers deletes the oldest scaled-down controllers beyond the
+// revision history limit of the deployment.
+func (dc *DeploymentController) cleanupOldControllers(deployment *api.Deployment, oldControllers []*api.ReplicationController) error {
+	if deployment.Spec.RevisionHistoryLimit == nil {
+		return nil
+	}
+	idle := []*api.ReplicationController{}
+	for _, controller := range oldControllers {
+		if controller.Spec.Replicas == 0 && controller.Status.Replicas == 0 {
+			idle = append(idle, controller)
+		}
+	}
+	sort.Sort(byRevision(idle))
+	for i := 0; i < len(idle)-*deployment.Spec.RevisionHistoryLimit; i++ {
+		glog.V(2).Infof("Deleting old replication controller %s of deployment %s/%s", idle[i].Name, deployment.Namespace, deployment.Name)
+		if err := dc.kubeClient.ReplicationControllers(idle[i].Namespace).Delete(idle[i].Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateStatus records the observed replica counts and current revision of a deployment.
+func (dc *DeploymentController) updateStatus(deployment *api.Deployment, newController *api.ReplicationController, oldControllers []*api.ReplicationController) error {
+	status := api.DeploymentStatus{}
+	for _, controller := range oldControllers {
+		status.Replicas += controller.Status.Replicas
+	}
+	if newController != nil {
+		status.Replicas += newController.Status.Replicas
+		status.UpdatedReplicas = newController.Status.Replicas
+		status.Revision = Revision(newController)
+	}
+	if status == deployment.Status {
+		return nil
+	}
+	deployment.Status = status
+	_, err := dc.kubeClient.Deployments(deployment.Namespace).UpdateStatus(deployment)
+	return err
+}
+
+func (dc *DeploymentController) scale(controller *api.ReplicationController, replicas int) error {
+	if controller.Spec.Replicas == replicas {
+		return nil
+	}
+	glog.V(4).Infof("Scaling replication controller %s/%s from %d to %d", controller.Namespace, controller.Name, controller.Spec.Replicas, replicas)
+	controller.Spec.Replicas = replicas
+	_, err := dc.kubeClient.ReplicationControllers(controller.Namespace).Update(controller)
+	return err
+}
+
+// ControllersForDeployment returns the replication controllers whose pods are
+// selected by the deployment.
+func ControllersForDeployment(c client.Interface, deployment *api.Deployment) ([]api.ReplicationController, error) {
+	list, err := c.ReplicationControllers(deployment.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.Set(deployment.Spec.Selector).AsSelector()
+	controllers := []api.ReplicationController{}
+	for _, controller := range list.Items {
+		if controller.Spec.Template == nil || !selector.Matches(labels.Set(controller.Spec.Template.Labels)) {
+			continue
+		}
+		controllers = append(controllers, controller)
+	}
+	return controllers, nil
+}
+
+// Revision returns the deployment revision recorded on a replication controller,
+// or 0 if it has none.
+func Revision(controller *api.ReplicationController) int64 {
+	revision, err := strconv.ParseInt(controller.Annotations[api.DeploymentRevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+func setRevision(controller *api.ReplicationController, revision int64) {
+	if controller.Annotations == nil {
+		controller.Annotations = map[string]string{}
+	}
+	controller.Annotations[api.DeploymentRevisionAnnotation] = strconv.FormatInt(revision, 10)
+}
+
+// PodTemplateHash returns a label value identifying a pod template.
+func PodTemplateHash(template *api.PodTemplateSpec) string {
+	hasher := fnv.New32a()
+	util.DeepHashObject(hasher, template)
+	return fmt.Sprintf("%d", hasher.Sum32())
+}
+
+func copyLabelsWith(in map[string]string, key, value string) map[string]string {
+	out := map[string]string{}
+	for k, v := range in {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
+
+func totalReplicas(controllers []*api.ReplicationController) int {
+	total := 0
+	for _, controller := range controllers {
+		total += controller.Spec.Replicas
+	}
+	return total
+}
+
+// byRevision sorts replication controllers from the oldest revision to the newest.
+type byRevision []*api.ReplicationController
+
+func (r byRevision) Len() int           { return len(r) }
+func (r byRevision) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byRevision) Less(i, j int) bool { return Revision(r[i]) < Revision(r[j]) }
@@ -0,0 +1,406 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+func newTemplate(image string) *api.PodTemplateSpec {
+	return &api.PodTemplateSpec{
+		ObjectMeta: api.ObjectMeta{
+			Labels: map[string]string{"app": "web"},
+		},
+		Spec: api.PodSpec{
+			RestartPolicy: api.RestartPolicyAlways,
+			DNSPolicy:     api.DNSClusterFirst,
+			Containers:    []api.Container{{Name: "web", Image: image}},
+		},
+	}
+}
+
+func newDeployment(replicas int, image string) api.Deployment {
+	return api.Deployment{
+		ObjectMeta: api.ObjectMeta{Name: "web", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		Spec: api.DeploymentSpec{
+			Replicas: replicas,
+			Selector: map[string]string{"app": "web"},
+			Template: newTemplate(image),
+			Strategy: api.DeploymentStrategy{
+				Type: api.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &api.RollingUpdateDeployment{
+					MaxUnavailable: util.NewIntOrStringFromInt(1),
+					MaxSurge:       util.NewIntOrStringFromInt(1),
+				},
+			},
+		},
+	}
+}
+
+// newController returns a controller as created by the deployment controller for a template.
+func newController(image string, revision int64, replicas int) api.ReplicationController {
+	template := newTemplate(image)
+	hash := PodTemplateHash(template)
+	template.Labels = copyLabelsWith(template.Labels, api.DeploymentPodTemplateHashLabel, hash)
+	return api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{
+			Name:        "web-" + hash,
+			Namespace:   api.NamespaceDefault,
+			Annotations: map[string]string{api.DeploymentRevisionAnnotation: strconv.FormatInt(revision, 10)},
+		},
+		Spec: api.ReplicationControllerSpec{
+			Replicas: replicas,
+			Selector: copyLabelsWith(map[string]string{"app": "web"}, api.DeploymentPodTemplateHashLabel, hash),
+			Template: template,
+		},
+		Status: api.ReplicationControllerStatus{Replicas: replicas},
+	}
+}
+
+func newPods(count int, ready bool) []api.Pod {
+	status := api.ConditionFalse
+	if ready {
+		status = api.ConditionTrue
+	}
+	pods := []api.Pod{}
+	for i := 0; i < count; i++ {
+		pods = append(pods, api.Pod{
+			ObjectMeta: api.ObjectMeta{Name: "pod" + strconv.Itoa(i), Namespace: api.NamespaceDefault, Labels: map[string]string{"app": "web"}},
+			Status: api.PodStatus{
+				Phase:      api.PodRunning,
+				Conditions: []api.PodCondition{{Type: api.PodReady, Status: status}},
+			},
+		})
+	}
+	return pods
+}
+
+// controllerUpdates returns the replica counts of updated controllers by name.
+func controllerUpdates(fake *client.Fake) map[string]int {
+	updates := map[string]int{}
+	for _, action := range fake.Actions {
+		if action.Action == "update-controller" {
+			controller := action.Value.(*api.ReplicationController)
+			updates[controller.Name] = controller.Spec.Replicas
+		}
+	}
+	return updates
+}
+
+func findActions(fake *client.Fake, name string) []client.FakeAction {
+	actions := []client.FakeAction{}
+	for _, action := range fake.Actions {
+		if action.Action == name {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+func TestSyncDeploymentCreatesController(t *testing.T) {
+	fake := &client.Fake{}
+	dc := NewDeploymentController(fake)
+	deployment := newDeployment(3, "nginx:1")
+	if err := dc.syncDeployment(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	creates := findActions(fake, "create-controller")
+	if len(creates) != 1 {
+		t.Fatalf("expected one controller to be created, got %#v", fake.Actions)
+	}
+	controller := creates[0].Value.(*api.ReplicationController)
+	hash := PodTemplateHash(deployment.Spec.Template)
+	if controller.Name != "web-"+hash {
+		t.Errorf("unexpected controller name %q", controller.Name)
+	}
+	if controller.Spec.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %d", controller.Spec.Replicas)
+	}
+	if Revision(controller) != 1 {
+		t.Errorf("expected revision 1, got %d", Revision(controller))
+	}
+	if controller.Spec.Selector[api.DeploymentPodTemplateHashLabel] != hash || controller.Spec.Template.Labels[api.DeploymentPodTemplateHashLabel] != hash {
+		t.Errorf("expected the pod template hash in selector and template labels: %#v", controller.Spec)
+	}
+	if _, ok := deployment.Spec.Template.Labels[api.DeploymentPodTemplateHashLabel]; ok {
+		t.Errorf("deployment template should not be modified")
+	}
+}
+
+func TestSyncDeploymentCreateBoundedBySurge(t *testing.T) {
+	fake := &client.Fake{
+		CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{newController("nginx:1", 1, 3)}},
+	}
+	dc := NewDeploymentController(fake)
+	if err := dc.syncDeployment(newDeployment(3, "nginx:2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	creates := findActions(fake, "create-controller")
+	if len(creates) != 1 {
+		t.Fatalf("expected one controller to be created, got %#v", fake.Actions)
+	}
+	controller := creates[0].Value.(*api.ReplicationController)
+	if controller.Spec.Replicas != 1 {
+		t.Errorf("expected the new controller to start with maxSurge replicas, got %d", controller.Spec.Replicas)
+	}
+	if Revision(controller) != 2 {
+		t.Errorf("expected revision 2, got %d", Revision(controller))
+	}
+}
+
+func TestSyncDeploymentRollingUpdate(t *testing.T) {
+	oldController := newController("nginx:1", 1, 3)
+	newController := newController("nginx:2", 2, 1)
+	tests := []struct {
+		name     string
+		old, new int
+		ready    int
+		expected map[string]int
+	}{
+		{
+			name:     "scale down old while keeping replicas-maxUnavailable available",
+			old:      3,
+			new:      1,
+			ready:    3,
+			expected: map[string]int{oldController.Name: 2},
+		},
+		{
+			name:     "scale up new within maxSurge",
+			old:      2,
+			new:      1,
+			ready:    2,
+			expected: map[string]int{newController.Name: 2},
+		},
+		{
+			name:     "no progress while pods are unavailable",
+			old:      2,
+			new:      2,
+			ready:    2,
+			expected: map[string]int{},
+		},
+		{
+			name:     "finish rollout",
+			old:      1,
+			new:      3,
+			ready:    3,
+			expected: map[string]int{oldController.Name: 0},
+		},
+	}
+	for _, test := range tests {
+		oldController.Spec.Replicas = test.old
+		newController.Spec.Replicas = test.new
+		fake := &client.Fake{
+			CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{oldController, newController}},
+			PodsList: api.PodList{Items: newPods(test.ready, true)},
+		}
+		dc := NewDeploymentController(fake)
+		if err := dc.syncDeployment(newDeployment(3, "nginx:2")); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(findActions(fake, "create-controller")) != 0 {
+			t.Errorf("%s: unexpected controller creation", test.name)
+		}
+		updates := controllerUpdates(fake)
+		if len(updates) != len(test.expected) {
+			t.Errorf("%s: expected updates %v, got %v", test.name, test.expected, updates)
+			continue
+		}
+		for name, replicas := range test.expected {
+			if updates[name] != replicas {
+				t.Errorf("%s: expected %s to be scaled to %d, got %v", test.name, name, replicas, updates)
+			}
+		}
+	}
+}
+
+func TestSyncDeploymentRecreate(t *testing.T) {
+	oldController := newController("nginx:1", 1, 3)
+	newController := newController("nginx:2", 2, 0)
+	fake := &client.Fake{
+		CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{oldController, newController}},
+	}
+	deployment := newDeployment(3, "nginx:2")
+	deployment.Spec.Strategy = api.DeploymentStrategy{Type: api.RecreateDeploymentStrategyType}
+	dc := NewDeploymentController(fake)
+	if err := dc.syncDeployment(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updates := controllerUpdates(fake); len(updates) != 1 || updates[oldController.Name] != 0 {
+		t.Errorf("expected only the old controller to be scaled to 0, got %v", updates)
+	}
+
+	oldController.Spec.Replicas = 0
+	fake = &client.Fake{
+		CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{oldController, newController}},
+	}
+	dc = NewDeploymentController(fake)
+	if err := dc.syncDeployment(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updates := controllerUpdates(fake); len(updates) != 1 || updates[newController.Name] != 3 {
+		t.Errorf("expected only the new controller to be scaled to 3, got %v", updates)
+	}
+}
+
+func TestSyncDeploymentPaused(t *testing.T) {
+	fake := &client.Fake{
+		CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{newController("nginx:1", 1, 3)}},
+	}
+	deployment := newDeployment(3, "nginx:2")
+	deployment.Spec.Paused = true
+	dc := NewDeploymentController(fake)
+	if err := dc.syncDeployment(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, action := range fake.Actions {
+		switch action.Action {
+		case "list-controllers", "update-status-deployment":
+		default:
+			t.Errorf("unexpected action for a paused deployment: %#v", action)
+		}
+	}
+	statusUpdates := findActions(fake, "update-status-deployment")
+	if len(statusUpdates) != 1 {
+		t.Fatalf("expected a status update, got %#v", fake.Actions)
+	}
+	if status := statusUpdates[0].Value.(*api.Deployment).Status; status.Replicas != 3 || status.UpdatedReplicas != 0 {
+		t.Errorf("unexpected status %#v", status)
+	}
+}
+
+func TestSyncDeploymentRollback(t *testing.T) {
+	tests := []struct {
+		revision      int64
+		expectedImage string
+	}{
+		{0, "nginx:2"},
+		{1, "nginx:1"},
+		{7, "nginx:3"},
+	}
+	for _, test := range tests {
+		fake := &client.Fake{
+			CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{
+				newController("nginx:1", 1, 0),
+				newController("nginx:2", 2, 0),
+				newController("nginx:3", 3, 3),
+			}},
+		}
+		deployment := newDeployment(3, "nginx:3")
+		deployment.Spec.RollbackTo = &api.RollbackConfig{Revision: test.revision}
+		dc := NewDeploymentController(fake)
+		if err := dc.syncDeployment(deployment); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		updates := findActions(fake, "update-deployment")
+		if len(updates) != 1 {
+			t.Fatalf("expected the deployment to be updated, got %#v", fake.Actions)
+		}
+		updated := updates[0].Value.(*api.Deployment)
+		if updated.Spec.RollbackTo != nil {
+			t.Errorf("expected rollbackTo to be cleared")
+		}
+		if image := updated.Spec.Template.Spec.Containers[0].Image; image != test.expectedImage {
+			t.Errorf("revision %d: expected image %s, got %s", test.revision, test.expectedImage, image)
+		}
+		if _, ok := updated.Spec.Template.Labels[api.DeploymentPodTemplateHashLabel]; ok {
+			t.Errorf("expected the pod template hash label to be removed: %v", updated.Spec.Template.Labels)
+		}
+		if PodTemplateHash(updated.Spec.Template) != PodTemplateHash(newTemplate(test.expectedImage)) {
+			t.Errorf("expected the restored template to match its original revision")
+		}
+	}
+}
+
+func TestSyncDeploymentBumpsReusedRevision(t *testing.T) {
+	fake := &client.Fake{
+		CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{
+			newController("nginx:1", 1, 0),
+			newController("nginx:2", 2, 3),
+		}},
+		PodsList: api.PodList{Items: newPods(3, true)},
+	}
+	dc := NewDeploymentController(fake)
+	if err := dc.syncDeployment(newDeployment(3, "nginx:1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updates := findActions(fake, "update-controller")
+	if len(updates) == 0 {
+		t.Fatalf("expected controller updates, got %#v", fake.Actions)
+	}
+	if revision := Revision(updates[0].Value.(*api.ReplicationController)); revision != 3 {
+		t.Errorf("expected the reused controller to become revision 3, got %d", revision)
+	}
+}
+
+func TestSyncDeploymentCleansUpHistory(t *testing.T) {
+	fake := &client.Fake{
+		CtrlList: api.ReplicationControllerList{Items: []api.ReplicationController{
+			newController("nginx:3", 3, 0),
+			newController("nginx:1", 1, 0),
+			newController("nginx:2", 2, 0),
+			newController("nginx:4", 4, 3),
+		}},
+	}
+	deployment := newDeployment(3, "nginx:4")
+	limit := 1
+	deployment.Spec.RevisionHistoryLimit = &limit
+	dc := NewDeploymentController(fake)
+	if err := dc.syncDeployment(deployment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deletes := findActions(fake, "delete-controller")
+	expected := []string{newController("nginx:1", 1, 0).Name, newController("nginx:2", 2, 0).Name}
+	if len(deletes) != len(expected) {
+		t.Fatalf("expected %v to be deleted, got %#v", expected, deletes)
+	}
+	for i := range expected {
+		if deletes[i].Value.(string) != expected[i] {
+			t.Errorf("expected %s to be deleted, got %v", expected[i], deletes[i].Value)
+		}
+	}
+}
+
+func TestRollingUpdateBounds(t *testing.T) {
+	tests := []struct {
+		replicas                 int
+		maxSurge, maxUnavailable util.IntOrString
+		surge, unavailable       int
+	}{
+		{10, util.NewIntOrStringFromString("25%"), util.NewIntOrStringFromString("25%"), 3, 2},
+		{3, util.NewIntOrStringFromInt(0), util.NewIntOrStringFromInt(5), 0, 3},
+		{3, util.NewIntOrStringFromString("0%"), util.NewIntOrStringFromString("10%"), 0, 1},
+	}
+	for i, test := range tests {
+		deployment := newDeployment(test.replicas, "nginx")
+		deployment.Spec.Strategy.RollingUpdate = &api.RollingUpdateDeployment{MaxSurge: test.maxSurge, MaxUnavailable: test.maxUnavailable}
+		surge, unavailable, err := rollingUpdateBounds(&deployment)
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if surge != test.surge || unavailable != test.unavailable {
+			t.Errorf("case %d: expected (%d, %d), got (%d, %d)", i, test.surge, test.unavailable, surge, unavailable)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/validation"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+	"github.com/golang/glog"
+)
+
+// JobController is responsible for creating the pods of a Job until enough of
+// them have succeeded, and for reporting the progress of the job.
+type JobController struct {
+	kubeClient client.Interface
+	podControl PodControlInterface
+
+	// To allow injection of syncJob for testing.
+	syncHandler func(job api.Job) error
+}
+
+// PodControlInterface is an interface that knows how to add or delete pods
+// created as an interface to allow testing.
+type PodControlInterface interface {
+	// createPod creates a new pod according to the template of the job.
+	createPod(namespace string, job *api.Job) error
+	// deletePod deletes the pod identified by podID.
+	deletePod(namespace string, podID string) error
+}
+
+// RealPodControl is the default implementation of PodControlInterface.
+type RealPodControl struct {
+	kubeClient client.Interface
+}
+
+func (r RealPodControl) createPod(namespace string, job *api.Job) error {
+	desiredLabels := make(labels.Set)
+	for k, v := range job.Spec.Template.Labels {
+		desiredLabels[k] = v
+	}
+	desiredAnnotations := make(labels.Set)
+	for k, v := range job.Spec.Template.Annotations {
+		desiredAnnotations[k] = v
+	}
+
+	// use the dash (if the name isn't too long) to make the pod name a bit prettier
+	prefix := fmt.Sprintf("%s-", job.Name)
+	if ok, _ := validation.ValidatePodName(prefix, true); !ok {
+		prefix = job.Name
+	}
+
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			Labels:       desiredLabels,
+			Annotations:  desiredAnnotations,
+			GenerateName: prefix,
+		},
+	}
+	if err := api.Scheme.Convert(&job.Spec.Template.Spec, &pod.Spec); err != nil {
+		return fmt.Errorf("unable to convert pod template: %v", err)
+	}
+	if labels.Set(pod.Labels).AsSelector().Empty() {
+		return fmt.Errorf("unable to create pod, no labels")
+	}
+	if _, err := r.kubeClient.Pods(namespace).Create(pod); err != nil {
+		return fmt.Errorf("unable to create pod: %v", err)
+	}
+	return nil
+}
+
+func (r RealPodControl) deletePod(namespace, podID string) error {
+	return r.kubeClient.Pods(namespace).Delete(podID)
+}
+
+// NewJobController creates a new JobController.
+func NewJobController(kubeClient client.Interface) *JobController {
+	jc := &JobController{
+		kubeClient: kubeClient,
+		podControl: RealPodControl{
+			kubeClient: kubeClient,
+		},
+	}
+	jc.syncHandler = jc.syncJob
+	return jc
+}
+
+// Run begins syncing jobs every period.
+func (jc *JobController) Run(period time.Duration) {
+	go util.Forever(func() { jc.synchronize() }, period)
+}
+
+func (jc *JobController) synchronize() {
+	list, err := jc.kubeClient.Jobs(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		glog.Errorf("Synchronization error: %v", err)
+		return
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(len(list.Items))
+	for ix := range list.Items {
+		go func(ix int) {
+			defer wg.Done()
+			job := list.Items[ix]
+			glog.V(4).Infof("periodic sync of %v/%v", job.Namespace, job.Name)
+			if err := jc.syncHandler(job); err != nil {
+				glog.Errorf("Error synchronizing job %v/%v: %v", job.Namespace, job.Name, err)
+			}
+		}(ix)
+	}
+	wg.Wait()
+}
+
+// syncJob counts the pods of a job by phase, creates or deletes pods so that
+// the job runs as many pods as it still needs, and records its status.
+func (jc *JobController) syncJob(job api.Job) error {
+	pods, err := jc.kubeClient.Pods(job.Namespace).List(labels.Set(job.Spec.Selector).AsSelector())
+	if err != nil {
+		return err
+	}
+	active := []*api.Pod{}
+	succeeded, failed := 0, 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		switch pod.Status.Phase {
+		case api.PodSucceeded:
+			succeeded++
+		case api.PodFailed:
+			failed++
+		default:
+			active = append(active, pod)
+		}
+	}
+
+	completions := 1
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	parallelism := 1
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+
+	// Never run more pods than are needed to reach the completions target.
+	wantActive := completions - succeeded
+	if wantActive > parallelism {
+		wantActive = parallelism
+	}
+	if wantActive < 0 {
+		wantActive = 0
+	}
+
+	activeCount := len(active)
+	var errs []error
+	if diff := wantActive - activeCount; diff > 0 {
+		glog.V(2).Infof("Creating %d pods for job %s/%s", diff, job.Namespace, job.Name)
+		for i := 0; i < diff; i++ {
+			if err := jc.podControl.createPod(job.Namespace, &job); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			activeCount++
+		}
+	} else if diff < 0 {
+		// Delete pods that have not started running first.
+		toDelete := sortForDeletion(active)[:-diff]
+		glog.V(2).Infof("Deleting %d pods of job %s/%s", len(toDelete), job.Namespace, job.Name)
+		for _, pod := range toDelete {
+			if err := jc.podControl.deletePod(job.Namespace, pod.Name); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			activeCount--
+		}
+	}
+
+	status := job.Status
+	status.Active = activeCount
+	status.Succeeded = succeeded
+	status.Failed = failed
+	now := util.Now()
+	if status.StartTime == nil {
+		status.StartTime = &now
+	}
+	if succeeded >= completions && !isComplete(&job) {
+		status.CompletionTime = &now
+		status.Conditions = append(status.Conditions, api.JobCondition{
+			Type:               api.JobComplete,
+			Status:             api.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             "Completed",
+			Message:            fmt.Sprintf("%d of %d pods completed successfully", succeeded, completions),
+		})
+		glog.V(2).Infof("Job %s/%s is complete", job.Namespace, job.Name)
+	}
+	if !api.Semantic.DeepEqual(status, job.Status) {
+		job.Status = status
+		if _, err := jc.kubeClient.Jobs(job.Namespace).UpdateStatus(&job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// isComplete returns true if the job already reported that it is complete.
+func isComplete(job *api.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == api.JobComplete && c.Status == api.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// sortForDeletion orders pods so that pods which are not running yet come first.
+func sortForDeletion(pods []*api.Pod) []*api.Pod {
+	sorted := make([]*api.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Status.Phase != api.PodRunning {
+			sorted = append(sorted, pod)
+		}
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == api.PodRunning {
+			sorted = append(sorted, pod)
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+type FakePodControl struct {
+	createdJobs   []string
+	deletePodName []string
+	createErr     error
+	lock          sync.Mutex
+}
+
+func (f *FakePodControl) createPod(namespace string, job *api.Job) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.createdJobs = append(f.createdJobs, job.Name)
+	return nil
+}
+
+func (f *FakePodControl) deletePod(namespace string, podName string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deletePodName = append(f.deletePodName, podName)
+	return nil
+}
+
+func newJob(parallelism, completions int) api.Job {
+	return api.Job{
+		ObjectMeta: api.ObjectMeta{Name: "pi", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		Spec: api.JobSpec{
+			Parallelism: &parallelism,
+			Completions: &completions,
+			Selector:    map[string]string{"job": "pi"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"job": "pi"},
+				},
+				Spec: api.PodSpec{
+					RestartPolicy: api.RestartPolicyOnFailure,
+					Containers:    []api.Container{{Name: "pi", Image: "perl"}},
+				},
+			},
+		},
+	}
+}
+
+func newPodList(pending, running, succeeded, failed int) api.PodList {
+	pods := []api.Pod{}
+	add := func(count int, phase api.PodPhase) {
+		for i := 0; i < count; i++ {
+			pods = append(pods, api.Pod{
+				ObjectMeta: api.ObjectMeta{
+					Name:      fmt.Sprintf("pi-%s-%d", phase, i),
+					Namespace: api.NamespaceDefault,
+					Labels:    map[string]string{"job": "pi"},
+				},
+				Status: api.PodStatus{Phase: phase},
+			})
+		}
+	}
+	add(pending, api.PodPending)
+	add(running, api.PodRunning)
+	add(succeeded, api.PodSucceeded)
+	add(failed, api.PodFailed)
+	return api.PodList{Items: pods}
+}
+
+func TestSyncJob(t *testing.T) {
+	tests := map[string]struct {
+		parallelism, completions            int
+		pending, running, succeeded, failed int
+
+		expectedCreates   int
+		expectedDeletes   []string
+		expectedActive    int
+		expectedComplete  bool
+		expectedSucceeded int
+		expectedFailed    int
+	}{
+		"new job": {
+			parallelism: 2, completions: 5,
+			expectedCreates: 2, expectedActive: 2,
+		},
+		"running within parallelism": {
+			parallelism: 2, completions: 5,
+			running: 2, succeeded: 1,
+			expectedActive: 2, expectedSucceeded: 1,
+		},
+		"replace failed pods": {
+			parallelism: 2, completions: 5,
+			running: 1, succeeded: 1, failed: 2,
+			expectedCreates: 1, expectedActive: 2, expectedSucceeded: 1, expectedFailed: 2,
+		},
+		"do not overshoot completions": {
+			parallelism: 3, completions: 5,
+			running: 1, succeeded: 3,
+			expectedCreates: 1, expectedActive: 2, expectedSucceeded: 3,
+		},
+		"parallelism lowered deletes pending pods first": {
+			parallelism: 1, completions: 5,
+			pending: 1, running: 1,
+			expectedDeletes: []string{"pi-Pending-0"}, expectedActive: 1,
+		},
+		"complete": {
+			parallelism: 2, completions: 3,
+			succeeded: 3, failed: 1,
+			expectedActive: 0, expectedComplete: true, expectedSucceeded: 3, expectedFailed: 1,
+		},
+		"complete with leftover pods": {
+			parallelism: 2, completions: 3,
+			running: 1, succeeded: 3,
+			expectedDeletes: []string{"pi-Running-0"}, expectedActive: 0, expectedComplete: true, expectedSucceeded: 3,
+		},
+	}
+	for name, test := range tests {
+		fakeClient := &client.Fake{PodsList: newPodList(test.pending, test.running, test.succeeded, test.failed)}
+		fakePodControl := &FakePodControl{}
+		controller := NewJobController(fakeClient)
+		controller.podControl = fakePodControl
+
+		if err := controller.syncJob(newJob(test.parallelism, test.completions)); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if len(fakePodControl.createdJobs) != test.expectedCreates {
+			t.Errorf("%s: expected %d creates, got %d", name, test.expectedCreates, len(fakePodControl.createdJobs))
+		}
+		if len(fakePodControl.deletePodName) != len(test.expectedDeletes) {
+			t.Errorf("%s: expected deletes %v, got %v", name, test.expectedDeletes, fakePodControl.deletePodName)
+		} else {
+			for i := range test.expectedDeletes {
+				if fakePodControl.deletePodName[i] != test.expectedDeletes[i] {
+					t.Errorf("%s: expected deletes %v, got %v", name, test.expectedDeletes, fakePodControl.deletePodName)
+				}
+			}
+		}
+
+		var status *api.JobStatus
+		for _, action := range fakeClient.Actions {
+			if action.Action == "update-status-job" {
+				status = &action.Value.(*api.Job).Status
+			}
+		}
+		if status == nil {
+			t.Errorf("%s: expected a status update", name)
+			continue
+		}
+		if status.Active != test.expectedActive || status.Succeeded != test.expectedSucceeded || status.Failed != test.expectedFailed {
+			t.Errorf("%s: unexpected status %#v", name, status)
+		}
+		if status.StartTime == nil {
+			t.Errorf("%s: expected the start time to be set", name)
+		}
+		complete := len(status.Conditions) == 1 && status.Conditions[0].Type == api.JobComplete && status.Conditions[0].Status == api.ConditionTrue
+		if complete != test.expectedComplete {
+			t.Errorf("%s: expected complete=%v, got conditions %#v", name, test.expectedComplete, status.Conditions)
+		}
+		if complete && status.CompletionTime == nil {
+			t.Errorf("%s: expected the completion time to be set", name)
+		}
+	}
+}
+
+func TestSyncJobCompleteIsStable(t *testing.T) {
+	fakeClient := &client.Fake{PodsList: newPodList(0, 0, 3, 0)}
+	controller := NewJobController(fakeClient)
+	controller.podControl = &FakePodControl{}
+
+	job := newJob(1, 3)
+	if err := controller.syncJob(job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fakeClient.Actions) != 2 || fakeClient.Actions[1].Action != "update-status-job" {
+		t.Fatalf("expected a status update, got %#v", fakeClient.Actions)
+	}
+	job.Status = fakeClient.Actions[1].Value.(*api.Job).Status
+
+	fakeClient.Actions = nil
+	if err := controller.syncJob(job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, action := range fakeClient.Actions {
+		if action.Action == "update-status-job" {
+			t.Errorf("unexpected status update of a completed job: %#v", action.Value)
+		}
+	}
+}
+
+func TestSyncJobCreateError(t *testing.T) {
+	fakeClient := &client.Fake{}
+	controller := NewJobController(fakeClient)
+	controller.podControl = &FakePodControl{createErr: fmt.Errorf("quota exceeded")}
+
+	if err := controller.syncJob(newJob(2, 2)); err == nil {
+		t.Errorf("expected an error when pods can not be created")
+	}
+	for _, action := range fakeClient.Actions {
+		if action.Action == "update-status-job" && action.Value.(*api.Job).Status.Active != 0 {
+			t.Errorf("expected no active pods to be recorded, got %#v", action.Value.(*api.Job).Status)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/meta"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+)
+
+// LastAppliedConfigAnnotation is the annotation in which kubectl apply records
+// the configuration it last applied to an object.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// GetOriginalConfiguration returns the configuration last applied to obj, or
+// nil if obj was not created or updated by apply.
+func GetOriginalConfiguration(accessor meta.MetadataAccessor, obj runtime.Object) ([]byte, error) {
+	annotations, err := accessor.Annotations(obj)
+	if err != nil {
+		return nil, err
+	}
+	original, ok := annotations[LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(original), nil
+}
+
+// GetModifiedConfiguration returns obj encoded with codec, with that encoding
+// (minus any previously recorded configuration) recorded in its
+// LastAppliedConfigAnnotation.  obj is left unchanged.
+func GetModifiedConfiguration(accessor meta.MetadataAccessor, codec runtime.Codec, obj runtime.Object) ([]byte, error) {
+	annotations, err := accessor.Annotations(obj)
+	if err != nil {
+		return nil, err
+	}
+	defer accessor.SetAnnotations(obj, annotations)
+
+	withoutConfig := map[string]string{}
+	for k, v := range annotations {
+		if k != LastAppliedConfigAnnotation {
+			withoutConfig[k] = v
+		}
+	}
+	if len(withoutConfig) == 0 {
+		withoutConfig = nil
+	}
+	if err := accessor.SetAnnotations(obj, withoutConfig); err != nil {
+		return nil, err
+	}
+	config, err := codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	withConfig := map[string]string{LastAppliedConfigAnnotation: string(config)}
+	for k, v := range withoutConfig {
+		withConfig[k] = v
+	}
+	if err := accessor.SetAnnotations(obj, withConfig); err != nil {
+		return nil, err
+	}
+	return codec.Encode(obj)
+}
+
+// CreateThreeWayMergePatch returns a JSON merge patch (RFC 7386) that turns
+// current into modified, while preserving the fields of current that are not
+// mentioned in original or modified.  Fields set in original but absent from
+// modified are deleted, fields of modified that differ from current are set,
+// and everything else, such as fields set by the server or by other clients,
+// is left alone.  original may be nil, in which case nothing is deleted.
+// Objects are merged field by field, but lists are replaced as a whole, as
+// merge patches require.
+func CreateThreeWayMergePatch(original, modified, current []byte) ([]byte, error) {
+	originalMap := map[string]interface{}{}
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &originalMap); err != nil {
+			return nil, fmt.Errorf("unable to parse the last applied configuration: %v", err)
+		}
+	}
+	modifiedMap := map[string]interface{}{}
+	if err := json.Unmarshal(modified, &modifiedMap); err != nil {
+		return nil, fmt.Errorf("unable to parse the new configuration: %v", err)
+	}
+	currentMap := map[string]interface{}{}
+	if err := json.Unmarshal(current, &currentMap); err != nil {
+		return nil, fmt.Errorf("unable to parse the current object: %v", err)
+	}
+	return json.Marshal(threeWayMergePatch(originalMap, modifiedMap, currentMap))
+}
+
+func threeWayMergePatch(original, modified, current map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key, modifiedValue := range modified {
+		// a null in the configuration is the same as an absent field
+		if modifiedValue == nil {
+			continue
+		}
+		currentValue, inCurrent := current[key]
+		modifiedMap, modifiedIsMap := modifiedValue.(map[string]interface{})
+		currentMap, currentIsMap := currentValue.(map[string]interface{})
+		if modifiedIsMap && currentIsMap {
+			originalMap, _ := original[key].(map[string]interface{})
+			if nested := threeWayMergePatch(originalMap, modifiedMap, currentMap); len(nested) > 0 {
+				patch[key] = nested
+			}
+			continue
+		}
+		if !inCurrent || !reflect.DeepEqual(modifiedValue, currentValue) {
+			patch[key] = modifiedValue
+		}
+	}
+	for key, originalValue := range original {
+		if originalValue == nil {
+			continue
+		}
+		if modifiedValue, inModified := modified[key]; inModified && modifiedValue != nil {
+			continue
+		}
+		if currentValue, inCurrent := current[key]; inCurrent && currentValue != nil {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/meta"
+)
+
+func TestCreateThreeWayMergePatch(t *testing.T) {
+	testCases := map[string]struct {
+		original string
+		modified string
+		current  string
+		expected string
+	}{
+		"no changes": {
+			original: `{"a":1,"b":{"c":"d"}}`,
+			modified: `{"a":1,"b":{"c":"d"}}`,
+			current:  `{"a":1,"b":{"c":"d"},"status":"running"}`,
+			expected: `{}`,
+		},
+		"changed field": {
+			original: `{"a":1}`,
+			modified: `{"a":2}`,
+			current:  `{"a":1,"status":"running"}`,
+			expected: `{"a":2}`,
+		},
+		"field changed by another client is reverted": {
+			original: `{"a":1}`,
+			modified: `{"a":1}`,
+			current:  `{"a":3}`,
+			expected: `{"a":1}`,
+		},
+		"field removed from the configuration": {
+			original: `{"a":1,"b":2}`,
+			modified: `{"a":1}`,
+			current:  `{"a":1,"b":2,"c":3}`,
+			expected: `{"b":null}`,
+		},
+		"field removed from the configuration and the object": {
+			original: `{"a":1,"b":2}`,
+			modified: `{"a":1}`,
+			current:  `{"a":1}`,
+			expected: `{}`,
+		},
+		"nested fields": {
+			original: `{"metadata":{"labels":{"a":"1","b":"2"}}}`,
+			modified: `{"metadata":{"labels":{"a":"1","c":"3"}}}`,
+			current:  `{"metadata":{"labels":{"a":"1","b":"2","d":"4"},"resourceVersion":"10"}}`,
+			expected: `{"metadata":{"labels":{"b":null,"c":"3"}}}`,
+		},
+		"new nested object": {
+			modified: `{"spec":{"a":{"b":1}}}`,
+			current:  `{"spec":{"c":2}}`,
+			expected: `{"spec":{"a":{"b":1}}}`,
+		},
+		"lists are replaced": {
+			original: `{"ports":[1,2]}`,
+			modified: `{"ports":[1,3]}`,
+			current:  `{"ports":[1,2]}`,
+			expected: `{"ports":[1,3]}`,
+		},
+		"no original configuration": {
+			modified: `{"a":1}`,
+			current:  `{"a":1,"b":2}`,
+			expected: `{}`,
+		},
+		"nulls in the configuration are ignored": {
+			original: `{"a":1,"b":null}`,
+			modified: `{"a":1,"c":null}`,
+			current:  `{"a":1,"c":"set by server"}`,
+			expected: `{}`,
+		},
+	}
+	for k, testCase := range testCases {
+		var original []byte
+		if len(testCase.original) > 0 {
+			original = []byte(testCase.original)
+		}
+		patch, err := CreateThreeWayMergePatch(original, []byte(testCase.modified), []byte(testCase.current))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		var actual, expected interface{}
+		if err := json.Unmarshal(patch, &actual); err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		json.Unmarshal([]byte(testCase.expected), &expected)
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected patch %s, got %s", k, testCase.expected, string(patch))
+		}
+	}
+
+	if _, err := CreateThreeWayMergePatch([]byte("{"), []byte("{}"), []byte("{}")); err == nil {
+		t.Errorf("expected error for an invalid original configuration")
+	}
+}
+
+func TestModifiedConfiguration(t *testing.T) {
+	pod := &api.Pod{
+		TypeMeta: api.TypeMeta{Kind: "Pod", APIVersion: latest.Version},
+		ObjectMeta: api.ObjectMeta{
+			Name: "foo",
+			Annotations: map[string]string{
+				"other":                     "value",
+				LastAppliedConfigAnnotation: "stale",
+			},
+		},
+	}
+	accessor := meta.NewAccessor()
+	modified, err := GetModifiedConfiguration(accessor, latest.Codec, pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Annotations[LastAppliedConfigAnnotation] != "stale" || len(pod.Annotations) != 2 {
+		t.Errorf("expected the object to be left unchanged: %v", pod.Annotations)
+	}
+
+	obj, err := latest.Codec.Decode(modified)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	original, err := GetOriginalConfiguration(accessor, obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorded, err := latest.Codec.Decode(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo", Annotations: map[string]string{"other": "value"}}}
+	if recorded.(*api.Pod).Name != expected.Name || !reflect.DeepEqual(recorded.(*api.Pod).Annotations, expected.Annotations) {
+		t.Errorf("expected the recorded configuration to omit itself, got %#v", recorded)
+	}
+	if obj.(*api.Pod).Annotations["other"] != "value" {
+		t.Errorf("expected other annotations to be kept: %v", obj.(*api.Pod).Annotations)
+	}
+
+	original, err = GetOriginalConfiguration(accessor, &api.Pod{})
+	if err != nil || original != nil {
+		t.Errorf("expected no original configuration, got %q, %v", string(original), err)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl"
+	cmdutil "github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	apply_long = `Apply a configuration to a resource by filename or stdin.
+
+The resource is created if it doesn't exist yet. Otherwise only the fields that
+changed since the configuration was last applied are updated, so fields set by
+the server or by other clients are preserved, and fields that were removed from
+the configuration are removed from the resource. The applied configuration is
+recorded in the resource's kubectl.kubernetes.io/last-applied-configuration
+annotation.
+
+JSON and YAML formats are accepted.`
+	apply_example = `// Apply the configuration in pod.json to a pod.
+$ kubectl apply -f pod.json
+
+// Apply the configurations of all the resources in a directory.
+$ kubectl apply -f ./manifests
+
+// Apply the JSON passed into stdin to a pod.
+$ cat pod.json | kubectl apply -f -`
+)
+
+func (f *Factory) NewCmdApply(out io.Writer) *cobra.Command {
+	var filenames util.StringList
+	cmd := &cobra.Command{
+		Use:     "apply -f FILENAME",
+		Short:   "Apply a configuration to a resource by filename or stdin",
+		Long:    apply_long,
+		Example: apply_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(ValidateArgs(cmd, args))
+			cmdutil.CheckErr(RunApply(f, out, cmd, filenames))
+		},
+	}
+	cmd.Flags().VarP(&filenames, "filename", "f", "Filename, directory, or URL to file that contains the configuration to apply")
+	return cmd
+}
+
+func RunApply(f *Factory, out io.Writer, cmd *cobra.Command, filenames util.StringList) error {
+	if len(filenames) == 0 {
+		return cmdutil.UsageError(cmd, "Must specify --filename to apply")
+	}
+
+	schema, err := f.Validator()
+	if err != nil {
+		return err
+	}
+
+	cmdNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).RequireNamespace().
+		FilenameParam(filenames...).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = r.Visit(func(info *resource.Info) error {
+		data, err := info.Mapping.Codec.Encode(info.Object)
+		if err != nil {
+			return err
+		}
+		if err := schema.ValidateBytes(data); err != nil {
+			return err
+		}
+
+		// the configuration to apply, recording itself as the last applied configuration
+		modified, err := kubectl.GetModifiedConfiguration(info.Mapping.MetadataAccessor, info.Mapping.Codec, info.Object)
+		if err != nil {
+			return err
+		}
+
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		current, err := helper.Get(info.Namespace, info.Name)
+		if errors.IsNotFound(err) {
+			obj, err := helper.Create(info.Namespace, true, modified)
+			if err != nil {
+				return err
+			}
+			count++
+			info.Refresh(obj, true)
+			fmt.Fprintf(out, "%s/%s\n", info.Mapping.Resource, info.Name)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		original, err := kubectl.GetOriginalConfiguration(info.Mapping.MetadataAccessor, current)
+		if err != nil {
+			return err
+		}
+		currentData, err := info.Mapping.Codec.Encode(current)
+		if err != nil {
+			return err
+		}
+		patch, err := kubectl.CreateThreeWayMergePatch(original, modified, currentData)
+		if err != nil {
+			return fmt.Errorf("unable to compute the changes to %s/%s: %v", info.Mapping.Resource, info.Name, err)
+		}
+		obj, err := helper.Patch(info.Namespace, info.Name, api.MergePatchType, patch)
+		if err != nil {
+			return err
+		}
+		count++
+		info.Refresh(obj, true)
+		fmt.Fprintf(out, "%s/%s\n", info.Mapping.Resource, info.Name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("no objects passed to apply")
+	}
+	return nil
+}
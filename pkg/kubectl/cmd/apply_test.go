@@ -0,0 +1,146 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl"
+	"github.com/evanphx/json-patch"
+)
+
+func TestApplyObject(t *testing.T) {
+	_, _, rc := testData()
+	current := rc.Items[0]
+	current.Name = "redis-master-controller"
+	current.Labels = map[string]string{"name": "redis-master", "removed": "true", "set-by-other": "true"}
+	current.Annotations = map[string]string{
+		kubectl.LastAppliedConfigAnnotation: `{"kind":"ReplicationController","apiVersion":"v1beta1","id":"redis-master-controller","labels":{"name":"redis-master","removed":"true"}}`,
+	}
+
+	var patch []byte
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master-controller" && m == "GET":
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &current)}, nil
+			case p == "/namespaces/test/replicationcontrollers/redis-master-controller" && m == "PATCH":
+				if contentType := req.Header.Get("Content-Type"); contentType != string(api.MergePatchType) {
+					t.Errorf("unexpected patch type: %s", contentType)
+				}
+				patch, _ = ioutil.ReadAll(req.Body)
+				return &http.Response{StatusCode: 200, Body: objBody(codec, &rc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := f.NewCmdApply(buf)
+	cmd.Flags().Set("filename", "../../../examples/guestbook/redis-master-controller.json")
+	cmd.Run(cmd, []string{})
+
+	// uses the name from the response, as update does
+	if buf.String() != "replicationControllers/rc1\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	// the patch is computed against the object in the version of the file
+	i, err := latest.InterfacesFor("v1beta1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	currentData, err := i.Codec.Encode(&current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	patchedData, err := jsonpatch.MergePatch(currentData, patch)
+	if err != nil {
+		t.Fatalf("unexpected error applying patch %s: %v", string(patch), err)
+	}
+	obj, err := latest.Codec.Decode(patchedData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	patched := obj.(*api.ReplicationController)
+	if _, ok := patched.Labels["removed"]; ok {
+		t.Errorf("expected the label removed from the configuration to be deleted: %v", patched.Labels)
+	}
+	if patched.Labels["set-by-other"] != "true" || patched.Labels["name"] != "redis-master" {
+		t.Errorf("expected labels from the file and from other clients to be kept: %v", patched.Labels)
+	}
+	if patched.Spec.Replicas != 1 {
+		t.Errorf("expected the replica count from the file, got %d", patched.Spec.Replicas)
+	}
+	applied := patched.Annotations[kubectl.LastAppliedConfigAnnotation]
+	if !strings.Contains(applied, `"replicas":1`) || strings.Contains(applied, "removed") {
+		t.Errorf("expected the applied configuration to be recorded, got %s", applied)
+	}
+}
+
+func TestApplyCreatesMissingObject(t *testing.T) {
+	_, _, rc := testData()
+
+	var created []byte
+	f, tf, codec := NewAPIFactory()
+	tf.Printer = &testPrinter{}
+	tf.Client = &client.FakeRESTClient{
+		Codec: codec,
+		Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/namespaces/test/replicationcontrollers/redis-master-controller" && m == "GET":
+				return &http.Response{StatusCode: 404, Body: stringBody("")}, nil
+			case p == "/namespaces/test/replicationcontrollers" && m == "POST":
+				created, _ = ioutil.ReadAll(req.Body)
+				return &http.Response{StatusCode: 201, Body: objBody(codec, &rc.Items[0])}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	tf.Namespace = "test"
+	buf := bytes.NewBuffer([]byte{})
+
+	cmd := f.NewCmdApply(buf)
+	cmd.Flags().Set("filename", "../../../examples/guestbook/redis-master-controller.json")
+	cmd.Run(cmd, []string{})
+
+	if buf.String() != "replicationControllers/rc1\n" {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+	obj, err := latest.Codec.Decode(created)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := obj.(*api.ReplicationController).Annotations[kubectl.LastAppliedConfigAnnotation]; !ok {
+		t.Errorf("expected the applied configuration to be recorded on create: %v", obj.(*api.ReplicationController).Annotations)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/remotecommand"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	attach_long = `Attach to a process that is already running inside an existing container.
+
+Unlike exec, attach does not start a new process: the output of the main process of
+the container is streamed back to the client, and with -i stdin is sent to it. The
+container must set stdin to true in its spec to accept input, and tty to true to use
+-t.`
+	attach_example = `// get output from the running main process of the first container of pod 123456-7890
+$ kubectl attach 123456-7890
+
+// switch to raw terminal mode, sends stdin to the main process of ruby-container from pod 123456-7890 and sends its output back to the client
+$ kubectl attach 123456-7890 -c ruby-container -i -t`
+)
+
+func (f *Factory) NewCmdAttach(cmdIn io.Reader, cmdOut, cmdErr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "attach POD -c CONTAINER",
+		Short:   "Attach to a running container.",
+		Long:    attach_long,
+		Example: attach_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunAttach(f, cmdIn, cmdOut, cmdErr, cmd, args)
+			util.CheckErr(err)
+		},
+	}
+	// TODO support UID
+	cmd.Flags().StringP("container", "c", "", "Container name")
+	cmd.Flags().BoolP("stdin", "i", false, "Pass stdin to the container")
+	cmd.Flags().BoolP("tty", "t", false, "Stdin is a TTY")
+	return cmd
+}
+
+func RunAttach(f *Factory, cmdIn io.Reader, cmdOut, cmdErr io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return util.UsageError(cmd, "POD is required for attach")
+	}
+	podName := args[0]
+
+	namespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	pod, err := client.Pods(namespace).Get(podName)
+	if err != nil {
+		return err
+	}
+
+	if pod.Status.Phase != api.PodRunning {
+		return fmt.Errorf("unable to attach to pod %s because it is not running, current status=%v", podName, pod.Status.Phase)
+	}
+
+	containerName := util.GetFlagString(cmd, "container")
+	if len(containerName) == 0 {
+		containerName = pod.Spec.Containers[0].Name
+	}
+
+	var stdin io.Reader
+	tty := util.GetFlagBool(cmd, "tty")
+	if util.GetFlagBool(cmd, "stdin") {
+		stdin = cmdIn
+		if tty {
+			restore, ok := setRawTerminal(cmdIn)
+			if !ok {
+				tty = false
+				glog.Warning("Unable to use a TTY")
+			}
+			// this handles a clean exit, where the container's process finished
+			defer restore()
+		}
+	}
+
+	config, err := f.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	req := client.RESTClient.Get().
+		Prefix("proxy").
+		Resource("minions").
+		Name(pod.Status.Host).
+		Suffix("attach", namespace, podName, containerName)
+
+	e := remotecommand.NewAttach(req, config, stdin, cmdOut, cmdErr, tty)
+	return e.Execute()
+}
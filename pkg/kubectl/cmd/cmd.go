@@ -244,14 +244,22 @@ Find more information at https://github.com/GoogleCloudPlatform/kubernetes.`,
 	cmds.AddCommand(f.NewCmdDescribe(out))
 	cmds.AddCommand(f.NewCmdCreate(out))
 	cmds.AddCommand(f.NewCmdUpdate(out))
+	cmds.AddCommand(f.NewCmdApply(out))
+	cmds.AddCommand(f.NewCmdEdit(out))
+	cmds.AddCommand(f.NewCmdPatch(out))
 	cmds.AddCommand(f.NewCmdDelete(out))
 
 	cmds.AddCommand(NewCmdNamespace(out))
 	cmds.AddCommand(f.NewCmdLog(out))
 	cmds.AddCommand(f.NewCmdRollingUpdate(out))
 	cmds.AddCommand(f.NewCmdResize(out))
+	cmds.AddCommand(f.NewCmdRollout(out))
+	cmds.AddCommand(f.NewCmdCordon(out))
+	cmds.AddCommand(f.NewCmdUncordon(out))
+	cmds.AddCommand(f.NewCmdDrain(out))
 
 	cmds.AddCommand(f.NewCmdExec(in, out, err))
+	cmds.AddCommand(f.NewCmdAttach(in, out, err))
 	cmds.AddCommand(f.NewCmdPortForward())
 	cmds.AddCommand(f.NewCmdProxy(out))
 
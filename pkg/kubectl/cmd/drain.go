@@ -0,0 +1,139 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cordon_long = `Mark a node as unschedulable.
+
+New pods will not be scheduled onto the node. Pods already running on the node are not affected.`
+	cordon_example = `// Mark node 'foo' as unschedulable.
+$ kubectl cordon foo`
+	uncordon_long = `Mark a node as schedulable.
+
+Reverts a previous cordon or drain of the node.`
+	uncordon_example = `// Mark node 'foo' as schedulable.
+$ kubectl uncordon foo`
+	drain_long = `Drain a node in preparation for maintenance.
+
+The node is marked unschedulable and the pods bound to it are deleted. Mirror pods of
+static pods are skipped, since they cannot be deleted through the API server, and so are
+pods of daemon sets, which are meant to run on every node. If there are pods that are
+not managed by a replication controller or a job, drain refuses to delete any pods
+unless --force is given, because those pods are not recreated elsewhere.
+
+Drain waits until the replication controllers of the deleted pods have their replicas
+running on other nodes. Once the maintenance is done, use 'kubectl uncordon' to make
+the node schedulable again.`
+	drain_example = `// Drain node 'foo', even if there are pods not managed by a replication controller or a job on it.
+$ kubectl drain foo --force`
+)
+
+func (f *Factory) NewCmdCordon(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cordon NODE",
+		Short:   "Mark a node as unschedulable.",
+		Long:    cordon_long,
+		Example: cordon_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunSetSchedulable(f, out, cmd, args, false)
+			util.CheckErr(err)
+		},
+	}
+}
+
+func (f *Factory) NewCmdUncordon(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "uncordon NODE",
+		Short:   "Mark a node as schedulable.",
+		Long:    uncordon_long,
+		Example: uncordon_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunSetSchedulable(f, out, cmd, args, true)
+			util.CheckErr(err)
+		},
+	}
+}
+
+func (f *Factory) NewCmdDrain(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "drain NODE [--force] [--timeout=DURATION]",
+		Short:   "Drain a node in preparation for maintenance.",
+		Long:    drain_long,
+		Example: drain_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunDrain(f, out, cmd, args)
+			util.CheckErr(err)
+		},
+	}
+	cmd.Flags().Bool("force", false, "Delete pods that are not managed by a replication controller or a job.")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "The length of time to wait for the deleted pods to be replaced on other nodes.")
+	return cmd
+}
+
+// RunSetSchedulable marks the node named in args schedulable or unschedulable.
+func RunSetSchedulable(f *Factory, out io.Writer, cmd *cobra.Command, args []string, schedulable bool) error {
+	if len(args) != 1 {
+		return util.UsageError(cmd, "NODE is required")
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	changed, err := kubectl.SetNodeSchedulable(client, args[0], schedulable)
+	if err != nil {
+		return err
+	}
+	verb := "cordoned"
+	if schedulable {
+		verb = "uncordoned"
+	}
+	if !changed {
+		verb = "already " + verb
+	}
+	fmt.Fprintf(out, "%s %s\n", args[0], verb)
+	return nil
+}
+
+func RunDrain(f *Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return util.UsageError(cmd, "NODE is required")
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	drainer := kubectl.NewNodeDrainer(client, util.GetFlagBool(cmd, "force"), util.GetFlagDuration(cmd, "timeout"))
+	pods, err := drainer.Drain(args[0])
+	for _, pod := range pods {
+		fmt.Fprintf(out, "pods/%s deleted\n", pod.Name)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s drained\n", args[0])
+	return nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/validation"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl"
+	cmdutil "github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util/editor"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	edit_long = `Edit a resource from the default editor.
+
+The edit command allows you to directly edit any API resource you can retrieve via the
+command line tools. It will open the editor defined by your KUBE_EDITOR or EDITOR
+environment variables, or fall back to 'vi'. You can edit multiple objects, which are
+opened one after the other. The resources are shown in YAML unless --output=json is
+given, in the version given by --output-version (default api-version).
+
+When the editor is closed, the edited resource is validated and updated with the
+resource version it was fetched with, so the update fails if someone else changed
+the resource in the meantime. If an error occurs, the editor is reopened with the
+error as a comment at the top of the file. Exiting the editor without changes
+cancels the edit.`
+	edit_example = `// Edit the service named 'docker-registry':
+$ kubectl edit svc/docker-registry
+
+// Use an alternative editor
+$ KUBE_EDITOR="nano" kubectl edit svc/docker-registry
+
+// Edit the replication controller 'frontend' in JSON using the v1beta3 API format:
+$ kubectl edit rc/frontend --output-version=v1beta3 -o json`
+)
+
+func (f *Factory) NewCmdEdit(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "edit (RESOURCE/NAME | RESOURCE NAME ...)",
+		Short:   "Edit a resource on the server",
+		Long:    edit_long,
+		Example: edit_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunEdit(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringP("output", "o", "yaml", "Output format. One of: yaml|json.")
+	cmd.Flags().String("output-version", "", "Output the formatted object with the given version (default api-version).")
+	cmd.Flags().StringP("selector", "l", "", "Selector (label query) to filter on")
+	return cmd
+}
+
+func RunEdit(f *Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	var ext string
+	switch format := cmdutil.GetFlagString(cmd, "output"); format {
+	case "yaml":
+		ext = ".yaml"
+	case "json":
+		ext = ".json"
+	default:
+		return cmdutil.UsageError(cmd, "The flag 'output' must be one of yaml|json")
+	}
+
+	cmdNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	clientConfig, err := f.ClientConfig()
+	if err != nil {
+		return err
+	}
+	schema, err := f.Validator()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	infos, err := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		SelectorParam(cmdutil.GetFlagString(cmd, "selector")).
+		ResourceTypeOrNameArgs(false, args...).
+		Latest().
+		Flatten().
+		Do().
+		Infos()
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("no resources found to edit")
+	}
+
+	edit := editor.NewDefaultEditor("KUBE_EDITOR", "EDITOR")
+	for _, info := range infos {
+		printer, _, err := kubectl.GetPrinter(cmdutil.GetFlagString(cmd, "output"), "")
+		if err != nil {
+			return err
+		}
+		version := cmdutil.OutputVersion(cmd, clientConfig.Version)
+		if len(version) == 0 {
+			version = info.Mapping.APIVersion
+		}
+		printer = kubectl.NewVersionedPrinter(printer, info.Mapping.ObjectConvertor, version)
+
+		original := &bytes.Buffer{}
+		if err := printer.PrintObj(info.Object, original); err != nil {
+			return err
+		}
+		if err := editObject(edit, info, original.Bytes(), ext, schema, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// editObject opens the printed object in the editor until the edited object
+// is successfully updated or the edit is cancelled.
+func editObject(edit editor.Editor, info *resource.Info, original []byte, ext string, schema validation.Schema, out io.Writer) error {
+	resourceVersion, err := info.Mapping.MetadataAccessor.ResourceVersion(info.Object)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	shown := original
+	for {
+		buf := &bytes.Buffer{}
+		writeEditHeader(buf, info, lastErr)
+		buf.Write(shown)
+
+		edited, path, err := edit.LaunchTempFile("kubectl-edit-", ext, buf)
+		if len(path) > 0 {
+			os.Remove(path)
+		}
+		if err != nil {
+			return err
+		}
+		edited = stripComments(edited)
+
+		if len(bytes.TrimSpace(edited)) == 0 {
+			fmt.Fprintf(out, "Edit cancelled, saved file was empty.\n")
+			return nil
+		}
+		if bytes.Equal(bytes.TrimSpace(edited), bytes.TrimSpace(shown)) {
+			if lastErr != nil {
+				return fmt.Errorf("edit of %s/%s cancelled: %v", info.Mapping.Resource, info.Name, lastErr)
+			}
+			fmt.Fprintf(out, "Edit cancelled, no changes made.\n")
+			return nil
+		}
+		shown = edited
+
+		obj, err := updateEditedObject(info, edited, resourceVersion, schema)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		info.Refresh(obj, true)
+		fmt.Fprintf(out, "%s/%s\n", info.Mapping.Resource, info.Name)
+		return nil
+	}
+}
+
+// updateEditedObject validates the edited object and updates the server
+// with it, as of the resource version the object was fetched with.
+func updateEditedObject(info *resource.Info, edited []byte, resourceVersion string, schema validation.Schema) (runtime.Object, error) {
+	data, err := yaml.ToJSON(edited)
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.ValidateBytes(data); err != nil {
+		return nil, err
+	}
+	if _, kind, err := api.Scheme.DataVersionAndKind(data); err != nil {
+		return nil, err
+	} else if kind != info.Mapping.Kind {
+		return nil, fmt.Errorf("the kind of the object may not be changed from %q to %q", info.Mapping.Kind, kind)
+	}
+	obj, err := info.Mapping.Codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	accessor := info.Mapping.MetadataAccessor
+	if name, err := accessor.Name(obj); err != nil {
+		return nil, err
+	} else if name != info.Name {
+		return nil, fmt.Errorf("the name of the object may not be changed from %q to %q", info.Name, name)
+	}
+	if err := accessor.SetResourceVersion(obj, resourceVersion); err != nil {
+		return nil, err
+	}
+	data, err = info.Mapping.Codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewHelper(info.Client, info.Mapping).Update(info.Namespace, info.Name, false, data)
+}
+
+// writeEditHeader writes the comment that explains the edited file, and the
+// error of the last attempt to save it, if any.
+func writeEditHeader(w io.Writer, info *resource.Info, lastErr error) {
+	fmt.Fprintf(w, "# Please edit the object below. Lines beginning with a '#' will be ignored,\n")
+	fmt.Fprintf(w, "# and an empty file will abort the edit. If an error occurs while saving this file will be\n")
+	fmt.Fprintf(w, "# reopened with the relevant failures.\n")
+	fmt.Fprintf(w, "#\n")
+	if lastErr != nil {
+		fmt.Fprintf(w, "# The edited %s %q could not be saved:\n", info.Mapping.Kind, info.Name)
+		for _, line := range strings.Split(lastErr.Error(), "\n") {
+			fmt.Fprintf(w, "# %s\n", line)
+		}
+		fmt.Fprintf(w, "#\n")
+	}
+}
+
+// stripComments removes the comment lines at the start of data, which
+// include the header written by writeEditHeader.  Comments elsewhere are
+// left alone, since in YAML a '#' may start a line of a multi-line string.
+func stripComments(data []byte) []byte {
+	for len(data) > 0 && bytes.HasPrefix(bytes.TrimLeft(data, " \t"), []byte("#")) {
+		i := bytes.IndexByte(data, '\n')
+		if i == -1 {
+			return nil
+		}
+		data = data[i+1:]
+	}
+	return data
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+// setEditor makes the edit command run script as the editor.
+func setEditor(t *testing.T, dir, script string) func() {
+	path := filepath.Join(dir, "editor.sh")
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	old := os.Getenv("KUBE_EDITOR")
+	os.Setenv("KUBE_EDITOR", path)
+	return func() { os.Setenv("KUBE_EDITOR", old) }
+}
+
+func TestEditObject(t *testing.T) {
+	_, _, rc := testData()
+	dir, err := ioutil.TempDir("", "edit-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := map[string]struct {
+		script    string
+		putErrors []error
+
+		expectPuts     int
+		expectReplicas int
+		expectOutput   string
+		expectErr      string
+		expectHeader   string
+	}{
+		"edit": {
+			script:         `sed -i 's/replicas: 1/replicas: 3/' "$1"`,
+			expectPuts:     1,
+			expectReplicas: 3,
+			expectOutput:   "replicationControllers/rc1\n",
+		},
+		"no changes": {
+			script:       `true`,
+			expectOutput: "Edit cancelled, no changes made.\n",
+		},
+		"empty file": {
+			script:       `: > "$1"`,
+			expectOutput: "Edit cancelled, saved file was empty.\n",
+		},
+		"error reopens the file": {
+			script: `if grep -q "could not be saved" "$1"; then
+  grep "^#" "$1" > "` + dir + `/header"
+  sed -i 's/replicas: 3/replicas: 4/' "$1"
+else
+  sed -i 's/replicas: 1/replicas: 3/' "$1"
+fi`,
+			putErrors:      []error{apierrors.NewConflict("replicationControllers", "rc1", errors.New("the object has been modified"))},
+			expectPuts:     2,
+			expectReplicas: 4,
+			expectOutput:   "replicationControllers/rc1\n",
+			expectHeader:   "the object has been modified",
+		},
+		"error without changes cancels": {
+			script: `if ! grep -q "could not be saved" "$1"; then
+  sed -i 's/replicas: 1/replicas: 3/' "$1"
+fi`,
+			putErrors:  []error{apierrors.NewConflict("replicationControllers", "rc1", errors.New("the object has been modified"))},
+			expectPuts: 1,
+			expectErr:  "the object has been modified",
+		},
+		"name change is rejected": {
+			script: `if ! grep -q "could not be saved" "$1"; then
+  sed -i 's/rc1/rc2/' "$1"
+fi`,
+			expectErr: "the name of the object may not be changed",
+		},
+	}
+	for k, testCase := range testCases {
+		os.Remove(filepath.Join(dir, "header"))
+		restore := setEditor(t, dir, testCase.script)
+
+		var puts []*api.ReplicationController
+		f, tf, codec := NewAPIFactory()
+		tf.ClientConfig = &client.Config{Version: testapi.Version()}
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/replicationcontrollers/rc1" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &rc.Items[0])}, nil
+				case p == "/namespaces/test/replicationcontrollers/rc1" && m == "PUT":
+					data, _ := ioutil.ReadAll(req.Body)
+					obj, err := codec.Decode(data)
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", k, err)
+					}
+					puts = append(puts, obj.(*api.ReplicationController))
+					if len(puts) <= len(testCase.putErrors) {
+						status := testCase.putErrors[len(puts)-1].(*apierrors.StatusError).Status()
+						return &http.Response{StatusCode: status.Code, Body: objBody(codec, &status)}, nil
+					}
+					return &http.Response{StatusCode: 200, Body: objBody(codec, obj)}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", k, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := f.NewCmdEdit(buf)
+		err := RunEdit(f, buf, cmd, []string{"replicationcontrollers/rc1"})
+		restore()
+
+		if len(testCase.expectErr) == 0 && err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+		}
+		if len(testCase.expectErr) > 0 && (err == nil || !strings.Contains(err.Error(), testCase.expectErr)) {
+			t.Errorf("%s: expected error containing %q, got %v", k, testCase.expectErr, err)
+		}
+		if len(puts) != testCase.expectPuts {
+			t.Errorf("%s: expected %d updates, got %d", k, testCase.expectPuts, len(puts))
+		}
+		for _, put := range puts {
+			if put.ResourceVersion != "18" {
+				t.Errorf("%s: expected the original resource version, got %q", k, put.ResourceVersion)
+			}
+		}
+		if len(puts) > 0 && testCase.expectReplicas != 0 && puts[len(puts)-1].Spec.Replicas != testCase.expectReplicas {
+			t.Errorf("%s: expected %d replicas, got %d", k, testCase.expectReplicas, puts[len(puts)-1].Spec.Replicas)
+		}
+		if len(testCase.expectErr) == 0 && buf.String() != testCase.expectOutput {
+			t.Errorf("%s: unexpected output: %s", k, buf.String())
+		}
+		if len(testCase.expectHeader) > 0 {
+			header, _ := ioutil.ReadFile(filepath.Join(dir, "header"))
+			if !strings.Contains(string(header), testCase.expectHeader) {
+				t.Errorf("%s: expected the error in the header, got %s", k, string(header))
+			}
+		}
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	testCases := map[string]string{
+		"":                                   "",
+		"# comment":                          "",
+		"# comment\n#\na: 1\n":               "a: 1\n",
+		"  # indented\na: |\n  # kept\n":     "a: |\n  # kept\n",
+		"a: 1\n# trailing comment is kept\n": "a: 1\n# trailing comment is kept\n",
+	}
+	for in, expected := range testCases {
+		if actual := string(stripComments([]byte(in))); actual != expected {
+			t.Errorf("%q: expected %q, got %q", in, expected, actual)
+		}
+	}
+}
@@ -96,34 +96,13 @@ func RunExec(f *Factory, cmdIn io.Reader, cmdOut, cmdErr io.Writer, cmd *cobra.C
 	if util.GetFlagBool(cmd, "stdin") {
 		stdin = cmdIn
 		if tty {
-			if file, ok := cmdIn.(*os.File); ok {
-				inFd := file.Fd()
-				if term.IsTerminal(inFd) {
-					oldState, err := term.SetRawTerminal(inFd)
-					if err != nil {
-						glog.Fatal(err)
-					}
-					// this handles a clean exit, where the command finished
-					defer term.RestoreTerminal(inFd, oldState)
-
-					// SIGINT is handled by term.SetRawTerminal (it runs a goroutine that listens
-					// for SIGINT and restores the terminal before exiting)
-
-					// this handles SIGTERM
-					sigChan := make(chan os.Signal, 1)
-					signal.Notify(sigChan, syscall.SIGTERM)
-					go func() {
-						<-sigChan
-						term.RestoreTerminal(inFd, oldState)
-						os.Exit(0)
-					}()
-				} else {
-					glog.Warning("Stdin is not a terminal")
-				}
-			} else {
+			restore, ok := setRawTerminal(cmdIn)
+			if !ok {
 				tty = false
 				glog.Warning("Unable to use a TTY")
 			}
+			// this handles a clean exit, where the command finished
+			defer restore()
 		}
 	}
 
@@ -141,3 +120,35 @@ func RunExec(f *Factory, cmdIn io.Reader, cmdOut, cmdErr io.Writer, cmd *cobra.C
 	e := remotecommand.New(req, config, args, stdin, cmdOut, cmdErr, tty)
 	return e.Execute()
 }
+
+// setRawTerminal switches cmdIn to raw terminal mode if it is a terminal and returns a
+// function that restores its previous state. It returns false if cmdIn is not a file and
+// so cannot be used as a TTY.
+func setRawTerminal(cmdIn io.Reader) (func(), bool) {
+	file, ok := cmdIn.(*os.File)
+	if !ok {
+		return func() {}, false
+	}
+	inFd := file.Fd()
+	if !term.IsTerminal(inFd) {
+		glog.Warning("Stdin is not a terminal")
+		return func() {}, true
+	}
+	oldState, err := term.SetRawTerminal(inFd)
+	if err != nil {
+		glog.Fatal(err)
+	}
+
+	// SIGINT is handled by term.SetRawTerminal (it runs a goroutine that listens
+	// for SIGINT and restores the terminal before exiting)
+
+	// this handles SIGTERM
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		term.RestoreTerminal(inFd, oldState)
+		os.Exit(0)
+	}()
+	return func() { term.RestoreTerminal(inFd, oldState) }, true
+}
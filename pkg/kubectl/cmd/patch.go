@@ -0,0 +1,125 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	cmdutil "github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/yaml"
+	"github.com/spf13/cobra"
+)
+
+// patchTypes maps the values of the --type flag to patch types.
+var patchTypes = map[string]api.PatchType{
+	"json":      api.JSONPatchType,
+	"merge":     api.MergePatchType,
+	"strategic": api.StrategicMergePatchType,
+}
+
+const (
+	patch_long = `Update field(s) of a resource using a patch.
+
+The patch is applied by the server, so it does not race with other updates.
+--type selects how it is applied:
+  strategic: a partial object that is merged into the resource. Lists of objects
+             with a key, such as containers by name, are merged by that key, and
+             other lists are replaced. This is the default.
+  merge:     a JSON merge patch (RFC 7386): a partial object that is merged into
+             the resource, replacing any lists.
+  json:      a JSON patch (RFC 6902): a list of operations on the resource.
+
+JSON and YAML formats are accepted.`
+	patch_example = `// Partially update a node using strategic merge patch
+$ kubectl patch node k8s-node-1 -p '{"spec":{"unschedulable":true}}'
+
+// Update a container's image; spec.containers[*].name is required because it's a merge key
+$ kubectl patch pod valid-pod -p '{"spec":{"containers":[{"name":"kubernetes-serve-hostname","image":"new image"}]}}'
+
+// Update a container's image using a JSON patch with positional arrays
+$ kubectl patch pod valid-pod --type=json -p '[{"op": "replace", "path": "/spec/containers/0/image", "value":"new image"}]'`
+)
+
+func (f *Factory) NewCmdPatch(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "patch (RESOURCE NAME | RESOURCE/NAME) --patch PATCH",
+		Short:   "Update field(s) of a resource by a patch",
+		Long:    patch_long,
+		Example: patch_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunPatch(f, out, cmd, args)
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringP("patch", "p", "", "The patch to be applied to the resource JSON file.")
+	cmd.Flags().String("type", "strategic", "The type of patch being provided; one of [json merge strategic]")
+	return cmd
+}
+
+func RunPatch(f *Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	patch := cmdutil.GetFlagString(cmd, "patch")
+	if len(patch) == 0 {
+		return cmdutil.UsageError(cmd, "Must specify --patch")
+	}
+	patchType, ok := patchTypes[cmdutil.GetFlagString(cmd, "type")]
+	if !ok {
+		return cmdutil.UsageError(cmd, "--type must be one of json, merge or strategic, not %q", cmdutil.GetFlagString(cmd, "type"))
+	}
+	patchBytes, err := yaml.ToJSON([]byte(patch))
+	if err != nil {
+		return fmt.Errorf("unable to parse %q: %v", patch, err)
+	}
+
+	cmdNamespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+
+	mapper, typer := f.Object()
+	r := resource.NewBuilder(mapper, typer, f.ClientMapperForCommand()).
+		ContinueOnError().
+		NamespaceParam(cmdNamespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(false, args...).
+		Flatten().
+		Do()
+	err = r.Err()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = r.Visit(func(info *resource.Info) error {
+		obj, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, patchType, patchBytes)
+		if err != nil {
+			return err
+		}
+		count++
+		info.Refresh(obj, true)
+		fmt.Fprintf(out, "%s/%s\n", info.Mapping.Resource, info.Name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("no objects passed to patch")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+func TestPatchObject(t *testing.T) {
+	_, svc, _ := testData()
+
+	testCases := map[string]struct {
+		args        []string
+		patch       string
+		patchType   string
+		expectType  api.PatchType
+		expectPatch string
+		expectErr   bool
+	}{
+		"strategic by default": {
+			args:        []string{"services", "frontend"},
+			patch:       `{"spec":{"type":"NodePort"}}`,
+			expectType:  api.StrategicMergePatchType,
+			expectPatch: `{"spec":{"type":"NodePort"}}`,
+		},
+		"merge patch in yaml": {
+			args:        []string{"services/frontend"},
+			patch:       "spec:\n  type: NodePort\n",
+			patchType:   "merge",
+			expectType:  api.MergePatchType,
+			expectPatch: `{"spec":{"type":"NodePort"}}`,
+		},
+		"json patch": {
+			args:        []string{"services", "frontend"},
+			patch:       `[{"op":"replace","path":"/spec/type","value":"NodePort"}]`,
+			patchType:   "json",
+			expectType:  api.JSONPatchType,
+			expectPatch: `[{"op":"replace","path":"/spec/type","value":"NodePort"}]`,
+		},
+		"unknown type": {
+			args:      []string{"services", "frontend"},
+			patch:     `{}`,
+			patchType: "xml",
+			expectErr: true,
+		},
+		"no patch": {
+			args:      []string{"services", "frontend"},
+			expectErr: true,
+		},
+	}
+	for k, testCase := range testCases {
+		var patchType string
+		var patch []byte
+		f, tf, codec := NewAPIFactory()
+		tf.Printer = &testPrinter{}
+		tf.Client = &client.FakeRESTClient{
+			Codec: codec,
+			Client: client.HTTPClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch p, m := req.URL.Path, req.Method; {
+				case p == "/namespaces/test/services/frontend" && m == "GET":
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+				case p == "/namespaces/test/services/frontend" && m == "PATCH":
+					patchType = req.Header.Get("Content-Type")
+					patch, _ = ioutil.ReadAll(req.Body)
+					return &http.Response{StatusCode: 200, Body: objBody(codec, &svc.Items[0])}, nil
+				default:
+					t.Fatalf("%s: unexpected request: %#v\n%#v", k, req.URL, req)
+					return nil, nil
+				}
+			}),
+		}
+		tf.Namespace = "test"
+		buf := bytes.NewBuffer([]byte{})
+
+		cmd := f.NewCmdPatch(buf)
+		cmd.Flags().Set("patch", testCase.patch)
+		if len(testCase.patchType) > 0 {
+			cmd.Flags().Set("type", testCase.patchType)
+		}
+		err := RunPatch(f, buf, cmd, testCase.args)
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error", k)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if patchType != string(testCase.expectType) {
+			t.Errorf("%s: expected patch type %s, got %s", k, testCase.expectType, patchType)
+		}
+		if string(patch) != testCase.expectPatch {
+			t.Errorf("%s: expected patch %s, got %s", k, testCase.expectPatch, string(patch))
+		}
+		// uses the name from the response
+		if buf.String() != "services/baz\n" {
+			t.Errorf("%s: unexpected output: %s", k, buf.String())
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/deployment"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubectl/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	rollout_long = `Manage the rollout of a deployment.
+
+Rollouts are performed by the server: changing the pod template of a deployment
+starts a new revision, which the controller manager rolls out within the bounds
+of the deployment's strategy.`
+	rollout_pause_example = `// Stop the rollout of deployment 'nginx' at its current state.
+$ kubectl rollout pause nginx`
+	rollout_resume_example = `// Continue the rollout of the paused deployment 'nginx'.
+$ kubectl rollout resume nginx`
+	rollout_undo_example = `// Roll back deployment 'nginx' to its previous revision.
+$ kubectl rollout undo nginx
+
+// Roll back deployment 'nginx' to revision 3.
+$ kubectl rollout undo nginx --to-revision=3`
+	rollout_history_example = `// List the revisions of deployment 'nginx'.
+$ kubectl rollout history nginx`
+)
+
+func (f *Factory) NewCmdRollout(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout SUBCOMMAND",
+		Short: "Manage the rollout of a deployment.",
+		Long:  rollout_long,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	cmd.AddCommand(f.NewCmdRolloutPause(out))
+	cmd.AddCommand(f.NewCmdRolloutResume(out))
+	cmd.AddCommand(f.NewCmdRolloutUndo(out))
+	cmd.AddCommand(f.NewCmdRolloutHistory(out))
+	return cmd
+}
+
+func (f *Factory) NewCmdRolloutPause(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "pause DEPLOYMENT",
+		Short:   "Pause the rollout of a deployment.",
+		Example: rollout_pause_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutUpdate(f, out, cmd, args, "paused", func(d *api.Deployment) error {
+				if d.Spec.Paused {
+					return fmt.Errorf("deployment %q is already paused", d.Name)
+				}
+				d.Spec.Paused = true
+				return nil
+			})
+			util.CheckErr(err)
+		},
+	}
+}
+
+func (f *Factory) NewCmdRolloutResume(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "resume DEPLOYMENT",
+		Short:   "Resume the rollout of a paused deployment.",
+		Example: rollout_resume_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutUpdate(f, out, cmd, args, "resumed", func(d *api.Deployment) error {
+				if !d.Spec.Paused {
+					return fmt.Errorf("deployment %q is not paused", d.Name)
+				}
+				d.Spec.Paused = false
+				return nil
+			})
+			util.CheckErr(err)
+		},
+	}
+}
+
+func (f *Factory) NewCmdRolloutUndo(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "undo DEPLOYMENT [--to-revision=REVISION]",
+		Short:   "Roll back a deployment to a previous revision.",
+		Example: rollout_undo_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			revision := util.GetFlagInt(cmd, "to-revision")
+			if revision < 0 {
+				util.CheckErr(util.UsageError(cmd, "--to-revision must not be negative"))
+			}
+			err := RunRolloutUpdate(f, out, cmd, args, "rolled back", func(d *api.Deployment) error {
+				d.Spec.RollbackTo = &api.RollbackConfig{Revision: int64(revision)}
+				return nil
+			})
+			util.CheckErr(err)
+		},
+	}
+	cmd.Flags().Int("to-revision", 0, "The revision to roll back to. Defaults to the previous revision.")
+	return cmd
+}
+
+func (f *Factory) NewCmdRolloutHistory(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "history DEPLOYMENT",
+		Short:   "Show the revision history of a deployment.",
+		Example: rollout_history_example,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := RunRolloutHistory(f, out, cmd, args)
+			util.CheckErr(err)
+		},
+	}
+}
+
+// RunRolloutUpdate applies updateFn to the deployment named in args and saves the result.
+func RunRolloutUpdate(f *Factory, out io.Writer, cmd *cobra.Command, args []string, verb string, updateFn func(*api.Deployment) error) error {
+	if len(args) != 1 {
+		return util.UsageError(cmd, "DEPLOYMENT is required")
+	}
+	namespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	d, err := client.Deployments(namespace).Get(args[0])
+	if err != nil {
+		return err
+	}
+	if err := updateFn(d); err != nil {
+		return err
+	}
+	if _, err := client.Deployments(namespace).Update(d); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s\n", verb)
+	return nil
+}
+
+func RunRolloutHistory(f *Factory, out io.Writer, cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return util.UsageError(cmd, "DEPLOYMENT is required")
+	}
+	namespace, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	client, err := f.Client()
+	if err != nil {
+		return err
+	}
+	d, err := client.Deployments(namespace).Get(args[0])
+	if err != nil {
+		return err
+	}
+	controllers, err := deployment.ControllersForDeployment(client, d)
+	if err != nil {
+		return err
+	}
+	return printRolloutHistory(controllers, out)
+}
+
+func printRolloutHistory(controllers []api.ReplicationController, out io.Writer) error {
+	sort.Sort(controllersByRevision(controllers))
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintf(w, "REVISION\tCONTROLLER\tIMAGE(S)\tREPLICAS\n")
+	for _, controller := range controllers {
+		images := []string{}
+		for _, container := range controller.Spec.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+		_, err := fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", deployment.Revision(&controller), controller.Name, strings.Join(images, ","), controller.Spec.Replicas)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type controllersByRevision []api.ReplicationController
+
+func (c controllersByRevision) Len() int      { return len(c) }
+func (c controllersByRevision) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c controllersByRevision) Less(i, j int) bool {
+	return deployment.Revision(&c[i]) < deployment.Revision(&c[j])
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func TestPrintRolloutHistory(t *testing.T) {
+	newController := func(name, revision, image string) api.ReplicationController {
+		return api.ReplicationController{
+			ObjectMeta: api.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{api.DeploymentRevisionAnnotation: revision},
+			},
+			Spec: api.ReplicationControllerSpec{
+				Template: &api.PodTemplateSpec{
+					Spec: api.PodSpec{Containers: []api.Container{{Name: "web", Image: image}}},
+				},
+			},
+		}
+	}
+	controllers := []api.ReplicationController{
+		newController("web-2", "10", "nginx:2"),
+		newController("web-1", "9", "nginx:1"),
+	}
+	buf := bytes.NewBuffer([]byte{})
+	if err := printRolloutHistory(controllers, buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "9 ") || !strings.Contains(lines[1], "nginx:1") {
+		t.Errorf("expected revision 9 first, got %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "10 ") || !strings.Contains(lines[2], "nginx:2") {
+		t.Errorf("expected revision 10 last, got %q", lines[2])
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package editor launches the user's editor on temporary files.
+package editor
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+const (
+	defaultEditor = "vi"
+	defaultShell  = "/bin/bash"
+)
+
+// Editor runs a command that edits a file.
+type Editor struct {
+	// Args is the command and arguments, to which the path of the file is appended.
+	Args []string
+	// Shell is true if Args should be run by the user's shell, e.g. because
+	// the editor was given as a shell command line.
+	Shell bool
+}
+
+// NewDefaultEditor returns the editor named by the first of the given
+// environment variables that is set, and vi otherwise.  Editors that contain
+// spaces, such as "emacs -nw", are run by the user's shell.
+func NewDefaultEditor(envs ...string) Editor {
+	editor := defaultEditor
+	for _, env := range envs {
+		if value := os.Getenv(env); len(value) > 0 {
+			editor = value
+			break
+		}
+	}
+	if !strings.Contains(editor, " ") {
+		return Editor{Args: []string{editor}}
+	}
+	shell := os.Getenv("SHELL")
+	if len(shell) == 0 {
+		shell = defaultShell
+	}
+	return Editor{Args: []string{shell, "-c", editor}, Shell: true}
+}
+
+func (e Editor) args(path string) []string {
+	args := make([]string, len(e.Args))
+	copy(args, e.Args)
+	if e.Shell {
+		last := args[len(args)-1]
+		args[len(args)-1] = fmt.Sprintf("%s '%s'", last, strings.Replace(path, "'", `'\''`, -1))
+	} else {
+		args = append(args, path)
+	}
+	return args
+}
+
+// Launch opens the file at path in the editor, attached to the terminal of
+// this process, and waits for the editor to exit.
+func (e Editor) Launch(path string) error {
+	if len(e.Args) == 0 {
+		return fmt.Errorf("no editor defined, can't open %s", path)
+	}
+	args := e.args(path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	glog.V(5).Infof("Opening file with editor %v", args)
+	if err := cmd.Run(); err != nil {
+		if err, ok := err.(*exec.Error); ok && err.Err == exec.ErrNotFound {
+			return fmt.Errorf("unable to launch the editor %q", strings.Join(e.Args, " "))
+		}
+		return fmt.Errorf("there was a problem with the editor %q: %v", strings.Join(e.Args, " "), err)
+	}
+	return nil
+}
+
+// LaunchTempFile writes the contents of r to a new temporary file whose name
+// starts with prefix and ends with suffix, opens it in the editor, and returns
+// the edited contents and the path of the file.  The caller is responsible for
+// removing the file.
+func (e Editor) LaunchTempFile(prefix, suffix string, r io.Reader) ([]byte, string, error) {
+	f, path, err := tempFile(prefix, suffix)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, path, err
+	}
+	f.Close()
+	if err := e.Launch(path); err != nil {
+		return nil, path, err
+	}
+	data, err := ioutil.ReadFile(path)
+	return data, path, err
+}
+
+// tempFile creates a new temporary file with the given suffix, which editors
+// use to pick the syntax of the file.
+func tempFile(prefix, suffix string) (*os.File, string, error) {
+	f, err := ioutil.TempFile("", prefix)
+	if err != nil {
+		return nil, "", err
+	}
+	path := f.Name() + suffix
+	if err := os.Rename(f.Name(), path); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, "", err
+	}
+	return f, path, nil
+}
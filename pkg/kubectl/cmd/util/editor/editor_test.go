@@ -0,0 +1,80 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	if e, a := []string{"vi", "/tmp/a b"}, (Editor{Args: []string{"vi"}}).args("/tmp/a b"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+	if e, a := []string{"/bin/bash", "-c", `emacs -nw '/tmp/it'\''s'`}, (Editor{Args: []string{"/bin/bash", "-c", "emacs -nw"}, Shell: true}).args("/tmp/it's"); !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected args: %v", a)
+	}
+}
+
+func TestNewDefaultEditor(t *testing.T) {
+	os.Setenv("KUBE_EDITOR_TEST_UNSET", "")
+	os.Setenv("KUBE_EDITOR_TEST", "nano")
+	os.Setenv("KUBE_EDITOR_TEST_SHELL", "emacs -nw")
+	shell := os.Getenv("SHELL")
+	os.Setenv("SHELL", "/bin/sh")
+	defer func() {
+		os.Setenv("KUBE_EDITOR_TEST", "")
+		os.Setenv("KUBE_EDITOR_TEST_SHELL", "")
+		os.Setenv("SHELL", shell)
+	}()
+
+	if e := NewDefaultEditor("KUBE_EDITOR_TEST_UNSET"); !reflect.DeepEqual(e, Editor{Args: []string{"vi"}}) {
+		t.Errorf("unexpected editor: %#v", e)
+	}
+	if e := NewDefaultEditor("KUBE_EDITOR_TEST_UNSET", "KUBE_EDITOR_TEST", "KUBE_EDITOR_TEST_SHELL"); !reflect.DeepEqual(e, Editor{Args: []string{"nano"}}) {
+		t.Errorf("unexpected editor: %#v", e)
+	}
+	if e := NewDefaultEditor("KUBE_EDITOR_TEST_SHELL"); !reflect.DeepEqual(e, Editor{Args: []string{"/bin/sh", "-c", "emacs -nw"}, Shell: true}) {
+		t.Errorf("unexpected editor: %#v", e)
+	}
+}
+
+func TestLaunchTempFile(t *testing.T) {
+	edit := Editor{Args: []string{"/bin/sh", "-c", "sed -i s/foo/bar/"}, Shell: true}
+	contents, path, err := edit.LaunchTempFile("test-", ".yaml", bytes.NewBufferString("foo: 1\n"))
+	if path != "" {
+		defer os.Remove(path)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(contents) != "bar: 1\n" {
+		t.Errorf("unexpected contents: %q", string(contents))
+	}
+	if !strings.HasSuffix(path, ".yaml") {
+		t.Errorf("expected the file to keep its suffix: %s", path)
+	}
+
+	if _, path, err := (Editor{Args: []string{"/nonexistent/editor"}}).LaunchTempFile("test-", "", bytes.NewBufferString("")); err == nil {
+		t.Errorf("expected an error for a missing editor")
+	} else {
+		os.Remove(path)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/wait"
+)
+
+// mirrorPodAnnotationKey marks the mirror pods the kubelet creates for its static pods.
+// It must match kubelet.ConfigMirrorAnnotationKey.
+const mirrorPodAnnotationKey = "kubernetes.io/config.mirror"
+
+// SetNodeSchedulable marks the named node schedulable or unschedulable. It returns false
+// if the node was already in the requested state.
+func SetNodeSchedulable(c client.Interface, name string, schedulable bool) (bool, error) {
+	node, err := c.Nodes().Get(name)
+	if err != nil {
+		return false, err
+	}
+	if node.Spec.Unschedulable == !schedulable {
+		return false, nil
+	}
+	node.Spec.Unschedulable = !schedulable
+	if _, err := c.Nodes().Update(node); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// A NodeDrainer takes a node out of service by marking it unschedulable and deleting
+// the pods bound to it.
+type NodeDrainer struct {
+	client.Interface
+	// Force allows deleting pods that are not managed by a replication controller or
+	// a job, which will not be recreated elsewhere.
+	Force                 bool
+	pollInterval, timeout time.Duration
+}
+
+// NewNodeDrainer returns a drainer that waits up to timeout for the replacements of
+// deleted pods.
+func NewNodeDrainer(c client.Interface, force bool, timeout time.Duration) *NodeDrainer {
+	return &NodeDrainer{c, force, interval, timeout}
+}
+
+// podManagers holds the controllers of a namespace which can manage pods.
+type podManagers struct {
+	controllers []api.ReplicationController
+	daemonSets  []api.DaemonSet
+	jobs        []api.Job
+}
+
+// selectsPod returns true if the selector of a controller matches the pod.  An empty
+// selector matches nothing.
+func selectsPod(selector map[string]string, pod *api.Pod) bool {
+	s := labels.SelectorFromSet(selector)
+	return !s.Empty() && s.Matches(labels.Set(pod.Labels))
+}
+
+// managersFor lists the controllers of the namespace which can manage pods.
+func (d *NodeDrainer) managersFor(namespace string) (*podManagers, error) {
+	rcs, err := d.ReplicationControllers(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	daemonSets, err := d.DaemonSets(namespace).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := d.Jobs(namespace).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return &podManagers{rcs.Items, daemonSets.Items, jobs.Items}, nil
+}
+
+// Drain cordons the named node and deletes the pods bound to it, except for mirror pods,
+// which the kubelet would recreate, and pods of daemon sets, which are meant to run on
+// every node. Unless Force is set, it refuses to delete any pods if one of them is not
+// managed by a replication controller or a job. Once the pods are deleted it waits until
+// their replication controllers have the desired number of pods running on other nodes.
+// The deleted pods are returned.
+func (d *NodeDrainer) Drain(name string) ([]api.Pod, error) {
+	if _, err := SetNodeSchedulable(d, name, false); err != nil {
+		return nil, err
+	}
+	pods, err := d.Pods(api.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	deletes := []api.Pod{}
+	controllers := map[string]api.ReplicationController{}
+	unmanaged := []string{}
+	namespaceManagers := map[string]*podManagers{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.Host != name {
+			continue
+		}
+		if _, ok := pod.Annotations[mirrorPodAnnotationKey]; ok {
+			continue
+		}
+		managers, ok := namespaceManagers[pod.Namespace]
+		if !ok {
+			managers, err = d.managersFor(pod.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			namespaceManagers[pod.Namespace] = managers
+		}
+		daemon := false
+		for _, ds := range managers.daemonSets {
+			if selectsPod(ds.Spec.Selector, pod) {
+				daemon = true
+				break
+			}
+		}
+		if daemon {
+			continue
+		}
+		managed := false
+		for _, rc := range managers.controllers {
+			if selectsPod(rc.Spec.Selector, pod) {
+				controllers[rc.Namespace+"/"+rc.Name] = rc
+				managed = true
+			}
+		}
+		for _, job := range managers.jobs {
+			if selectsPod(job.Spec.Selector, pod) {
+				managed = true
+			}
+		}
+		if !managed {
+			unmanaged = append(unmanaged, pod.Namespace+"/"+pod.Name)
+		}
+		deletes = append(deletes, *pod)
+	}
+	if len(unmanaged) > 0 && !d.Force {
+		return nil, fmt.Errorf("refusing to delete pods not managed by a replication controller or a job (use --force to override): %s", strings.Join(unmanaged, ", "))
+	}
+
+	for _, pod := range deletes {
+		if err := d.Pods(pod.Namespace).Delete(pod.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, rc := range controllers {
+		if err := wait.Poll(d.pollInterval, d.timeout, d.controllerRunningElsewhere(rc, name)); err != nil {
+			return deletes, fmt.Errorf("replication controller %s/%s does not have %d pods running on other nodes: %v", rc.Namespace, rc.Name, rc.Spec.Replicas, err)
+		}
+	}
+	return deletes, nil
+}
+
+// controllerRunningElsewhere returns a condition that is true once the controller has
+// as many running pods on nodes other than the named node as it desires.
+func (d *NodeDrainer) controllerRunningElsewhere(rc api.ReplicationController, name string) wait.ConditionFunc {
+	return func() (bool, error) {
+		pods, err := d.Pods(rc.Namespace).List(labels.SelectorFromSet(rc.Spec.Selector))
+		if err != nil {
+			return false, err
+		}
+		running := 0
+		for _, pod := range pods.Items {
+			if pod.Spec.Host != "" && pod.Spec.Host != name && pod.Status.Phase == api.PodRunning {
+				running++
+			}
+		}
+		return running >= rc.Spec.Replicas, nil
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+func TestSetNodeSchedulable(t *testing.T) {
+	tests := []struct {
+		unschedulable bool
+		schedulable   bool
+		changed       bool
+		actions       []string
+	}{
+		{false, false, true, []string{"get-minion", "update-minion"}},
+		{true, false, false, []string{"get-minion"}},
+		{true, true, true, []string{"get-minion", "update-minion"}},
+		{false, true, false, []string{"get-minion"}},
+	}
+	for i, test := range tests {
+		fake := &client.Fake{
+			MinionsList: api.NodeList{Items: []api.Node{{
+				ObjectMeta: api.ObjectMeta{Name: "node1"},
+				Spec:       api.NodeSpec{Unschedulable: test.unschedulable},
+			}}},
+		}
+		changed, err := SetNodeSchedulable(fake, "node1", test.schedulable)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if changed != test.changed {
+			t.Errorf("%d: expected changed %t, got %t", i, test.changed, changed)
+		}
+		if actions := fakeActions(fake); !reflect.DeepEqual(actions, test.actions) {
+			t.Errorf("%d: expected actions %v, got %v", i, test.actions, actions)
+			continue
+		}
+		if test.changed {
+			node := fake.Actions[1].Value.(*api.Node)
+			if node.Spec.Unschedulable != !test.schedulable {
+				t.Errorf("%d: unexpected update: %#v", i, node)
+			}
+		}
+	}
+}
+
+func TestNodeDrainerDrain(t *testing.T) {
+	newPod := func(name, host string, labels, annotations map[string]string, phase api.PodPhase) api.Pod {
+		return api.Pod{
+			ObjectMeta: api.ObjectMeta{Name: name, Namespace: "default", Labels: labels, Annotations: annotations},
+			Spec:       api.PodSpec{Host: host},
+			Status:     api.PodStatus{Phase: phase},
+		}
+	}
+	web := map[string]string{"app": "web"}
+	rc := api.ReplicationController{
+		ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       api.ReplicationControllerSpec{Replicas: 1, Selector: web},
+	}
+	managed := newPod("web-1", "node1", web, nil, api.PodRunning)
+	replacement := newPod("web-2", "node2", web, nil, api.PodRunning)
+	pendingReplacement := newPod("web-2", "node2", web, nil, api.PodPending)
+	unmanaged := newPod("bare", "node1", nil, nil, api.PodRunning)
+	mirror := newPod("static-node1", "node1", nil, map[string]string{mirrorPodAnnotationKey: "mirror"}, api.PodRunning)
+	other := newPod("other", "node2", nil, nil, api.PodRunning)
+	daemon := newPod("logger-1", "node1", map[string]string{"app": "logger"}, nil, api.PodRunning)
+	job := newPod("batch-1", "node1", map[string]string{"app": "batch"}, nil, api.PodRunning)
+
+	tests := []struct {
+		pods        []api.Pod
+		force       bool
+		deleted     []string
+		expectError bool
+		test        string
+	}{
+		{
+			pods:    []api.Pod{managed, replacement, other},
+			deleted: []string{"web-1"},
+			test:    "managed pod is deleted once replaced",
+		},
+		{
+			pods:    []api.Pod{mirror, managed, replacement},
+			deleted: []string{"web-1"},
+			test:    "mirror pods are skipped",
+		},
+		{
+			pods:        []api.Pod{managed, unmanaged, replacement},
+			expectError: true,
+			test:        "unmanaged pods are refused",
+		},
+		{
+			pods:    []api.Pod{managed, unmanaged, replacement},
+			force:   true,
+			deleted: []string{"web-1", "bare"},
+			test:    "unmanaged pods are deleted when forced",
+		},
+		{
+			pods:    []api.Pod{daemon, managed, replacement},
+			deleted: []string{"web-1"},
+			test:    "daemon set pods are skipped",
+		},
+		{
+			pods:    []api.Pod{job, managed, replacement},
+			deleted: []string{"batch-1", "web-1"},
+			test:    "job pods are managed",
+		},
+		{
+			pods:        []api.Pod{managed, pendingReplacement},
+			deleted:     []string{"web-1"},
+			expectError: true,
+			test:        "replacement is not running",
+		},
+	}
+	for _, test := range tests {
+		fake := &client.Fake{
+			MinionsList: api.NodeList{Items: []api.Node{{ObjectMeta: api.ObjectMeta{Name: "node1"}}}},
+			PodsList:    api.PodList{Items: test.pods},
+			CtrlList:    api.ReplicationControllerList{Items: []api.ReplicationController{rc}},
+			DaemonSetList: api.DaemonSetList{Items: []api.DaemonSet{{
+				ObjectMeta: api.ObjectMeta{Name: "logger", Namespace: "default"},
+				Spec:       api.DaemonSetSpec{Selector: map[string]string{"app": "logger"}},
+			}}},
+			JobList: api.JobList{Items: []api.Job{{
+				ObjectMeta: api.ObjectMeta{Name: "batch", Namespace: "default"},
+				Spec:       api.JobSpec{Selector: map[string]string{"app": "batch"}},
+			}}},
+		}
+		drainer := &NodeDrainer{fake, test.force, time.Millisecond, 5 * time.Millisecond}
+		pods, err := drainer.Drain("node1")
+		if err != nil != test.expectError {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+			continue
+		}
+		names := []string{}
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		deleted := []string{}
+		for _, action := range fake.Actions {
+			if action.Action == "delete-pod" {
+				deleted = append(deleted, action.Value.(string))
+			}
+		}
+		if test.deleted == nil {
+			test.deleted = []string{}
+		}
+		if !reflect.DeepEqual(deleted, test.deleted) {
+			t.Errorf("%s: expected deleted pods %v, got %v", test.test, test.deleted, deleted)
+		}
+		if !test.expectError && !reflect.DeepEqual(names, test.deleted) {
+			t.Errorf("%s: expected returned pods %v, got %v", test.test, test.deleted, names)
+		}
+		if actions := fakeActions(fake); len(actions) < 2 || actions[1] != "update-minion" {
+			t.Errorf("%s: expected node to be cordoned, got %v", test.test, actions)
+		}
+	}
+}
+
+func fakeActions(fake *client.Fake) []string {
+	actions := []string{}
+	for _, action := range fake.Actions {
+		actions = append(actions, action.Action)
+	}
+	return actions
+}
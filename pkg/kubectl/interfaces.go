@@ -27,4 +27,5 @@ type RESTClient interface {
 	Post() *client.Request
 	Delete() *client.Request
 	Put() *client.Request
+	Patch() *client.Request
 }
@@ -107,6 +107,7 @@ func expandResourceShortcut(resource string) string {
 		"ev":     "events",
 		"limits": "limitRanges",
 		"quota":  "resourceQuotas",
+		"hpa":    "horizontalpodautoscalers",
 	}
 	if expanded, ok := shortForms[resource]; ok {
 		return expanded
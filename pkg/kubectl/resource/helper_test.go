@@ -481,3 +481,66 @@ func TestHelperUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestHelperPatch(t *testing.T) {
+	tests := []struct {
+		Resp    *http.Response
+		HttpErr error
+		Err     bool
+	}{
+		{
+			HttpErr: errors.New("failure"),
+			Err:     true,
+		},
+		{
+			Resp: &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       objBody(&api.Status{Status: api.StatusFailure}),
+			},
+			Err: true,
+		},
+		{
+			Resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       objBody(&api.Pod{ObjectMeta: api.ObjectMeta{Name: "foo"}}),
+			},
+		},
+	}
+	for i, test := range tests {
+		client := &client.FakeRESTClient{
+			Codec: testapi.Codec(),
+			Resp:  test.Resp,
+			Err:   test.HttpErr,
+		}
+		modifier := &Helper{
+			RESTClient:      client,
+			Resource:        "pods",
+			NamespaceScoped: true,
+		}
+		patch := []byte(`{"metadata":{"labels":{"a":"b"}}}`)
+		obj, err := modifier.Patch("bar", "foo", api.StrategicMergePatchType, patch)
+		if (err != nil) != test.Err {
+			t.Errorf("%d: unexpected error: %t %v", i, test.Err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if client.Req.Method != "PATCH" {
+			t.Errorf("%d: unexpected method: %#v", i, client.Req)
+		}
+		if contentType := client.Req.Header.Get("Content-Type"); contentType != string(api.StrategicMergePatchType) {
+			t.Errorf("%d: unexpected content type: %s", i, contentType)
+		}
+		parts := splitPath(client.Req.URL.Path)
+		if len(parts) != 4 || parts[1] != "bar" || parts[2] != "pods" || parts[3] != "foo" {
+			t.Errorf("%d: unexpected path: %s", i, client.Req.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(client.Req.Body)
+		if !reflect.DeepEqual(patch, body) {
+			t.Errorf("%d: unexpected body: %s", i, string(body))
+		}
+		if pod, ok := obj.(*api.Pod); !ok || pod.Name != "foo" {
+			t.Errorf("%d: unexpected object: %#v", i, obj)
+		}
+	}
+}
@@ -28,6 +28,7 @@ type RESTClient interface {
 	Post() *client.Request
 	Delete() *client.Request
 	Put() *client.Request
+	Patch() *client.Request
 }
 
 // ClientMapper retrieves a client object for a given mapping
@@ -228,7 +228,11 @@ func (h *HumanReadablePrinter) validatePrintHandlerFunc(printFunc reflect.Value)
 
 var podColumns = []string{"POD", "IP", "CONTAINER(S)", "IMAGE(S)", "HOST", "LABELS", "STATUS", "CREATED"}
 var replicationControllerColumns = []string{"CONTROLLER", "CONTAINER(S)", "IMAGE(S)", "SELECTOR", "REPLICAS"}
-var serviceColumns = []string{"NAME", "LABELS", "SELECTOR", "IP", "PORT"}
+var deploymentColumns = []string{"NAME", "CONTAINER(S)", "IMAGE(S)", "SELECTOR", "REPLICAS", "UPDATED", "REVISION"}
+var jobColumns = []string{"NAME", "CONTAINER(S)", "IMAGE(S)", "SELECTOR", "SUCCESSFUL"}
+var daemonSetColumns = []string{"NAME", "CONTAINER(S)", "IMAGE(S)", "SELECTOR", "NODE-SELECTOR", "CURRENT", "DESIRED"}
+var horizontalPodAutoscalerColumns = []string{"NAME", "REFERENCE", "TARGET", "CURRENT", "MINPODS", "MAXPODS"}
+var serviceColumns = []string{"NAME", "LABELS", "SELECTOR", "IP", "PORT(S)"}
 var endpointColumns = []string{"NAME", "ENDPOINTS"}
 var nodeColumns = []string{"NAME", "LABELS", "STATUS"}
 var statusColumns = []string{"STATUS"}
@@ -237,6 +241,9 @@ var limitRangeColumns = []string{"NAME"}
 var resourceQuotaColumns = []string{"NAME"}
 var namespaceColumns = []string{"NAME", "LABELS", "STATUS"}
 var secretColumns = []string{"NAME", "DATA"}
+var serviceAccountColumns = []string{"NAME", "SECRETS"}
+var roleColumns = []string{"NAME", "RULES"}
+var roleBindingColumns = []string{"NAME", "ROLE", "SUBJECTS"}
 
 // addDefaultHandlers adds print handlers for default Kubernetes types.
 func (h *HumanReadablePrinter) addDefaultHandlers() {
@@ -244,6 +251,14 @@ func (h *HumanReadablePrinter) addDefaultHandlers() {
 	h.Handler(podColumns, printPodList)
 	h.Handler(replicationControllerColumns, printReplicationController)
 	h.Handler(replicationControllerColumns, printReplicationControllerList)
+	h.Handler(deploymentColumns, printDeployment)
+	h.Handler(deploymentColumns, printDeploymentList)
+	h.Handler(jobColumns, printJob)
+	h.Handler(jobColumns, printJobList)
+	h.Handler(daemonSetColumns, printDaemonSet)
+	h.Handler(daemonSetColumns, printDaemonSetList)
+	h.Handler(horizontalPodAutoscalerColumns, printHorizontalPodAutoscaler)
+	h.Handler(horizontalPodAutoscalerColumns, printHorizontalPodAutoscalerList)
 	h.Handler(serviceColumns, printService)
 	h.Handler(serviceColumns, printServiceList)
 	h.Handler(endpointColumns, printEndpoints)
@@ -261,6 +276,16 @@ func (h *HumanReadablePrinter) addDefaultHandlers() {
 	h.Handler(namespaceColumns, printNamespaceList)
 	h.Handler(secretColumns, printSecret)
 	h.Handler(secretColumns, printSecretList)
+	h.Handler(serviceAccountColumns, printServiceAccount)
+	h.Handler(serviceAccountColumns, printServiceAccountList)
+	h.Handler(roleColumns, printRole)
+	h.Handler(roleColumns, printRoleList)
+	h.Handler(roleColumns, printClusterRole)
+	h.Handler(roleColumns, printClusterRoleList)
+	h.Handler(roleBindingColumns, printRoleBinding)
+	h.Handler(roleBindingColumns, printRoleBindingList)
+	h.Handler(roleBindingColumns, printClusterRoleBinding)
+	h.Handler(roleBindingColumns, printClusterRoleBindingList)
 }
 
 func (h *HumanReadablePrinter) unknown(data []byte, w io.Writer) error {
@@ -389,9 +414,169 @@ func printReplicationControllerList(list *api.ReplicationControllerList, w io.Wr
 	return nil
 }
 
+func printDeployment(deployment *api.Deployment, w io.Writer) error {
+	var containers []api.Container
+	if deployment.Spec.Template != nil {
+		containers = deployment.Spec.Template.Spec.Containers
+	}
+	var firstContainer api.Container
+	if len(containers) > 0 {
+		firstContainer, containers = containers[0], containers[1:]
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\t%d\t%d\n",
+		deployment.Name,
+		firstContainer.Name,
+		firstContainer.Image,
+		formatLabels(deployment.Spec.Selector),
+		deployment.Status.Replicas,
+		deployment.Spec.Replicas,
+		deployment.Status.UpdatedReplicas,
+		deployment.Status.Revision)
+	if err != nil {
+		return err
+	}
+	// Lay out all the other containers on separate lines.
+	for _, container := range containers {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "", container.Name, container.Image, "", "", "", "")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printDeploymentList(list *api.DeploymentList, w io.Writer) error {
+	for _, deployment := range list.Items {
+		if err := printDeployment(&deployment, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJob(job *api.Job, w io.Writer) error {
+	var containers []api.Container
+	if job.Spec.Template != nil {
+		containers = job.Spec.Template.Spec.Containers
+	}
+	var firstContainer api.Container
+	if len(containers) > 0 {
+		firstContainer, containers = containers[0], containers[1:]
+	}
+	completions := 0
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\n",
+		job.Name,
+		firstContainer.Name,
+		firstContainer.Image,
+		formatLabels(job.Spec.Selector),
+		job.Status.Succeeded,
+		completions)
+	if err != nil {
+		return err
+	}
+	// Lay out all the other containers on separate lines.
+	for _, container := range containers {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "", container.Name, container.Image, "", "")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJobList(list *api.JobList, w io.Writer) error {
+	for _, job := range list.Items {
+		if err := printJob(&job, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printDaemonSet(ds *api.DaemonSet, w io.Writer) error {
+	var containers []api.Container
+	var nodeSelector map[string]string
+	if ds.Spec.Template != nil {
+		containers = ds.Spec.Template.Spec.Containers
+		nodeSelector = ds.Spec.Template.Spec.NodeSelector
+	}
+	var firstContainer api.Container
+	if len(containers) > 0 {
+		firstContainer, containers = containers[0], containers[1:]
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\n",
+		ds.Name,
+		firstContainer.Name,
+		firstContainer.Image,
+		formatLabels(ds.Spec.Selector),
+		formatLabels(nodeSelector),
+		ds.Status.CurrentNumberScheduled,
+		ds.Status.DesiredNumberScheduled)
+	if err != nil {
+		return err
+	}
+	// Lay out all the other containers on separate lines.
+	for _, container := range containers {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "", container.Name, container.Image, "", "", "", "")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printDaemonSetList(list *api.DaemonSetList, w io.Writer) error {
+	for _, ds := range list.Items {
+		if err := printDaemonSet(&ds, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printHorizontalPodAutoscaler(hpa *api.HorizontalPodAutoscaler, w io.Writer) error {
+	reference := fmt.Sprintf("%s/%s", hpa.Spec.ScaleRef.Kind, hpa.Spec.ScaleRef.Name)
+	target := "<unset>"
+	if hpa.Spec.CPUUtilization != nil {
+		target = fmt.Sprintf("%d%%", hpa.Spec.CPUUtilization.TargetPercentage)
+	}
+	current := "<waiting>"
+	if hpa.Status.CurrentCPUUtilizationPercentage != nil {
+		current = fmt.Sprintf("%d%%", *hpa.Status.CurrentCPUUtilizationPercentage)
+	}
+	minPods := "<unset>"
+	if hpa.Spec.MinReplicas != nil {
+		minPods = fmt.Sprintf("%d", *hpa.Spec.MinReplicas)
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n",
+		hpa.Name,
+		reference,
+		target,
+		current,
+		minPods,
+		hpa.Spec.MaxReplicas)
+	return err
+}
+
+func printHorizontalPodAutoscalerList(list *api.HorizontalPodAutoscalerList, w io.Writer) error {
+	for i := range list.Items {
+		if err := printHorizontalPodAutoscaler(&list.Items[i], w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printService(svc *api.Service, w io.Writer) error {
-	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", svc.Name, formatLabels(svc.Labels),
-		formatLabels(svc.Spec.Selector), svc.Spec.PortalIP, svc.Spec.Port)
+	ports := []string{}
+	for _, p := range svc.Spec.Ports {
+		ports = append(ports, fmt.Sprintf("%d/%s", p.Port, p.Protocol))
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", svc.Name, formatLabels(svc.Labels),
+		formatLabels(svc.Spec.Selector), svc.Spec.PortalIP, strings.Join(ports, ","))
 	return err
 }
 
@@ -447,6 +632,93 @@ func printSecretList(list *api.SecretList, w io.Writer) error {
 	return nil
 }
 
+func printServiceAccount(item *api.ServiceAccount, w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\t%d\n", item.Name, len(item.Secrets))
+	return err
+}
+
+func printServiceAccountList(list *api.ServiceAccountList, w io.Writer) error {
+	for _, item := range list.Items {
+		if err := printServiceAccount(&item, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printRole(item *api.Role, w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\t%d\n", item.Name, len(item.Rules))
+	return err
+}
+
+func printRoleList(list *api.RoleList, w io.Writer) error {
+	for _, item := range list.Items {
+		if err := printRole(&item, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printClusterRole(item *api.ClusterRole, w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\t%d\n", item.Name, len(item.Rules))
+	return err
+}
+
+func printClusterRoleList(list *api.ClusterRoleList, w io.Writer) error {
+	for _, item := range list.Items {
+		if err := printClusterRole(&item, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatSubjects(subjects []api.Subject) string {
+	names := []string{}
+	for _, subject := range subjects {
+		if len(subject.Namespace) > 0 {
+			names = append(names, fmt.Sprintf("%s:%s/%s", subject.Kind, subject.Namespace, subject.Name))
+			continue
+		}
+		names = append(names, fmt.Sprintf("%s:%s", subject.Kind, subject.Name))
+	}
+	return strings.Join(names, ",")
+}
+
+func printRoleBinding(item *api.RoleBinding, w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\t%s/%s\t%s\n", item.Name, item.RoleRef.Kind, item.RoleRef.Name, formatSubjects(item.Subjects))
+	return err
+}
+
+func printRoleBindingList(list *api.RoleBindingList, w io.Writer) error {
+	for _, item := range list.Items {
+		if err := printRoleBinding(&item, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printClusterRoleBinding(item *api.ClusterRoleBinding, w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\t%s/%s\t%s\n", item.Name, item.RoleRef.Kind, item.RoleRef.Name, formatSubjects(item.Subjects))
+	return err
+}
+
+func printClusterRoleBindingList(list *api.ClusterRoleBindingList, w io.Writer) error {
+	for _, item := range list.Items {
+		if err := printClusterRoleBinding(&item, w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func printNode(node *api.Node, w io.Writer) error {
 	conditionMap := make(map[api.NodeConditionType]*api.NodeCondition)
 	NodeAllConditions := []api.NodeConditionType{api.NodeSchedulable, api.NodeReady, api.NodeReachable}
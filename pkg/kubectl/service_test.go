@@ -42,13 +42,11 @@ func TestGenerateService(t *testing.T) {
 					Name: "test",
 				},
 				Spec: api.ServiceSpec{
+					Ports: []api.ServicePort{{Port: 80, Protocol: "TCP", TargetPort: util.NewIntOrStringFromInt(1234)}},
 					Selector: map[string]string{
 						"foo": "bar",
 						"baz": "blah",
 					},
-					Port:       80,
-					Protocol:   "TCP",
-					TargetPort: util.NewIntOrStringFromInt(1234),
 				},
 			},
 		},
@@ -65,13 +63,11 @@ func TestGenerateService(t *testing.T) {
 					Name: "test",
 				},
 				Spec: api.ServiceSpec{
+					Ports: []api.ServicePort{{Port: 80, Protocol: "UDP", TargetPort: util.NewIntOrStringFromString("foobar")}},
 					Selector: map[string]string{
 						"foo": "bar",
 						"baz": "blah",
 					},
-					Port:       80,
-					Protocol:   "UDP",
-					TargetPort: util.NewIntOrStringFromString("foobar"),
 				},
 			},
 		},
@@ -93,13 +89,11 @@ func TestGenerateService(t *testing.T) {
 					},
 				},
 				Spec: api.ServiceSpec{
+					Ports: []api.ServicePort{{Port: 80, Protocol: "TCP", TargetPort: util.NewIntOrStringFromInt(1234)}},
 					Selector: map[string]string{
 						"foo": "bar",
 						"baz": "blah",
 					},
-					Port:       80,
-					Protocol:   "TCP",
-					TargetPort: util.NewIntOrStringFromInt(1234),
 				},
 			},
 		},
@@ -117,14 +111,12 @@ func TestGenerateService(t *testing.T) {
 					Name: "test",
 				},
 				Spec: api.ServiceSpec{
+					Ports: []api.ServicePort{{Port: 80, Protocol: "UDP", TargetPort: util.NewIntOrStringFromString("foobar")}},
 					Selector: map[string]string{
 						"foo": "bar",
 						"baz": "blah",
 					},
-					Port:       80,
-					Protocol:   "UDP",
-					PublicIPs:  []string{"1.2.3.4"},
-					TargetPort: util.NewIntOrStringFromString("foobar"),
+					PublicIPs: []string{"1.2.3.4"},
 				},
 			},
 		},
@@ -143,18 +135,34 @@ func TestGenerateService(t *testing.T) {
 					Name: "test",
 				},
 				Spec: api.ServiceSpec{
+					Ports: []api.ServicePort{{Port: 80, Protocol: "UDP", TargetPort: util.NewIntOrStringFromString("foobar")}},
 					Selector: map[string]string{
 						"foo": "bar",
 						"baz": "blah",
 					},
-					Port:                       80,
-					Protocol:                   "UDP",
 					PublicIPs:                  []string{"1.2.3.4"},
-					TargetPort:                 util.NewIntOrStringFromString("foobar"),
 					CreateExternalLoadBalancer: true,
 				},
 			},
 		},
+		{
+			params: map[string]string{
+				"selector": "foo=bar",
+				"name":     "test",
+				"port":     "80",
+				"type":     "NodePort",
+			},
+			expected: api.Service{
+				ObjectMeta: api.ObjectMeta{
+					Name: "test",
+				},
+				Spec: api.ServiceSpec{
+					Ports:    []api.ServicePort{{Port: 80, TargetPort: util.NewIntOrStringFromInt(80)}},
+					Selector: map[string]string{"foo": "bar"},
+					Type:     api.ServiceTypeNodePort,
+				},
+			},
+		},
 	}
 	generator := ServiceGenerator{}
 	for _, test := range tests {
@@ -37,6 +37,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/credentialprovider"
 	kubecontainer "github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet/container"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet/leaky"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/securitycontext"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/docker/docker/pkg/parsers"
@@ -72,6 +73,7 @@ type DockerInterface interface {
 	Version() (*docker.Env, error)
 	CreateExec(docker.CreateExecOptions) (*docker.Exec, error)
 	StartExec(string, docker.StartExecOptions) error
+	AttachToContainer(opts docker.AttachToContainerOptions) error
 }
 
 // DockerID is an ID of docker container. It is a type to make it clear when we're working with docker container Ids
@@ -300,6 +302,32 @@ func (d *dockerContainerCommandRunner) ExecInContainer(containerId string, cmd [
 	}
 }
 
+// AttachContainer connects the supplied stdin/stdout/stderr to the streams of the main
+// process of the container identified by containerID, using docker attach. If tty is set
+// the container is expected to have a TTY, and its output is copied to stdout unmodified.
+func (d *dockerContainerCommandRunner) AttachContainer(containerID string, stdin io.Reader, stdout, stderr io.WriteCloser, tty bool) error {
+	opts := docker.AttachToContainerOptions{
+		Container:    containerID,
+		InputStream:  stdin,
+		OutputStream: stdout,
+		ErrorStream:  stderr,
+		Stream:       true,
+		Stdin:        stdin != nil,
+		Stdout:       stdout != nil,
+		Stderr:       stderr != nil,
+		RawTerminal:  tty,
+	}
+	err := d.client.AttachToContainer(opts)
+	// make sure to close the output streams, the client waits for them
+	if stdout != nil {
+		stdout.Close()
+	}
+	if stderr != nil {
+		stderr.Close()
+	}
+	return err
+}
+
 // PortForward executes socat in the pod's network namespace and copies
 // data between stream (representing the user's local connection on their
 // computer) and the specified port in the container.
@@ -833,6 +861,7 @@ type ContainerCommandRunner interface {
 	RunInContainer(containerID string, cmd []string) ([]byte, error)
 	GetDockerServerVersion() ([]uint, error)
 	ExecInContainer(containerID string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool) error
+	AttachContainer(containerID string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	PortForward(podInfraContainerID string, port uint16, stream io.ReadWriteCloser) error
 }
 
@@ -946,6 +975,32 @@ func makeCapabilites(capAdd []api.CapabilityType, capDrop []api.CapabilityType)
 	return addCaps, dropCaps
 }
 
+// verifyRunAsNonRoot returns an error if securityContext requires container to
+// run as a non-root user, but it would run as root.
+func verifyRunAsNonRoot(client DockerInterface, container *api.Container, securityContext *api.SecurityContext) error {
+	if securityContext == nil || !securityContext.RunAsNonRoot {
+		return nil
+	}
+	if securityContext.RunAsUser != nil {
+		if *securityContext.RunAsUser == 0 {
+			return fmt.Errorf("container %q has runAsNonRoot set, but runs as root (uid 0)", container.Name)
+		}
+		return nil
+	}
+	image, err := client.InspectImage(container.Image)
+	if err != nil {
+		return fmt.Errorf("unable to verify that container %q runs as a non-root user: %v", container.Name, err)
+	}
+	user := ""
+	if image.Config != nil {
+		user = image.Config.User
+	}
+	if securitycontext.IsRootUser(user) {
+		return fmt.Errorf("container %q has runAsNonRoot set, but image %q runs as root; set runAsUser to a non-zero uid", container.Name, container.Image)
+	}
+	return nil
+}
+
 // RunContainer creates and starts a docker container with the required RunContainerOptions.
 // On success it will return the container's ID with nil error. During the process, it will
 // use the reference and event recorder to report the state of the container (e.g. created,
@@ -966,6 +1021,13 @@ func RunContainer(client DockerInterface, container *api.Container, pod *api.Pod
 	if len(containerHostname) > hostnameMaxLen {
 		containerHostname = containerHostname[:hostnameMaxLen]
 	}
+	securityContext := securitycontext.Effective(&pod.Spec, container)
+	if err := verifyRunAsNonRoot(client, container, securityContext); err != nil {
+		if ref != nil {
+			recorder.Eventf(ref, "failed", "Failed to create docker container with error: %v", err)
+		}
+		return "", err
+	}
 	dockerOpts := docker.CreateContainerOptions{
 		Name: BuildDockerName(dockerName, container),
 		Config: &docker.Config{
@@ -977,8 +1039,15 @@ func RunContainer(client DockerInterface, container *api.Container, pod *api.Pod
 			Memory:       container.Resources.Limits.Memory().Value(),
 			CPUShares:    milliCPUToShares(container.Resources.Limits.Cpu().MilliValue()),
 			WorkingDir:   container.WorkingDir,
+			// Stdin stays open across attaches, it is not closed when the first
+			// attached client detaches.
+			OpenStdin: container.Stdin,
+			Tty:       container.TTY,
 		},
 	}
+	if securityContext != nil && securityContext.RunAsUser != nil {
+		dockerOpts.Config.User = strconv.FormatInt(*securityContext.RunAsUser, 10)
+	}
 	dockerContainer, err := client.CreateContainer(dockerOpts)
 	if err != nil {
 		if ref != nil {
@@ -1027,6 +1096,10 @@ func RunContainer(client DockerInterface, container *api.Container, pod *api.Pod
 		CapAdd:       capAdd,
 		CapDrop:      capDrop,
 	}
+	if securityContext != nil {
+		hc.ReadonlyRootfs = securityContext.ReadOnlyRootFilesystem
+		hc.SecurityOpt = securitycontext.SELinuxLabelOptions(securityContext.SELinuxOptions)
+	}
 	if len(opts.DNS) > 0 {
 		hc.DNS = opts.DNS
 	}
@@ -17,13 +17,18 @@ limitations under the License.
 package dockertools
 
 import (
+	"bytes"
 	"fmt"
 	"hash/adler32"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/record"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/credentialprovider"
+	kubecontainer "github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet/container"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	docker "github.com/fsouza/go-dockerclient"
@@ -166,6 +171,77 @@ func TestExecSupportNotExists(t *testing.T) {
 	}
 }
 
+type attachDockerClient struct {
+	*FakeDockerClient
+	opts docker.AttachToContainerOptions
+}
+
+func (f *attachDockerClient) AttachToContainer(opts docker.AttachToContainerOptions) error {
+	f.opts = opts
+	return f.FakeDockerClient.AttachToContainer(opts)
+}
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestAttachContainer(t *testing.T) {
+	tests := []struct {
+		stdin, stdout, stderr, tty bool
+	}{
+		{stdin: true, stdout: true, stderr: true},
+		{stdout: true},
+		{stdin: true, stdout: true, tty: true},
+	}
+	for i, test := range tests {
+		fakeDocker := &attachDockerClient{FakeDockerClient: &FakeDockerClient{}}
+		runner := dockerContainerCommandRunner{fakeDocker}
+		var stdout, stderr *closeRecorder
+		var in io.Reader
+		var out, errOut io.WriteCloser
+		if test.stdin {
+			in = strings.NewReader("input")
+		}
+		if test.stdout {
+			stdout = &closeRecorder{}
+			out = stdout
+		}
+		if test.stderr {
+			stderr = &closeRecorder{}
+			errOut = stderr
+		}
+		if err := runner.AttachContainer("1234", in, out, errOut, test.tty); err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+			continue
+		}
+		if err := fakeDocker.AssertCalls([]string{"attach"}); err != nil {
+			t.Errorf("%d: %v", i, err)
+		}
+		opts := fakeDocker.opts
+		if opts.Container != "1234" || !opts.Stream || opts.RawTerminal != test.tty {
+			t.Errorf("%d: unexpected options: %#v", i, opts)
+		}
+		if opts.Stdin != test.stdin || opts.Stdout != test.stdout || opts.Stderr != test.stderr {
+			t.Errorf("%d: unexpected streams: %#v", i, opts)
+		}
+		if test.stdin && opts.InputStream != in {
+			t.Errorf("%d: expected stdin to be attached", i)
+		}
+		if test.stdout && !stdout.closed {
+			t.Errorf("%d: expected stdout to be closed", i)
+		}
+		if test.stderr && !stderr.closed {
+			t.Errorf("%d: expected stderr to be closed", i)
+		}
+	}
+}
+
 func TestDockerContainerCommand(t *testing.T) {
 	runner := dockerContainerCommandRunner{}
 	containerID := "1234"
@@ -701,3 +777,100 @@ func TestMakePortsAndBindings(t *testing.T) {
 		}
 	}
 }
+
+func TestRunContainerSecurityContext(t *testing.T) {
+	uid := func(i int64) *int64 { return &i }
+	testCases := map[string]struct {
+		pod         *api.SecurityContext
+		container   *api.SecurityContext
+		imageUser   string
+		expectErr   bool
+		expectCalls []string
+		expectHost  docker.HostConfig
+	}{
+		"no security context": {
+			expectCalls: []string{"create", "start"},
+		},
+		"read-only root and SELinux labels": {
+			pod:         &api.SecurityContext{SELinuxOptions: &api.SELinuxOptions{Level: "s0:c1,c2"}},
+			container:   &api.SecurityContext{ReadOnlyRootFilesystem: true},
+			expectCalls: []string{"create", "start"},
+			expectHost:  docker.HostConfig{ReadonlyRootfs: true, SecurityOpt: []string{"label:level:s0:c1,c2"}},
+		},
+		"non-root with uid": {
+			container:   &api.SecurityContext{RunAsUser: uid(1000), RunAsNonRoot: true},
+			expectCalls: []string{"create", "start"},
+		},
+		"non-root with root uid": {
+			pod:       &api.SecurityContext{RunAsNonRoot: true},
+			container: &api.SecurityContext{RunAsUser: uid(0)},
+			expectErr: true,
+		},
+		"non-root image": {
+			pod:         &api.SecurityContext{RunAsNonRoot: true},
+			imageUser:   "nobody",
+			expectCalls: []string{"inspect_image", "create", "start"},
+		},
+		"root image": {
+			pod:         &api.SecurityContext{RunAsNonRoot: true},
+			imageUser:   "root",
+			expectErr:   true,
+			expectCalls: []string{"inspect_image"},
+		},
+	}
+	for k, testCase := range testCases {
+		fakeDocker := &FakeDockerClient{
+			Image: &docker.Image{Config: &docker.Config{User: testCase.imageUser}},
+		}
+		container := &api.Container{Name: "foo", Image: "image", SecurityContext: testCase.container}
+		pod := &api.Pod{
+			ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "ns", UID: "uid"},
+			Spec: api.PodSpec{
+				Containers:      []api.Container{*container},
+				SecurityContext: testCase.pod,
+			},
+		}
+		_, err := RunContainer(fakeDocker, container, pod, &kubecontainer.RunContainerOptions{}, kubecontainer.NewRefManager(), nil, &record.FakeRecorder{})
+		if testCase.expectErr != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", k, err)
+		}
+		verifyCalls(t, fakeDocker, testCase.expectCalls)
+		if err != nil {
+			continue
+		}
+		hostConfig := fakeDocker.Container.HostConfig
+		if hostConfig.ReadonlyRootfs != testCase.expectHost.ReadonlyRootfs || !reflect.DeepEqual(hostConfig.SecurityOpt, testCase.expectHost.SecurityOpt) {
+			t.Errorf("%s: unexpected host config %#v", k, hostConfig)
+		}
+	}
+}
+
+func TestRunContainerStdinAndTTY(t *testing.T) {
+	testCases := []struct {
+		stdin, tty bool
+	}{
+		{false, false},
+		{true, false},
+		{true, true},
+	}
+	for _, testCase := range testCases {
+		fakeDocker := &FakeDockerClient{}
+		container := &api.Container{Name: "foo", Image: "image", Stdin: testCase.stdin, TTY: testCase.tty}
+		pod := &api.Pod{
+			ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "ns", UID: "uid"},
+			Spec:       api.PodSpec{Containers: []api.Container{*container}},
+		}
+		if _, err := RunContainer(fakeDocker, container, pod, &kubecontainer.RunContainerOptions{}, kubecontainer.NewRefManager(), nil, &record.FakeRecorder{}); err != nil {
+			t.Errorf("stdin %t, tty %t: unexpected error: %v", testCase.stdin, testCase.tty, err)
+			continue
+		}
+		if len(fakeDocker.CreatedConfigs) != 1 {
+			t.Errorf("stdin %t, tty %t: expected one created container, got %d", testCase.stdin, testCase.tty, len(fakeDocker.CreatedConfigs))
+			continue
+		}
+		config := fakeDocker.CreatedConfigs[0]
+		if config.OpenStdin != testCase.stdin || config.Tty != testCase.tty {
+			t.Errorf("stdin %t, tty %t: unexpected config %#v", testCase.stdin, testCase.tty, config)
+		}
+	}
+}
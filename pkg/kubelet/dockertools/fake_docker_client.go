@@ -41,9 +41,12 @@ type FakeDockerClient struct {
 	Stopped       []string
 	pulled        []string
 	Created       []string
-	Removed       []string
-	RemovedImages util.StringSet
-	VersionInfo   docker.Env
+	// CreatedConfigs holds the config of every created container, in the order
+	// of Created.
+	CreatedConfigs []*docker.Config
+	Removed        []string
+	RemovedImages  util.StringSet
+	VersionInfo    docker.Env
 }
 
 func (f *FakeDockerClient) ClearCalls() {
@@ -53,6 +56,7 @@ func (f *FakeDockerClient) ClearCalls() {
 	f.Stopped = []string{}
 	f.pulled = []string{}
 	f.Created = []string{}
+	f.CreatedConfigs = []*docker.Config{}
 	f.Removed = []string{}
 }
 
@@ -123,6 +127,7 @@ func (f *FakeDockerClient) CreateContainer(c docker.CreateContainerOptions) (*do
 	defer f.Unlock()
 	f.called = append(f.called, "create")
 	f.Created = append(f.Created, c.Name)
+	f.CreatedConfigs = append(f.CreatedConfigs, c.Config)
 	// This is not a very good fake. We'll just add this container's name to the list.
 	// Docker likes to add a '/', so copy that behavior.
 	name := "/" + c.Name
@@ -210,6 +215,13 @@ func (f *FakeDockerClient) StartExec(_ string, _ docker.StartExecOptions) error
 	return nil
 }
 
+func (f *FakeDockerClient) AttachToContainer(opts docker.AttachToContainerOptions) error {
+	f.Lock()
+	defer f.Unlock()
+	f.called = append(f.called, "attach")
+	return f.Err
+}
+
 func (f *FakeDockerClient) ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error) {
 	return f.Images, f.Err
 }
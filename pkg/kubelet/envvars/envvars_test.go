@@ -30,45 +30,43 @@ func TestFromServices(t *testing.T) {
 			{
 				ObjectMeta: api.ObjectMeta{Name: "foo-bar"},
 				Spec: api.ServiceSpec{
-					Port:     8080,
 					Selector: map[string]string{"bar": "baz"},
-					Protocol: "TCP",
+					Ports:    []api.ServicePort{{Protocol: "TCP", Port: 8080}},
 					PortalIP: "1.2.3.4",
 				},
 			},
 			{
 				ObjectMeta: api.ObjectMeta{Name: "abc-123"},
 				Spec: api.ServiceSpec{
-					Port:     8081,
 					Selector: map[string]string{"bar": "baz"},
-					Protocol: "UDP",
+					Ports:    []api.ServicePort{{Protocol: "UDP", Port: 8081}},
 					PortalIP: "5.6.7.8",
 				},
 			},
 			{
 				ObjectMeta: api.ObjectMeta{Name: "q-u-u-x"},
 				Spec: api.ServiceSpec{
-					Port:     8082,
 					Selector: map[string]string{"bar": "baz"},
-					Protocol: "TCP",
+					Ports: []api.ServicePort{
+						{Name: "u-d-p", Port: 8081, Protocol: "UDP"},
+						{Name: "t-c-p", Port: 8081, Protocol: "TCP"},
+					},
 					PortalIP: "9.8.7.6",
 				},
 			},
 			{
 				ObjectMeta: api.ObjectMeta{Name: "svrc-portalip-none"},
 				Spec: api.ServiceSpec{
-					Port:     8082,
 					Selector: map[string]string{"bar": "baz"},
-					Protocol: "TCP",
+					Ports:    []api.ServicePort{{Protocol: "TCP", Port: 8082}},
 					PortalIP: "None",
 				},
 			},
 			{
 				ObjectMeta: api.ObjectMeta{Name: "svrc-portalip-empty"},
 				Spec: api.ServiceSpec{
-					Port:     8082,
 					Selector: map[string]string{"bar": "baz"},
-					Protocol: "TCP",
+					Ports:    []api.ServicePort{{Protocol: "TCP", Port: 8082}},
 					PortalIP: "",
 				},
 			},
@@ -91,12 +89,18 @@ func TestFromServices(t *testing.T) {
 		{Name: "ABC_123_PORT_8081_UDP_PORT", Value: "8081"},
 		{Name: "ABC_123_PORT_8081_UDP_ADDR", Value: "5.6.7.8"},
 		{Name: "Q_U_U_X_SERVICE_HOST", Value: "9.8.7.6"},
-		{Name: "Q_U_U_X_SERVICE_PORT", Value: "8082"},
-		{Name: "Q_U_U_X_PORT", Value: "tcp://9.8.7.6:8082"},
-		{Name: "Q_U_U_X_PORT_8082_TCP", Value: "tcp://9.8.7.6:8082"},
-		{Name: "Q_U_U_X_PORT_8082_TCP_PROTO", Value: "tcp"},
-		{Name: "Q_U_U_X_PORT_8082_TCP_PORT", Value: "8082"},
-		{Name: "Q_U_U_X_PORT_8082_TCP_ADDR", Value: "9.8.7.6"},
+		{Name: "Q_U_U_X_SERVICE_PORT", Value: "8081"},
+		{Name: "Q_U_U_X_SERVICE_PORT_U_D_P", Value: "8081"},
+		{Name: "Q_U_U_X_SERVICE_PORT_T_C_P", Value: "8081"},
+		{Name: "Q_U_U_X_PORT", Value: "udp://9.8.7.6:8081"},
+		{Name: "Q_U_U_X_PORT_8081_UDP", Value: "udp://9.8.7.6:8081"},
+		{Name: "Q_U_U_X_PORT_8081_UDP_PROTO", Value: "udp"},
+		{Name: "Q_U_U_X_PORT_8081_UDP_PORT", Value: "8081"},
+		{Name: "Q_U_U_X_PORT_8081_UDP_ADDR", Value: "9.8.7.6"},
+		{Name: "Q_U_U_X_PORT_8081_TCP", Value: "tcp://9.8.7.6:8081"},
+		{Name: "Q_U_U_X_PORT_8081_TCP_PROTO", Value: "tcp"},
+		{Name: "Q_U_U_X_PORT_8081_TCP_PORT", Value: "8081"},
+		{Name: "Q_U_U_X_PORT_8081_TCP_ADDR", Value: "9.8.7.6"},
 	}
 	if len(vars) != len(expected) {
 		t.Errorf("Expected %d env vars, got: %+v", len(expected), vars)
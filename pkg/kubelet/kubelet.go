@@ -1994,6 +1994,25 @@ func (kl *Kubelet) ExecInContainer(podFullName string, uid types.UID, container
 	return kl.runner.ExecInContainer(dockerContainer.ID, cmd, stdin, stdout, stderr, tty)
 }
 
+// AttachContainer connects the supplied stdin/stdout/stderr to the IO streams of the
+// main process of a running container.
+func (kl *Kubelet) AttachContainer(podFullName string, uid types.UID, container string, stdin io.Reader, stdout, stderr io.WriteCloser, tty bool) error {
+	uid = kl.podManager.TranslatePodUID(uid)
+
+	if kl.runner == nil {
+		return fmt.Errorf("no runner specified.")
+	}
+	dockerContainers, err := dockertools.GetKubeletDockerContainers(kl.dockerClient, false)
+	if err != nil {
+		return err
+	}
+	dockerContainer, found, _ := dockerContainers.FindPodContainer(podFullName, uid, container)
+	if !found {
+		return fmt.Errorf("container not found (%q)", container)
+	}
+	return kl.runner.AttachContainer(dockerContainer.ID, stdin, stdout, stderr, tty)
+}
+
 // PortForward connects to the pod's port and copies data between the port
 // and the stream.
 func (kl *Kubelet) PortForward(podFullName string, uid types.UID, port uint16, stream io.ReadWriteCloser) error {
@@ -48,7 +48,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/volume"
-	_ "github.com/GoogleCloudPlatform/kubernetes/pkg/volume/host_path"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/volume/host_path"
 	"github.com/fsouza/go-dockerclient"
 	cadvisorApi "github.com/google/cadvisor/info/v1"
 )
@@ -1110,6 +1110,74 @@ func TestMountExternalVolumes(t *testing.T) {
 	}
 }
 
+func TestMountPersistentVolumeClaim(t *testing.T) {
+	testKubelet := newTestKubelet(t)
+	kubelet := testKubelet.kubelet
+	kubelet.volumePluginMgr.InitPlugins(host_path.ProbeVolumePlugins(), &volumeHost{kubelet})
+
+	testKubelet.fakeKubeClient.PersistentVolumeClaim = api.PersistentVolumeClaim{
+		ObjectMeta: api.ObjectMeta{Name: "claim", Namespace: "test", UID: "claim-uid"},
+		Status: api.PersistentVolumeClaimStatus{
+			Phase:     api.ClaimBound,
+			VolumeRef: &api.ObjectReference{Name: "volume"},
+		},
+	}
+	testKubelet.fakeKubeClient.PersistentVolume = api.PersistentVolume{
+		ObjectMeta: api.ObjectMeta{Name: "volume"},
+		Spec: api.PersistentVolumeSpec{
+			PersistentVolumeSource: api.PersistentVolumeSource{
+				HostPath: &api.HostPathVolumeSource{Path: "/mnt/volume"},
+			},
+			ClaimRef: &api.ObjectReference{Name: "claim", Namespace: "test", UID: "claim-uid"},
+		},
+	}
+
+	pod := api.Pod{
+		ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      "foo",
+			Namespace: "test",
+		},
+		Spec: api.PodSpec{
+			Volumes: []api.Volume{
+				{
+					Name: "vol1",
+					VolumeSource: api.VolumeSource{
+						PersistentVolumeClaimVolumeSource: &api.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+					},
+				},
+			},
+		},
+	}
+	podVolumes, err := kubelet.mountExternalVolumes(&pod)
+	if err != nil {
+		t.Fatalf("Expected success: %v", err)
+	}
+	builder, ok := podVolumes["vol1"]
+	if !ok {
+		t.Fatalf("api.Pod volumes map is missing key: vol1. %#v", podVolumes)
+	}
+	if path := builder.GetPath(); path != "/mnt/volume" {
+		t.Errorf("Expected the bound volume to be mounted, got path %q", path)
+	}
+
+	// A claim that is not bound yet must not be mounted.
+	testKubelet.fakeKubeClient.PersistentVolumeClaim.Status = api.PersistentVolumeClaimStatus{Phase: api.ClaimPending}
+	if _, err := kubelet.mountExternalVolumes(&pod); err == nil {
+		t.Errorf("Expected an error for an unbound claim")
+	}
+
+	// A volume bound to another claim must not be mounted.
+	testKubelet.fakeKubeClient.PersistentVolumeClaim.Status = api.PersistentVolumeClaimStatus{
+		Phase:     api.ClaimBound,
+		VolumeRef: &api.ObjectReference{Name: "volume"},
+	}
+	testKubelet.fakeKubeClient.PersistentVolume.Spec.ClaimRef.UID = "other-uid"
+	if _, err := kubelet.mountExternalVolumes(&pod); err == nil {
+		t.Errorf("Expected an error for a volume bound to another claim")
+	}
+}
+
 func TestGetPodVolumesFromDisk(t *testing.T) {
 	testKubelet := newTestKubelet(t)
 	kubelet := testKubelet.kubelet
@@ -1422,6 +1490,15 @@ func (f *fakeContainerCommandRunner) ExecInContainer(id string, cmd []string, in
 	return f.E
 }
 
+func (f *fakeContainerCommandRunner) AttachContainer(id string, in io.Reader, out, err io.WriteCloser, tty bool) error {
+	f.ID = id
+	f.Stdin = in
+	f.Stdout = out
+	f.Stderr = err
+	f.TTY = tty
+	return f.E
+}
+
 func (f *fakeContainerCommandRunner) PortForward(podInfraContainerID string, port uint16, stream io.ReadWriteCloser) error {
 	f.ID = podInfraContainerID
 	f.Port = port
@@ -1786,97 +1863,97 @@ func TestMakeEnvironmentVariables(t *testing.T) {
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes", Namespace: api.NamespaceDefault},
 			Spec: api.ServiceSpec{
-				Port:     8081,
+				Ports: []api.ServicePort{{Port: 8081}},
 				PortalIP: "1.2.3.1",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes-ro", Namespace: api.NamespaceDefault},
 			Spec: api.ServiceSpec{
-				Port:     8082,
+				Ports: []api.ServicePort{{Port: 8082}},
 				PortalIP: "1.2.3.2",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes-ro", Namespace: api.NamespaceDefault},
 			Spec: api.ServiceSpec{
-				Port:     8082,
+				Ports: []api.ServicePort{{Port: 8082}},
 				PortalIP: "None",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes-ro", Namespace: api.NamespaceDefault},
 			Spec: api.ServiceSpec{
-				Port:     8082,
+				Ports: []api.ServicePort{{Port: 8082}},
 				PortalIP: "",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "test", Namespace: "test1"},
 			Spec: api.ServiceSpec{
-				Port:     8083,
+				Ports: []api.ServicePort{{Port: 8083}},
 				PortalIP: "1.2.3.3",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes", Namespace: "test2"},
 			Spec: api.ServiceSpec{
-				Port:     8084,
+				Ports: []api.ServicePort{{Port: 8084}},
 				PortalIP: "1.2.3.4",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "test", Namespace: "test2"},
 			Spec: api.ServiceSpec{
-				Port:     8085,
+				Ports: []api.ServicePort{{Port: 8085}},
 				PortalIP: "1.2.3.5",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "test", Namespace: "test2"},
 			Spec: api.ServiceSpec{
-				Port:     8085,
+				Ports: []api.ServicePort{{Port: 8085}},
 				PortalIP: "None",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "test", Namespace: "test2"},
 			Spec: api.ServiceSpec{
-				Port: 8085,
+				Ports: []api.ServicePort{{Port: 8085}},
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes", Namespace: "kubernetes"},
 			Spec: api.ServiceSpec{
-				Port:     8086,
+				Ports: []api.ServicePort{{Port: 8086}},
 				PortalIP: "1.2.3.6",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "kubernetes-ro", Namespace: "kubernetes"},
 			Spec: api.ServiceSpec{
-				Port:     8087,
+				Ports: []api.ServicePort{{Port: 8087}},
 				PortalIP: "1.2.3.7",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "not-special", Namespace: "kubernetes"},
 			Spec: api.ServiceSpec{
-				Port:     8088,
+				Ports: []api.ServicePort{{Port: 8088}},
 				PortalIP: "1.2.3.8",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "not-special", Namespace: "kubernetes"},
 			Spec: api.ServiceSpec{
-				Port:     8088,
+				Ports: []api.ServicePort{{Port: 8088}},
 				PortalIP: "None",
 			},
 		},
 		{
 			ObjectMeta: api.ObjectMeta{Name: "not-special", Namespace: "kubernetes"},
 			Spec: api.ServiceSpec{
-				Port:     8088,
+				Ports: []api.ServicePort{{Port: 8088}},
 				PortalIP: "",
 			},
 		},
@@ -2641,6 +2718,86 @@ func TestExecInContainer(t *testing.T) {
 	}
 }
 
+func TestAttachContainer(t *testing.T) {
+	fakeCommandRunner := fakeContainerCommandRunner{}
+	testKubelet := newTestKubelet(t)
+	kubelet := testKubelet.kubelet
+	fakeDocker := testKubelet.fakeDocker
+	kubelet.runner = &fakeCommandRunner
+
+	podName := "podFoo"
+	podNamespace := "nsFoo"
+	containerID := "containerFoo"
+	stdin := &bytes.Buffer{}
+	stdout := &fakeReadWriteCloser{}
+	stderr := &fakeReadWriteCloser{}
+	tty := true
+
+	fakeDocker.ContainerList = []docker.APIContainers{
+		{
+			ID:    containerID,
+			Names: []string{"/k8s_" + containerID + "_" + podName + "_" + podNamespace + "_12345678_42"},
+		},
+	}
+
+	err := kubelet.AttachContainer(
+		kubecontainer.GetPodFullName(&api.Pod{ObjectMeta: api.ObjectMeta{
+			UID:       "12345678",
+			Name:      podName,
+			Namespace: podNamespace,
+		}}),
+		"",
+		containerID,
+		stdin,
+		stdout,
+		stderr,
+		tty,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e, a := containerID, fakeCommandRunner.ID; e != a {
+		t.Fatalf("container id: expected %s, got %s", e, a)
+	}
+	if e, a := stdin, fakeCommandRunner.Stdin; e != a {
+		t.Fatalf("stdin: expected %#v, got %#v", e, a)
+	}
+	if e, a := stdout, fakeCommandRunner.Stdout; e != a {
+		t.Fatalf("stdout: expected %#v, got %#v", e, a)
+	}
+	if e, a := stderr, fakeCommandRunner.Stderr; e != a {
+		t.Fatalf("stderr: expected %#v, got %#v", e, a)
+	}
+	if e, a := tty, fakeCommandRunner.TTY; e != a {
+		t.Fatalf("tty: expected %t, got %t", e, a)
+	}
+}
+
+func TestAttachContainerNoSuchContainer(t *testing.T) {
+	fakeCommandRunner := fakeContainerCommandRunner{}
+	testKubelet := newTestKubelet(t)
+	kubelet := testKubelet.kubelet
+	fakeDocker := testKubelet.fakeDocker
+	fakeDocker.ContainerList = []docker.APIContainers{}
+	kubelet.runner = &fakeCommandRunner
+
+	err := kubelet.AttachContainer(
+		kubecontainer.GetPodFullName(&api.Pod{ObjectMeta: api.ObjectMeta{Name: "podFoo", Namespace: "nsFoo"}}),
+		"",
+		"containerFoo",
+		nil,
+		nil,
+		nil,
+		false,
+	)
+	if err == nil {
+		t.Fatal("unexpected non-error")
+	}
+	if fakeCommandRunner.ID != "" {
+		t.Fatal("unexpected invocation of runner.AttachContainer")
+	}
+}
+
 func TestPortForwardNoSuchPod(t *testing.T) {
 	fakeCommandRunner := fakeContainerCommandRunner{}
 	testKubelet := newTestKubelet(t)
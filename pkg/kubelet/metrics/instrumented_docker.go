@@ -147,3 +147,11 @@ func (self instrumentedDockerInterface) StartExec(startExec string, opts docker.
 	}()
 	return self.client.StartExec(startExec, opts)
 }
+
+func (self instrumentedDockerInterface) AttachToContainer(opts docker.AttachToContainerOptions) error {
+	start := time.Now()
+	defer func() {
+		DockerOperationsLatency.WithLabelValues("attach").Observe(SinceInMicroseconds(start))
+	}()
+	return self.client.AttachToContainer(opts)
+}
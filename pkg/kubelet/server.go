@@ -88,6 +88,7 @@ type HostInterface interface {
 	GetPodStatus(name string) (api.PodStatus, error)
 	RunInContainer(name string, uid types.UID, container string, cmd []string) ([]byte, error)
 	ExecInContainer(name string, uid types.UID, container string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool) error
+	AttachContainer(name string, uid types.UID, container string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	GetKubeletContainerLogs(podFullName, containerName, tail string, follow bool, stdout, stderr io.Writer) error
 	ServeLogs(w http.ResponseWriter, req *http.Request)
 	PortForward(name string, uid types.UID, port uint16, stream io.ReadWriteCloser) error
@@ -127,6 +128,7 @@ func (s *Server) InstallDefaultHandlers() {
 func (s *Server) InstallDebuggingHandlers() {
 	s.mux.HandleFunc("/run/", s.handleRun)
 	s.mux.HandleFunc("/exec/", s.handleExec)
+	s.mux.HandleFunc("/attach/", s.handleAttach)
 	s.mux.HandleFunc("/portForward/", s.handlePortForward)
 
 	s.mux.HandleFunc("/logs/", s.handleLogs)
@@ -436,6 +438,63 @@ func (s *Server) handleExec(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	stdinStream, stdoutStream, stderrStream, errorStream, conn, tty, ok := s.createStreams(w, req)
+	if conn != nil {
+		defer conn.Close()
+	}
+	if !ok {
+		return
+	}
+	defer errorStream.Reset()
+
+	err = s.host.ExecInContainer(kubecontainer.GetPodFullName(pod), uid, container, u.Query()[api.ExecCommandParamm], stdinStream, stdoutStream, stderrStream, tty)
+	if err != nil {
+		msg := fmt.Sprintf("Error executing command in container: %v", err)
+		glog.Error(msg)
+		errorStream.Write([]byte(msg))
+	}
+}
+
+// handleAttach handles requests to attach to the streams of the main process of a container.
+func (s *Server) handleAttach(w http.ResponseWriter, req *http.Request) {
+	u, err := url.ParseRequestURI(req.RequestURI)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	podNamespace, podID, uid, container, err := parseContainerCoordinates(u.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pod, ok := s.host.GetPodByName(podNamespace, podID)
+	if !ok {
+		http.Error(w, "Pod does not exist", http.StatusNotFound)
+		return
+	}
+
+	stdinStream, stdoutStream, stderrStream, errorStream, conn, tty, ok := s.createStreams(w, req)
+	if conn != nil {
+		defer conn.Close()
+	}
+	if !ok {
+		return
+	}
+	defer errorStream.Reset()
+
+	err = s.host.AttachContainer(kubecontainer.GetPodFullName(pod), uid, container, stdinStream, stdoutStream, stderrStream, tty)
+	if err != nil {
+		msg := fmt.Sprintf("Error attaching to container: %v", err)
+		glog.Error(msg)
+		errorStream.Write([]byte(msg))
+	}
+}
+
+// createStreams upgrades the request to a streaming connection and waits for the client
+// to create the error stream and the stdin, stdout and stderr streams it asked for. If ok
+// is false the streams could not be set up and the caller must return, closing the
+// connection if one was returned.
+func (s *Server) createStreams(w http.ResponseWriter, req *http.Request) (stdinStream, stdoutStream, stderrStream, errorStream httpstream.Stream, conn httpstream.Connection, tty, ok bool) {
 	req.ParseForm()
 	// start at 1 for error stream
 	expectedStreams := 1
@@ -445,7 +504,7 @@ func (s *Server) handleExec(w http.ResponseWriter, req *http.Request) {
 	if req.FormValue(api.ExecStdoutParam) == "1" {
 		expectedStreams++
 	}
-	tty := req.FormValue(api.ExecTTYParam) == "1"
+	tty = req.FormValue(api.ExecTTYParam) == "1"
 	if !tty && req.FormValue(api.ExecStderrParam) == "1" {
 		expectedStreams++
 	}
@@ -458,7 +517,7 @@ func (s *Server) handleExec(w http.ResponseWriter, req *http.Request) {
 	streamCh := make(chan httpstream.Stream)
 
 	upgrader := spdy.NewResponseUpgrader()
-	conn := upgrader.UpgradeResponse(w, req, func(stream httpstream.Stream) error {
+	conn = upgrader.UpgradeResponse(w, req, func(stream httpstream.Stream) error {
 		streamCh <- stream
 		return nil
 	})
@@ -469,7 +528,6 @@ func (s *Server) handleExec(w http.ResponseWriter, req *http.Request) {
 		// if we weren't successful in upgrading.
 		return
 	}
-	defer conn.Close()
 
 	conn.SetIdleTimeout(s.host.StreamingConnectionIdleTimeout())
 
@@ -477,7 +535,6 @@ func (s *Server) handleExec(w http.ResponseWriter, req *http.Request) {
 	// TODO make it configurable?
 	expired := time.NewTimer(2 * time.Second)
 
-	var errorStream, stdinStream, stdoutStream, stderrStream httpstream.Stream
 	receivedStreams := 0
 WaitForStreams:
 	for {
@@ -487,7 +544,6 @@ WaitForStreams:
 			switch streamType {
 			case api.StreamTypeError:
 				errorStream = stream
-				defer errorStream.Reset()
 				receivedStreams++
 			case api.StreamTypeStdin:
 				stdinStream = stream
@@ -508,6 +564,9 @@ WaitForStreams:
 			// TODO find a way to return the error to the user. Maybe use a separate
 			// stream to report errors?
 			glog.Error("Timed out waiting for client to create streams")
+			if errorStream != nil {
+				errorStream.Reset()
+			}
 			return
 		}
 	}
@@ -517,12 +576,7 @@ WaitForStreams:
 		stdinStream.Close()
 	}
 
-	err = s.host.ExecInContainer(kubecontainer.GetPodFullName(pod), uid, container, u.Query()[api.ExecCommandParamm], stdinStream, stdoutStream, stderrStream, tty)
-	if err != nil {
-		msg := fmt.Sprintf("Error executing command in container: %v", err)
-		glog.Error(msg)
-		errorStream.Write([]byte(msg))
-	}
+	return stdinStream, stdoutStream, stderrStream, errorStream, conn, tty, true
 }
 
 func parsePodCoordinates(path string) (namespace, pod string, uid types.UID, err error) {
@@ -49,6 +49,7 @@ type fakeKubelet struct {
 	runFunc                            func(podFullName string, uid types.UID, containerName string, cmd []string) ([]byte, error)
 	dockerVersionFunc                  func() ([]uint, error)
 	execFunc                           func(pod string, uid types.UID, container string, cmd []string, in io.Reader, out, err io.WriteCloser, tty bool) error
+	attachFunc                         func(pod string, uid types.UID, container string, in io.Reader, out, err io.WriteCloser, tty bool) error
 	portForwardFunc                    func(name string, uid types.UID, port uint16, stream io.ReadWriteCloser) error
 	containerLogsFunc                  func(podFullName, containerName, tail string, follow bool, stdout, stderr io.Writer) error
 	streamingConnectionIdleTimeoutFunc func() time.Duration
@@ -103,6 +104,10 @@ func (fk *fakeKubelet) ExecInContainer(name string, uid types.UID, container str
 	return fk.execFunc(name, uid, container, cmd, in, out, err, tty)
 }
 
+func (fk *fakeKubelet) AttachContainer(name string, uid types.UID, container string, in io.Reader, out, err io.WriteCloser, tty bool) error {
+	return fk.attachFunc(name, uid, container, in, out, err, tty)
+}
+
 func (fk *fakeKubelet) PortForward(name string, uid types.UID, port uint16, stream io.ReadWriteCloser) error {
 	return fk.portForwardFunc(name, uid, port, stream)
 }
@@ -674,6 +679,14 @@ func TestServeExecInContainerIdleTimeout(t *testing.T) {
 }
 
 func TestServeExecInContainer(t *testing.T) {
+	testExecAttach(t, "exec")
+}
+
+func TestServeAttachContainer(t *testing.T) {
+	testExecAttach(t, "attach")
+}
+
+func testExecAttach(t *testing.T, verb string) {
 	tests := []struct {
 		stdin              bool
 		stdout             bool
@@ -711,7 +724,7 @@ func TestServeExecInContainer(t *testing.T) {
 		clientStdoutReadDone := make(chan struct{})
 		clientStderrReadDone := make(chan struct{})
 
-		fw.fakeKubelet.execFunc = func(podFullName string, uid types.UID, containerName string, cmd []string, in io.Reader, out, stderr io.WriteCloser, tty bool) error {
+		testStreamFunc := func(podFullName string, uid types.UID, containerName string, cmd []string, in io.Reader, out, stderr io.WriteCloser, tty bool) error {
 			defer close(execFuncDone)
 			if podFullName != expectedPodName {
 				t.Fatalf("%d: podFullName: expected %s, got %s", i, expectedPodName, podFullName)
@@ -722,7 +735,7 @@ func TestServeExecInContainer(t *testing.T) {
 			if containerName != expectedContainerName {
 				t.Fatalf("%d: containerName: expected %s, got %s", i, expectedContainerName, containerName)
 			}
-			if strings.Join(cmd, " ") != expectedCommand {
+			if verb == "exec" && strings.Join(cmd, " ") != expectedCommand {
 				t.Fatalf("%d: cmd: expected: %s, got %v", i, expectedCommand, cmd)
 			}
 
@@ -776,25 +789,37 @@ func TestServeExecInContainer(t *testing.T) {
 
 			return nil
 		}
+		if verb == "exec" {
+			fw.fakeKubelet.execFunc = testStreamFunc
+		} else {
+			fw.fakeKubelet.attachFunc = func(podFullName string, uid types.UID, containerName string, in io.Reader, out, stderr io.WriteCloser, tty bool) error {
+				return testStreamFunc(podFullName, uid, containerName, nil, in, out, stderr, tty)
+			}
+		}
 
 		var url string
 		if test.uid {
-			url = fw.testHTTPServer.URL + "/exec/" + podNamespace + "/" + podName + "/" + expectedUid + "/" + expectedContainerName + "?command=ls&command=-a"
+			url = fw.testHTTPServer.URL + "/" + verb + "/" + podNamespace + "/" + podName + "/" + expectedUid + "/" + expectedContainerName
 		} else {
-			url = fw.testHTTPServer.URL + "/exec/" + podNamespace + "/" + podName + "/" + expectedContainerName + "?command=ls&command=-a"
+			url = fw.testHTTPServer.URL + "/" + verb + "/" + podNamespace + "/" + podName + "/" + expectedContainerName
+		}
+		query := []string{}
+		if verb == "exec" {
+			query = append(query, "command=ls", "command=-a")
 		}
 		if test.stdin {
-			url += "&" + api.ExecStdinParam + "=1"
+			query = append(query, api.ExecStdinParam+"=1")
 		}
 		if test.stdout {
-			url += "&" + api.ExecStdoutParam + "=1"
+			query = append(query, api.ExecStdoutParam+"=1")
 		}
 		if test.stderr && !test.tty {
-			url += "&" + api.ExecStderrParam + "=1"
+			query = append(query, api.ExecStderrParam+"=1")
 		}
 		if test.tty {
-			url += "&" + api.ExecTTYParam + "=1"
+			query = append(query, api.ExecTTYParam+"=1")
 		}
+		url += "?" + strings.Join(query, "&")
 
 		var (
 			resp                *http.Response
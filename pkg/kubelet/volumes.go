@@ -106,6 +106,16 @@ func (kl *Kubelet) mountExternalVolumes(pod *api.Pod) (volumeMap, error) {
 			return nil, err
 		}
 
+		// Claims are resolved to the volume they are bound to, which is then
+		// handed to the plugin supporting that volume.
+		if volSpec.PersistentVolumeClaimVolumeSource != nil {
+			volSpec, err = kl.newVolumeSpecFromClaim(pod.Namespace, volSpec, podRef)
+			if err != nil {
+				glog.Errorf("Could not resolve persistent volume claim for pod %s: %v", pod.UID, err)
+				return nil, err
+			}
+		}
+
 		// Try to use a plugin for this volume.
 		builder, err := kl.newVolumeBuilderFromPlugins(volSpec, podRef)
 		if err != nil {
@@ -124,6 +134,40 @@ func (kl *Kubelet) mountExternalVolumes(pod *api.Pod) (volumeMap, error) {
 	return podVolumes, nil
 }
 
+// newVolumeSpecFromClaim looks up the PersistentVolume bound to the claim
+// referenced by spec and returns a volume spec for it under the same volume
+// name.  An event is recorded for the pod if the claim is not bound yet.
+func (kl *Kubelet) newVolumeSpecFromClaim(namespace string, spec *api.Volume, podRef *api.ObjectReference) (*api.Volume, error) {
+	source := spec.PersistentVolumeClaimVolumeSource
+	if kl.kubeClient == nil {
+		return nil, fmt.Errorf("cannot resolve claim %q for volume %q without an apiserver", source.ClaimName, spec.Name)
+	}
+	claim, err := kl.kubeClient.PersistentVolumeClaims(namespace).Get(source.ClaimName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim %q for volume %q: %v", source.ClaimName, spec.Name, err)
+	}
+	if claim.Status.Phase != api.ClaimBound || claim.Status.VolumeRef == nil {
+		kl.recorder.Eventf(podRef, "claimNotBound", "Persistent volume claim %q for volume %q is not bound yet", source.ClaimName, spec.Name)
+		return nil, fmt.Errorf("claim %q for volume %q is not bound", source.ClaimName, spec.Name)
+	}
+	pv, err := kl.kubeClient.PersistentVolumes().Get(claim.Status.VolumeRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persistent volume %q bound to claim %q: %v", claim.Status.VolumeRef.Name, source.ClaimName, err)
+	}
+	if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.UID != claim.UID {
+		return nil, fmt.Errorf("persistent volume %q is not bound to claim %q", pv.Name, source.ClaimName)
+	}
+
+	volSpec := volume.NewSpecFromPersistentVolume(pv)
+	volSpec.Name = spec.Name
+	if source.ReadOnly && volSpec.GCEPersistentDisk != nil {
+		pd := *volSpec.GCEPersistentDisk
+		pd.ReadOnly = true
+		volSpec.GCEPersistentDisk = &pd
+	}
+	return volSpec, nil
+}
+
 // getPodVolumesFromDisk examines directory structure to determine volumes that
 // are presently active and mounted. Returns a map of volume.Cleaner types.
 func (kl *Kubelet) getPodVolumesFromDisk() map[string]volume.Cleaner {
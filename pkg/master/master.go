@@ -19,11 +19,13 @@ package master
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/pprof"
 	"net/url"
 	rt "runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -42,21 +44,32 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/cloudprovider"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/master/ports"
+	clusterroleetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/clusterrole/etcd"
+	clusterrolebindingetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/clusterrolebinding/etcd"
 	controlleretcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/controller/etcd"
+	daemonsetetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/daemonset/etcd"
+	deploymentetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/deployment/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/endpoint"
 	endpointsetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/endpoint/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/event"
+	autoscaleretcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/horizontalpodautoscaler/etcd"
+	jobetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/job/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/limitrange"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/minion"
 	nodeetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/minion/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/namespace"
 	namespaceetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/namespace/etcd"
+	pvetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/persistentvolume/etcd"
+	pvcetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/persistentvolumeclaim/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/pod"
 	podetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/pod/etcd"
 	resourcequotaetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/resourcequota/etcd"
+	roleetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/role/etcd"
+	rolebindingetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/rolebinding/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/secret"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/service"
+	serviceaccountetcd "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/serviceaccount/etcd"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/ui"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
@@ -90,6 +103,16 @@ type Config struct {
 	AdmissionControl       admission.Interface
 	MasterServiceNamespace string
 
+	// If specified, an audit event is written here for each request on the
+	// secure port, filtered by AuditPolicy.
+	AuditWriter io.Writer
+	AuditPolicy apiserver.AuditPolicy
+
+	// If specified, values stored in etcd for a resource, such as "secrets",
+	// are transformed (for example encrypted) by the matching transformer.
+	// Every key must be one of the resources accepted by ParseStorageResources.
+	StorageTransformers map[string]tools.ValueTransformer
+
 	// Map requests to contexts. Exported so downstream consumers can provider their own mappers
 	RequestContextMapper api.RequestContextMapper
 
@@ -119,13 +142,18 @@ type Config struct {
 
 	// The name of the cluster.
 	ClusterName string
+
+	// The range of host ports from which node ports are assigned to services
+	// of type NodePort.  Defaults to 30000-32767 if not set.
+	ServiceNodePorts util.PortRange
 }
 
 // Master contains state for a Kubernetes cluster master/api server.
 type Master struct {
 	// "Inputs", Copied from Config
-	portalNet    *net.IPNet
-	cacheTimeout time.Duration
+	portalNet        *net.IPNet
+	serviceNodePorts util.PortRange
+	cacheTimeout     time.Duration
 
 	mux                   apiserver.Mux
 	muxHelper             *apiserver.MuxHelper
@@ -185,6 +213,75 @@ func NewEtcdHelper(client tools.EtcdGetSet, version string) (helper tools.EtcdHe
 	return tools.NewEtcdHelper(client, versionInterfaces.Codec), nil
 }
 
+// storageResourceKeys maps the name of every resource stored in etcd to the
+// key, relative to the registry root, which its values are stored under.
+var storageResourceKeys = map[string]string{
+	"clusterrolebindings":      "clusterrolebindings",
+	"clusterroles":             "clusterroles",
+	"daemonsets":               "daemonsets",
+	"deployments":              "deployments",
+	"endpoints":                "services/endpoints",
+	"events":                   "events",
+	"horizontalpodautoscalers": "horizontalpodautoscalers",
+	"jobs":                     "jobs",
+	"limitranges":              "limitranges",
+	"namespaces":               "namespaces",
+	"nodes":                    "minions",
+	"persistentvolumeclaims":   "persistentvolumeclaims",
+	"persistentvolumes":        "persistentvolumes",
+	"pods":                     "pods",
+	"replicationcontrollers":   "controllers",
+	"resourcequotas":           "resourcequotas",
+	"rolebindings":             "rolebindings",
+	"roles":                    "roles",
+	"secrets":                  "secrets",
+	"serviceaccounts":          "serviceaccounts",
+	"services":                 "services/specs",
+}
+
+// ParseStorageResources splits a comma-delimited list of resource names, such
+// as "secrets,serviceaccounts", and returns an error if any of them is not
+// stored in etcd by the master.
+func ParseStorageResources(list string) ([]string, error) {
+	resources := []string{}
+	for _, resource := range strings.Split(list, ",") {
+		resource = strings.TrimSpace(resource)
+		if len(resource) == 0 {
+			continue
+		}
+		if _, ok := storageResourceKeys[resource]; !ok {
+			known := make([]string, 0, len(storageResourceKeys))
+			for name := range storageResourceKeys {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown resource %q, must be one of %s", resource, strings.Join(known, ", "))
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// StorageResourceKey returns the etcd key, relative to the registry root,
+// which the values of a resource accepted by ParseStorageResources are
+// stored under.
+func StorageResourceKey(resource string) string {
+	return storageResourceKeys[resource]
+}
+
+// etcdHelperFor returns the EtcdHelper used to store resource, which applies
+// the resource's storage transformer if one is configured.
+func (c *Config) etcdHelperFor(resource string) tools.EtcdHelper {
+	if _, ok := storageResourceKeys[resource]; !ok {
+		glog.Fatalf("No etcd key is registered for resource %q", resource)
+	}
+	helper := c.EtcdHelper
+	if transformer, ok := c.StorageTransformers[resource]; ok {
+		helper.Transformer = transformer
+	}
+	return helper
+}
+
 // setDefaults fills in any fields not set that are required to have valid data.
 func setDefaults(c *Config) {
 	if c.PortalNet == nil {
@@ -196,6 +293,15 @@ func setDefaults(c *Config) {
 		}
 		c.PortalNet = portalNet
 	}
+	if c.ServiceNodePorts.Size == 0 {
+		defaultNodePorts := "30000-32767"
+		glog.Warningf("Service node port range unspecified. Defaulting to %v.", defaultNodePorts)
+		nodePorts, err := util.ParsePortRange(defaultNodePorts)
+		if err != nil {
+			glog.Fatalf("Unable to parse port range: %v", err)
+		}
+		c.ServiceNodePorts = *nodePorts
+	}
 	if c.MasterCount == 0 {
 		// Clearly, there will be at least one master.
 		c.MasterCount = 1
@@ -228,6 +334,7 @@ func setDefaults(c *Config) {
 // Certain config fields will be set to a default value if unset,
 // including:
 //   PortalNet
+//   ServiceNodePorts
 //   MasterCount
 //   ReadOnlyPort
 //   ReadWritePort
@@ -253,6 +360,11 @@ func New(c *Config) *Master {
 	if c.KubeletClient == nil {
 		glog.Fatalf("master.New() called with config.KubeletClient == nil")
 	}
+	for resource := range c.StorageTransformers {
+		if _, ok := storageResourceKeys[resource]; !ok {
+			glog.Fatalf("master.New() called with a storage transformer for unknown resource %q", resource)
+		}
+	}
 
 	// Select the first two valid IPs from portalNet to use as the master service portalIPs
 	serviceReadOnlyIP, err := service.GetIndexedIP(c.PortalNet, 1)
@@ -267,6 +379,7 @@ func New(c *Config) *Master {
 
 	m := &Master{
 		portalNet:             c.PortalNet,
+		serviceNodePorts:      c.ServiceNodePorts,
 		rootWebService:        new(restful.WebService),
 		enableLogsSupport:     c.EnableLogsSupport,
 		enableUISupport:       c.EnableUISupport,
@@ -355,29 +468,40 @@ func logStackOnRecover(panicReason interface{}, httpWriter http.ResponseWriter)
 
 // init initializes master.
 func (m *Master) init(c *Config) {
-	podStorage, bindingStorage, podStatusStorage := podetcd.NewStorage(c.EtcdHelper)
+	podStorage, bindingStorage, podStatusStorage := podetcd.NewStorage(c.etcdHelperFor("pods"))
 	podRegistry := pod.NewRegistry(podStorage)
 
-	eventRegistry := event.NewEtcdRegistry(c.EtcdHelper, uint64(c.EventTTL.Seconds()))
-	limitRangeRegistry := limitrange.NewEtcdRegistry(c.EtcdHelper)
+	eventRegistry := event.NewEtcdRegistry(c.etcdHelperFor("events"), uint64(c.EventTTL.Seconds()))
+	limitRangeRegistry := limitrange.NewEtcdRegistry(c.etcdHelperFor("limitranges"))
 
-	resourceQuotaStorage, resourceQuotaStatusStorage := resourcequotaetcd.NewStorage(c.EtcdHelper)
-	secretRegistry := secret.NewEtcdRegistry(c.EtcdHelper)
+	resourceQuotaStorage, resourceQuotaStatusStorage := resourcequotaetcd.NewStorage(c.etcdHelperFor("resourcequotas"))
+	secretRegistry := secret.NewEtcdRegistry(c.etcdHelperFor("secrets"))
+	persistentVolumeStorage, persistentVolumeStatusStorage := pvetcd.NewStorage(c.etcdHelperFor("persistentvolumes"))
+	persistentVolumeClaimStorage, persistentVolumeClaimStatusStorage := pvcetcd.NewStorage(c.etcdHelperFor("persistentvolumeclaims"))
 
-	namespaceStorage, namespaceStatusStorage, namespaceFinalizeStorage := namespaceetcd.NewStorage(c.EtcdHelper)
+	namespaceStorage, namespaceStatusStorage, namespaceFinalizeStorage := namespaceetcd.NewStorage(c.etcdHelperFor("namespaces"))
 	m.namespaceRegistry = namespace.NewRegistry(namespaceStorage)
 
-	endpointsStorage := endpointsetcd.NewStorage(c.EtcdHelper)
+	endpointsStorage := endpointsetcd.NewStorage(c.etcdHelperFor("endpoints"))
 	m.endpointRegistry = endpoint.NewRegistry(endpointsStorage)
 
-	nodeStorage := nodeetcd.NewStorage(c.EtcdHelper, c.KubeletClient)
+	nodeStorage := nodeetcd.NewStorage(c.etcdHelperFor("nodes"), c.KubeletClient)
 	m.nodeRegistry = minion.NewRegistry(nodeStorage)
 
 	// TODO: split me up into distinct storage registries
-	registry := etcd.NewRegistry(c.EtcdHelper, podRegistry, m.endpointRegistry)
+	registry := etcd.NewRegistry(c.etcdHelperFor("services"), podRegistry, m.endpointRegistry)
 	m.serviceRegistry = registry
 
-	controllerStorage := controlleretcd.NewREST(c.EtcdHelper)
+	controllerStorage := controlleretcd.NewREST(c.etcdHelperFor("replicationcontrollers"))
+	deploymentStorage, deploymentStatusStorage := deploymentetcd.NewStorage(c.etcdHelperFor("deployments"))
+	jobStorage, jobStatusStorage := jobetcd.NewStorage(c.etcdHelperFor("jobs"))
+	daemonSetStorage, daemonSetStatusStorage := daemonsetetcd.NewStorage(c.etcdHelperFor("daemonsets"))
+	autoscalerStorage, autoscalerStatusStorage := autoscaleretcd.NewStorage(c.etcdHelperFor("horizontalpodautoscalers"))
+	serviceAccountStorage := serviceaccountetcd.NewStorage(c.etcdHelperFor("serviceaccounts"))
+	roleStorage := roleetcd.NewStorage(c.etcdHelperFor("roles"))
+	clusterRoleStorage := clusterroleetcd.NewStorage(c.etcdHelperFor("clusterroles"))
+	roleBindingStorage := rolebindingetcd.NewStorage(c.etcdHelperFor("rolebindings"))
+	clusterRoleBindingStorage := clusterrolebindingetcd.NewStorage(c.etcdHelperFor("clusterrolebindings"))
 
 	// TODO: Factor out the core API registration
 	m.storage = map[string]rest.Storage{
@@ -387,7 +511,13 @@ func (m *Master) init(c *Config) {
 		"bindings":     bindingStorage,
 
 		"replicationControllers": controllerStorage,
-		"services":               service.NewStorage(m.serviceRegistry, c.Cloud, m.nodeRegistry, m.endpointRegistry, m.portalNet, c.ClusterName),
+		"deployments":            deploymentStorage,
+		"deployments/status":     deploymentStatusStorage,
+		"jobs":                   jobStorage,
+		"jobs/status":            jobStatusStorage,
+		"daemonsets":             daemonSetStorage,
+		"daemonsets/status":      daemonSetStatusStorage,
+		"services":               service.NewStorage(m.serviceRegistry, c.Cloud, m.nodeRegistry, m.endpointRegistry, m.portalNet, m.serviceNodePorts, c.ClusterName),
 		"endpoints":              endpointsStorage,
 		"minions":                nodeStorage,
 		"nodes":                  nodeStorage,
@@ -400,6 +530,20 @@ func (m *Master) init(c *Config) {
 		"namespaces/status":     namespaceStatusStorage,
 		"namespaces/finalize":   namespaceFinalizeStorage,
 		"secrets":               secret.NewStorage(secretRegistry),
+		"serviceAccounts":       serviceAccountStorage,
+
+		"roles":               roleStorage,
+		"clusterRoles":        clusterRoleStorage,
+		"roleBindings":        roleBindingStorage,
+		"clusterRoleBindings": clusterRoleBindingStorage,
+
+		"persistentVolumes":             persistentVolumeStorage,
+		"persistentVolumes/status":      persistentVolumeStatusStorage,
+		"persistentVolumeClaims":        persistentVolumeClaimStorage,
+		"persistentVolumeClaims/status": persistentVolumeClaimStatusStorage,
+
+		"horizontalpodautoscalers":        autoscalerStorage,
+		"horizontalpodautoscalers/status": autoscalerStatusStorage,
 	}
 
 	apiVersions := []string{"v1beta1", "v1beta2"}
@@ -460,6 +604,12 @@ func (m *Master) init(c *Config) {
 	attributeGetter := apiserver.NewRequestAttributeGetter(m.requestContextMapper, latest.RESTMapper, "api")
 	handler = apiserver.WithAuthorizationCheck(handler, attributeGetter, m.authorizer)
 
+	// Install audit logging inside authentication, so that the user is known and
+	// rejected requests are recorded.
+	if c.AuditWriter != nil {
+		handler = apiserver.WithAudit(handler, m.requestContextMapper, latest.RESTMapper, c.AuditWriter, c.AuditPolicy, "api")
+	}
+
 	// Install Authenticator
 	if c.Authenticator != nil {
 		authenticatedHandler, err := handlers.NewRequestAuthenticator(m.requestContextMapper, c.Authenticator, handlers.Unauthorized, handler)
@@ -17,6 +17,7 @@ limitations under the License.
 package master
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
@@ -30,7 +31,7 @@ func TestGetServersToValidate(t *testing.T) {
 	config := Config{}
 	fakeClient := tools.NewFakeEtcdClient(t)
 	fakeClient.Machines = []string{"http://machine1:4001", "http://machine2", "http://machine3:4003"}
-	config.EtcdHelper = tools.EtcdHelper{fakeClient, latest.Codec, nil}
+	config.EtcdHelper = tools.EtcdHelper{fakeClient, latest.Codec, nil, nil}
 
 	master.nodeRegistry = registrytest.NewMinionRegistry([]string{"node1", "node2"}, api.NodeResources{})
 
@@ -45,3 +46,28 @@ func TestGetServersToValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStorageResources(t *testing.T) {
+	resources, err := ParseStorageResources(" secrets, replicationcontrollers,,nodes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"secrets", "replicationcontrollers", "nodes"}
+	if !reflect.DeepEqual(expected, resources) {
+		t.Errorf("expected %v, got %v", expected, resources)
+	}
+	keys := []string{}
+	for _, resource := range resources {
+		keys = append(keys, StorageResourceKey(resource))
+	}
+	expected = []string{"secrets", "controllers", "minions"}
+	if !reflect.DeepEqual(expected, keys) {
+		t.Errorf("expected keys %v, got %v", expected, keys)
+	}
+
+	for _, list := range []string{"serviceAccounts", "secrets,foo", "services/specs"} {
+		if _, err := ParseStorageResources(list); err == nil {
+			t.Errorf("%q: expected an error", list)
+		}
+	}
+}
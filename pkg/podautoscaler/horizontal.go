@@ -0,0 +1,183 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podautoscaler
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+	"github.com/golang/glog"
+)
+
+const (
+	// tolerance is the relative distance from the target utilization within
+	// which the number of replicas is not changed.
+	tolerance = 0.1
+
+	// upscaleForbiddenWindow is the time after a resize during which the
+	// replicas of a controller are not increased.
+	upscaleForbiddenWindow = 3 * time.Minute
+	// downscaleForbiddenWindow is the time after a resize during which the
+	// replicas of a controller are not decreased.
+	downscaleForbiddenWindow = 5 * time.Minute
+)
+
+// HorizontalController is responsible for resizing the replication controllers
+// targeted by HorizontalPodAutoscalers.
+type HorizontalController struct {
+	kubeClient    client.Interface
+	metricsClient MetricsClient
+}
+
+// NewHorizontalController creates a new HorizontalController.
+func NewHorizontalController(kubeClient client.Interface, metricsClient MetricsClient) *HorizontalController {
+	return &HorizontalController{
+		kubeClient:    kubeClient,
+		metricsClient: metricsClient,
+	}
+}
+
+// Run begins reconciling autoscalers every period.
+func (a *HorizontalController) Run(period time.Duration) {
+	go util.Forever(func() {
+		if err := a.reconcileAutoscalers(); err != nil {
+			glog.Errorf("Couldn't reconcile horizontal pod autoscalers: %v", err)
+		}
+	}, period)
+}
+
+func (a *HorizontalController) reconcileAutoscalers() error {
+	list, err := a.kubeClient.HorizontalPodAutoscalers(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, hpa := range list.Items {
+		if err := a.reconcileAutoscaler(hpa); err != nil {
+			errs = append(errs, fmt.Errorf("autoscaler %s/%s: %v", hpa.Namespace, hpa.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// reconcileAutoscaler computes the number of replicas the CPU utilization of
+// the pods of the target controller calls for, resizes the controller if the
+// cooldown allows it, and records the status of the autoscaler.
+func (a *HorizontalController) reconcileAutoscaler(hpa api.HorizontalPodAutoscaler) error {
+	rc, err := a.kubeClient.ReplicationControllers(hpa.Namespace).Get(hpa.Spec.ScaleRef.Name)
+	if err != nil {
+		return err
+	}
+	currentReplicas := rc.Spec.Replicas
+	minReplicas := 1
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+	targetPercentage := 80
+	if hpa.Spec.CPUUtilization != nil {
+		targetPercentage = hpa.Spec.CPUUtilization.TargetPercentage
+	}
+
+	var currentUtilization *int
+	desiredReplicas := currentReplicas
+	utilization, pods, metricsErr := a.metricsClient.GetCPUUtilization(hpa.Namespace, labels.Set(rc.Spec.Selector).AsSelector())
+	switch {
+	case currentReplicas == 0:
+		// The controller was scaled to zero on purpose, which disables autoscaling.
+	case metricsErr != nil:
+		// Without metrics the replicas are only brought back within bounds.
+		glog.V(2).Infof("No CPU utilization for autoscaler %s/%s: %v", hpa.Namespace, hpa.Name, metricsErr)
+	default:
+		currentUtilization = &utilization
+		desiredReplicas = desiredReplicasFor(currentReplicas, pods, utilization, targetPercentage)
+	}
+	if currentReplicas != 0 {
+		if desiredReplicas < minReplicas {
+			desiredReplicas = minReplicas
+		}
+		if desiredReplicas > hpa.Spec.MaxReplicas {
+			desiredReplicas = hpa.Spec.MaxReplicas
+		}
+	}
+
+	status := api.HorizontalPodAutoscalerStatus{
+		CurrentReplicas:                 currentReplicas,
+		DesiredReplicas:                 desiredReplicas,
+		CurrentCPUUtilizationPercentage: currentUtilization,
+		LastScaleTime:                   hpa.Status.LastScaleTime,
+	}
+	if shouldScale(&hpa, currentReplicas, desiredReplicas, minReplicas, time.Now()) {
+		rc.Spec.Replicas = desiredReplicas
+		if _, err := a.kubeClient.ReplicationControllers(hpa.Namespace).Update(rc); err != nil {
+			return fmt.Errorf("failed to resize %s to %d replicas: %v", rc.Name, desiredReplicas, err)
+		}
+		glog.Infof("Resized %s/%s from %d to %d replicas", rc.Namespace, rc.Name, currentReplicas, desiredReplicas)
+		now := util.Now()
+		status.LastScaleTime = &now
+	}
+
+	if !api.Semantic.DeepEqual(status, hpa.Status) {
+		hpa.Status = status
+		if _, err := a.kubeClient.HorizontalPodAutoscalers(hpa.Namespace).UpdateStatus(&hpa); err != nil {
+			return err
+		}
+	}
+	if metricsErr != nil && currentReplicas != 0 {
+		return metricsErr
+	}
+	return nil
+}
+
+// desiredReplicasFor returns the number of replicas needed to bring the
+// average utilization of the measured pods to targetPercentage. Utilization
+// within tolerance of the target keeps the current number of replicas.
+func desiredReplicasFor(currentReplicas, pods, utilization, targetPercentage int) int {
+	usageRatio := float64(utilization) / float64(targetPercentage)
+	if math.Abs(1.0-usageRatio) <= tolerance {
+		return currentReplicas
+	}
+	return int(math.Ceil(usageRatio * float64(pods)))
+}
+
+// shouldScale returns true if the controller should be resized to
+// desiredReplicas now. Resizes in response to load are held back for a while
+// after the previous resize, so that the new pods have an effect on the
+// utilization before the next decision is made.
+func shouldScale(hpa *api.HorizontalPodAutoscaler, currentReplicas, desiredReplicas, minReplicas int, now time.Time) bool {
+	if desiredReplicas == currentReplicas {
+		return false
+	}
+	// Bounds are enforced right away.
+	if currentReplicas < minReplicas || currentReplicas > hpa.Spec.MaxReplicas {
+		return true
+	}
+	if hpa.Status.LastScaleTime == nil {
+		return true
+	}
+	window := upscaleForbiddenWindow
+	if desiredReplicas < currentReplicas {
+		window = downscaleForbiddenWindow
+	}
+	return hpa.Status.LastScaleTime.Add(window).Before(now)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podautoscaler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+type fakeMetricsClient struct {
+	utilization int
+	pods        int
+	err         error
+}
+
+func (f *fakeMetricsClient) GetCPUUtilization(namespace string, selector labels.Selector) (int, int, error) {
+	return f.utilization, f.pods, f.err
+}
+
+func newAutoscaler(minReplicas, maxReplicas, target int, lastScale *util.Time) api.HorizontalPodAutoscaler {
+	return api.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{Name: "frontend", Namespace: api.NamespaceDefault, ResourceVersion: "1"},
+		Spec: api.HorizontalPodAutoscalerSpec{
+			ScaleRef:       api.ObjectReference{Kind: "ReplicationController", Name: "frontend"},
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    maxReplicas,
+			CPUUtilization: &api.CPUTargetUtilization{TargetPercentage: target},
+		},
+		Status: api.HorizontalPodAutoscalerStatus{LastScaleTime: lastScale},
+	}
+}
+
+func TestReconcileAutoscaler(t *testing.T) {
+	recently := util.NewTime(time.Now().Add(-time.Minute))
+	longAgo := util.NewTime(time.Now().Add(-time.Hour))
+	metricsErr := fmt.Errorf("no metrics")
+	tests := map[string]struct {
+		replicas   int
+		autoscaler api.HorizontalPodAutoscaler
+		metrics    fakeMetricsClient
+
+		expectedReplicas int
+		expectResize     bool
+		expectErr        bool
+	}{
+		"scale up": {
+			replicas:         2,
+			autoscaler:       newAutoscaler(1, 10, 50, nil),
+			metrics:          fakeMetricsClient{utilization: 100, pods: 2},
+			expectedReplicas: 4,
+			expectResize:     true,
+		},
+		"scale down": {
+			replicas:         4,
+			autoscaler:       newAutoscaler(1, 10, 80, &longAgo),
+			metrics:          fakeMetricsClient{utilization: 20, pods: 4},
+			expectedReplicas: 1,
+			expectResize:     true,
+		},
+		"within tolerance": {
+			replicas:         3,
+			autoscaler:       newAutoscaler(1, 10, 50, nil),
+			metrics:          fakeMetricsClient{utilization: 54, pods: 3},
+			expectedReplicas: 3,
+		},
+		"capped at max": {
+			replicas:         3,
+			autoscaler:       newAutoscaler(1, 5, 10, nil),
+			metrics:          fakeMetricsClient{utilization: 100, pods: 3},
+			expectedReplicas: 5,
+			expectResize:     true,
+		},
+		"upscale cooldown": {
+			replicas:         2,
+			autoscaler:       newAutoscaler(1, 10, 50, &recently),
+			metrics:          fakeMetricsClient{utilization: 100, pods: 2},
+			expectedReplicas: 2,
+		},
+		"upscale after cooldown": {
+			replicas:         2,
+			autoscaler:       newAutoscaler(1, 10, 50, &longAgo),
+			metrics:          fakeMetricsClient{utilization: 100, pods: 2},
+			expectedReplicas: 4,
+			expectResize:     true,
+		},
+		"below min ignores cooldown and metrics errors": {
+			replicas:         1,
+			autoscaler:       newAutoscaler(3, 10, 50, &recently),
+			metrics:          fakeMetricsClient{err: metricsErr},
+			expectedReplicas: 3,
+			expectResize:     true,
+			expectErr:        true,
+		},
+		"metrics error": {
+			replicas:         2,
+			autoscaler:       newAutoscaler(1, 10, 50, nil),
+			metrics:          fakeMetricsClient{err: metricsErr},
+			expectedReplicas: 2,
+			expectErr:        true,
+		},
+		"scaled to zero": {
+			replicas:         0,
+			autoscaler:       newAutoscaler(1, 10, 50, nil),
+			metrics:          fakeMetricsClient{err: metricsErr},
+			expectedReplicas: 0,
+		},
+	}
+	for name, test := range tests {
+		fakeClient := &client.Fake{
+			Ctrl: api.ReplicationController{
+				ObjectMeta: api.ObjectMeta{Name: "frontend", Namespace: api.NamespaceDefault},
+				Spec: api.ReplicationControllerSpec{
+					Replicas: test.replicas,
+					Selector: map[string]string{"name": "frontend"},
+				},
+			},
+		}
+		metrics := test.metrics
+		controller := NewHorizontalController(fakeClient, &metrics)
+		err := controller.reconcileAutoscaler(test.autoscaler)
+		if test.expectErr != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		resized := false
+		var status *api.HorizontalPodAutoscalerStatus
+		for _, action := range fakeClient.Actions {
+			switch action.Action {
+			case "update-controller":
+				resized = true
+				if replicas := action.Value.(*api.ReplicationController).Spec.Replicas; replicas != test.expectedReplicas {
+					t.Errorf("%s: expected the controller to be resized to %d, got %d", name, test.expectedReplicas, replicas)
+				}
+			case "update-status-horizontalPodAutoscaler":
+				status = &action.Value.(*api.HorizontalPodAutoscaler).Status
+			}
+		}
+		if resized != test.expectResize {
+			t.Errorf("%s: expected resize %v, got %v", name, test.expectResize, resized)
+		}
+		if status == nil {
+			// A controller scaled to zero leaves the empty status as it is.
+			if test.replicas != 0 {
+				t.Errorf("%s: expected a status update", name)
+			}
+			continue
+		}
+		if status.CurrentReplicas != test.replicas {
+			t.Errorf("%s: expected current replicas %d, got %d", name, test.replicas, status.CurrentReplicas)
+		}
+		if resized && (status.LastScaleTime == nil || !status.LastScaleTime.After(longAgo.Time)) {
+			t.Errorf("%s: expected the last scale time to be updated, got %v", name, status.LastScaleTime)
+		}
+		if test.metrics.err == nil && (status.CurrentCPUUtilizationPercentage == nil || *status.CurrentCPUUtilizationPercentage != test.metrics.utilization) {
+			t.Errorf("%s: expected the current utilization to be recorded, got %v", name, status.CurrentCPUUtilizationPercentage)
+		}
+	}
+}
+
+func TestReconcileAutoscalers(t *testing.T) {
+	fakeClient := &client.Fake{
+		HorizontalPodAutoscalerList: api.HorizontalPodAutoscalerList{
+			Items: []api.HorizontalPodAutoscaler{newAutoscaler(1, 10, 50, nil)},
+		},
+		Ctrl: api.ReplicationController{
+			ObjectMeta: api.ObjectMeta{Name: "frontend", Namespace: api.NamespaceDefault},
+			Spec:       api.ReplicationControllerSpec{Replicas: 2},
+		},
+	}
+	controller := NewHorizontalController(fakeClient, &fakeMetricsClient{utilization: 50, pods: 2})
+	if err := controller.reconcileAutoscalers(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fakeClient.Actions) != 3 || fakeClient.Actions[0].Action != "list-horizontalPodAutoscalers" || fakeClient.Actions[1].Action != "get-controller" {
+		t.Errorf("unexpected actions: %#v", fakeClient.Actions)
+	}
+}
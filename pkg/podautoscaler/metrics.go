@@ -0,0 +1,108 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podautoscaler
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+)
+
+// statsWindow is the number of cadvisor samples, taken about a second apart,
+// over which the CPU usage of a container is averaged.
+const statsWindow = 60
+
+// MetricsClient knows how to query the resource usage of pods.
+type MetricsClient interface {
+	// GetCPUUtilization returns the average CPU utilization of the running pods
+	// in namespace that match selector, as a percentage of the CPU requested by
+	// their containers, and the number of pods that were measured.
+	GetCPUUtilization(namespace string, selector labels.Selector) (utilization int, pods int, err error)
+}
+
+// cadvisorMetricsClient reads CPU usage from the cadvisor stats that every
+// kubelet serves on /stats/.
+type cadvisorMetricsClient struct {
+	kubeClient client.Interface
+	infoGetter client.ContainerInfoGetter
+}
+
+// NewCadvisorMetricsClient returns a MetricsClient that aggregates the CPU
+// usage of pods from the stats endpoints of the kubelets they run on.
+func NewCadvisorMetricsClient(kubeClient client.Interface, infoGetter client.ContainerInfoGetter) MetricsClient {
+	return &cadvisorMetricsClient{
+		kubeClient: kubeClient,
+		infoGetter: infoGetter,
+	}
+}
+
+func (m *cadvisorMetricsClient) GetCPUUtilization(namespace string, selector labels.Selector) (int, int, error) {
+	pods, err := m.kubeClient.Pods(namespace).List(selector)
+	if err != nil {
+		return 0, 0, err
+	}
+	var usage, request int64
+	measured := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != api.PodRunning || len(pod.Status.HostIP) == 0 {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			cpuRequest, ok := container.Resources.Requests[api.ResourceCPU]
+			if !ok || cpuRequest.MilliValue() == 0 {
+				return 0, 0, fmt.Errorf("container %s of pod %s/%s has no CPU request", container.Name, pod.Namespace, pod.Name)
+			}
+			// Ask for the stats of this particular instance of the pod, as
+			// /stats/<namespace>/<pod name>/<uid>/<container name>.
+			podID := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
+			info, err := m.infoGetter.GetContainerInfo(pod.Status.HostIP, podID, container.Name, &cadvisorApi.ContainerInfoRequest{NumStats: statsWindow})
+			if err != nil {
+				return 0, 0, fmt.Errorf("unable to get stats of container %s of pod %s/%s: %v", container.Name, pod.Namespace, pod.Name, err)
+			}
+			milliCores, err := cpuUsage(info)
+			if err != nil {
+				return 0, 0, fmt.Errorf("container %s of pod %s/%s: %v", container.Name, pod.Namespace, pod.Name, err)
+			}
+			usage += milliCores
+			request += cpuRequest.MilliValue()
+		}
+		measured++
+	}
+	if measured == 0 || request == 0 {
+		return 0, 0, fmt.Errorf("no running pods with CPU stats match %v", selector)
+	}
+	return int(usage * 100 / request), measured, nil
+}
+
+// cpuUsage returns the average CPU usage in millicores between the first and
+// the last sample of info.
+func cpuUsage(info *cadvisorApi.ContainerInfo) (int64, error) {
+	if len(info.Stats) < 2 {
+		return 0, fmt.Errorf("not enough CPU samples")
+	}
+	first, last := info.Stats[0], info.Stats[len(info.Stats)-1]
+	elapsed := last.Timestamp.Sub(first.Timestamp).Nanoseconds()
+	if elapsed <= 0 || last.Cpu.Usage.Total < first.Cpu.Usage.Total {
+		return 0, fmt.Errorf("invalid CPU samples")
+	}
+	// Usage is cumulative CPU time in nanoseconds.
+	return int64(last.Cpu.Usage.Total-first.Cpu.Usage.Total) * 1000 / elapsed, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podautoscaler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
+	cadvisorApi "github.com/google/cadvisor/info/v1"
+)
+
+// fakeContainerInfoGetter serves CPU usage in millicores per host, pod and container.
+type fakeContainerInfoGetter struct {
+	milliCores map[string]int64
+	requests   []string
+}
+
+func (f *fakeContainerInfoGetter) GetContainerInfo(host, podID, containerID string, req *cadvisorApi.ContainerInfoRequest) (*cadvisorApi.ContainerInfo, error) {
+	key := fmt.Sprintf("%s/%s/%s", host, podID, containerID)
+	f.requests = append(f.requests, key)
+	usage, ok := f.milliCores[key]
+	if !ok {
+		return nil, fmt.Errorf("no stats for %s", key)
+	}
+	start := time.Unix(1432000000, 0)
+	info := &cadvisorApi.ContainerInfo{}
+	for i := 0; i < req.NumStats; i++ {
+		stats := &cadvisorApi.ContainerStats{Timestamp: start.Add(time.Duration(i) * time.Second)}
+		// Cumulative usage in nanoseconds of CPU time.
+		stats.Cpu.Usage.Total = uint64(int64(i) * usage * int64(time.Millisecond))
+		info.Stats = append(info.Stats, stats)
+	}
+	return info, nil
+}
+
+func (f *fakeContainerInfoGetter) GetRootInfo(host string, req *cadvisorApi.ContainerInfoRequest) (*cadvisorApi.ContainerInfo, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeContainerInfoGetter) GetMachineInfo(host string) (*cadvisorApi.MachineInfo, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func newPod(name string, phase api.PodPhase, cpuRequest string) api.Pod {
+	return api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: name, Namespace: api.NamespaceDefault, UID: types.UID("uid-" + name)},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{
+				Name: "web",
+				Resources: api.ResourceRequirements{
+					Requests: api.ResourceList{api.ResourceCPU: resource.MustParse(cpuRequest)},
+				},
+			}},
+		},
+		Status: api.PodStatus{Phase: phase, HostIP: "10.0.0.1"},
+	}
+}
+
+func TestGetCPUUtilization(t *testing.T) {
+	fakeClient := &client.Fake{PodsList: api.PodList{Items: []api.Pod{
+		newPod("a", api.PodRunning, "200m"),
+		newPod("b", api.PodRunning, "200m"),
+		newPod("pending", api.PodPending, "200m"),
+	}}}
+	infoGetter := &fakeContainerInfoGetter{milliCores: map[string]int64{
+		"10.0.0.1/default/a/uid-a/web": 100,
+		"10.0.0.1/default/b/uid-b/web": 300,
+	}}
+	metrics := NewCadvisorMetricsClient(fakeClient, infoGetter)
+	utilization, pods, err := metrics.GetCPUUtilization(api.NamespaceDefault, labels.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pods != 2 {
+		t.Errorf("expected 2 running pods to be measured, got %d", pods)
+	}
+	if utilization != 100 {
+		t.Errorf("expected 100%% utilization, got %d", utilization)
+	}
+}
+
+func TestGetCPUUtilizationErrors(t *testing.T) {
+	noRequest := newPod("a", api.PodRunning, "200m")
+	noRequest.Spec.Containers[0].Resources = api.ResourceRequirements{}
+	tests := map[string]api.PodList{
+		"no CPU request": {Items: []api.Pod{noRequest}},
+		"no stats":       {Items: []api.Pod{newPod("b", api.PodRunning, "200m")}},
+		"no running pod": {Items: []api.Pod{newPod("c", api.PodPending, "200m")}},
+	}
+	for name, pods := range tests {
+		infoGetter := &fakeContainerInfoGetter{milliCores: map[string]int64{"10.0.0.1/default/a/uid-a/web": 100}}
+		metrics := NewCadvisorMetricsClient(&client.Fake{PodsList: pods}, infoGetter)
+		if _, _, err := metrics.GetCPUUtilization(api.NamespaceDefault, labels.Everything()); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCPUUsage(t *testing.T) {
+	start := time.Unix(1432000000, 0)
+	info := &cadvisorApi.ContainerInfo{Stats: []*cadvisorApi.ContainerStats{
+		{Timestamp: start},
+		{Timestamp: start.Add(10 * time.Second)},
+	}}
+	info.Stats[1].Cpu.Usage.Total = uint64(5 * time.Second)
+	usage, err := cpuUsage(info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage != 500 {
+		t.Errorf("expected 500 millicores, got %d", usage)
+	}
+
+	if _, err := cpuUsage(&cadvisorApi.ContainerInfo{Stats: info.Stats[:1]}); err == nil {
+		t.Errorf("expected an error for a single sample")
+	}
+}
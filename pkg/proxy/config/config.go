@@ -70,6 +70,22 @@ type EndpointsConfigHandler interface {
 	OnUpdate(endpoints []api.Endpoints)
 }
 
+// ServiceConfigHandlerFunc adapts an ordinary function to a ServiceConfigHandler.
+type ServiceConfigHandlerFunc func(services []api.Service)
+
+// OnUpdate calls f(services).
+func (f ServiceConfigHandlerFunc) OnUpdate(services []api.Service) {
+	f(services)
+}
+
+// EndpointsConfigHandlerFunc adapts an ordinary function to an EndpointsConfigHandler.
+type EndpointsConfigHandlerFunc func(endpoints []api.Endpoints)
+
+// OnUpdate calls f(endpoints).
+func (f EndpointsConfigHandlerFunc) OnUpdate(endpoints []api.Endpoints) {
+	f(endpoints)
+}
+
 // EndpointsConfig tracks a set of endpoints configurations.
 // It accepts "set", "add" and "remove" operations of endpoints via channels, and invokes registered handlers on change.
 type EndpointsConfig struct {
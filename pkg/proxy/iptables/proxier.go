@@ -0,0 +1,479 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iptables
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+	utiliptables "github.com/GoogleCloudPlatform/kubernetes/pkg/util/iptables"
+	"github.com/golang/glog"
+)
+
+// The services chain holds one rule per portal (and public IP) which jumps
+// to the chain for that service port.  It is jumped to from PREROUTING, for
+// traffic from containers, and from OUTPUT, for traffic from the host.
+const iptablesServicesChain utiliptables.Chain = "KUBE-SERVICES"
+
+// The node ports chain holds the rules for services of type NodePort.  It
+// is jumped to from the services chain for traffic addressed to the node
+// itself, and only exists while there are NodePort services.
+const iptablesNodePortsChain utiliptables.Chain = "KUBE-NODEPORTS"
+
+// The postrouting chain masquerades traffic that was marked by an endpoint
+// chain.  It is jumped to from POSTROUTING.
+const iptablesPostroutingChain utiliptables.Chain = "KUBE-POSTROUTING"
+
+// Packets a pod sends to itself through its own service are marked with this
+// value so they can be masqueraded; otherwise the reply would bypass the DNAT.
+const iptablesMasqueradeMark = "0x4d415351"
+
+// The chains installed by the userspace proxier.  They are flushed on startup
+// so that a node switching modes does not keep redirecting to dead proxy ports.
+var userspacePortalChains = []utiliptables.Chain{"KUBE-PORTALS-CONTAINER", "KUBE-PORTALS-HOST"}
+
+// How long a ClientIP session affinity record lasts, in seconds.
+const stickyMaxAgeSeconds = 180 * 60
+
+// servicePortName identifies a single port of a service.
+type servicePortName struct {
+	types.NamespacedName
+	Port string
+}
+
+func (spn servicePortName) String() string {
+	return fmt.Sprintf("%s:%s", spn.NamespacedName, spn.Port)
+}
+
+// serviceInfo holds the parts of a service port that are programmed into iptables.
+type serviceInfo struct {
+	portalIP            net.IP
+	port                int
+	nodePort            int
+	protocol            api.Protocol
+	publicIPs           []string
+	sessionAffinityType api.AffinityType
+}
+
+// Proxier implements services with iptables DNAT rules.  Each service port
+// gets its own chain, which picks one of the per-endpoint chains at random
+// (or by ClientIP affinity) and each endpoint chain DNATs to its endpoint.
+// Because of the iptables logic, it is assumed that there is only a single
+// Proxier active on a machine.
+type Proxier struct {
+	mu           sync.Mutex // protects the fields below and serializes syncs
+	serviceMap   map[servicePortName]*serviceInfo
+	endpointsMap map[servicePortName][]string // "ip:port" strings
+	// chainRules records the rules last written to every chain this Proxier
+	// owns, so unchanged chains are not rewritten and stale ones can be removed.
+	chainRules map[utiliptables.Chain][]string
+	// portalRules holds the rules last written to the services chain, by
+	// their joined arguments.
+	portalRules map[string][]string
+	syncPeriod  time.Duration
+	iptables    utiliptables.Interface
+}
+
+// NewProxier returns a new Proxier which programs the given iptables.
+// syncPeriod is how often the rules are re-asserted, in case something
+// else on the machine has changed them.
+func NewProxier(ipt utiliptables.Interface, syncPeriod time.Duration) (*Proxier, error) {
+	glog.Infof("Initializing iptables")
+	for _, chain := range userspacePortalChains {
+		// Ignore errors, the chain probably doesn't exist.
+		ipt.FlushChain(utiliptables.TableNAT, chain)
+	}
+	if err := iptablesInit(ipt); err != nil {
+		return nil, fmt.Errorf("failed to initialize iptables: %v", err)
+	}
+	// Rules for services will be rewritten when OnServiceUpdate() is first
+	// called.
+	if err := ipt.FlushChain(utiliptables.TableNAT, iptablesServicesChain); err != nil {
+		return nil, fmt.Errorf("failed to flush iptables: %v", err)
+	}
+	// Chains left behind by an earlier run are no longer referenced once the
+	// services chain is flushed.  Adopt them, so the first sync removes the
+	// ones which are not needed any more.
+	chainRules, err := existingProxierChains(ipt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list iptables chains: %v", err)
+	}
+	return &Proxier{
+		serviceMap:   make(map[servicePortName]*serviceInfo),
+		endpointsMap: make(map[servicePortName][]string),
+		chainRules:   chainRules,
+		portalRules:  make(map[string][]string),
+		syncPeriod:   syncPeriod,
+		iptables:     ipt,
+	}, nil
+}
+
+// existingProxierChains returns the service port, endpoint and node port
+// chains already present in the nat table, with no rules recorded.
+func existingProxierChains(ipt utiliptables.Interface) (map[utiliptables.Chain][]string, error) {
+	chains, err := ipt.ListChains(utiliptables.TableNAT)
+	if err != nil {
+		return nil, err
+	}
+	chainRules := make(map[utiliptables.Chain][]string)
+	for _, chain := range chains {
+		if chain == iptablesNodePortsChain ||
+			strings.HasPrefix(string(chain), "KUBE-SVC-") ||
+			strings.HasPrefix(string(chain), "KUBE-SEP-") {
+			chainRules[chain] = nil
+		}
+	}
+	return chainRules, nil
+}
+
+// Ensure that the iptables infrastructure we use is set up.  This can safely be called periodically.
+func iptablesInit(ipt utiliptables.Interface) error {
+	if _, err := ipt.EnsureChain(utiliptables.TableNAT, iptablesServicesChain); err != nil {
+		return err
+	}
+	args := []string{"-m", "comment", "--comment", "kubernetes service portals", "-j", string(iptablesServicesChain)}
+	if _, err := ipt.EnsureRule(utiliptables.TableNAT, utiliptables.ChainPrerouting, args...); err != nil {
+		return err
+	}
+	if _, err := ipt.EnsureRule(utiliptables.TableNAT, utiliptables.ChainOutput, args...); err != nil {
+		return err
+	}
+	if _, err := ipt.EnsureChain(utiliptables.TableNAT, iptablesPostroutingChain); err != nil {
+		return err
+	}
+	args = []string{"-m", "comment", "--comment", "kubernetes postrouting rules", "-j", string(iptablesPostroutingChain)}
+	if _, err := ipt.EnsureRule(utiliptables.TableNAT, utiliptables.ChainPostrouting, args...); err != nil {
+		return err
+	}
+	args = []string{"-m", "comment", "--comment", "kubernetes service traffic requiring SNAT", "-m", "mark", "--mark", iptablesMasqueradeMark, "-j", "MASQUERADE"}
+	if _, err := ipt.EnsureRule(utiliptables.TableNAT, iptablesPostroutingChain, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SyncLoop runs periodic work.  This is expected to run as a goroutine or as the main loop of the app.  It does not return.
+func (proxier *Proxier) SyncLoop() {
+	for {
+		select {
+		case <-time.After(proxier.syncPeriod):
+			glog.V(2).Infof("Periodic sync")
+			proxier.Sync()
+		}
+	}
+}
+
+// Sync re-asserts all of the iptables rules for the current services and endpoints.
+func (proxier *Proxier) Sync() {
+	proxier.mu.Lock()
+	defer proxier.mu.Unlock()
+	if err := iptablesInit(proxier.iptables); err != nil {
+		glog.Errorf("Failed to ensure iptables: %v", err)
+	}
+	proxier.syncProxyRules()
+}
+
+// OnServiceUpdate replaces the set of service ports which are programmed.
+func (proxier *Proxier) OnServiceUpdate(services []api.Service) {
+	glog.V(4).Infof("Received service update notice: %+v", services)
+	serviceMap := make(map[servicePortName]*serviceInfo)
+	for i := range services {
+		service := &services[i]
+
+		// if PortalIP is "None" or empty, skip proxying
+		if !api.IsServiceIPSet(service) {
+			continue
+		}
+		for j := range service.Spec.Ports {
+			port := &service.Spec.Ports[j]
+
+			name := servicePortName{types.NamespacedName{Namespace: service.Namespace, Name: service.Name}, port.Name}
+			info := &serviceInfo{
+				portalIP:            net.ParseIP(service.Spec.PortalIP),
+				port:                port.Port,
+				protocol:            port.Protocol,
+				publicIPs:           service.Spec.PublicIPs,
+				sessionAffinityType: service.Spec.SessionAffinity,
+			}
+			if service.Spec.Type == api.ServiceTypeNodePort {
+				info.nodePort = port.NodePort
+			}
+			serviceMap[name] = info
+		}
+	}
+
+	proxier.mu.Lock()
+	defer proxier.mu.Unlock()
+	proxier.serviceMap = serviceMap
+	proxier.syncProxyRules()
+}
+
+// OnEndpointsUpdate replaces the set of endpoints which service ports are balanced across.
+func (proxier *Proxier) OnEndpointsUpdate(allEndpoints []api.Endpoints) {
+	glog.V(4).Infof("Received endpoints update notice: %+v", allEndpoints)
+	endpointsMap := make(map[servicePortName][]string)
+	for i := range allEndpoints {
+		endpoints := &allEndpoints[i]
+
+		for j := range endpoints.Subsets {
+			subset := &endpoints.Subsets[j]
+
+			for k := range subset.Ports {
+				port := &subset.Ports[k]
+
+				name := servicePortName{types.NamespacedName{Namespace: endpoints.Namespace, Name: endpoints.Name}, port.Name}
+				for _, addr := range subset.Addresses {
+					endpointsMap[name] = append(endpointsMap[name], net.JoinHostPort(addr.IP, strconv.Itoa(port.Port)))
+				}
+			}
+		}
+	}
+
+	proxier.mu.Lock()
+	defer proxier.mu.Unlock()
+	proxier.endpointsMap = endpointsMap
+	proxier.syncProxyRules()
+}
+
+// servicePortChainName returns the name of the chain for a service port.
+// Chain names are limited to 28 characters, so this uses a hash.
+func servicePortChainName(name servicePortName, protocol api.Protocol) utiliptables.Chain {
+	return utiliptables.Chain("KUBE-SVC-" + hashName(name.String()+string(protocol)))
+}
+
+// servicePortEndpointChainName returns the name of the chain for one endpoint of a service port.
+func servicePortEndpointChainName(name servicePortName, protocol api.Protocol, endpoint string) utiliptables.Chain {
+	return utiliptables.Chain("KUBE-SEP-" + hashName(name.String()+string(protocol)+endpoint))
+}
+
+func hashName(s string) string {
+	hash := sha256.Sum256([]byte(s))
+	encoded := base32.StdEncoding.EncodeToString(hash[:])
+	return encoded[:16]
+}
+
+// syncProxyRules writes the chains for every service port and endpoint, then
+// points the services chain at them and removes chains which are no longer
+// needed.  This assumes proxier.mu is locked.
+func (proxier *Proxier) syncProxyRules() {
+	el := []error{}
+	activeChains := map[utiliptables.Chain]bool{}
+	portalRules := [][]string{}
+	nodePortRules := [][]string{}
+
+	// Sort for a stable rule order.
+	names := make([]string, 0, len(proxier.serviceMap))
+	byName := make(map[string]servicePortName, len(proxier.serviceMap))
+	for name := range proxier.serviceMap {
+		names = append(names, name.String())
+		byName[name.String()] = name
+	}
+	sort.Strings(names)
+
+	for _, s := range names {
+		name := byName[s]
+		info := proxier.serviceMap[name]
+		protocol := strings.ToLower(string(info.protocol))
+		svcChain := servicePortChainName(name, info.protocol)
+		activeChains[svcChain] = true
+
+		// Write one chain per endpoint.
+		endpoints := proxier.endpointsMap[name]
+		endpointChains := make([]utiliptables.Chain, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			epChain := servicePortEndpointChainName(name, info.protocol, endpoint)
+			endpointChains = append(endpointChains, epChain)
+			activeChains[epChain] = true
+
+			host, _, err := net.SplitHostPort(endpoint)
+			if err != nil {
+				el = append(el, fmt.Errorf("invalid endpoint %q for %q: %v", endpoint, name, err))
+				continue
+			}
+			epRules := [][]string{
+				// Mark hairpin traffic so it is masqueraded.
+				{"-m", "comment", "--comment", name.String(), "-s", fmt.Sprintf("%s/32", host), "-j", "MARK", "--set-xmark", iptablesMasqueradeMark + "/0xffffffff"},
+			}
+			dnat := []string{"-m", "comment", "--comment", name.String()}
+			if info.sessionAffinityType == api.AffinityTypeClientIP {
+				dnat = append(dnat, "-m", "recent", "--name", string(epChain), "--set")
+			}
+			dnat = append(dnat, "-p", protocol, "-m", protocol, "-j", "DNAT", "--to-destination", endpoint)
+			epRules = append(epRules, dnat)
+			if err := proxier.writeChain(epChain, epRules); err != nil {
+				el = append(el, err)
+			}
+		}
+
+		// Write the service port chain, which picks an endpoint chain.  If there
+		// are no endpoints the chain is empty and traffic falls through.
+		svcRules := [][]string{}
+		if info.sessionAffinityType == api.AffinityTypeClientIP {
+			for _, epChain := range endpointChains {
+				svcRules = append(svcRules, []string{
+					"-m", "comment", "--comment", name.String(),
+					"-m", "recent", "--name", string(epChain), "--rcheck", "--seconds", strconv.Itoa(stickyMaxAgeSeconds), "--reap",
+					"-j", string(epChain),
+				})
+			}
+		}
+		n := len(endpointChains)
+		for i, epChain := range endpointChains {
+			args := []string{"-m", "comment", "--comment", name.String()}
+			if i < n-1 {
+				// Each rule is only reached when the ones before it did not
+				// match, so this gives every endpoint an equal share.
+				args = append(args, "-m", "statistic", "--mode", "random", "--probability", fmt.Sprintf("%0.5f", 1.0/float64(n-i)))
+			}
+			args = append(args, "-j", string(epChain))
+			svcRules = append(svcRules, args)
+		}
+		if err := proxier.writeChain(svcChain, svcRules); err != nil {
+			el = append(el, err)
+		}
+
+		// Capture traffic to the portal and any public IPs.
+		for _, ip := range append([]string{info.portalIP.String()}, info.publicIPs...) {
+			portalRules = append(portalRules, []string{
+				"-m", "comment", "--comment", name.String(),
+				"-p", protocol, "-m", protocol,
+				"-d", fmt.Sprintf("%s/32", ip),
+				"--dport", strconv.Itoa(info.port),
+				"-j", string(svcChain),
+			})
+		}
+
+		// Capture traffic to the node port, masquerading it so that replies
+		// from endpoints on other nodes come back through this one.
+		if info.nodePort != 0 {
+			match := []string{
+				"-m", "comment", "--comment", name.String(),
+				"-p", protocol, "-m", protocol,
+				"--dport", strconv.Itoa(info.nodePort),
+			}
+			nodePortRules = append(nodePortRules,
+				append(append([]string{}, match...), "-j", "MARK", "--set-xmark", iptablesMasqueradeMark+"/0xffffffff"),
+				append(append([]string{}, match...), "-j", string(svcChain)),
+			)
+		}
+	}
+
+	// Only traffic addressed to this node can hit a node port.
+	if len(nodePortRules) != 0 {
+		activeChains[iptablesNodePortsChain] = true
+		if err := proxier.writeChain(iptablesNodePortsChain, nodePortRules); err != nil {
+			el = append(el, err)
+		}
+		portalRules = append(portalRules, []string{
+			"-m", "comment", "--comment", "kubernetes service nodeports",
+			"-m", "addrtype", "--dst-type", "LOCAL",
+			"-j", string(iptablesNodePortsChain),
+		})
+	}
+
+	if err := proxier.syncServicesChain(portalRules); err != nil {
+		el = append(el, err)
+	}
+
+	// Remove the chains that are no longer needed.  Everything is flushed
+	// before anything is deleted, so that no stale chain is still referenced.
+	stale := []utiliptables.Chain{}
+	for chain := range proxier.chainRules {
+		if !activeChains[chain] {
+			stale = append(stale, chain)
+		}
+	}
+	for _, chain := range stale {
+		if err := proxier.iptables.FlushChain(utiliptables.TableNAT, chain); err != nil {
+			el = append(el, err)
+		}
+	}
+	for _, chain := range stale {
+		if err := proxier.iptables.DeleteChain(utiliptables.TableNAT, chain); err != nil {
+			el = append(el, err)
+			continue
+		}
+		delete(proxier.chainRules, chain)
+	}
+
+	if len(el) != 0 {
+		glog.Errorf("Some errors syncing iptables rules: %v", utilerrors.NewAggregate(el))
+	}
+}
+
+// writeChain makes the chain hold exactly the given rules, in order.  If the
+// rules are unchanged since the last write they are only re-asserted.
+func (proxier *Proxier) writeChain(chain utiliptables.Chain, rules [][]string) error {
+	joined := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		joined = append(joined, strings.Join(rule, " "))
+	}
+	if _, err := proxier.iptables.EnsureChain(utiliptables.TableNAT, chain); err != nil {
+		return err
+	}
+	if old, found := proxier.chainRules[chain]; !found || !reflect.DeepEqual(old, joined) {
+		if err := proxier.iptables.FlushChain(utiliptables.TableNAT, chain); err != nil {
+			return err
+		}
+	}
+	// Record the chain before writing, so a partial write is retried.
+	proxier.chainRules[chain] = nil
+	for _, rule := range rules {
+		if _, err := proxier.iptables.EnsureRule(utiliptables.TableNAT, chain, rule...); err != nil {
+			return err
+		}
+	}
+	proxier.chainRules[chain] = joined
+	return nil
+}
+
+// syncServicesChain makes the services chain hold the given rules.  Order does
+// not matter in this chain, so rules are added and removed individually rather
+// than rewriting the chain, which would briefly drop traffic for every service.
+func (proxier *Proxier) syncServicesChain(rules [][]string) error {
+	el := []error{}
+	want := make(map[string][]string, len(rules))
+	for _, rule := range rules {
+		want[strings.Join(rule, " ")] = rule
+		if _, err := proxier.iptables.EnsureRule(utiliptables.TableNAT, iptablesServicesChain, rule...); err != nil {
+			el = append(el, err)
+		}
+	}
+	for key, rule := range proxier.portalRules {
+		if _, found := want[key]; found {
+			continue
+		}
+		if err := proxier.iptables.DeleteRule(utiliptables.TableNAT, iptablesServicesChain, rule...); err != nil {
+			// Keep it around so the delete is retried on the next sync.
+			el = append(el, err)
+			want[key] = rule
+		}
+	}
+	proxier.portalRules = want
+	return utilerrors.NewAggregate(el)
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iptables
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
+	utiliptables "github.com/GoogleCloudPlatform/kubernetes/pkg/util/iptables"
+)
+
+func newFakeProxier(t *testing.T) (*Proxier, *utiliptables.FakeIPTables) {
+	fake := utiliptables.NewFake()
+	proxier, err := NewProxier(fake, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return proxier, fake
+}
+
+func makeService(name string, affinity api.AffinityType, ports ...api.ServicePort) api.Service {
+	return api.Service{
+		ObjectMeta: api.ObjectMeta{Namespace: "ns", Name: name},
+		Spec: api.ServiceSpec{
+			PortalIP:        "10.0.0.1",
+			Ports:           ports,
+			SessionAffinity: affinity,
+		},
+	}
+}
+
+func makeEndpoints(name string, ips []string, ports ...api.EndpointPort) api.Endpoints {
+	addrs := []api.EndpointAddress{}
+	for _, ip := range ips {
+		addrs = append(addrs, api.EndpointAddress{IP: ip})
+	}
+	return api.Endpoints{
+		ObjectMeta: api.ObjectMeta{Namespace: "ns", Name: name},
+		Subsets:    []api.EndpointSubset{{Addresses: addrs, Ports: ports}},
+	}
+}
+
+func getRules(t *testing.T, fake *utiliptables.FakeIPTables, chain utiliptables.Chain) []string {
+	rules, found := fake.Rules(utiliptables.TableNAT, chain)
+	if !found {
+		t.Fatalf("expected chain %q to exist", chain)
+	}
+	return rules
+}
+
+func TestNewProxierInstallsBaseRules(t *testing.T) {
+	_, fake := newFakeProxier(t)
+
+	jump := "-m comment --comment kubernetes service portals -j KUBE-SERVICES"
+	for _, chain := range []utiliptables.Chain{utiliptables.ChainPrerouting, utiliptables.ChainOutput} {
+		if rules := getRules(t, fake, chain); !reflect.DeepEqual(rules, []string{jump}) {
+			t.Errorf("unexpected rules in %s: %v", chain, rules)
+		}
+	}
+	if rules := getRules(t, fake, utiliptables.ChainPostrouting); len(rules) != 1 || !strings.HasSuffix(rules[0], "-j KUBE-POSTROUTING") {
+		t.Errorf("unexpected rules in POSTROUTING: %v", rules)
+	}
+	if rules := getRules(t, fake, iptablesPostroutingChain); len(rules) != 1 || !strings.HasSuffix(rules[0], "-j MASQUERADE") {
+		t.Errorf("unexpected rules in %s: %v", iptablesPostroutingChain, rules)
+	}
+	if rules := getRules(t, fake, iptablesServicesChain); len(rules) != 0 {
+		t.Errorf("expected no service rules, got %v", rules)
+	}
+}
+
+func TestNewProxierFlushesUserspaceRules(t *testing.T) {
+	fake := utiliptables.NewFake()
+	fake.EnsureChain(utiliptables.TableNAT, "KUBE-PORTALS-CONTAINER")
+	fake.EnsureRule(utiliptables.TableNAT, "KUBE-PORTALS-CONTAINER", "-j", "REDIRECT")
+	if _, err := NewProxier(fake, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules := getRules(t, fake, "KUBE-PORTALS-CONTAINER"); len(rules) != 0 {
+		t.Errorf("expected userspace rules to be flushed, got %v", rules)
+	}
+}
+
+func TestServiceWithEndpoints(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), "p"}
+
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("foo", api.AffinityTypeNone, api.ServicePort{Name: "p", Port: 80, Protocol: "TCP"}),
+	})
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("foo", []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, api.EndpointPort{Name: "p", Port: 8080}),
+	})
+
+	svcChain := servicePortChainName(name, "TCP")
+	expected := []string{"-m comment --comment ns/foo:p -p tcp -m tcp -d 10.0.0.1/32 --dport 80 -j " + string(svcChain)}
+	if rules := getRules(t, fake, iptablesServicesChain); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected services rules %v, got %v", expected, rules)
+	}
+
+	ep1 := servicePortEndpointChainName(name, "TCP", "1.1.1.1:8080")
+	ep2 := servicePortEndpointChainName(name, "TCP", "2.2.2.2:8080")
+	ep3 := servicePortEndpointChainName(name, "TCP", "3.3.3.3:8080")
+	expected = []string{
+		"-m comment --comment ns/foo:p -m statistic --mode random --probability 0.33333 -j " + string(ep1),
+		"-m comment --comment ns/foo:p -m statistic --mode random --probability 0.50000 -j " + string(ep2),
+		"-m comment --comment ns/foo:p -j " + string(ep3),
+	}
+	if rules := getRules(t, fake, svcChain); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected service chain rules %v, got %v", expected, rules)
+	}
+
+	expected = []string{
+		"-m comment --comment ns/foo:p -s 2.2.2.2/32 -j MARK --set-xmark " + iptablesMasqueradeMark + "/0xffffffff",
+		"-m comment --comment ns/foo:p -p tcp -m tcp -j DNAT --to-destination 2.2.2.2:8080",
+	}
+	if rules := getRules(t, fake, ep2); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected endpoint chain rules %v, got %v", expected, rules)
+	}
+}
+
+func TestServiceWithoutEndpoints(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), ""}
+
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("foo", api.AffinityTypeNone, api.ServicePort{Port: 53, Protocol: "UDP"}),
+	})
+
+	svcChain := servicePortChainName(name, "UDP")
+	if rules := getRules(t, fake, svcChain); len(rules) != 0 {
+		t.Errorf("expected empty service chain, got %v", rules)
+	}
+	if rules := getRules(t, fake, iptablesServicesChain); len(rules) != 1 || !strings.Contains(rules[0], "-p udp -m udp") {
+		t.Errorf("unexpected services rules: %v", rules)
+	}
+}
+
+func TestServiceSessionAffinity(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), ""}
+
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("foo", []string{"1.1.1.1", "2.2.2.2"}, api.EndpointPort{Port: 8080}),
+	})
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("foo", api.AffinityTypeClientIP, api.ServicePort{Port: 80, Protocol: "TCP"}),
+	})
+
+	svcChain := servicePortChainName(name, "TCP")
+	ep1 := servicePortEndpointChainName(name, "TCP", "1.1.1.1:8080")
+	ep2 := servicePortEndpointChainName(name, "TCP", "2.2.2.2:8080")
+	expected := []string{
+		"-m comment --comment ns/foo: -m recent --name " + string(ep1) + " --rcheck --seconds 10800 --reap -j " + string(ep1),
+		"-m comment --comment ns/foo: -m recent --name " + string(ep2) + " --rcheck --seconds 10800 --reap -j " + string(ep2),
+		"-m comment --comment ns/foo: -m statistic --mode random --probability 0.50000 -j " + string(ep1),
+		"-m comment --comment ns/foo: -j " + string(ep2),
+	}
+	if rules := getRules(t, fake, svcChain); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected service chain rules %v, got %v", expected, rules)
+	}
+	rules := getRules(t, fake, ep1)
+	if len(rules) != 2 || !strings.Contains(rules[1], "-m recent --name "+string(ep1)+" --set") {
+		t.Errorf("expected endpoint chain to record the client, got %v", rules)
+	}
+}
+
+func TestServiceMultiplePortsAndPublicIPs(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	svc := makeService("foo", api.AffinityTypeNone,
+		api.ServicePort{Name: "http", Port: 80, Protocol: "TCP"},
+		api.ServicePort{Name: "dns", Port: 53, Protocol: "UDP"})
+	svc.Spec.PublicIPs = []string{"4.4.4.4"}
+	proxier.OnServiceUpdate([]api.Service{svc})
+
+	rules := getRules(t, fake, iptablesServicesChain)
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 services rules, got %v", rules)
+	}
+	for _, want := range []string{
+		"-d 10.0.0.1/32 --dport 80 -j " + string(servicePortChainName(servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), "http"}, "TCP")),
+		"-d 4.4.4.4/32 --dport 80 -j " + string(servicePortChainName(servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), "http"}, "TCP")),
+		"-d 10.0.0.1/32 --dport 53 -j " + string(servicePortChainName(servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), "dns"}, "UDP")),
+		"-d 4.4.4.4/32 --dport 53 -j " + string(servicePortChainName(servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), "dns"}, "UDP")),
+	} {
+		found := false
+		for _, rule := range rules {
+			if strings.HasSuffix(rule, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a rule ending in %q, got %v", want, rules)
+		}
+	}
+}
+
+func TestServiceWithoutPortalIPIsSkipped(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	svc := makeService("foo", api.AffinityTypeNone, api.ServicePort{Port: 80, Protocol: "TCP"})
+	svc.Spec.PortalIP = "None"
+	proxier.OnServiceUpdate([]api.Service{svc})
+
+	if rules := getRules(t, fake, iptablesServicesChain); len(rules) != 0 {
+		t.Errorf("expected no services rules, got %v", rules)
+	}
+}
+
+func TestRemovedServiceAndEndpointsAreCleanedUp(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), ""}
+
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("foo", api.AffinityTypeNone, api.ServicePort{Port: 80, Protocol: "TCP"}),
+	})
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("foo", []string{"1.1.1.1", "2.2.2.2"}, api.EndpointPort{Port: 8080}),
+	})
+	svcChain := servicePortChainName(name, "TCP")
+	ep1 := servicePortEndpointChainName(name, "TCP", "1.1.1.1:8080")
+	ep2 := servicePortEndpointChainName(name, "TCP", "2.2.2.2:8080")
+
+	// Drop one endpoint.
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("foo", []string{"1.1.1.1"}, api.EndpointPort{Port: 8080}),
+	})
+	if _, found := fake.Rules(utiliptables.TableNAT, ep2); found {
+		t.Errorf("expected chain %q to be deleted", ep2)
+	}
+	expected := []string{"-m comment --comment ns/foo: -j " + string(ep1)}
+	if rules := getRules(t, fake, svcChain); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected service chain rules %v, got %v", expected, rules)
+	}
+
+	// Drop the service.
+	proxier.OnServiceUpdate([]api.Service{})
+	for _, chain := range []utiliptables.Chain{svcChain, ep1} {
+		if _, found := fake.Rules(utiliptables.TableNAT, chain); found {
+			t.Errorf("expected chain %q to be deleted", chain)
+		}
+	}
+	if rules := getRules(t, fake, iptablesServicesChain); len(rules) != 0 {
+		t.Errorf("expected no services rules, got %v", rules)
+	}
+}
+
+func TestChainsFromEarlierRunAreCleanedUp(t *testing.T) {
+	fake := utiliptables.NewFake()
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), ""}
+	svcChain := servicePortChainName(name, "TCP")
+	epChain := servicePortEndpointChainName(name, "TCP", "1.1.1.1:8080")
+	oldSvcChain := utiliptables.Chain("KUBE-SVC-OLD")
+	oldEpChain := utiliptables.Chain("KUBE-SEP-OLD")
+	otherChain := utiliptables.Chain("OTHER")
+	// Leave chains behind as an earlier run would, one of them still in use.
+	for _, chain := range []utiliptables.Chain{svcChain, oldSvcChain, oldEpChain, iptablesNodePortsChain, otherChain} {
+		fake.EnsureChain(utiliptables.TableNAT, chain)
+	}
+	fake.EnsureRule(utiliptables.TableNAT, oldSvcChain, "-j", string(oldEpChain))
+	fake.EnsureRule(utiliptables.TableNAT, oldEpChain, "-j", "DNAT", "--to-destination", "9.9.9.9:80")
+
+	proxier, err := NewProxier(fake, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("foo", api.AffinityTypeNone, api.ServicePort{Port: 80, Protocol: "TCP"}),
+	})
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("foo", []string{"1.1.1.1"}, api.EndpointPort{Port: 8080}),
+	})
+
+	for _, chain := range []utiliptables.Chain{oldSvcChain, oldEpChain, iptablesNodePortsChain} {
+		if _, found := fake.Rules(utiliptables.TableNAT, chain); found {
+			t.Errorf("expected chain %q to be deleted", chain)
+		}
+	}
+	for _, chain := range []utiliptables.Chain{svcChain, epChain, otherChain} {
+		if _, found := fake.Rules(utiliptables.TableNAT, chain); !found {
+			t.Errorf("expected chain %q to exist", chain)
+		}
+	}
+	expected := []string{"-m comment --comment ns/foo: -j " + string(epChain)}
+	if rules := getRules(t, fake, svcChain); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected service chain rules %v, got %v", expected, rules)
+	}
+}
+
+func TestServiceNodePort(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), ""}
+
+	svc := makeService("foo", api.AffinityTypeNone, api.ServicePort{Port: 80, Protocol: "TCP", NodePort: 30001})
+	svc.Spec.Type = api.ServiceTypeNodePort
+	proxier.OnServiceUpdate([]api.Service{svc})
+
+	svcChain := servicePortChainName(name, "TCP")
+	expected := []string{
+		"-m comment --comment ns/foo: -p tcp -m tcp --dport 30001 -j MARK --set-xmark " + iptablesMasqueradeMark + "/0xffffffff",
+		"-m comment --comment ns/foo: -p tcp -m tcp --dport 30001 -j " + string(svcChain),
+	}
+	if rules := getRules(t, fake, iptablesNodePortsChain); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected node port rules %v, got %v", expected, rules)
+	}
+	jump := "-m comment --comment kubernetes service nodeports -m addrtype --dst-type LOCAL -j " + string(iptablesNodePortsChain)
+	rules := getRules(t, fake, iptablesServicesChain)
+	if len(rules) != 2 || rules[1] != jump {
+		t.Errorf("expected a jump to %s, got %v", iptablesNodePortsChain, rules)
+	}
+
+	// Without NodePort services the chain goes away.
+	svc.Spec.Type = api.ServiceTypeClusterIP
+	proxier.OnServiceUpdate([]api.Service{svc})
+	if _, found := fake.Rules(utiliptables.TableNAT, iptablesNodePortsChain); found {
+		t.Errorf("expected chain %q to be deleted", iptablesNodePortsChain)
+	}
+	if rules := getRules(t, fake, iptablesServicesChain); len(rules) != 1 {
+		t.Errorf("expected only the portal rule, got %v", rules)
+	}
+}
+
+func TestSyncRepairsRules(t *testing.T) {
+	proxier, fake := newFakeProxier(t)
+	name := servicePortName{types.NewNamespacedNameOrDie("ns", "foo"), ""}
+
+	proxier.OnServiceUpdate([]api.Service{
+		makeService("foo", api.AffinityTypeNone, api.ServicePort{Port: 80, Protocol: "TCP"}),
+	})
+	proxier.OnEndpointsUpdate([]api.Endpoints{
+		makeEndpoints("foo", []string{"1.1.1.1"}, api.EndpointPort{Port: 8080}),
+	})
+	svcChain := servicePortChainName(name, "TCP")
+	before := getRules(t, fake, svcChain)
+
+	// Somebody else flushed our rules.
+	fake.FlushChain(utiliptables.TableNAT, svcChain)
+	fake.FlushChain(utiliptables.TableNAT, iptablesServicesChain)
+	proxier.Sync()
+
+	if rules := getRules(t, fake, svcChain); !reflect.DeepEqual(rules, before) {
+		t.Errorf("expected service chain rules %v, got %v", before, rules)
+	}
+	if rules := getRules(t, fake, iptablesServicesChain); len(rules) != 1 {
+		t.Errorf("expected services rules to be restored, got %v", rules)
+	}
+}
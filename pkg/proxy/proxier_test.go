@@ -92,6 +92,10 @@ func (fake *fakeIptables) FlushChain(table iptables.Table, chain iptables.Chain)
 	return nil
 }
 
+func (fake *fakeIptables) ListChains(table iptables.Table) ([]iptables.Chain, error) {
+	return nil, nil
+}
+
 func (fake *fakeIptables) EnsureRule(table iptables.Table, chain iptables.Chain, args ...string) (bool, error) {
 	return false, nil
 }
@@ -195,13 +199,13 @@ func waitForNumProxyLoops(t *testing.T, p *Proxier, want int32) {
 
 func TestTCPProxy(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: tcpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
 			}},
 		},
 	})
@@ -219,13 +223,13 @@ func TestTCPProxy(t *testing.T) {
 
 func TestUDPProxy(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: udpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: udpServerPort}},
 			}},
 		},
 	})
@@ -242,7 +246,7 @@ func TestUDPProxy(t *testing.T) {
 }
 
 // Helper: Stops the proxy for the named service.
-func stopProxyByName(proxier *Proxier, service types.NamespacedName) error {
+func stopProxyByName(proxier *Proxier, service servicePort) error {
 	info, found := proxier.getServiceInfo(service)
 	if !found {
 		return fmt.Errorf("unknown service: %s", service)
@@ -252,13 +256,13 @@ func stopProxyByName(proxier *Proxier, service types.NamespacedName) error {
 
 func TestTCPProxyStop(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Namespace: service.Namespace, Name: service.Name},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: tcpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
 			}},
 		},
 	})
@@ -287,13 +291,13 @@ func TestTCPProxyStop(t *testing.T) {
 
 func TestUDPProxyStop(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Namespace: service.Namespace, Name: service.Name},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: udpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: udpServerPort}},
 			}},
 		},
 	})
@@ -322,13 +326,13 @@ func TestUDPProxyStop(t *testing.T) {
 
 func TestTCPProxyUpdateDelete(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Namespace: service.Namespace, Name: service.Name},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: tcpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
 			}},
 		},
 	})
@@ -356,13 +360,13 @@ func TestTCPProxyUpdateDelete(t *testing.T) {
 
 func TestUDPProxyUpdateDelete(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Namespace: service.Namespace, Name: service.Name},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: udpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: udpServerPort}},
 			}},
 		},
 	})
@@ -390,13 +394,13 @@ func TestUDPProxyUpdateDelete(t *testing.T) {
 
 func TestTCPProxyUpdateDeleteUpdate(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: tcpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
 			}},
 		},
 	})
@@ -421,7 +425,7 @@ func TestTCPProxyUpdateDeleteUpdate(t *testing.T) {
 	}
 	waitForNumProxyLoops(t, p, 0)
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: svcInfo.proxyPort, Protocol: "TCP", PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: svcInfo.proxyPort, Protocol: "TCP"}}, PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
 	})
 	svcInfo, exists := p.getServiceInfo(service)
 	if !exists {
@@ -433,13 +437,13 @@ func TestTCPProxyUpdateDeleteUpdate(t *testing.T) {
 
 func TestUDPProxyUpdateDeleteUpdate(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: udpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: udpServerPort}},
 			}},
 		},
 	})
@@ -464,7 +468,7 @@ func TestUDPProxyUpdateDeleteUpdate(t *testing.T) {
 	}
 	waitForNumProxyLoops(t, p, 0)
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: svcInfo.proxyPort, Protocol: "UDP", PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: svcInfo.proxyPort, Protocol: "UDP"}}, PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
 	})
 	svcInfo, exists := p.getServiceInfo(service)
 	if !exists {
@@ -476,13 +480,13 @@ func TestUDPProxyUpdateDeleteUpdate(t *testing.T) {
 
 func TestTCPProxyUpdatePort(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: tcpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
 			}},
 		},
 	})
@@ -498,7 +502,7 @@ func TestTCPProxyUpdatePort(t *testing.T) {
 	waitForNumProxyLoops(t, p, 1)
 
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: 99, Protocol: "TCP", PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: 99, Protocol: "TCP"}}, PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
 	})
 	// Wait for the socket to actually get free.
 	if err := waitForClosedPortTCP(p, svcInfo.proxyPort); err != nil {
@@ -516,13 +520,13 @@ func TestTCPProxyUpdatePort(t *testing.T) {
 
 func TestUDPProxyUpdatePort(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: udpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: udpServerPort}},
 			}},
 		},
 	})
@@ -537,7 +541,7 @@ func TestUDPProxyUpdatePort(t *testing.T) {
 	waitForNumProxyLoops(t, p, 1)
 
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: 99, Protocol: "UDP", PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: 99, Protocol: "UDP"}}, PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
 	})
 	// Wait for the socket to actually get free.
 	if err := waitForClosedPortUDP(p, svcInfo.proxyPort); err != nil {
@@ -553,13 +557,13 @@ func TestUDPProxyUpdatePort(t *testing.T) {
 
 func TestProxyUpdatePortal(t *testing.T) {
 	lb := NewLoadBalancerRR()
-	service := types.NewNamespacedNameOrDie("testnamespace", "echo")
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
 	lb.OnUpdate([]api.Endpoints{
 		{
 			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
 			Subsets: []api.EndpointSubset{{
 				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
-				Ports:     []api.EndpointPort{{Port: tcpServerPort}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
 			}},
 		},
 	})
@@ -575,7 +579,7 @@ func TestProxyUpdatePortal(t *testing.T) {
 	waitForNumProxyLoops(t, p, 1)
 
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: svcInfo.proxyPort, Protocol: "TCP"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: svcInfo.proxyPort, Protocol: "TCP"}}}, Status: api.ServiceStatus{}},
 	})
 	_, exists := p.getServiceInfo(service)
 	if exists {
@@ -583,7 +587,7 @@ func TestProxyUpdatePortal(t *testing.T) {
 	}
 
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: svcInfo.proxyPort, Protocol: "TCP", PortalIP: ""}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: svcInfo.proxyPort, Protocol: "TCP"}}, PortalIP: ""}, Status: api.ServiceStatus{}},
 	})
 	_, exists = p.getServiceInfo(service)
 	if exists {
@@ -591,7 +595,7 @@ func TestProxyUpdatePortal(t *testing.T) {
 	}
 
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: svcInfo.proxyPort, Protocol: "TCP", PortalIP: "None"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: svcInfo.proxyPort, Protocol: "TCP"}}, PortalIP: "None"}, Status: api.ServiceStatus{}},
 	})
 	_, exists = p.getServiceInfo(service)
 	if exists {
@@ -599,7 +603,7 @@ func TestProxyUpdatePortal(t *testing.T) {
 	}
 
 	p.OnUpdate([]api.Service{
-		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Port: svcInfo.proxyPort, Protocol: "TCP", PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
+		{ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace}, Spec: api.ServiceSpec{Ports: []api.ServicePort{{Name: "p", Port: svcInfo.proxyPort, Protocol: "TCP"}}, PortalIP: "1.2.3.4"}, Status: api.ServiceStatus{}},
 	})
 	svcInfo, exists = p.getServiceInfo(service)
 	if !exists {
@@ -609,4 +613,130 @@ func TestProxyUpdatePortal(t *testing.T) {
 	waitForNumProxyLoops(t, p, 1)
 }
 
+func TestProxyMultiplePorts(t *testing.T) {
+	lb := NewLoadBalancerRR()
+	serviceP := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
+	serviceQ := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "q"}
+	lb.OnUpdate([]api.Endpoints{
+		{
+			ObjectMeta: api.ObjectMeta{Name: serviceP.Name, Namespace: serviceP.Namespace},
+			Subsets: []api.EndpointSubset{{
+				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}, {Name: "q", Port: udpServerPort}},
+			}},
+		},
+	})
+
+	p := CreateProxier(lb, net.ParseIP("0.0.0.0"), &fakeIptables{}, net.ParseIP("127.0.0.1"))
+	waitForNumProxyLoops(t, p, 0)
+
+	p.OnUpdate([]api.Service{
+		{
+			ObjectMeta: api.ObjectMeta{Name: serviceP.Name, Namespace: serviceP.Namespace},
+			Spec: api.ServiceSpec{
+				PortalIP: "1.2.3.4",
+				Ports: []api.ServicePort{
+					{Name: "p", Port: 80, Protocol: "TCP"},
+					{Name: "q", Port: 81, Protocol: "UDP"},
+				},
+			},
+		},
+	})
+	svcInfoP, exists := p.getServiceInfo(serviceP)
+	if !exists {
+		t.Fatalf("can't find serviceInfo for %s", serviceP)
+	}
+	svcInfoQ, exists := p.getServiceInfo(serviceQ)
+	if !exists {
+		t.Fatalf("can't find serviceInfo for %s", serviceQ)
+	}
+	testEchoTCP(t, "127.0.0.1", svcInfoP.proxyPort)
+	testEchoUDP(t, "127.0.0.1", svcInfoQ.proxyPort)
+	waitForNumProxyLoops(t, p, 2)
+
+	// Dropping a port stops only that port's proxy.
+	p.OnUpdate([]api.Service{
+		{
+			ObjectMeta: api.ObjectMeta{Name: serviceP.Name, Namespace: serviceP.Namespace},
+			Spec: api.ServiceSpec{
+				PortalIP: "1.2.3.4",
+				Ports:    []api.ServicePort{{Name: "p", Port: 80, Protocol: "TCP"}},
+			},
+		},
+	})
+	if _, exists := p.getServiceInfo(serviceQ); exists {
+		t.Fatalf("expected %s to be removed", serviceQ)
+	}
+	if info, exists := p.getServiceInfo(serviceP); !exists || info != svcInfoP {
+		t.Fatalf("expected %s to be unchanged", serviceP)
+	}
+	waitForNumProxyLoops(t, p, 1)
+}
+
+func TestProxyNodePort(t *testing.T) {
+	lb := NewLoadBalancerRR()
+	service := servicePort{types.NewNamespacedNameOrDie("testnamespace", "echo"), "p"}
+	lb.OnUpdate([]api.Endpoints{
+		{
+			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
+			Subsets: []api.EndpointSubset{{
+				Addresses: []api.EndpointAddress{{IP: "127.0.0.1"}},
+				Ports:     []api.EndpointPort{{Name: "p", Port: tcpServerPort}},
+			}},
+		},
+	})
+
+	// Find a free port to use as the node port.
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	nodePort := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	p := CreateProxier(lb, net.ParseIP("0.0.0.0"), &fakeIptables{}, net.ParseIP("127.0.0.1"))
+	waitForNumProxyLoops(t, p, 0)
+
+	p.OnUpdate([]api.Service{
+		{
+			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
+			Spec: api.ServiceSpec{
+				PortalIP: "1.2.3.4",
+				Type:     api.ServiceTypeNodePort,
+				Ports:    []api.ServicePort{{Name: "p", Port: 80, Protocol: "TCP", NodePort: nodePort}},
+			},
+		},
+	})
+	svcInfo, exists := p.getServiceInfo(service)
+	if !exists {
+		t.Fatalf("can't find serviceInfo for %s", service)
+	}
+	if svcInfo.proxyPort != nodePort {
+		t.Errorf("expected proxy port %d, got %d", nodePort, svcInfo.proxyPort)
+	}
+	testEchoTCP(t, "127.0.0.1", nodePort)
+	waitForNumProxyLoops(t, p, 1)
+
+	// Switching to ClusterIP moves the proxy off the node port.
+	p.OnUpdate([]api.Service{
+		{
+			ObjectMeta: api.ObjectMeta{Name: service.Name, Namespace: service.Namespace},
+			Spec: api.ServiceSpec{
+				PortalIP: "1.2.3.4",
+				Type:     api.ServiceTypeClusterIP,
+				Ports:    []api.ServicePort{{Name: "p", Port: 80, Protocol: "TCP"}},
+			},
+		},
+	})
+	svcInfo, exists = p.getServiceInfo(service)
+	if !exists {
+		t.Fatalf("can't find serviceInfo for %s", service)
+	}
+	if svcInfo.proxyPort == nodePort {
+		t.Errorf("expected proxy to move off node port %d", nodePort)
+	}
+	testEchoTCP(t, "127.0.0.1", svcInfo.proxyPort)
+	waitForNumProxyLoops(t, p, 1)
+}
+
 // TODO: Test UDP timeouts.
@@ -0,0 +1,63 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/clusterrole"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/generic"
+	etcdgeneric "github.com/GoogleCloudPlatform/kubernetes/pkg/registry/generic/etcd"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+)
+
+// REST implements a RESTStorage for cluster roles against etcd
+type REST struct {
+	*etcdgeneric.Etcd
+}
+
+// NewStorage returns a RESTStorage object that will work against cluster roles.
+func NewStorage(h tools.EtcdHelper) *REST {
+	prefix := "/registry/clusterroles"
+	return &REST{
+		&etcdgeneric.Etcd{
+			NewFunc:     func() runtime.Object { return &api.ClusterRole{} },
+			NewListFunc: func() runtime.Object { return &api.ClusterRoleList{} },
+			KeyRootFunc: func(ctx api.Context) string {
+				return prefix
+			},
+			KeyFunc: func(ctx api.Context, name string) (string, error) {
+				return prefix + "/" + name, nil
+			},
+			ObjectNameFunc: func(obj runtime.Object) (string, error) {
+				return obj.(*api.ClusterRole).Name, nil
+			},
+			PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+				return clusterrole.Matcher(label, field)
+			},
+			EndpointName: "clusterroles",
+
+			CreateStrategy:      clusterrole.Strategy,
+			UpdateStrategy:      clusterrole.Strategy,
+			ReturnDeletedObject: true,
+
+			Helper: h,
+		},
+	}
+}
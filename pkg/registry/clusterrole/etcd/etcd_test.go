@@ -0,0 +1,137 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func validNewClusterRole(name string) *api.ClusterRole {
+	return &api.ClusterRole{
+		ObjectMeta: api.ObjectMeta{
+			Name: name,
+		},
+		Rules: []api.PolicyRule{
+			{Verbs: []string{"get", "list"}, Resources: []string{"pods"}},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError).ClusterScope()
+	clusterRole := validNewClusterRole("foo")
+	clusterRole.ObjectMeta = api.ObjectMeta{}
+	test.TestCreateHasMetadata(clusterRole)
+	test.TestCreateGeneratesName(&api.ClusterRole{Rules: clusterRole.Rules})
+	test.TestCreateInvokesValidation(
+		&api.ClusterRole{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestListClusterRoles(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/clusterroles"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewClusterRole("foo"))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewClusterRole("bar"))},
+				},
+			},
+		},
+	}
+	storage := NewStorage(helper)
+	obj, err := storage.List(api.NewContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clusterRoles := obj.(*api.ClusterRoleList)
+
+	if len(clusterRoles.Items) != 2 {
+		t.Errorf("Unexpected cluster role list: %#v", clusterRoles)
+	}
+	if clusterRoles.Items[0].Name != "foo" || clusterRoles.Items[1].Name != "bar" {
+		t.Errorf("Unexpected cluster roles: %#v", clusterRoles.Items)
+	}
+}
+
+func TestUpdateRules(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage := NewStorage(helper)
+	ctx := api.NewContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, validNewClusterRole("foo")), 1)
+
+	in := validNewClusterRole("foo")
+	in.ResourceVersion = "1"
+	in.Rules = append(in.Rules, api.PolicyRule{Verbs: []string{"create"}, Resources: []string{"services"}})
+
+	if _, _, err := storage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.ClusterRole{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := validNewClusterRole("foo")
+	expected.ResourceVersion = "2"
+	expected.Rules = in.Rules
+	if !api.Semantic.DeepEqual(expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(expected, out))
+	}
+}
+
+func TestDeleteClusterRole(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/clusterroles/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewClusterRole("foo")),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage := NewStorage(helper)
+	_, err := storage.Delete(api.NewContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func validNewClusterRoleBinding(name string) *api.ClusterRoleBinding {
+	return &api.ClusterRoleBinding{
+		ObjectMeta: api.ObjectMeta{
+			Name: name,
+		},
+		Subjects: []api.Subject{
+			{Kind: api.UserKind, Name: "alice"},
+		},
+		RoleRef: api.ObjectReference{Kind: "ClusterRole", Name: "reader"},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError).ClusterScope()
+	clusterRoleBinding := validNewClusterRoleBinding("foo")
+	clusterRoleBinding.ObjectMeta = api.ObjectMeta{}
+	test.TestCreateHasMetadata(clusterRoleBinding)
+	test.TestCreateGeneratesName(&api.ClusterRoleBinding{Subjects: clusterRoleBinding.Subjects, RoleRef: clusterRoleBinding.RoleRef})
+	test.TestCreateInvokesValidation(
+		&api.ClusterRoleBinding{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestListClusterRoleBindings(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/clusterrolebindings"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewClusterRoleBinding("foo"))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewClusterRoleBinding("bar"))},
+				},
+			},
+		},
+	}
+	storage := NewStorage(helper)
+	obj, err := storage.List(api.NewContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clusterRoleBindings := obj.(*api.ClusterRoleBindingList)
+
+	if len(clusterRoleBindings.Items) != 2 {
+		t.Errorf("Unexpected cluster role binding list: %#v", clusterRoleBindings)
+	}
+	if clusterRoleBindings.Items[0].Name != "foo" || clusterRoleBindings.Items[1].Name != "bar" {
+		t.Errorf("Unexpected cluster role bindings: %#v", clusterRoleBindings.Items)
+	}
+}
+
+func TestUpdateSubjects(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage := NewStorage(helper)
+	ctx := api.NewContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, validNewClusterRoleBinding("foo")), 1)
+
+	in := validNewClusterRoleBinding("foo")
+	in.ResourceVersion = "1"
+	in.Subjects = append(in.Subjects, api.Subject{Kind: api.GroupKind, Name: "admins"})
+
+	if _, _, err := storage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.ClusterRoleBinding{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := validNewClusterRoleBinding("foo")
+	expected.ResourceVersion = "2"
+	expected.Subjects = in.Subjects
+	if !api.Semantic.DeepEqual(expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(expected, out))
+	}
+}
+
+func TestDeleteClusterRoleBinding(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/clusterrolebindings/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewClusterRoleBinding("foo")),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage := NewStorage(helper)
+	_, err := storage.Delete(api.NewContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterrolebinding
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/validation"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/generic"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/fielderrors"
+)
+
+// strategy implements behavior for ClusterRoleBinding objects
+type strategy struct {
+	runtime.ObjectTyper
+	api.NameGenerator
+}
+
+// Strategy is the default logic that applies when creating and updating ClusterRoleBinding
+// objects via the REST API.
+var Strategy = strategy{api.Scheme, api.SimpleNameGenerator}
+
+// NamespaceScoped is false for cluster role bindings.
+func (strategy) NamespaceScoped() bool {
+	return false
+}
+
+// PrepareForCreate is a no-op for cluster role bindings.
+func (strategy) PrepareForCreate(obj runtime.Object) {
+}
+
+// PrepareForUpdate is a no-op for cluster role bindings.
+func (strategy) PrepareForUpdate(obj, old runtime.Object) {
+}
+
+// Validate validates a new cluster role binding.
+func (strategy) Validate(obj runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateClusterRoleBinding(obj.(*api.ClusterRoleBinding))
+}
+
+// AllowCreateOnUpdate is false for cluster role bindings.
+func (strategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (strategy) ValidateUpdate(obj, old runtime.Object) fielderrors.ValidationErrorList {
+	return validation.ValidateClusterRoleBindingUpdate(old.(*api.ClusterRoleBinding), obj.(*api.ClusterRoleBinding))
+}
+
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
+		binding, ok := obj.(*api.ClusterRoleBinding)
+		if !ok {
+			return false, fmt.Errorf("not a cluster role binding")
+		}
+		fields := SelectableFields(binding)
+		return label.Matches(labels.Set(binding.Labels)) && field.Matches(fields), nil
+	})
+}
+
+// SelectableFields returns a label set that represents the object
+// TODO: fields are not labels, and the validation rules for them do not apply.
+func SelectableFields(obj *api.ClusterRoleBinding) labels.Set {
+	return labels.Set{
+		"name": obj.Name,
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient, h := newHelper(t)
+	storage, statusStorage := NewStorage(h)
+	return storage, statusStorage, fakeEtcdClient, h
+}
+
+func validNewDaemonSet(name, ns string) *api.DaemonSet {
+	return &api.DaemonSet{
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: api.DaemonSetSpec{
+			Selector: map[string]string{"name": "fluentd"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"name": "fluentd"},
+				},
+				Spec: api.PodSpec{
+					RestartPolicy: api.RestartPolicyAlways,
+					DNSPolicy:     api.DNSClusterFirst,
+					NodeSelector:  map[string]string{"logging": "true"},
+					Containers:    []api.Container{{Name: "fluentd", Image: "fluentd", ImagePullPolicy: api.PullIfNotPresent, TerminationMessagePath: api.TerminationMessagePathDefault}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	daemonSet := validNewDaemonSet("foo", api.NamespaceDefault)
+	daemonSet.ObjectMeta = api.ObjectMeta{}
+	test.TestCreate(
+		// valid
+		daemonSet,
+		// invalid
+		&api.DaemonSet{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestCreateRegistryError(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Err = fmt.Errorf("test error")
+	storage, _ := NewStorage(helper)
+
+	daemonSet := validNewDaemonSet("foo", api.NamespaceDefault)
+	_, err := storage.Create(api.NewDefaultContext(), daemonSet)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateClearsStatus(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	daemonSet := validNewDaemonSet("foo", api.NamespaceDefault)
+	daemonSet.Status = api.DaemonSetStatus{CurrentNumberScheduled: 1, DesiredNumberScheduled: 2}
+	_, err := storage.Create(api.NewDefaultContext(), daemonSet)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := &api.DaemonSet{}
+	if err := helper.ExtractObj("/registry/daemonsets/default/foo", actual, false); err != nil {
+		t.Fatalf("unexpected extraction error: %v", err)
+	}
+	if actual.Name != daemonSet.Name {
+		t.Errorf("unexpected daemon set: %#v", actual)
+	}
+	if len(actual.UID) == 0 {
+		t.Errorf("expected daemon set UID to be set: %#v", actual)
+	}
+	if !api.Semantic.DeepEqual(actual.Status, api.DaemonSetStatus{}) {
+		t.Errorf("expected new daemon set status to be cleared: %#v", actual.Status)
+	}
+}
+
+func TestListDaemonSetList(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/daemonsets/default"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewDaemonSet("foo", api.NamespaceDefault))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewDaemonSet("bar", api.NamespaceDefault))},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	obj, err := storage.List(api.NewDefaultContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	daemonSets := obj.(*api.DaemonSetList)
+
+	if len(daemonSets.Items) != 2 {
+		t.Errorf("Unexpected daemon set list: %#v", daemonSets)
+	}
+	if daemonSets.Items[0].Name != "foo" {
+		t.Errorf("Unexpected daemon set: %#v", daemonSets.Items[0])
+	}
+	if daemonSets.Items[1].Name != "bar" {
+		t.Errorf("Unexpected daemon set: %#v", daemonSets.Items[1])
+	}
+}
+
+func TestUpdateNodeSelector(t *testing.T) {
+	storage, _, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewDaemonSet("foo", api.NamespaceDefault)
+	start.Status = api.DaemonSetStatus{CurrentNumberScheduled: 2, DesiredNumberScheduled: 2}
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewDaemonSet("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	in.Spec.Template.Spec.NodeSelector = map[string]string{"monitoring": "true"}
+	in.Status = api.DaemonSetStatus{}
+	if _, _, err := storage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.DaemonSet{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.Spec.Template.Spec.NodeSelector["monitoring"] != "true" {
+		t.Errorf("expected the node selector to be updated: %#v", out.Spec)
+	}
+	if out.Status.CurrentNumberScheduled != 2 {
+		t.Errorf("expected status to be preserved, got %#v", out.Status)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	storage, statusStorage, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewDaemonSet("foo", api.NamespaceDefault)
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewDaemonSet("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	in.Spec.Template.Spec.NodeSelector = nil
+	in.Status = api.DaemonSetStatus{CurrentNumberScheduled: 3, NumberMisscheduled: 1, DesiredNumberScheduled: 4}
+
+	expected := *start
+	expected.ResourceVersion = "2"
+	expected.Status = in.Status
+
+	if _, _, err := statusStorage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.DaemonSet{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !api.Semantic.DeepEqual(&expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(&expected, out))
+	}
+}
+
+func TestDeleteDaemonSet(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/daemonsets/default/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewDaemonSet("foo", api.NamespaceDefault)),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	_, err := storage.Delete(api.NewDefaultContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
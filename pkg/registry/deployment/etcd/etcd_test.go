@@ -0,0 +1,232 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient, h := newHelper(t)
+	storage, statusStorage := NewStorage(h)
+	return storage, statusStorage, fakeEtcdClient, h
+}
+
+func validNewDeployment(name, ns string) *api.Deployment {
+	return &api.Deployment{
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: api.DeploymentSpec{
+			Replicas: 3,
+			Selector: map[string]string{"app": "frontend"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"app": "frontend"},
+				},
+				Spec: api.PodSpec{
+					RestartPolicy: api.RestartPolicyAlways,
+					DNSPolicy:     api.DNSClusterFirst,
+					Containers:    []api.Container{{Name: "web", Image: "nginx", ImagePullPolicy: api.PullIfNotPresent, TerminationMessagePath: api.TerminationMessagePathDefault}},
+				},
+			},
+			Strategy: api.DeploymentStrategy{
+				Type: api.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &api.RollingUpdateDeployment{
+					MaxUnavailable: util.NewIntOrStringFromInt(1),
+					MaxSurge:       util.NewIntOrStringFromInt(1),
+				},
+			},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	deployment := validNewDeployment("foo", api.NamespaceDefault)
+	deployment.ObjectMeta = api.ObjectMeta{}
+	test.TestCreate(
+		// valid
+		deployment,
+		// invalid
+		&api.Deployment{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestCreateRegistryError(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Err = fmt.Errorf("test error")
+	storage, _ := NewStorage(helper)
+
+	deployment := validNewDeployment("foo", api.NamespaceDefault)
+	_, err := storage.Create(api.NewDefaultContext(), deployment)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateClearsStatus(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	deployment := validNewDeployment("foo", api.NamespaceDefault)
+	deployment.Status = api.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, Revision: 4}
+	_, err := storage.Create(api.NewDefaultContext(), deployment)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := &api.Deployment{}
+	if err := helper.ExtractObj("/registry/deployments/default/foo", actual, false); err != nil {
+		t.Fatalf("unexpected extraction error: %v", err)
+	}
+	if actual.Name != deployment.Name {
+		t.Errorf("unexpected deployment: %#v", actual)
+	}
+	if len(actual.UID) == 0 {
+		t.Errorf("expected deployment UID to be set: %#v", actual)
+	}
+	if actual.Status != (api.DeploymentStatus{}) {
+		t.Errorf("expected new deployment status to be cleared: %#v", actual.Status)
+	}
+}
+
+func TestListDeploymentList(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/deployments/default"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewDeployment("foo", api.NamespaceDefault))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewDeployment("bar", api.NamespaceDefault))},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	obj, err := storage.List(api.NewDefaultContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deployments := obj.(*api.DeploymentList)
+
+	if len(deployments.Items) != 2 {
+		t.Errorf("Unexpected deployment list: %#v", deployments)
+	}
+	if deployments.Items[0].Name != "foo" {
+		t.Errorf("Unexpected deployment: %#v", deployments.Items[0])
+	}
+	if deployments.Items[1].Name != "bar" {
+		t.Errorf("Unexpected deployment: %#v", deployments.Items[1])
+	}
+}
+
+func TestUpdateKeepsStatus(t *testing.T) {
+	storage, _, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewDeployment("foo", api.NamespaceDefault)
+	start.Status = api.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, Revision: 1}
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewDeployment("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	in.Spec.Paused = true
+	if _, _, err := storage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.Deployment{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !out.Spec.Paused {
+		t.Errorf("expected spec to be updated: %#v", out.Spec)
+	}
+	if out.Status != start.Status {
+		t.Errorf("expected status to be preserved, got %#v", out.Status)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	storage, statusStorage, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewDeployment("foo", api.NamespaceDefault)
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewDeployment("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	in.Spec.Replicas = 10
+	in.Status = api.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, Revision: 2}
+
+	expected := *start
+	expected.ResourceVersion = "2"
+	expected.Status = in.Status
+
+	if _, _, err := statusStorage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.Deployment{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !api.Semantic.DeepEqual(&expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(&expected, out))
+	}
+}
+
+func TestDeleteDeployment(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/deployments/default/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewDeployment("foo", api.NamespaceDefault)),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	_, err := storage.Delete(api.NewDefaultContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
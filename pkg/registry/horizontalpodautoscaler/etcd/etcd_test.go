@@ -0,0 +1,175 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient, h := newHelper(t)
+	storage, statusStorage := NewStorage(h)
+	return storage, statusStorage, fakeEtcdClient, h
+}
+
+func validNewAutoscaler(name, ns string) *api.HorizontalPodAutoscaler {
+	minReplicas := 1
+	return &api.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: api.HorizontalPodAutoscalerSpec{
+			ScaleRef:       api.ObjectReference{Kind: "ReplicationController", Name: "frontend"},
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    5,
+			CPUUtilization: &api.CPUTargetUtilization{TargetPercentage: 70},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	autoscaler := validNewAutoscaler("foo", api.NamespaceDefault)
+	autoscaler.ObjectMeta = api.ObjectMeta{}
+	test.TestCreate(
+		// valid
+		autoscaler,
+		// invalid
+		&api.HorizontalPodAutoscaler{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestCreateClearsStatus(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	autoscaler := validNewAutoscaler("foo", api.NamespaceDefault)
+	autoscaler.Status = api.HorizontalPodAutoscalerStatus{CurrentReplicas: 1, DesiredReplicas: 2}
+	_, err := storage.Create(api.NewDefaultContext(), autoscaler)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := &api.HorizontalPodAutoscaler{}
+	if err := helper.ExtractObj("/registry/horizontalpodautoscalers/default/foo", actual, false); err != nil {
+		t.Fatalf("unexpected extraction error: %v", err)
+	}
+	if actual.Name != autoscaler.Name {
+		t.Errorf("unexpected autoscaler: %#v", actual)
+	}
+	if !api.Semantic.DeepEqual(actual.Status, api.HorizontalPodAutoscalerStatus{}) {
+		t.Errorf("expected new autoscaler status to be cleared: %#v", actual.Status)
+	}
+}
+
+func TestListAutoscalerList(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/horizontalpodautoscalers/default"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewAutoscaler("foo", api.NamespaceDefault))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewAutoscaler("bar", api.NamespaceDefault))},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	obj, err := storage.List(api.NewDefaultContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	autoscalers := obj.(*api.HorizontalPodAutoscalerList)
+
+	if len(autoscalers.Items) != 2 {
+		t.Errorf("Unexpected autoscaler list: %#v", autoscalers)
+	}
+	if autoscalers.Items[0].Name != "foo" || autoscalers.Items[1].Name != "bar" {
+		t.Errorf("Unexpected autoscalers: %#v", autoscalers.Items)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	storage, statusStorage, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewAutoscaler("foo", api.NamespaceDefault)
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewAutoscaler("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	in.Spec.MaxReplicas = 10
+	utilization := 95
+	now := util.Unix(1432000000, 0)
+	in.Status = api.HorizontalPodAutoscalerStatus{CurrentReplicas: 2, DesiredReplicas: 3, CurrentCPUUtilizationPercentage: &utilization, LastScaleTime: &now}
+
+	expected := *start
+	expected.ResourceVersion = "2"
+	expected.Status = in.Status
+
+	if _, _, err := statusStorage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.HorizontalPodAutoscaler{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !api.Semantic.DeepEqual(&expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(&expected, out))
+	}
+}
+
+func TestDeleteAutoscaler(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/horizontalpodautoscalers/default/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewAutoscaler("foo", api.NamespaceDefault)),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	_, err := storage.Delete(api.NewDefaultContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient, h := newHelper(t)
+	storage, statusStorage := NewStorage(h)
+	return storage, statusStorage, fakeEtcdClient, h
+}
+
+func validNewJob(name, ns string) *api.Job {
+	parallelism := 2
+	completions := 4
+	return &api.Job{
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: api.JobSpec{
+			Parallelism: &parallelism,
+			Completions: &completions,
+			Selector:    map[string]string{"job": "pi"},
+			Template: &api.PodTemplateSpec{
+				ObjectMeta: api.ObjectMeta{
+					Labels: map[string]string{"job": "pi"},
+				},
+				Spec: api.PodSpec{
+					RestartPolicy: api.RestartPolicyOnFailure,
+					DNSPolicy:     api.DNSClusterFirst,
+					Containers:    []api.Container{{Name: "pi", Image: "perl", ImagePullPolicy: api.PullIfNotPresent, TerminationMessagePath: api.TerminationMessagePathDefault}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	job := validNewJob("foo", api.NamespaceDefault)
+	job.ObjectMeta = api.ObjectMeta{}
+	test.TestCreate(
+		// valid
+		job,
+		// invalid
+		&api.Job{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestCreateRegistryError(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Err = fmt.Errorf("test error")
+	storage, _ := NewStorage(helper)
+
+	job := validNewJob("foo", api.NamespaceDefault)
+	_, err := storage.Create(api.NewDefaultContext(), job)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateClearsStatus(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	job := validNewJob("foo", api.NamespaceDefault)
+	job.Status = api.JobStatus{Active: 1, Succeeded: 2}
+	_, err := storage.Create(api.NewDefaultContext(), job)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := &api.Job{}
+	if err := helper.ExtractObj("/registry/jobs/default/foo", actual, false); err != nil {
+		t.Fatalf("unexpected extraction error: %v", err)
+	}
+	if actual.Name != job.Name {
+		t.Errorf("unexpected job: %#v", actual)
+	}
+	if len(actual.UID) == 0 {
+		t.Errorf("expected job UID to be set: %#v", actual)
+	}
+	if !api.Semantic.DeepEqual(actual.Status, api.JobStatus{}) {
+		t.Errorf("expected new job status to be cleared: %#v", actual.Status)
+	}
+}
+
+func TestListJobList(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/jobs/default"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewJob("foo", api.NamespaceDefault))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewJob("bar", api.NamespaceDefault))},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	obj, err := storage.List(api.NewDefaultContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jobs := obj.(*api.JobList)
+
+	if len(jobs.Items) != 2 {
+		t.Errorf("Unexpected job list: %#v", jobs)
+	}
+	if jobs.Items[0].Name != "foo" {
+		t.Errorf("Unexpected job: %#v", jobs.Items[0])
+	}
+	if jobs.Items[1].Name != "bar" {
+		t.Errorf("Unexpected job: %#v", jobs.Items[1])
+	}
+}
+
+func TestUpdateParallelism(t *testing.T) {
+	storage, _, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewJob("foo", api.NamespaceDefault)
+	start.Status = api.JobStatus{Active: 2}
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewJob("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	parallelism := 3
+	in.Spec.Parallelism = &parallelism
+	if _, _, err := storage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.Job{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *out.Spec.Parallelism != 3 {
+		t.Errorf("expected parallelism to be updated: %#v", out.Spec)
+	}
+	if out.Status.Active != 2 {
+		t.Errorf("expected status to be preserved, got %#v", out.Status)
+	}
+
+	in = validNewJob("foo", api.NamespaceDefault)
+	in.ResourceVersion = "2"
+	completions := 10
+	in.Spec.Completions = &completions
+	if _, _, err := storage.Update(ctx, in); err == nil {
+		t.Errorf("expected an error when updating completions")
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	storage, statusStorage, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	start := validNewJob("foo", api.NamespaceDefault)
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, start), 1)
+
+	in := validNewJob("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	completions := 10
+	in.Spec.Completions = &completions
+	in.Status = api.JobStatus{Active: 1, Succeeded: 3, Failed: 1}
+
+	expected := *start
+	expected.ResourceVersion = "2"
+	expected.Status = in.Status
+
+	if _, _, err := statusStorage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.Job{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !api.Semantic.DeepEqual(&expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(&expected, out))
+	}
+}
+
+func TestDeleteJob(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/jobs/default/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewJob("foo", api.NamespaceDefault)),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	_, err := storage.Delete(api.NewDefaultContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/persistentvolume"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient, h := newHelper(t)
+	storage, statusStorage := NewStorage(h)
+	return storage, statusStorage, fakeEtcdClient, h
+}
+
+func validNewPersistentVolume(name string) *api.PersistentVolume {
+	return &api.PersistentVolume{
+		ObjectMeta: api.ObjectMeta{
+			Name: name,
+		},
+		Spec: api.PersistentVolumeSpec{
+			Capacity: api.ResourceList{
+				api.ResourceName(api.ResourceStorage): resource.MustParse("10G"),
+			},
+			PersistentVolumeSource: api.PersistentVolumeSource{
+				HostPath: &api.HostPathVolumeSource{Path: "/foo"},
+			},
+		},
+	}
+}
+
+func TestStorage(t *testing.T) {
+	storage, _, _, _ := newStorage(t)
+	persistentvolume.NewRegistry(storage)
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	pv := validNewPersistentVolume("foo")
+	pv.ObjectMeta = api.ObjectMeta{}
+	test.TestCreateHasMetadata(pv)
+	test.TestCreateGeneratesName(&api.PersistentVolume{Spec: pv.Spec})
+	test.TestCreateInvokesValidation(
+		&api.PersistentVolume{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestCreateRegistryError(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Err = fmt.Errorf("test error")
+	storage, _ := NewStorage(helper)
+
+	pv := validNewPersistentVolume("foo")
+	_, err := storage.Create(api.NewContext(), pv)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateSetsFields(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	pv := validNewPersistentVolume("foo")
+	pv.Status.Phase = api.VolumeBound
+	_, err := storage.Create(api.NewContext(), pv)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := &api.PersistentVolume{}
+	if err := helper.ExtractObj("/registry/persistentvolumes/foo", actual, false); err != nil {
+		t.Fatalf("unexpected extraction error: %v", err)
+	}
+	if actual.Name != pv.Name {
+		t.Errorf("unexpected persistentvolume: %#v", actual)
+	}
+	if len(actual.UID) == 0 {
+		t.Errorf("expected persistentvolume UID to be set: %#v", actual)
+	}
+	if actual.Status.Phase != api.VolumeAvailable {
+		t.Errorf("expected new persistentvolume to be available: %#v", actual)
+	}
+}
+
+func TestListPersistentVolumeList(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/persistentvolumes"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewPersistentVolume("foo"))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewPersistentVolume("bar"))},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	pvObj, err := storage.List(api.NewContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pvs := pvObj.(*api.PersistentVolumeList)
+
+	if len(pvs.Items) != 2 {
+		t.Errorf("Unexpected persistentvolume list: %#v", pvs)
+	}
+	if pvs.Items[0].Name != "foo" {
+		t.Errorf("Unexpected persistentvolume: %#v", pvs.Items[0])
+	}
+	if pvs.Items[1].Name != "bar" {
+		t.Errorf("Unexpected persistentvolume: %#v", pvs.Items[1])
+	}
+}
+
+func TestListPersistentVolumeListSelection(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	bound := validNewPersistentVolume("bound")
+	bound.Status.Phase = api.VolumeBound
+	available := validNewPersistentVolume("available")
+	available.Status.Phase = api.VolumeAvailable
+	fakeEtcdClient.Data["/registry/persistentvolumes"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, bound)},
+					{Value: runtime.EncodeOrDie(latest.Codec, available)},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+
+	table := []struct {
+		field       string
+		expectedIDs util.StringSet
+	}{
+		{
+			expectedIDs: util.NewStringSet("bound", "available"),
+		}, {
+			field:       "name=bound",
+			expectedIDs: util.NewStringSet("bound"),
+		}, {
+			field:       "status.phase=Available",
+			expectedIDs: util.NewStringSet("available"),
+		},
+	}
+
+	for index, item := range table {
+		field, err := fields.ParseSelector(item.field)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		pvObj, err := storage.List(api.NewContext(), labels.Everything(), field)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		pvs := pvObj.(*api.PersistentVolumeList)
+
+		set := util.NewStringSet()
+		for i := range pvs.Items {
+			set.Insert(pvs.Items[i].Name)
+		}
+		if !set.IsSuperset(item.expectedIDs) || len(set) != len(item.expectedIDs) {
+			t.Errorf("%v: Expected %v, got %v", index, item.expectedIDs, set)
+		}
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	storage, statusStorage, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	pvStart := validNewPersistentVolume("foo")
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, pvStart), 1)
+
+	pvIn := &api.PersistentVolume{
+		ObjectMeta: api.ObjectMeta{
+			Name:            "foo",
+			ResourceVersion: "1",
+		},
+		Spec: api.PersistentVolumeSpec{
+			Capacity: api.ResourceList{
+				api.ResourceName(api.ResourceStorage): resource.MustParse("20G"),
+			},
+		},
+		Status: api.PersistentVolumeStatus{
+			Phase: api.VolumeBound,
+		},
+	}
+
+	expected := *pvStart
+	expected.ResourceVersion = "2"
+	expected.Labels = pvIn.Labels
+	expected.Status = pvIn.Status
+
+	_, _, err := statusStorage.Update(ctx, pvIn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pvOut := &api.PersistentVolume{}
+	if err := helper.ExtractObj(key, pvOut, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !api.Semantic.DeepEqual(&expected, pvOut) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(&expected, pvOut))
+	}
+}
+
+func TestDeletePersistentVolume(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/persistentvolumes/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewPersistentVolume("foo")),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	_, err := storage.Delete(api.NewContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
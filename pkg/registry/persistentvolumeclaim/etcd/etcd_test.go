@@ -0,0 +1,237 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/persistentvolumeclaim"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient, h := newHelper(t)
+	storage, statusStorage := NewStorage(h)
+	return storage, statusStorage, fakeEtcdClient, h
+}
+
+func validNewPersistentVolumeClaim(name, ns string) *api.PersistentVolumeClaim {
+	return &api.PersistentVolumeClaim{
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: api.PersistentVolumeClaimSpec{
+			AccessModes: []api.AccessModeType{api.ReadWriteOnce},
+			Resources: api.ResourceRequirements{
+				Requests: api.ResourceList{
+					api.ResourceName(api.ResourceStorage): resource.MustParse("10G"),
+				},
+			},
+		},
+	}
+}
+
+func TestStorage(t *testing.T) {
+	storage, _, _, _ := newStorage(t)
+	persistentvolumeclaim.NewRegistry(storage)
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	pvc := validNewPersistentVolumeClaim("foo", api.NamespaceDefault)
+	pvc.ObjectMeta = api.ObjectMeta{}
+	test.TestCreate(
+		// valid
+		pvc,
+		// invalid
+		&api.PersistentVolumeClaim{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestCreateRegistryError(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Err = fmt.Errorf("test error")
+	storage, _ := NewStorage(helper)
+
+	pvc := validNewPersistentVolumeClaim("foo", api.NamespaceDefault)
+	_, err := storage.Create(api.NewDefaultContext(), pvc)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateSetsFields(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage, _ := NewStorage(helper)
+	pvc := validNewPersistentVolumeClaim("foo", api.NamespaceDefault)
+	pvc.Status.Phase = api.ClaimBound
+	_, err := storage.Create(api.NewDefaultContext(), pvc)
+	if err != fakeEtcdClient.Err {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := &api.PersistentVolumeClaim{}
+	if err := helper.ExtractObj("/registry/persistentvolumeclaims/default/foo", actual, false); err != nil {
+		t.Fatalf("unexpected extraction error: %v", err)
+	}
+	if actual.Name != pvc.Name {
+		t.Errorf("unexpected persistentvolumeclaim: %#v", actual)
+	}
+	if len(actual.UID) == 0 {
+		t.Errorf("expected persistentvolumeclaim UID to be set: %#v", actual)
+	}
+	if actual.Status.Phase != api.ClaimPending {
+		t.Errorf("expected new persistentvolumeclaim to be pending: %#v", actual)
+	}
+}
+
+func TestListPersistentVolumeClaimList(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/persistentvolumeclaims/default"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewPersistentVolumeClaim("foo", api.NamespaceDefault))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewPersistentVolumeClaim("bar", api.NamespaceDefault))},
+				},
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	pvcObj, err := storage.List(api.NewDefaultContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pvcs := pvcObj.(*api.PersistentVolumeClaimList)
+
+	if len(pvcs.Items) != 2 {
+		t.Errorf("Unexpected persistentvolumeclaim list: %#v", pvcs)
+	}
+	if pvcs.Items[0].Name != "foo" {
+		t.Errorf("Unexpected persistentvolumeclaim: %#v", pvcs.Items[0])
+	}
+	if pvcs.Items[1].Name != "bar" {
+		t.Errorf("Unexpected persistentvolumeclaim: %#v", pvcs.Items[1])
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	storage, statusStorage, fakeEtcdClient, helper := newStorage(t)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	pvcStart := validNewPersistentVolumeClaim("foo", api.NamespaceDefault)
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, pvcStart), 1)
+
+	pvcIn := &api.PersistentVolumeClaim{
+		ObjectMeta: api.ObjectMeta{
+			Name:            "foo",
+			Namespace:       api.NamespaceDefault,
+			ResourceVersion: "1",
+		},
+		Spec: api.PersistentVolumeClaimSpec{
+			AccessModes: []api.AccessModeType{api.ReadWriteMany},
+		},
+		Status: api.PersistentVolumeClaimStatus{
+			Phase:     api.ClaimBound,
+			VolumeRef: &api.ObjectReference{Kind: "PersistentVolume", Name: "bar"},
+		},
+	}
+
+	expected := *pvcStart
+	expected.ResourceVersion = "2"
+	expected.Status = pvcIn.Status
+
+	_, _, err := statusStorage.Update(ctx, pvcIn)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pvcOut := &api.PersistentVolumeClaim{}
+	if err := helper.ExtractObj(key, pvcOut, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !api.Semantic.DeepEqual(&expected, pvcOut) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(&expected, pvcOut))
+	}
+}
+
+func TestDeletePersistentVolumeClaim(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/persistentvolumeclaims/default/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewPersistentVolumeClaim("foo", api.NamespaceDefault)),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage, _ := NewStorage(helper)
+	_, err := storage.Delete(api.NewDefaultContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestEtcdGetDifferentNamespace ensures same-name claims in different namespaces do not clash
+func TestEtcdGetDifferentNamespace(t *testing.T) {
+	registry, _, fakeClient, _ := newStorage(t)
+
+	ctx1 := api.NewDefaultContext()
+	ctx2 := api.WithNamespace(api.NewContext(), "other")
+
+	key1, _ := registry.KeyFunc(ctx1, "foo")
+	key2, _ := registry.KeyFunc(ctx2, "foo")
+
+	fakeClient.Set(key1, runtime.EncodeOrDie(latest.Codec, validNewPersistentVolumeClaim("foo", "default")), 0)
+	fakeClient.Set(key2, runtime.EncodeOrDie(latest.Codec, validNewPersistentVolumeClaim("foo", "other")), 0)
+
+	for ctx, ns := range map[api.Context]string{ctx1: "default", ctx2: "other"} {
+		obj, err := registry.Get(ctx, "foo")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		pvc := obj.(*api.PersistentVolumeClaim)
+		if pvc.Name != "foo" || pvc.Namespace != ns {
+			t.Errorf("Unexpected persistentvolumeclaim: %#v", pvc)
+		}
+	}
+}
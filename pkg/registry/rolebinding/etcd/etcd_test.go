@@ -0,0 +1,140 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/rest/resttest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/tools"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/coreos/go-etcd/etcd"
+)
+
+func newHelper(t *testing.T) (*tools.FakeEtcdClient, tools.EtcdHelper) {
+	fakeEtcdClient := tools.NewFakeEtcdClient(t)
+	fakeEtcdClient.TestIndex = true
+	helper := tools.NewEtcdHelper(fakeEtcdClient, latest.Codec)
+	return fakeEtcdClient, helper
+}
+
+func validNewRoleBinding(name, ns string) *api.RoleBinding {
+	return &api.RoleBinding{
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Subjects: []api.Subject{
+			{Kind: api.UserKind, Name: "alice"},
+		},
+		RoleRef: api.ObjectReference{Kind: "Role", Name: "reader"},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage := NewStorage(helper)
+	test := resttest.New(t, storage, fakeEtcdClient.SetError)
+	roleBinding := validNewRoleBinding("foo", api.NamespaceDefault)
+	roleBinding.ObjectMeta = api.ObjectMeta{}
+	test.TestCreate(
+		// valid
+		roleBinding,
+		// invalid
+		&api.RoleBinding{
+			ObjectMeta: api.ObjectMeta{Name: "_-a123-a_"},
+		},
+	)
+}
+
+func TestListRoleBindings(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.Data["/registry/rolebindings/default"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Nodes: []*etcd.Node{
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewRoleBinding("foo", api.NamespaceDefault))},
+					{Value: runtime.EncodeOrDie(latest.Codec, validNewRoleBinding("bar", api.NamespaceDefault))},
+				},
+			},
+		},
+	}
+	storage := NewStorage(helper)
+	obj, err := storage.List(api.NewDefaultContext(), labels.Everything(), fields.Everything())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	roleBindings := obj.(*api.RoleBindingList)
+
+	if len(roleBindings.Items) != 2 {
+		t.Errorf("Unexpected role binding list: %#v", roleBindings)
+	}
+	if roleBindings.Items[0].Name != "foo" || roleBindings.Items[1].Name != "bar" {
+		t.Errorf("Unexpected role bindings: %#v", roleBindings.Items)
+	}
+}
+
+func TestUpdateSubjects(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	storage := NewStorage(helper)
+	ctx := api.NewDefaultContext()
+	key, _ := storage.KeyFunc(ctx, "foo")
+	fakeEtcdClient.Set(key, runtime.EncodeOrDie(latest.Codec, validNewRoleBinding("foo", api.NamespaceDefault)), 1)
+
+	in := validNewRoleBinding("foo", api.NamespaceDefault)
+	in.ResourceVersion = "1"
+	in.Subjects = append(in.Subjects, api.Subject{Kind: api.GroupKind, Name: "admins"})
+
+	if _, _, err := storage.Update(ctx, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := &api.RoleBinding{}
+	if err := helper.ExtractObj(key, out, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := validNewRoleBinding("foo", api.NamespaceDefault)
+	expected.ResourceVersion = "2"
+	expected.Subjects = in.Subjects
+	if !api.Semantic.DeepEqual(expected, out) {
+		t.Errorf("unexpected object: %s", util.ObjectDiff(expected, out))
+	}
+}
+
+func TestDeleteRoleBinding(t *testing.T) {
+	fakeEtcdClient, helper := newHelper(t)
+	fakeEtcdClient.ChangeIndex = 1
+	fakeEtcdClient.Data["/registry/rolebindings/default/foo"] = tools.EtcdResponseWithError{
+		R: &etcd.Response{
+			Node: &etcd.Node{
+				Value:         runtime.EncodeOrDie(latest.Codec, validNewRoleBinding("foo", api.NamespaceDefault)),
+				ModifiedIndex: 1,
+				CreatedIndex:  1,
+			},
+		},
+	}
+	storage := NewStorage(helper)
+	_, err := storage.Delete(api.NewDefaultContext(), "foo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+func TestNewPortAllocator(t *testing.T) {
+	if newPortAllocator(util.PortRange{}) != nil {
+		t.Errorf("expected nil")
+	}
+	pa := newPortAllocator(util.PortRange{Base: 30000, Size: 100})
+	if pa == nil {
+		t.Fatalf("expected non-nil")
+	}
+	if len(pa.used) != 0 {
+		t.Errorf("expected no ports to be in use")
+	}
+}
+
+func TestPortAllocatorAllocate(t *testing.T) {
+	pa := newPortAllocator(util.PortRange{Base: 30000, Size: 100})
+
+	if err := pa.Allocate(29999); err == nil {
+		t.Errorf("expected failure")
+	}
+	if err := pa.Allocate(30100); err == nil {
+		t.Errorf("expected failure")
+	}
+	if err := pa.Allocate(30000); err != nil {
+		t.Errorf("expected success, got %s", err)
+	}
+	if err := pa.Allocate(30099); err != nil {
+		t.Errorf("expected success, got %s", err)
+	}
+	if pa.Allocate(30000) == nil {
+		t.Errorf("expected failure")
+	}
+}
+
+func TestPortAllocatorAllocateNext(t *testing.T) {
+	pa := newPortAllocator(util.PortRange{Base: 30000, Size: 4})
+
+	seen := map[int]bool{}
+	for i := 0; i < 4; i++ {
+		port, err := pa.AllocateNext()
+		if err != nil {
+			t.Fatalf("expected success, got %s", err)
+		}
+		if port < 30000 || port > 30003 {
+			t.Errorf("port %d is out of range", port)
+		}
+		if seen[port] {
+			t.Errorf("port %d was allocated twice", port)
+		}
+		seen[port] = true
+	}
+	if _, err := pa.AllocateNext(); err == nil {
+		t.Errorf("expected failure")
+	}
+}
+
+func TestPortAllocatorAllocateNextLinear(t *testing.T) {
+	pa := newPortAllocator(util.PortRange{Base: 30000, Size: 4})
+	pa.randomAttempts = 0
+
+	for _, expected := range []int{30000, 30001, 30002, 30003} {
+		port, err := pa.AllocateNext()
+		if err != nil {
+			t.Fatalf("expected success, got %s", err)
+		}
+		if port != expected {
+			t.Errorf("expected %d, got %d", expected, port)
+		}
+	}
+}
+
+func TestPortAllocatorRelease(t *testing.T) {
+	pa := newPortAllocator(util.PortRange{Base: 30000, Size: 1})
+
+	port, err := pa.AllocateNext()
+	if err != nil {
+		t.Fatalf("expected success, got %s", err)
+	}
+	if _, err := pa.AllocateNext(); err == nil {
+		t.Errorf("expected failure")
+	}
+	if err := pa.Release(port); err != nil {
+		t.Errorf("expected success, got %s", err)
+	}
+	if err := pa.Release(12345); err == nil {
+		t.Errorf("expected failure")
+	}
+	if _, err := pa.AllocateNext(); err != nil {
+		t.Errorf("expected success, got %s", err)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/endpoint"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/minion"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/fielderrors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	"github.com/golang/glog"
@@ -47,12 +48,13 @@ type REST struct {
 	machines    minion.Registry
 	endpoints   endpoint.Registry
 	portalMgr   *ipAllocator
+	nodePorts   *portAllocator
 	clusterName string
 }
 
 // NewStorage returns a new REST.
 func NewStorage(registry Registry, cloud cloudprovider.Interface, machines minion.Registry, endpoints endpoint.Registry, portalNet *net.IPNet,
-	clusterName string) *REST {
+	serviceNodePorts util.PortRange, clusterName string) *REST {
 	// TODO: Before we can replicate masters, this has to be synced (e.g. lives in etcd)
 	ipa := newIPAllocator(portalNet)
 	if ipa == nil {
@@ -60,12 +62,19 @@ func NewStorage(registry Registry, cloud cloudprovider.Interface, machines minio
 	}
 	reloadIPsFromStorage(ipa, registry)
 
+	pa := newPortAllocator(serviceNodePorts)
+	if pa == nil {
+		glog.Fatalf("Failed to create a node port allocator. Is port range '%v' valid?", serviceNodePorts)
+	}
+	reloadNodePortsFromStorage(pa, registry)
+
 	return &REST{
 		registry:    registry,
 		cloud:       cloud,
 		machines:    machines,
 		endpoints:   endpoints,
 		portalMgr:   ipa,
+		nodePorts:   pa,
 		clusterName: clusterName,
 	}
 }
@@ -90,6 +99,28 @@ func reloadIPsFromStorage(ipa *ipAllocator, registry Registry) {
 	}
 }
 
+// Helper: mark all previously allocated node ports in the allocator.
+func reloadNodePortsFromStorage(pa *portAllocator, registry Registry) {
+	services, err := registry.ListServices(api.NewContext())
+	if err != nil {
+		// This is really bad.
+		glog.Errorf("can't list services to init service REST: %v", err)
+		return
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if service.Spec.Type != api.ServiceTypeNodePort {
+			continue
+		}
+		for _, port := range nodePortsOf(service) {
+			if err := pa.Allocate(port); err != nil {
+				// This is really bad.
+				glog.Errorf("service %q node port %d could not be allocated: %v", service.Name, port, err)
+			}
+		}
+	}
+}
+
 func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, error) {
 	service := obj.(*api.Service)
 
@@ -112,6 +143,14 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, err
 		}
 	}
 
+	nodePorts, err := rs.allocateNodePorts(service, nil)
+	if err != nil {
+		if api.IsServiceIPSet(service) {
+			rs.portalMgr.Release(net.ParseIP(service.Spec.PortalIP))
+		}
+		return nil, err
+	}
+
 	// TODO: Move this to post-creation rectification loop, so that we make/remove external load balancers
 	// correctly no matter what http operations happen.
 	if service.Spec.CreateExternalLoadBalancer {
@@ -120,6 +159,7 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, err
 			if api.IsServiceIPSet(service) {
 				rs.portalMgr.Release(net.ParseIP(service.Spec.PortalIP))
 			}
+			rs.releaseNodePorts(nodePorts)
 			return nil, err
 		}
 	}
@@ -129,6 +169,7 @@ func (rs *REST) Create(ctx api.Context, obj runtime.Object) (runtime.Object, err
 		if api.IsServiceIPSet(service) {
 			rs.portalMgr.Release(net.ParseIP(service.Spec.PortalIP))
 		}
+		rs.releaseNodePorts(nodePorts)
 		err = rest.CheckGeneratedNameError(rest.Services, err, service)
 	}
 	return out, err
@@ -150,6 +191,7 @@ func (rs *REST) Delete(ctx api.Context, id string) (runtime.Object, error) {
 	if api.IsServiceIPSet(service) {
 		rs.portalMgr.Release(net.ParseIP(service.Spec.PortalIP))
 	}
+	rs.releaseNodePorts(nodePortsOf(service))
 	if service.Spec.CreateExternalLoadBalancer {
 		rs.deleteExternalLoadBalancer(ctx, service)
 	}
@@ -209,26 +251,122 @@ func (rs *REST) Update(ctx api.Context, obj runtime.Object) (runtime.Object, boo
 	if errs := validation.ValidateServiceUpdate(oldService, service); len(errs) > 0 {
 		return nil, false, errors.NewInvalid("service", service.Name, errs)
 	}
+	oldNodePorts := nodePortsOf(oldService)
+	nodePorts, err := rs.allocateNodePorts(service, oldService)
+	if err != nil {
+		return nil, false, err
+	}
 	// Recreate external load balancer if changed.
 	if externalLoadBalancerNeedsUpdate(oldService, service) {
 		// TODO: support updating existing balancers
 		if oldService.Spec.CreateExternalLoadBalancer {
 			err = rs.deleteExternalLoadBalancer(ctx, oldService)
 			if err != nil {
+				rs.releaseNodePorts(nodePorts)
 				return nil, false, err
 			}
 		}
 		if service.Spec.CreateExternalLoadBalancer {
 			err = rs.createExternalLoadBalancer(ctx, service)
 			if err != nil {
+				rs.releaseNodePorts(nodePorts)
 				return nil, false, err
 			}
 		}
 	}
 	out, err := rs.registry.UpdateService(ctx, service)
+	if err != nil {
+		rs.releaseNodePorts(nodePorts)
+		return out, false, err
+	}
+	// Give back the node ports that the service no longer uses.
+	inUse := map[int]bool{}
+	for _, port := range nodePortsOf(service) {
+		inUse[port] = true
+	}
+	for _, port := range oldNodePorts {
+		if !inUse[port] {
+			rs.nodePorts.Release(port)
+		}
+	}
 	return out, false, err
 }
 
+// nodePortsOf returns the distinct node ports held by a NodePort service.
+func nodePortsOf(service *api.Service) []int {
+	if service.Spec.Type != api.ServiceTypeNodePort {
+		return nil
+	}
+	seen := map[int]bool{}
+	ports := []int{}
+	for i := range service.Spec.Ports {
+		port := service.Spec.Ports[i].NodePort
+		if port != 0 && !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// allocateNodePorts assigns a node port to every port of a NodePort service,
+// honoring node ports that were explicitly requested.  When oldService is
+// given, node ports it already holds are kept, and ports that did not ask for
+// a node port reuse the one of the same-named old port.  It returns the node
+// ports that were newly allocated, so that callers can release them if the
+// operation fails later on.
+func (rs *REST) allocateNodePorts(service, oldService *api.Service) ([]int, error) {
+	if service.Spec.Type != api.ServiceTypeNodePort {
+		return nil, nil
+	}
+
+	held := map[int]bool{}
+	heldByName := map[string]int{}
+	if oldService != nil && oldService.Spec.Type == api.ServiceTypeNodePort {
+		for i := range oldService.Spec.Ports {
+			port := &oldService.Spec.Ports[i]
+			if port.NodePort != 0 {
+				held[port.NodePort] = true
+				heldByName[port.Name] = port.NodePort
+			}
+		}
+	}
+
+	allocated := []int{}
+	for i := range service.Spec.Ports {
+		port := &service.Spec.Ports[i]
+		if port.NodePort == 0 {
+			port.NodePort = heldByName[port.Name]
+		}
+		if port.NodePort != 0 {
+			if held[port.NodePort] {
+				continue
+			}
+			if err := rs.nodePorts.Allocate(port.NodePort); err != nil {
+				rs.releaseNodePorts(allocated)
+				el := fielderrors.ValidationErrorList{fielderrors.NewFieldInvalid(fmt.Sprintf("spec.ports[%d].nodePort", i), port.NodePort, err.Error())}
+				return nil, errors.NewInvalid("Service", service.Name, el)
+			}
+		} else {
+			nodePort, err := rs.nodePorts.AllocateNext()
+			if err != nil {
+				rs.releaseNodePorts(allocated)
+				return nil, err
+			}
+			port.NodePort = nodePort
+		}
+		held[port.NodePort] = true
+		allocated = append(allocated, port.NodePort)
+	}
+	return allocated, nil
+}
+
+func (rs *REST) releaseNodePorts(ports []int) {
+	for _, port := range ports {
+		rs.nodePorts.Release(port)
+	}
+}
+
 // Implement Redirector.
 var _ = rest.Redirector(&REST{})
 
@@ -281,7 +419,11 @@ func (rs *REST) createExternalLoadBalancer(ctx api.Context, service *api.Service
 	if rs.cloud == nil {
 		return fmt.Errorf("requested an external service, but no cloud provider supplied.")
 	}
-	if service.Spec.Protocol != api.ProtocolTCP {
+	if len(service.Spec.Ports) != 1 {
+		// TODO: Support multi-port LB.
+		return fmt.Errorf("external load balancers for services with multiple ports are not currently supported.")
+	}
+	if service.Spec.Ports[0].Protocol != api.ProtocolTCP {
 		// TODO: Support UDP here too.
 		return fmt.Errorf("external load balancers for non TCP services are not currently supported.")
 	}
@@ -306,14 +448,14 @@ func (rs *REST) createExternalLoadBalancer(ctx api.Context, service *api.Service
 	var affinityType api.AffinityType = service.Spec.SessionAffinity
 	if len(service.Spec.PublicIPs) > 0 {
 		for _, publicIP := range service.Spec.PublicIPs {
-			_, err = balancer.CreateTCPLoadBalancer(name, zone.Region, net.ParseIP(publicIP), service.Spec.Port, hostsFromMinionList(hosts), affinityType)
+			_, err = balancer.CreateTCPLoadBalancer(name, zone.Region, net.ParseIP(publicIP), service.Spec.Ports[0].Port, hostsFromMinionList(hosts), affinityType)
 			if err != nil {
 				// TODO: have to roll-back any successful calls.
 				return err
 			}
 		}
 	} else {
-		endpoint, err := balancer.CreateTCPLoadBalancer(name, zone.Region, nil, service.Spec.Port, hostsFromMinionList(hosts), affinityType)
+		endpoint, err := balancer.CreateTCPLoadBalancer(name, zone.Region, nil, service.Spec.Ports[0].Port, hostsFromMinionList(hosts), affinityType)
 		if err != nil {
 			return err
 		}
@@ -353,11 +495,18 @@ func externalLoadBalancerNeedsUpdate(old, new *api.Service) bool {
 		return false
 	}
 	if old.Spec.CreateExternalLoadBalancer != new.Spec.CreateExternalLoadBalancer ||
-		old.Spec.Port != new.Spec.Port ||
-		old.Spec.SessionAffinity != new.Spec.SessionAffinity ||
-		old.Spec.Protocol != new.Spec.Protocol {
+		old.Spec.SessionAffinity != new.Spec.SessionAffinity {
+		return true
+	}
+	if len(old.Spec.Ports) != len(new.Spec.Ports) {
 		return true
 	}
+	for i := range old.Spec.Ports {
+		if old.Spec.Ports[i].Port != new.Spec.Ports[i].Port ||
+			old.Spec.Ports[i].Protocol != new.Spec.Ports[i].Protocol {
+			return true
+		}
+	}
 	if len(old.Spec.PublicIPs) != len(new.Spec.PublicIPs) {
 		return true
 	}
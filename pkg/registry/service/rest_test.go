@@ -30,6 +30,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/registrytest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 )
 
 func NewTestREST(t *testing.T, endpoints *api.EndpointsList) (*REST, *registrytest.ServiceRegistry, *cloud.FakeCloud) {
@@ -40,7 +41,7 @@ func NewTestREST(t *testing.T, endpoints *api.EndpointsList) (*REST, *registryte
 		Endpoints: endpoints,
 	}
 	nodeRegistry := registrytest.NewMinionRegistry(machines, api.NodeResources{})
-	storage := NewStorage(registry, fakeCloud, nodeRegistry, endpointRegistry, makeIPNet(t), "kubernetes")
+	storage := NewStorage(registry, fakeCloud, nodeRegistry, endpointRegistry, makeIPNet(t), makePortRange(t), "kubernetes")
 	return storage, registry, fakeCloud
 }
 
@@ -52,6 +53,14 @@ func makeIPNet(t *testing.T) *net.IPNet {
 	return net
 }
 
+func makePortRange(t *testing.T) util.PortRange {
+	pr, err := util.ParsePortRange("30000-30010")
+	if err != nil {
+		t.Error(err)
+	}
+	return *pr
+}
+
 func TestServiceRegistryCreate(t *testing.T) {
 	storage, registry, fakeCloud := NewTestREST(t, nil)
 	storage.portalMgr.randomAttempts = 0
@@ -59,9 +68,8 @@ func TestServiceRegistryCreate(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
-			Port:            6502,
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -98,17 +106,16 @@ func TestServiceStorageValidatesCreate(t *testing.T) {
 		"empty ID": {
 			ObjectMeta: api.ObjectMeta{Name: ""},
 			Spec: api.ServiceSpec{
-				Port:            6502,
+				Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 				Selector:        map[string]string{"bar": "baz"},
-				Protocol:        api.ProtocolTCP,
 				SessionAffinity: api.AffinityTypeNone,
 			},
 		},
 		"empty port": {
 			ObjectMeta: api.ObjectMeta{Name: "foo"},
 			Spec: api.ServiceSpec{
+				Ports:           []api.ServicePort{{Protocol: api.ProtocolTCP}},
 				Selector:        map[string]string{"bar": "baz"},
-				Protocol:        api.ProtocolTCP,
 				SessionAffinity: api.AffinityTypeNone,
 			},
 		},
@@ -132,7 +139,7 @@ func TestServiceRegistryUpdate(t *testing.T) {
 	svc, err := registry.CreateService(ctx, &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1", Namespace: api.NamespaceDefault},
 		Spec: api.ServiceSpec{
-			Port:     6502,
+			Ports:    []api.ServicePort{{Port: 6502}},
 			Selector: map[string]string{"bar": "baz1"},
 		},
 	})
@@ -145,9 +152,8 @@ func TestServiceRegistryUpdate(t *testing.T) {
 			Name:            "foo",
 			ResourceVersion: svc.ResourceVersion},
 		Spec: api.ServiceSpec{
-			Port:            6502,
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz2"},
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	})
@@ -175,7 +181,7 @@ func TestServiceStorageValidatesUpdate(t *testing.T) {
 	registry.CreateService(ctx, &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
-			Port:     6502,
+			Ports:    []api.ServicePort{{Port: 6502}},
 			Selector: map[string]string{"bar": "baz"},
 		},
 	})
@@ -183,18 +189,16 @@ func TestServiceStorageValidatesUpdate(t *testing.T) {
 		"empty ID": {
 			ObjectMeta: api.ObjectMeta{Name: ""},
 			Spec: api.ServiceSpec{
-				Port:            6502,
+				Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 				Selector:        map[string]string{"bar": "baz"},
-				Protocol:        api.ProtocolTCP,
 				SessionAffinity: api.AffinityTypeNone,
 			},
 		},
 		"invalid selector": {
 			ObjectMeta: api.ObjectMeta{Name: "foo"},
 			Spec: api.ServiceSpec{
-				Port:            6502,
+				Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 				Selector:        map[string]string{"ThisSelectorFailsValidation": "ok"},
-				Protocol:        api.ProtocolTCP,
 				SessionAffinity: api.AffinityTypeNone,
 			},
 		},
@@ -216,10 +220,9 @@ func TestServiceRegistryExternalService(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
-			Port:                       6502,
+			Ports:                      []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:                   map[string]string{"bar": "baz"},
 			CreateExternalLoadBalancer: true,
-			Protocol:                   api.ProtocolTCP,
 			SessionAffinity:            api.AffinityTypeNone,
 		},
 	}
@@ -245,10 +248,9 @@ func TestServiceRegistryExternalServiceError(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
-			Port:                       6502,
+			Ports:                      []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:                   map[string]string{"bar": "baz"},
 			CreateExternalLoadBalancer: true,
-			Protocol:                   api.ProtocolTCP,
 			SessionAffinity:            api.AffinityTypeNone,
 		},
 	}
@@ -268,8 +270,8 @@ func TestServiceRegistryDelete(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -289,9 +291,9 @@ func TestServiceRegistryDeleteExternal(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
+			Ports:                      []api.ServicePort{{Protocol: api.ProtocolTCP}},
 			Selector:                   map[string]string{"bar": "baz"},
 			CreateExternalLoadBalancer: true,
-			Protocol:                   api.ProtocolTCP,
 			SessionAffinity:            api.AffinityTypeNone,
 		},
 	}
@@ -313,10 +315,9 @@ func TestServiceRegistryUpdateExternalService(t *testing.T) {
 	svc1 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
 		Spec: api.ServiceSpec{
-			Port:                       6502,
+			Ports:                      []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:                   map[string]string{"bar": "baz"},
 			CreateExternalLoadBalancer: false,
-			Protocol:                   api.ProtocolTCP,
 			SessionAffinity:            api.AffinityTypeNone,
 		},
 	}
@@ -337,7 +338,7 @@ func TestServiceRegistryUpdateExternalService(t *testing.T) {
 	// Change port.
 	svc3 := new(api.Service)
 	*svc3 = *svc2
-	svc3.Spec.Port = 6504
+	svc3.Spec.Ports = []api.ServicePort{{Port: 6504, Protocol: api.ProtocolTCP}}
 	storage.Update(ctx, svc3)
 	if len(fakeCloud.Calls) != 6 || fakeCloud.Calls[0] != "get-zone" || fakeCloud.Calls[1] != "create" ||
 		fakeCloud.Calls[2] != "get-zone" || fakeCloud.Calls[3] != "delete" ||
@@ -467,9 +468,8 @@ func TestServiceRegistryIPAllocation(t *testing.T) {
 	svc1 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -486,9 +486,8 @@ func TestServiceRegistryIPAllocation(t *testing.T) {
 	svc2 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "bar"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		}}
 	ctx = api.NewDefaultContext()
@@ -504,10 +503,9 @@ func TestServiceRegistryIPAllocation(t *testing.T) {
 	svc3 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "quux"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
 			PortalIP:        "1.2.3.93",
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -526,9 +524,8 @@ func TestServiceRegistryIPReallocation(t *testing.T) {
 	svc1 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -547,9 +544,8 @@ func TestServiceRegistryIPReallocation(t *testing.T) {
 	svc2 := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "bar"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -571,17 +567,16 @@ func TestServiceRegistryIPUpdate(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
 	ctx := api.NewDefaultContext()
 	created_svc, _ := rest.Create(ctx, svc)
 	created_service := created_svc.(*api.Service)
-	if created_service.Spec.Port != 6502 {
-		t.Errorf("Expected port 6502, but got %v", created_service.Spec.Port)
+	if created_service.Spec.Ports[0].Port != 6502 {
+		t.Errorf("Expected port 6502, but got %v", created_service.Spec.Ports[0].Port)
 	}
 	if created_service.Spec.PortalIP != "1.2.3.1" {
 		t.Errorf("Unexpected PortalIP: %s", created_service.Spec.PortalIP)
@@ -589,16 +584,16 @@ func TestServiceRegistryIPUpdate(t *testing.T) {
 
 	update := new(api.Service)
 	*update = *created_service
-	update.Spec.Port = 6503
+	update.Spec.Ports[0].Port = 6503
 
 	updated_svc, _, _ := rest.Update(ctx, update)
 	updated_service := updated_svc.(*api.Service)
-	if updated_service.Spec.Port != 6503 {
-		t.Errorf("Expected port 6503, but got %v", updated_service.Spec.Port)
+	if updated_service.Spec.Ports[0].Port != 6503 {
+		t.Errorf("Expected port 6503, but got %v", updated_service.Spec.Ports[0].Port)
 	}
 
 	*update = *created_service
-	update.Spec.Port = 6503
+	update.Spec.Ports[0].Port = 6503
 	update.Spec.PortalIP = "1.2.3.76" // error
 
 	_, _, err := rest.Update(ctx, update)
@@ -614,18 +609,17 @@ func TestServiceRegistryIPExternalLoadBalancer(t *testing.T) {
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", ResourceVersion: "1"},
 		Spec: api.ServiceSpec{
-			Selector: map[string]string{"bar": "baz"},
-			Port:     6502,
+			Ports:                      []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
+			Selector:                   map[string]string{"bar": "baz"},
 			CreateExternalLoadBalancer: true,
-			Protocol:                   api.ProtocolTCP,
 			SessionAffinity:            api.AffinityTypeNone,
 		},
 	}
 	ctx := api.NewDefaultContext()
 	created_svc, _ := rest.Create(ctx, svc)
 	created_service := created_svc.(*api.Service)
-	if created_service.Spec.Port != 6502 {
-		t.Errorf("Expected port 6502, but got %v", created_service.Spec.Port)
+	if created_service.Spec.Ports[0].Port != 6502 {
+		t.Errorf("Expected port 6502, but got %v", created_service.Spec.Ports[0].Port)
 	}
 	if created_service.Spec.PortalIP != "1.2.3.1" {
 		t.Errorf("Unexpected PortalIP: %s", created_service.Spec.PortalIP)
@@ -649,15 +643,14 @@ func TestServiceRegistryIPReloadFromStorage(t *testing.T) {
 	machines := []string{"foo", "bar", "baz"}
 	nodeRegistry := registrytest.NewMinionRegistry(machines, api.NodeResources{})
 	endpoints := &registrytest.EndpointRegistry{}
-	rest1 := NewStorage(registry, fakeCloud, nodeRegistry, endpoints, makeIPNet(t), "kubernetes")
+	rest1 := NewStorage(registry, fakeCloud, nodeRegistry, endpoints, makeIPNet(t), makePortRange(t), "kubernetes")
 	rest1.portalMgr.randomAttempts = 0
 
 	svc := &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: api.NamespaceDefault},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -666,9 +659,8 @@ func TestServiceRegistryIPReloadFromStorage(t *testing.T) {
 	svc = &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: api.NamespaceDefault},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -676,15 +668,14 @@ func TestServiceRegistryIPReloadFromStorage(t *testing.T) {
 
 	// This will reload from storage, finding the previous 2
 	nodeRegistry = registrytest.NewMinionRegistry(machines, api.NodeResources{})
-	rest2 := NewStorage(registry, fakeCloud, nodeRegistry, endpoints, makeIPNet(t), "kubernetes")
+	rest2 := NewStorage(registry, fakeCloud, nodeRegistry, endpoints, makeIPNet(t), makePortRange(t), "kubernetes")
 	rest2.portalMgr.randomAttempts = 0
 
 	svc = &api.Service{
 		ObjectMeta: api.ObjectMeta{Name: "foo", Namespace: api.NamespaceDefault},
 		Spec: api.ServiceSpec{
+			Ports:           []api.ServicePort{{Port: 6502, Protocol: api.ProtocolTCP}},
 			Selector:        map[string]string{"bar": "baz"},
-			Port:            6502,
-			Protocol:        api.ProtocolTCP,
 			SessionAffinity: api.AffinityTypeNone,
 		},
 	}
@@ -695,6 +686,159 @@ func TestServiceRegistryIPReloadFromStorage(t *testing.T) {
 	}
 }
 
+func makeNodePortService(name string, nodePorts ...int) *api.Service {
+	svc := &api.Service{
+		ObjectMeta: api.ObjectMeta{Name: name, Namespace: api.NamespaceDefault},
+		Spec: api.ServiceSpec{
+			Selector:        map[string]string{"bar": "baz"},
+			SessionAffinity: api.AffinityTypeNone,
+			Type:            api.ServiceTypeNodePort,
+		},
+	}
+	for i, nodePort := range nodePorts {
+		svc.Spec.Ports = append(svc.Spec.Ports, api.ServicePort{
+			Name:     fmt.Sprintf("p%d", i),
+			Port:     6502 + i,
+			Protocol: api.ProtocolTCP,
+			NodePort: nodePort,
+		})
+	}
+	return svc
+}
+
+func TestServiceRegistryNodePortAllocation(t *testing.T) {
+	rest, _, _ := NewTestREST(t, nil)
+	rest.nodePorts.randomAttempts = 0
+	ctx := api.NewDefaultContext()
+
+	created_svc, err := rest.Create(ctx, makeNodePortService("foo", 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	created_service := created_svc.(*api.Service)
+	if created_service.Spec.Ports[0].NodePort != 30000 || created_service.Spec.Ports[1].NodePort != 30001 {
+		t.Errorf("Unexpected node ports: %#v", created_service.Spec.Ports)
+	}
+
+	// A requested node port is honored.
+	created_svc, err = rest.Create(ctx, makeNodePortService("bar", 30005))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port := created_svc.(*api.Service).Spec.Ports[0].NodePort; port != 30005 {
+		t.Errorf("Unexpected node port: %d", port)
+	}
+
+	// A node port that is already in use is rejected.
+	if _, err := rest.Create(ctx, makeNodePortService("baz", 0, 30005)); !errors.IsInvalid(err) {
+		t.Errorf("Expected an invalid resource error, got %v", err)
+	}
+	// A node port outside of the range is rejected.
+	if _, err := rest.Create(ctx, makeNodePortService("baz", 40000)); !errors.IsInvalid(err) {
+		t.Errorf("Expected an invalid resource error, got %v", err)
+	}
+	// The failed creates must not leak ports.
+	created_svc, err = rest.Create(ctx, makeNodePortService("baz", 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port := created_svc.(*api.Service).Spec.Ports[0].NodePort; port != 30002 {
+		t.Errorf("Unexpected node port: %d", port)
+	}
+
+	// ClusterIP services do not get node ports.
+	svc := makeNodePortService("qux", 0)
+	svc.Spec.Type = api.ServiceTypeClusterIP
+	created_svc, err = rest.Create(ctx, svc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port := created_svc.(*api.Service).Spec.Ports[0].NodePort; port != 0 {
+		t.Errorf("Unexpected node port: %d", port)
+	}
+}
+
+func TestServiceRegistryNodePortRelease(t *testing.T) {
+	rest, _, _ := NewTestREST(t, nil)
+	rest.nodePorts.randomAttempts = 0
+	ctx := api.NewDefaultContext()
+
+	if _, err := rest.Create(ctx, makeNodePortService("foo", 30000)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := rest.Delete(ctx, "foo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := rest.Create(ctx, makeNodePortService("bar", 30000)); err != nil {
+		t.Errorf("Expected node port to be released, got %v", err)
+	}
+}
+
+func TestServiceRegistryNodePortUpdate(t *testing.T) {
+	rest, _, _ := NewTestREST(t, nil)
+	rest.nodePorts.randomAttempts = 0
+	ctx := api.NewDefaultContext()
+
+	svc := makeNodePortService("foo", 0, 30005)
+	svc.ResourceVersion = "1"
+	created_svc, err := rest.Create(ctx, svc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	created_service := created_svc.(*api.Service)
+
+	// Dropping the node port of "p0" keeps it; replacing the one of "p1"
+	// releases 30005.
+	update := makeNodePortService("foo", 0, 30006)
+	update.ResourceVersion = "1"
+	update.Spec.PortalIP = created_service.Spec.PortalIP
+	updated_svc, _, err := rest.Update(ctx, update)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated_service := updated_svc.(*api.Service)
+	if updated_service.Spec.Ports[0].NodePort != 30000 || updated_service.Spec.Ports[1].NodePort != 30006 {
+		t.Errorf("Unexpected node ports: %#v", updated_service.Spec.Ports)
+	}
+
+	// Switching to ClusterIP releases all node ports.
+	update = makeNodePortService("foo", 0, 0)
+	update.ResourceVersion = "1"
+	update.Spec.PortalIP = updated_service.Spec.PortalIP
+	update.Spec.Type = api.ServiceTypeClusterIP
+	if _, _, err := rest.Update(ctx, update); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := rest.Create(ctx, makeNodePortService("bar", 30000, 30005, 30006)); err != nil {
+		t.Errorf("Expected node ports to be released, got %v", err)
+	}
+}
+
+func TestServiceRegistryNodePortReloadFromStorage(t *testing.T) {
+	registry := registrytest.NewServiceRegistry()
+	fakeCloud := &cloud.FakeCloud{}
+	machines := []string{"foo", "bar", "baz"}
+	nodeRegistry := registrytest.NewMinionRegistry(machines, api.NodeResources{})
+	endpoints := &registrytest.EndpointRegistry{}
+	rest1 := NewStorage(registry, fakeCloud, nodeRegistry, endpoints, makeIPNet(t), makePortRange(t), "kubernetes")
+	rest1.nodePorts.randomAttempts = 0
+
+	ctx := api.NewDefaultContext()
+	rest1.Create(ctx, makeNodePortService("foo", 0, 0))
+
+	// This will reload from storage, finding the previous 2
+	rest2 := NewStorage(registry, fakeCloud, nodeRegistry, endpoints, makeIPNet(t), makePortRange(t), "kubernetes")
+	rest2.nodePorts.randomAttempts = 0
+
+	created_svc, err := rest2.Create(ctx, makeNodePortService("bar", 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port := created_svc.(*api.Service).Spec.Ports[0].NodePort; port != 30002 {
+		t.Errorf("Unexpected node port: %d", port)
+	}
+}
+
 // TODO: remove, covered by TestCreate
 func TestCreateServiceWithConflictingNamespace(t *testing.T) {
 	storage := REST{}
@@ -741,10 +885,9 @@ func TestCreate(t *testing.T) {
 		// valid
 		&api.Service{
 			Spec: api.ServiceSpec{
+				Ports:           []api.ServicePort{{Port: 6502, Protocol: "TCP"}},
 				Selector:        map[string]string{"bar": "baz"},
 				PortalIP:        "None",
-				Port:            6502,
-				Protocol:        "TCP",
 				SessionAffinity: "None",
 			},
 		},
@@ -755,9 +898,8 @@ func TestCreate(t *testing.T) {
 		// invalid
 		&api.Service{
 			Spec: api.ServiceSpec{
+				Ports:           []api.ServicePort{{Port: 6502, Protocol: "TCP"}},
 				Selector:        map[string]string{"bar": "baz"},
-				Port:            6502,
-				Protocol:        "TCP",
 				PortalIP:        "invalid",
 				SessionAffinity: "None",
 			},
@@ -23,15 +23,30 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
-	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 	"github.com/golang/glog"
 )
 
-// ResourceQuotaManager is responsible for tracking quota usage status in the system
+// ResourceQuotaManager is responsible for tracking quota usage status in the system.
+// Usage is computed from caches that are kept up to date by watches, and only the
+// quotas of namespaces whose objects changed are synced.
 type ResourceQuotaManager struct {
 	kubeClient client.Interface
-	syncTime   <-chan time.Time
+
+	// lock guards stores, dirty and resyncAll.
+	lock sync.Mutex
+	// stores holds a cache of every resource referenced by a quota, keyed by resource.
+	// The cache of resource quotas is always present once Run is called.
+	stores map[string]*changeStore
+	// dirty holds the namespaces whose quota usage may have changed since it was last synced.
+	dirty util.StringSet
+	// resyncAll is set when the usage of every quota must be synced.
+	resyncAll bool
+	// changed receives a value whenever a namespace is marked dirty.
+	changed chan struct{}
 
 	// To allow injection of syncUsage for testing.
 	syncHandler func(quota api.ResourceQuota) error
@@ -42,6 +57,9 @@ func NewResourceQuotaManager(kubeClient client.Interface) *ResourceQuotaManager
 
 	rm := &ResourceQuotaManager{
 		kubeClient: kubeClient,
+		stores:     map[string]*changeStore{},
+		dirty:      util.StringSet{},
+		changed:    make(chan struct{}, 1),
 	}
 
 	// set the synchronization handler
@@ -49,25 +67,167 @@ func NewResourceQuotaManager(kubeClient client.Interface) *ResourceQuotaManager
 	return rm
 }
 
-// Run begins watching and syncing.
+// Run begins watching resource quotas, and the resources they limit, and syncs the
+// quotas of namespaces as their objects change.  Every period all quotas are synced
+// from the caches, which does not require listing objects from the server.
 func (rm *ResourceQuotaManager) Run(period time.Duration) {
-	rm.syncTime = time.Tick(period)
-	go util.Forever(func() { rm.synchronize() }, period)
+	rm.lock.Lock()
+	rm.startWatchLocked("resourcequotas")
+	rm.lock.Unlock()
+	go util.Forever(func() {
+		select {
+		case <-rm.changed:
+		case <-time.After(period):
+			rm.markAllDirty()
+		}
+		rm.synchronize()
+	}, 0)
+}
+
+// startWatchLocked starts a reflector that keeps the cache of a resource up to date,
+// unless one is already running.  rm.lock must be held.
+func (rm *ResourceQuotaManager) startWatchLocked(resource string) {
+	if _, ok := rm.stores[resource]; ok {
+		return
+	}
+	funcs, ok := countedResources[resource]
+	if !ok {
+		return
+	}
+	store := &changeStore{
+		Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{"namespace": cache.MetaNamespaceIndexFunc}),
+		manager: rm,
+	}
+	rm.stores[resource] = store
+	cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return funcs.list(rm.kubeClient, api.NamespaceAll)
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return funcs.watch(rm.kubeClient, api.NamespaceAll, resourceVersion)
+			},
+		},
+		funcs.object,
+		store,
+		0,
+	).Run()
+}
+
+// watchQuotaResources starts watching every resource that determines the usage of a quota.
+func (rm *ResourceQuotaManager) watchQuotaResources(quota *api.ResourceQuota) {
+	rm.lock.Lock()
+	defer rm.lock.Unlock()
+	for k := range quota.Spec.Hard {
+		if resource, ok := requiredResource(k); ok {
+			rm.startWatchLocked(resource)
+		}
+	}
+}
+
+func (rm *ResourceQuotaManager) markDirty(namespace string) {
+	rm.lock.Lock()
+	rm.dirty.Insert(namespace)
+	rm.lock.Unlock()
+	rm.notify()
+}
+
+func (rm *ResourceQuotaManager) markAllDirty() {
+	rm.lock.Lock()
+	rm.resyncAll = true
+	rm.lock.Unlock()
+	rm.notify()
+}
+
+func (rm *ResourceQuotaManager) notify() {
+	select {
+	case rm.changed <- struct{}{}:
+	default:
+		// A sync is already pending.
+	}
+}
+
+// changeStore passes all operations through to Indexer and marks the namespaces
+// of changed objects as dirty.  Newly seen quotas also start the watches they need.
+type changeStore struct {
+	cache.Indexer
+	manager *ResourceQuotaManager
+
+	lock sync.Mutex
+	// synced is set once the first list has been received.
+	synced bool
+}
+
+func (s *changeStore) Add(obj interface{}) error {
+	defer s.changed(obj)
+	return s.Indexer.Add(obj)
+}
+
+func (s *changeStore) Update(obj interface{}) error {
+	defer s.changed(obj)
+	return s.Indexer.Update(obj)
+}
+
+func (s *changeStore) Delete(obj interface{}) error {
+	defer s.changed(obj)
+	return s.Indexer.Delete(obj)
+}
+
+func (s *changeStore) Replace(list []interface{}) error {
+	err := s.Indexer.Replace(list)
+	s.lock.Lock()
+	s.synced = true
+	s.lock.Unlock()
+	for _, obj := range list {
+		if quota, ok := obj.(*api.ResourceQuota); ok {
+			s.manager.watchQuotaResources(quota)
+		}
+	}
+	s.manager.markAllDirty()
+	return err
+}
+
+func (s *changeStore) hasSynced() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.synced
+}
+
+func (s *changeStore) changed(obj interface{}) {
+	if quota, ok := obj.(*api.ResourceQuota); ok {
+		s.manager.watchQuotaResources(quota)
+	}
+	namespace, err := cache.MetaNamespaceIndexFunc(obj)
+	if err != nil {
+		glog.Errorf("Unable to determine the namespace of %#v: %v", obj, err)
+		return
+	}
+	s.manager.markDirty(namespace)
 }
 
 func (rm *ResourceQuotaManager) synchronize() {
+	rm.lock.Lock()
+	quotaStore := rm.stores["resourcequotas"]
+	dirty, resyncAll := rm.dirty, rm.resyncAll
+	rm.dirty, rm.resyncAll = util.StringSet{}, false
+	rm.lock.Unlock()
+	if quotaStore == nil {
+		return
+	}
+
 	var resourceQuotas []api.ResourceQuota
-	list, err := rm.kubeClient.ResourceQuotas(api.NamespaceAll).List(labels.Everything())
-	if err != nil {
-		glog.Errorf("Synchronization error: %v (%#v)", err, err)
+	for _, obj := range quotaStore.List() {
+		quota := obj.(*api.ResourceQuota)
+		if resyncAll || dirty.Has(quota.Namespace) {
+			resourceQuotas = append(resourceQuotas, *quota)
+		}
 	}
-	resourceQuotas = list.Items
 	wg := sync.WaitGroup{}
 	wg.Add(len(resourceQuotas))
 	for ix := range resourceQuotas {
 		go func(ix int) {
 			defer wg.Done()
-			glog.V(4).Infof("periodic sync of %v/%v", resourceQuotas[ix].Namespace, resourceQuotas[ix].Name)
+			glog.V(4).Infof("sync of %v/%v", resourceQuotas[ix].Namespace, resourceQuotas[ix].Name)
 			err := rm.syncHandler(resourceQuotas[ix])
 			if err != nil {
 				glog.Errorf("Error synchronizing: %v", err)
@@ -77,6 +237,30 @@ func (rm *ResourceQuotaManager) synchronize() {
 	wg.Wait()
 }
 
+// listObjects returns the objects of a resource in a namespace.  They are read from
+// the cache of the resource if it has synced, and listed from the server otherwise.
+func (rm *ResourceQuotaManager) listObjects(resource, namespace string) ([]runtime.Object, error) {
+	rm.lock.Lock()
+	store := rm.stores[resource]
+	rm.lock.Unlock()
+	if store != nil && store.hasSynced() {
+		items, err := store.Index("namespace", &api.ResourceQuota{ObjectMeta: api.ObjectMeta{Namespace: namespace}})
+		if err != nil {
+			return nil, err
+		}
+		objects := make([]runtime.Object, 0, len(items))
+		for _, item := range items {
+			objects = append(objects, item.(runtime.Object))
+		}
+		return objects, nil
+	}
+	list, err := countedResources[resource].list(rm.kubeClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.ExtractList(list)
+}
+
 // FilterQuotaPods eliminates pods that no longer have a cost against the quota
 // pods that have a restart policy of always are always returned
 // pods that are in a failed state, but have a restart policy of on failure are always returned
@@ -136,21 +320,23 @@ func (rm *ResourceQuotaManager) syncResourceQuota(quota api.ResourceQuota) (err
 		usage.Status.Used[k] = *v.Copy()
 	}
 
-	set := map[api.ResourceName]bool{}
-	for k := range usage.Status.Hard {
-		set[k] = true
-	}
-
-	pods := &api.PodList{}
-	if set[api.ResourcePods] || set[api.ResourceMemory] || set[api.ResourceCPU] {
-		pods, err = rm.kubeClient.Pods(usage.Namespace).List(labels.Everything())
+	// objects caches the objects of each resource needed by the quota
+	objects := map[string][]runtime.Object{}
+	listObjects := func(resource string) ([]runtime.Object, error) {
+		if items, ok := objects[resource]; ok {
+			return items, nil
+		}
+		items, err := rm.listObjects(resource, usage.Namespace)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		if resource == "pods" {
+			items = filterQuotaPodObjects(items)
+		}
+		objects[resource] = items
+		return items, nil
 	}
 
-	filteredPods := FilterQuotaPods(pods.Items)
-
 	// iterate over each resource, and update observation
 	for k := range usage.Status.Hard {
 
@@ -163,38 +349,45 @@ func (rm *ResourceQuotaManager) syncResourceQuota(quota api.ResourceQuota) (err
 		var value *resource.Quantity
 
 		switch k {
-		case api.ResourcePods:
-			value = resource.NewQuantity(int64(len(filteredPods)), resource.DecimalSI)
 		case api.ResourceMemory:
+			pods, err := listObjects("pods")
+			if err != nil {
+				return err
+			}
 			val := int64(0)
-			for i := range filteredPods {
-				val = val + PodMemory(&filteredPods[i]).Value()
+			for i := range pods {
+				val = val + PodMemory(pods[i].(*api.Pod)).Value()
 			}
 			value = resource.NewQuantity(int64(val), resource.DecimalSI)
 		case api.ResourceCPU:
+			pods, err := listObjects("pods")
+			if err != nil {
+				return err
+			}
 			val := int64(0)
-			for i := range filteredPods {
-				val = val + PodCPU(&filteredPods[i]).MilliValue()
+			for i := range pods {
+				val = val + PodCPU(pods[i].(*api.Pod)).MilliValue()
 			}
 			value = resource.NewMilliQuantity(int64(val), resource.DecimalSI)
-		case api.ResourceServices:
-			items, err := rm.kubeClient.Services(usage.Namespace).List(labels.Everything())
+		case api.ResourceRequestsStorage:
+			claims, err := listObjects("persistentvolumeclaims")
 			if err != nil {
 				return err
 			}
-			value = resource.NewQuantity(int64(len(items.Items)), resource.DecimalSI)
-		case api.ResourceReplicationControllers:
-			items, err := rm.kubeClient.ReplicationControllers(usage.Namespace).List(labels.Everything())
-			if err != nil {
-				return err
+			val := int64(0)
+			for i := range claims {
+				val = val + PersistentVolumeClaimStorage(claims[i].(*api.PersistentVolumeClaim)).Value()
 			}
-			value = resource.NewQuantity(int64(len(items.Items)), resource.DecimalSI)
-		case api.ResourceQuotas:
-			items, err := rm.kubeClient.ResourceQuotas(usage.Namespace).List(labels.Everything())
-			if err != nil {
-				return err
+			value = resource.NewQuantity(int64(val), resource.BinarySI)
+		default:
+			// object counts, e.g. pods, secrets or count/deployments
+			if kind, ok := countedResourceFor(k); ok {
+				items, err := listObjects(kind)
+				if err != nil {
+					return err
+				}
+				value = resource.NewQuantity(int64(len(items)), resource.DecimalSI)
 			}
-			value = resource.NewQuantity(int64(len(items.Items)), resource.DecimalSI)
 		}
 
 		// ignore fields we do not understand (assume another controller is tracking it)
@@ -214,6 +407,20 @@ func (rm *ResourceQuotaManager) syncResourceQuota(quota api.ResourceQuota) (err
 	return nil
 }
 
+// filterQuotaPodObjects applies FilterQuotaPods to a list of *api.Pod objects.
+func filterQuotaPodObjects(objects []runtime.Object) []runtime.Object {
+	pods := make([]api.Pod, 0, len(objects))
+	for _, obj := range objects {
+		pods = append(pods, *obj.(*api.Pod))
+	}
+	filtered := FilterQuotaPods(pods)
+	result := make([]runtime.Object, 0, len(filtered))
+	for i := range filtered {
+		result = append(result, &filtered[i])
+	}
+	return result
+}
+
 // PodCPU computes total cpu usage of a pod
 func PodCPU(pod *api.Pod) *resource.Quantity {
 	val := int64(0)
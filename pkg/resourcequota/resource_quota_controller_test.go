@@ -17,11 +17,14 @@ limitations under the License.
 package resourcequota
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 )
 
@@ -181,3 +184,148 @@ func TestSyncResourceQuota(t *testing.T) {
 	}
 
 }
+
+func TestSyncResourceQuotaObjectCountsAndStorage(t *testing.T) {
+	claim := func(name, storage string) api.PersistentVolumeClaim {
+		return api.PersistentVolumeClaim{
+			ObjectMeta: api.ObjectMeta{Name: name},
+			Spec: api.PersistentVolumeClaimSpec{
+				Resources: api.ResourceRequirements{
+					Requests: api.ResourceList{api.ResourceStorage: resource.MustParse(storage)},
+				},
+			},
+		}
+	}
+	kubeClient := &client.Fake{
+		SecretList: api.SecretList{
+			Items: []api.Secret{{ObjectMeta: api.ObjectMeta{Name: "a"}}, {ObjectMeta: api.ObjectMeta{Name: "b"}}},
+		},
+		PersistentVolumeClaimList: api.PersistentVolumeClaimList{
+			Items: []api.PersistentVolumeClaim{claim("a", "1Gi"), claim("b", "512Mi")},
+		},
+		DeploymentList: api.DeploymentList{
+			Items: []api.Deployment{{ObjectMeta: api.ObjectMeta{Name: "a"}}},
+		},
+	}
+	quota := api.ResourceQuota{
+		Spec: api.ResourceQuotaSpec{
+			Hard: api.ResourceList{
+				api.ResourceSecrets:                resource.MustParse("5"),
+				api.ResourcePersistentVolumeClaims: resource.MustParse("5"),
+				api.ResourceRequestsStorage:        resource.MustParse("10Gi"),
+				"count/deployments":                resource.MustParse("5"),
+				"count/unknown":                    resource.MustParse("5"),
+			},
+		},
+	}
+
+	if err := NewResourceQuotaManager(kubeClient).syncResourceQuota(quota); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expectedUsed := map[api.ResourceName]int64{
+		api.ResourceSecrets:                2,
+		api.ResourcePersistentVolumeClaims: 2,
+		api.ResourceRequestsStorage:        1536 * 1024 * 1024,
+		"count/deployments":                1,
+	}
+	used := kubeClient.ResourceQuotaStatus.Status.Used
+	for k, v := range expectedUsed {
+		actual, found := used[k]
+		if !found || actual.Value() != v {
+			t.Errorf("Usage Used: Key: %v, Expected: %v, Actual: %v", k, v, actual.String())
+		}
+	}
+	if _, found := used["count/unknown"]; found {
+		t.Errorf("Expected no usage for an untracked resource, got %v", used["count/unknown"])
+	}
+}
+
+func TestCountedResourcesMatchCountableResourceNames(t *testing.T) {
+	counted := util.NewStringSet()
+	for resource := range countedResources {
+		counted.Insert(resource)
+	}
+	countable := util.NewStringSet(api.CountableResourceNames()...)
+	if !counted.IsSuperset(countable) || !countable.IsSuperset(counted) {
+		t.Errorf("Expected the counted resources %v to match the countable resources %v", counted.List(), countable.List())
+	}
+}
+
+func TestSyncResourceQuotaFromCache(t *testing.T) {
+	kubeClient := &client.Fake{}
+	rm := NewResourceQuotaManager(kubeClient)
+	store := &changeStore{
+		Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{"namespace": cache.MetaNamespaceIndexFunc}),
+		manager: rm,
+	}
+	rm.stores["secrets"] = store
+	store.Replace([]interface{}{
+		&api.Secret{ObjectMeta: api.ObjectMeta{Name: "a", Namespace: "ns"}},
+		&api.Secret{ObjectMeta: api.ObjectMeta{Name: "b", Namespace: "other"}},
+	})
+
+	quota := api.ResourceQuota{
+		ObjectMeta: api.ObjectMeta{Name: "quota", Namespace: "ns"},
+		Spec:       api.ResourceQuotaSpec{Hard: api.ResourceList{api.ResourceSecrets: resource.MustParse("5")}},
+	}
+	if err := rm.syncResourceQuota(quota); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if used := kubeClient.ResourceQuotaStatus.Status.Used[api.ResourceSecrets]; used.Value() != 1 {
+		t.Errorf("Expected 1 secret in use, got %v", used.String())
+	}
+	for _, action := range kubeClient.Actions {
+		if action.Action == "list-secrets" {
+			t.Errorf("Expected secrets to be read from the cache, got %#v", kubeClient.Actions)
+		}
+	}
+}
+
+func TestSynchronizeOnlyDirtyNamespaces(t *testing.T) {
+	rm := NewResourceQuotaManager(&client.Fake{})
+	lock := sync.Mutex{}
+	synced := util.StringSet{}
+	rm.syncHandler = func(quota api.ResourceQuota) error {
+		lock.Lock()
+		defer lock.Unlock()
+		synced.Insert(quota.Namespace)
+		return nil
+	}
+	quotas := &changeStore{
+		Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{"namespace": cache.MetaNamespaceIndexFunc}),
+		manager: rm,
+	}
+	rm.stores["resourcequotas"] = quotas
+	for _, ns := range []string{"a", "b", "c"} {
+		quotas.Indexer.Add(&api.ResourceQuota{ObjectMeta: api.ObjectMeta{Name: "quota", Namespace: ns}})
+	}
+	secrets := &changeStore{
+		Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{"namespace": cache.MetaNamespaceIndexFunc}),
+		manager: rm,
+	}
+	rm.stores["secrets"] = secrets
+
+	secrets.Add(&api.Secret{ObjectMeta: api.ObjectMeta{Name: "s", Namespace: "b"}})
+	select {
+	case <-rm.changed:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a change notification")
+	}
+	rm.synchronize()
+	if !synced.Has("b") || len(synced) != 1 {
+		t.Errorf("Expected only namespace b to be synced, got %v", synced.List())
+	}
+
+	synced = util.StringSet{}
+	rm.synchronize()
+	if len(synced) != 0 {
+		t.Errorf("Expected no namespaces to be synced, got %v", synced.List())
+	}
+
+	rm.markAllDirty()
+	rm.synchronize()
+	if len(synced) != 3 {
+		t.Errorf("Expected all namespaces to be synced, got %v", synced.List())
+	}
+}
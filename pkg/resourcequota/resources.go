@@ -0,0 +1,225 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcequota
+
+import (
+	"strings"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// countedResource knows how to list and watch one kind of namespaced object.
+type countedResource struct {
+	// object is an empty instance of the kind, used to type check watch events.
+	object runtime.Object
+	list   func(c client.Interface, namespace string) (runtime.Object, error)
+	watch  func(c client.Interface, namespace, resourceVersion string) (watch.Interface, error)
+}
+
+// countedResources holds every kind whose objects can be counted against a
+// quota, keyed by lowercase plural resource name.  Its keys must be the
+// resources accepted by api.IsCountableResourceName, which validation uses to
+// reject quotas that could never be tracked.
+var countedResources = map[string]countedResource{
+	"pods": {
+		&api.Pod{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Pods(ns).List(labels.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Pods(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"services": {
+		&api.Service{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Services(ns).List(labels.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Services(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"replicationcontrollers": {
+		&api.ReplicationController{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.ReplicationControllers(ns).List(labels.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.ReplicationControllers(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"resourcequotas": {
+		&api.ResourceQuota{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.ResourceQuotas(ns).List(labels.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.ResourceQuotas(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"secrets": {
+		&api.Secret{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Secrets(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Secrets(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"persistentvolumeclaims": {
+		&api.PersistentVolumeClaim{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.PersistentVolumeClaims(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.PersistentVolumeClaims(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"endpoints": {
+		&api.Endpoints{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Endpoints(ns).List(labels.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Endpoints(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"limitranges": {
+		&api.LimitRange{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.LimitRanges(ns).List(labels.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.LimitRanges(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"serviceaccounts": {
+		&api.ServiceAccount{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.ServiceAccounts(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.ServiceAccounts(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"deployments": {
+		&api.Deployment{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Deployments(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Deployments(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"jobs": {
+		&api.Job{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Jobs(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Jobs(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"daemonsets": {
+		&api.DaemonSet{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.DaemonSets(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.DaemonSets(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"horizontalpodautoscalers": {
+		&api.HorizontalPodAutoscaler{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.HorizontalPodAutoscalers(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.HorizontalPodAutoscalers(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"roles": {
+		&api.Role{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.Roles(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.Roles(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+	"rolebindings": {
+		&api.RoleBinding{},
+		func(c client.Interface, ns string) (runtime.Object, error) {
+			return c.RoleBindings(ns).List(labels.Everything(), fields.Everything())
+		},
+		func(c client.Interface, ns, rv string) (watch.Interface, error) {
+			return c.RoleBindings(ns).Watch(labels.Everything(), fields.Everything(), rv)
+		},
+	},
+}
+
+// ObjectCountNames returns the quota resource names that count objects of the
+// given API resource, e.g. "secrets" and "count/secrets" for secrets.  The
+// resource may be in any case, since older API versions use mixed case.
+func ObjectCountNames(resource string) []api.ResourceName {
+	resource = strings.ToLower(resource)
+	if _, ok := countedResources[resource]; !ok {
+		return nil
+	}
+	names := []api.ResourceName{api.ResourceName(api.ResourceCountPrefix + resource)}
+	if api.IsStandardResourceName(resource) {
+		names = append(names, api.ResourceName(resource))
+	}
+	return names
+}
+
+// countedResourceFor returns the resource whose objects are counted by a quota
+// resource name, if it is an object count this package can track.
+func countedResourceFor(name api.ResourceName) (string, bool) {
+	resource := string(name)
+	if strings.HasPrefix(resource, api.ResourceCountPrefix) {
+		resource = strings.ToLower(strings.TrimPrefix(resource, api.ResourceCountPrefix))
+	} else if !api.IsStandardResourceName(resource) {
+		return "", false
+	}
+	_, ok := countedResources[resource]
+	return resource, ok
+}
+
+// requiredResource returns the resource whose objects determine the usage of
+// a quota resource name.
+func requiredResource(name api.ResourceName) (string, bool) {
+	switch name {
+	case api.ResourceCPU, api.ResourceMemory:
+		return "pods", true
+	case api.ResourceRequestsStorage:
+		return "persistentvolumeclaims", true
+	}
+	return countedResourceFor(name)
+}
+
+// PersistentVolumeClaimStorage returns the storage requested by a claim.
+func PersistentVolumeClaimStorage(claim *api.PersistentVolumeClaim) *resource.Quantity {
+	storage := claim.Spec.Resources.Requests[api.ResourceStorage]
+	return resource.NewQuantity(storage.Value(), resource.BinarySI)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitycontext
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+// Effective returns the security options that apply to container in a pod
+// with the given spec.  Options set on the container override those set on
+// the pod.  Returns nil if neither sets any options.
+func Effective(spec *api.PodSpec, container *api.Container) *api.SecurityContext {
+	if spec.SecurityContext == nil && container.SecurityContext == nil {
+		return nil
+	}
+	effective := &api.SecurityContext{}
+	for _, sc := range []*api.SecurityContext{spec.SecurityContext, container.SecurityContext} {
+		if sc == nil {
+			continue
+		}
+		if sc.RunAsUser != nil {
+			uid := *sc.RunAsUser
+			effective.RunAsUser = &uid
+		}
+		if sc.RunAsNonRoot {
+			effective.RunAsNonRoot = true
+		}
+		if sc.ReadOnlyRootFilesystem {
+			effective.ReadOnlyRootFilesystem = true
+		}
+		if sc.SELinuxOptions != nil {
+			options := *sc.SELinuxOptions
+			effective.SELinuxOptions = &options
+		}
+	}
+	return effective
+}
+
+// SELinuxLabelOptions returns the docker security options that apply the
+// labels in options.
+func SELinuxLabelOptions(options *api.SELinuxOptions) []string {
+	if options == nil {
+		return nil
+	}
+	labels := []string{}
+	for _, label := range []struct{ name, value string }{
+		{"user", options.User},
+		{"role", options.Role},
+		{"type", options.Type},
+		{"level", options.Level},
+	} {
+		if len(label.value) > 0 {
+			labels = append(labels, fmt.Sprintf("label:%s:%s", label.name, label.value))
+		}
+	}
+	return labels
+}
+
+// IsRootUser returns true if user, as given in an image's configuration,
+// refers to root.  An unspecified user means root.
+func IsRootUser(user string) bool {
+	// The user may be given as user:group.
+	user = strings.SplitN(user, ":", 2)[0]
+	return user == "" || user == "0" || user == "root"
+}
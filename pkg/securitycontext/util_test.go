@@ -0,0 +1,100 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitycontext
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func TestEffective(t *testing.T) {
+	uid := func(i int64) *int64 { return &i }
+	testCases := map[string]struct {
+		pod       *api.SecurityContext
+		container *api.SecurityContext
+		expected  *api.SecurityContext
+	}{
+		"none": {},
+		"pod only": {
+			pod:      &api.SecurityContext{RunAsUser: uid(1000), RunAsNonRoot: true},
+			expected: &api.SecurityContext{RunAsUser: uid(1000), RunAsNonRoot: true},
+		},
+		"container only": {
+			container: &api.SecurityContext{ReadOnlyRootFilesystem: true},
+			expected:  &api.SecurityContext{ReadOnlyRootFilesystem: true},
+		},
+		"container overrides pod": {
+			pod: &api.SecurityContext{
+				RunAsUser:      uid(1000),
+				SELinuxOptions: &api.SELinuxOptions{Level: "s0:c1"},
+			},
+			container: &api.SecurityContext{
+				RunAsUser:      uid(2000),
+				RunAsNonRoot:   true,
+				SELinuxOptions: &api.SELinuxOptions{Level: "s0:c2"},
+			},
+			expected: &api.SecurityContext{
+				RunAsUser:      uid(2000),
+				RunAsNonRoot:   true,
+				SELinuxOptions: &api.SELinuxOptions{Level: "s0:c2"},
+			},
+		},
+		"pod restrictions are kept": {
+			pod:       &api.SecurityContext{RunAsNonRoot: true, ReadOnlyRootFilesystem: true},
+			container: &api.SecurityContext{RunAsUser: uid(1000)},
+			expected:  &api.SecurityContext{RunAsUser: uid(1000), RunAsNonRoot: true, ReadOnlyRootFilesystem: true},
+		},
+	}
+	for k, testCase := range testCases {
+		spec := &api.PodSpec{SecurityContext: testCase.pod}
+		container := &api.Container{SecurityContext: testCase.container}
+		if actual := Effective(spec, container); !reflect.DeepEqual(testCase.expected, actual) {
+			t.Errorf("%s: expected %#v, got %#v", k, testCase.expected, actual)
+		}
+	}
+}
+
+func TestSELinuxLabelOptions(t *testing.T) {
+	if options := SELinuxLabelOptions(nil); options != nil {
+		t.Errorf("expected no options, got %v", options)
+	}
+	options := SELinuxLabelOptions(&api.SELinuxOptions{User: "user_u", Type: "svirt_lxc_net_t", Level: "s0:c1,c2"})
+	expected := []string{"label:user:user_u", "label:type:svirt_lxc_net_t", "label:level:s0:c1,c2"}
+	if !reflect.DeepEqual(expected, options) {
+		t.Errorf("expected %v, got %v", expected, options)
+	}
+}
+
+func TestIsRootUser(t *testing.T) {
+	for user, expected := range map[string]bool{
+		"":          true,
+		"0":         true,
+		"root":      true,
+		"0:0":       true,
+		"root:wig":  true,
+		"1000":      false,
+		"nobody":    false,
+		"1000:0":    false,
+		"daemon:10": false,
+	} {
+		if actual := IsRootUser(user); actual != expected {
+			t.Errorf("%q: expected %v, got %v", user, expected, actual)
+		}
+	}
+}
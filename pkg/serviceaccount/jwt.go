@@ -0,0 +1,248 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/authenticator"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// Issuer is the value of the "iss" claim of every service account token.
+	Issuer = "kubernetes/serviceaccount"
+
+	// SubjectClaim is the claim holding the username of the service account.
+	SubjectClaim = "sub"
+	// IssuerClaim is the claim holding the token issuer.
+	IssuerClaim = "iss"
+	// ServiceAccountNameClaim is the claim holding the name of the service account.
+	ServiceAccountNameClaim = "kubernetes.io/serviceaccount/service-account.name"
+	// ServiceAccountUIDClaim is the claim holding the UID of the service account.
+	ServiceAccountUIDClaim = "kubernetes.io/serviceaccount/service-account.uid"
+	// SecretNameClaim is the claim holding the name of the secret the token is stored in.
+	SecretNameClaim = "kubernetes.io/serviceaccount/secret.name"
+	// NamespaceClaim is the claim holding the namespace of the service account.
+	NamespaceClaim = "kubernetes.io/serviceaccount/namespace"
+
+	// signingAlgorithm is the only JWT signing algorithm tokens are issued or accepted with.
+	signingAlgorithm = "RS256"
+)
+
+// ServiceAccountTokenGetter defines functions to retrieve a named service account and secret
+type ServiceAccountTokenGetter interface {
+	GetServiceAccount(namespace, name string) (*api.ServiceAccount, error)
+	GetSecret(namespace, name string) (*api.Secret, error)
+}
+
+// TokenGenerator generates API tokens for service accounts.
+type TokenGenerator interface {
+	// GenerateToken generates a token which will identify the given ServiceAccount.
+	// The returned token will be stored in the given (and yet-unpersisted) Secret.
+	GenerateToken(serviceAccount api.ServiceAccount, secret api.Secret) (string, error)
+}
+
+// jwtHeader is the JOSE header of a signed token.
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// JWTTokenGenerator returns a TokenGenerator that generates RS256-signed JWT tokens, using the given private key.
+// JWTTokenAuthenticator() authenticates tokens using the matching public key.
+func JWTTokenGenerator(key *rsa.PrivateKey) TokenGenerator {
+	return &jwtTokenGenerator{key}
+}
+
+type jwtTokenGenerator struct {
+	key *rsa.PrivateKey
+}
+
+func (j *jwtTokenGenerator) GenerateToken(serviceAccount api.ServiceAccount, secret api.Secret) (string, error) {
+	header, err := json.Marshal(jwtHeader{Algorithm: signingAlgorithm, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]string{
+		IssuerClaim:             Issuer,
+		SubjectClaim:            MakeUsername(serviceAccount.Namespace, serviceAccount.Name),
+		NamespaceClaim:          serviceAccount.Namespace,
+		ServiceAccountNameClaim: serviceAccount.Name,
+		ServiceAccountUIDClaim:  string(serviceAccount.UID),
+		SecretNameClaim:         secret.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(header) + "." + encodeSegment(claims)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, j.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+// JWTTokenAuthenticator authenticates tokens as JWT tokens produced by JWTTokenGenerator
+// Token signatures are verified using each of the given public keys until one works (allowing key rotation)
+// If lookup is true, the service account and secret referenced as claims inside the token are retrieved and verified with the provided ServiceAccountTokenGetter
+func JWTTokenAuthenticator(keys []*rsa.PublicKey, lookup bool, getter ServiceAccountTokenGetter) authenticator.Token {
+	return &jwtTokenAuthenticator{keys, lookup, getter}
+}
+
+type jwtTokenAuthenticator struct {
+	keys   []*rsa.PublicKey
+	lookup bool
+	getter ServiceAccountTokenGetter
+}
+
+func (j *jwtTokenAuthenticator) AuthenticateToken(token string) (user.Info, bool, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		// Not a JWT, leave it to other authenticators
+		return nil, false, nil
+	}
+
+	header := jwtHeader{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, false, nil
+	}
+	claims := map[string]string{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, false, nil
+	}
+	if claims[IssuerClaim] != Issuer {
+		// Issued by someone else, leave it to other authenticators
+		return nil, false, nil
+	}
+	if header.Algorithm != signingAlgorithm {
+		return nil, false, fmt.Errorf("unexpected signing algorithm %q", header.Algorithm)
+	}
+
+	signature, err := base64.URLEncoding.DecodeString(padSegment(parts[2]))
+	if err != nil {
+		return nil, false, err
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	verified := false
+	for _, key := range j.keys {
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, false, errors.New("token signature could not be verified")
+	}
+
+	// Make sure the claims we need exist
+	sub := claims[SubjectClaim]
+	namespace := claims[NamespaceClaim]
+	serviceAccountName := claims[ServiceAccountNameClaim]
+	serviceAccountUID := claims[ServiceAccountUIDClaim]
+	secretName := claims[SecretNameClaim]
+	if len(sub) == 0 || len(namespace) == 0 || len(serviceAccountName) == 0 || len(serviceAccountUID) == 0 || len(secretName) == 0 {
+		return nil, false, errors.New("token is missing required claims")
+	}
+	if subNamespace, subName, err := SplitUsername(sub); err != nil || subNamespace != namespace || subName != serviceAccountName {
+		return nil, false, errors.New("sub claim is invalid")
+	}
+
+	if j.lookup {
+		// Make sure token hasn't been invalidated by deletion of the secret
+		secret, err := j.getter.GetSecret(namespace, secretName)
+		if err != nil {
+			glog.V(4).Infof("Could not retrieve token %s/%s for service account %s/%s: %v", namespace, secretName, namespace, serviceAccountName, err)
+			return nil, false, errors.New("Token has been invalidated")
+		}
+		if string(secret.Data[api.ServiceAccountTokenKey]) != token {
+			glog.V(4).Infof("Token contents no longer matches %s/%s for service account %s/%s", namespace, secretName, namespace, serviceAccountName)
+			return nil, false, errors.New("Token does not match server's copy")
+		}
+
+		// Make sure service account still exists (name and UID)
+		serviceAccount, err := j.getter.GetServiceAccount(namespace, serviceAccountName)
+		if err != nil {
+			glog.V(4).Infof("Could not retrieve service account %s/%s: %v", namespace, serviceAccountName, err)
+			return nil, false, err
+		}
+		if string(serviceAccount.UID) != serviceAccountUID {
+			glog.V(4).Infof("Service account UID no longer matches %s/%s: %q != %q", namespace, serviceAccountName, string(serviceAccount.UID), serviceAccountUID)
+			return nil, false, fmt.Errorf("ServiceAccount UID (%s) does not match claim (%s)", serviceAccount.UID, serviceAccountUID)
+		}
+	}
+
+	return &user.DefaultInfo{
+		Name:   sub,
+		UID:    serviceAccountUID,
+		Groups: MakeGroupNames(namespace),
+	}, true, nil
+}
+
+// NewGetterFromClient returns a ServiceAccountTokenGetter that uses the specified client to retrieve service accounts and secrets.
+// The client should NOT authenticate using a service account token the returned getter will be used to retrieve, or recursion will result.
+func NewGetterFromClient(c client.Interface) ServiceAccountTokenGetter {
+	return clientGetter{c}
+}
+
+type clientGetter struct {
+	client client.Interface
+}
+
+func (c clientGetter) GetServiceAccount(namespace, name string) (*api.ServiceAccount, error) {
+	return c.client.ServiceAccounts(namespace).Get(name)
+}
+
+func (c clientGetter) GetSecret(namespace, name string) (*api.Secret, error) {
+	return c.client.Secrets(namespace).Get(name)
+}
+
+// encodeSegment encodes a JWT segment as unpadded base64url.
+func encodeSegment(data []byte) string {
+	return strings.TrimRight(base64.URLEncoding.EncodeToString(data), "=")
+}
+
+// decodeSegment decodes an unpadded base64url JWT segment into obj.
+func decodeSegment(segment string, obj interface{}) error {
+	data, err := base64.URLEncoding.DecodeString(padSegment(segment))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// padSegment restores the base64 padding stripped from a JWT segment.
+func padSegment(segment string) string {
+	if l := len(segment) % 4; l > 0 {
+		segment += strings.Repeat("=", 4-l)
+	}
+	return segment
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func newTestKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	return key
+}
+
+// fakeGetter is a ServiceAccountTokenGetter backed by maps.
+type fakeGetter struct {
+	serviceAccounts map[string]*api.ServiceAccount
+	secrets         map[string]*api.Secret
+}
+
+func (f fakeGetter) GetServiceAccount(namespace, name string) (*api.ServiceAccount, error) {
+	if sa, ok := f.serviceAccounts[namespace+"/"+name]; ok {
+		return sa, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f fakeGetter) GetSecret(namespace, name string) (*api.Secret, error) {
+	if secret, ok := f.secrets[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestTokenGenerateAndValidate(t *testing.T) {
+	key := newTestKey(t)
+	otherKey := newTestKey(t)
+
+	serviceAccount := api.ServiceAccount{
+		ObjectMeta: api.ObjectMeta{Name: "my-service-account", Namespace: "test", UID: "12345"},
+	}
+	secret := api.Secret{
+		ObjectMeta: api.ObjectMeta{Name: "my-secret", Namespace: "test"},
+		Type:       api.SecretTypeServiceAccountToken,
+	}
+
+	token, err := JWTTokenGenerator(key).GenerateToken(serviceAccount, secret)
+	if err != nil {
+		t.Fatalf("unexpected error generating token: %v", err)
+	}
+	if len(token) == 0 {
+		t.Fatalf("unexpected empty token")
+	}
+	secret.Data = map[string][]byte{api.ServiceAccountTokenKey: []byte(token)}
+
+	recreated := serviceAccount
+	recreated.UID = "67890"
+
+	testCases := map[string]struct {
+		token  string
+		keys   []*rsa.PublicKey
+		lookup bool
+		getter fakeGetter
+
+		expectedOK       bool
+		expectedErr      bool
+		expectedUserName string
+		expectedUserUID  string
+	}{
+		"no keys": {
+			token:       token,
+			keys:        []*rsa.PublicKey{},
+			expectedErr: true,
+		},
+		"invalid keys": {
+			token:       token,
+			keys:        []*rsa.PublicKey{&otherKey.PublicKey},
+			expectedErr: true,
+		},
+		"valid key": {
+			token:            token,
+			keys:             []*rsa.PublicKey{&key.PublicKey},
+			expectedOK:       true,
+			expectedUserName: "system:serviceaccount:test:my-service-account",
+			expectedUserUID:  "12345",
+		},
+		"rotated keys": {
+			token:            token,
+			keys:             []*rsa.PublicKey{&otherKey.PublicKey, &key.PublicKey},
+			expectedOK:       true,
+			expectedUserName: "system:serviceaccount:test:my-service-account",
+			expectedUserUID:  "12345",
+		},
+		"not a jwt": {
+			token: "abc123",
+			keys:  []*rsa.PublicKey{&key.PublicKey},
+		},
+		"tampered token": {
+			token:       token[:len(token)-4] + "AAAA",
+			keys:        []*rsa.PublicKey{&key.PublicKey},
+			expectedErr: true,
+		},
+		"valid lookup": {
+			token:  token,
+			keys:   []*rsa.PublicKey{&key.PublicKey},
+			lookup: true,
+			getter: fakeGetter{
+				serviceAccounts: map[string]*api.ServiceAccount{"test/my-service-account": &serviceAccount},
+				secrets:         map[string]*api.Secret{"test/my-secret": &secret},
+			},
+			expectedOK:       true,
+			expectedUserName: "system:serviceaccount:test:my-service-account",
+			expectedUserUID:  "12345",
+		},
+		"deleted secret": {
+			token:  token,
+			keys:   []*rsa.PublicKey{&key.PublicKey},
+			lookup: true,
+			getter: fakeGetter{
+				serviceAccounts: map[string]*api.ServiceAccount{"test/my-service-account": &serviceAccount},
+			},
+			expectedErr: true,
+		},
+		"recreated service account": {
+			token:  token,
+			keys:   []*rsa.PublicKey{&key.PublicKey},
+			lookup: true,
+			getter: fakeGetter{
+				serviceAccounts: map[string]*api.ServiceAccount{"test/my-service-account": &recreated},
+				secrets:         map[string]*api.Secret{"test/my-secret": &secret},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for k, tc := range testCases {
+		authenticator := JWTTokenAuthenticator(tc.keys, tc.lookup, tc.getter)
+
+		user, ok, err := authenticator.AuthenticateToken(tc.token)
+		if (err != nil) != tc.expectedErr {
+			t.Errorf("%s: expected error=%v, got %v", k, tc.expectedErr, err)
+			continue
+		}
+		if ok != tc.expectedOK {
+			t.Errorf("%s: expected ok=%v, got %v", k, tc.expectedOK, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if user.GetName() != tc.expectedUserName {
+			t.Errorf("%s: expected username=%v, got %v", k, tc.expectedUserName, user.GetName())
+		}
+		if user.GetUID() != tc.expectedUserUID {
+			t.Errorf("%s: expected userUID=%v, got %v", k, tc.expectedUserUID, user.GetUID())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+	"github.com/golang/glog"
+)
+
+// ServiceAccountsController makes sure every active namespace contains the
+// default service account.
+type ServiceAccountsController struct {
+	kubeClient client.Interface
+	names      util.StringSet
+}
+
+// NewServiceAccountsController creates a new ServiceAccountsController that
+// ensures the DefaultServiceAccountName account exists in every namespace.
+func NewServiceAccountsController(kubeClient client.Interface) *ServiceAccountsController {
+	return &ServiceAccountsController{
+		kubeClient: kubeClient,
+		names:      util.NewStringSet(DefaultServiceAccountName),
+	}
+}
+
+// Run begins syncing service accounts every period.
+func (e *ServiceAccountsController) Run(period time.Duration) {
+	go util.Forever(func() {
+		if err := e.synchronize(); err != nil {
+			glog.Errorf("Error synchronizing service accounts: %v", err)
+		}
+	}, period)
+}
+
+// synchronize creates the missing service accounts of every active namespace.
+func (e *ServiceAccountsController) synchronize() error {
+	namespaces, err := e.kubeClient.Namespaces().List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	serviceAccounts, err := e.kubeClient.ServiceAccounts(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	existing := util.StringSet{}
+	for _, sa := range serviceAccounts.Items {
+		existing.Insert(sa.Namespace + "/" + sa.Name)
+	}
+
+	errs := []error{}
+	for _, namespace := range namespaces.Items {
+		if namespace.Status.Phase != api.NamespaceActive {
+			// Accounts created in a terminating namespace would be deleted right away
+			continue
+		}
+		for _, name := range e.names.List() {
+			if existing.Has(namespace.Name + "/" + name) {
+				continue
+			}
+			sa := &api.ServiceAccount{ObjectMeta: api.ObjectMeta{Name: name, Namespace: namespace.Name}}
+			if _, err := e.kubeClient.ServiceAccounts(namespace.Name).Create(sa); err != nil && !apierrors.IsAlreadyExists(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
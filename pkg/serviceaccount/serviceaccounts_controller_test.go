@@ -0,0 +1,66 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+func TestServiceAccountsControllerSynchronize(t *testing.T) {
+	namespace := func(name string, phase api.NamespacePhase) api.Namespace {
+		return api.Namespace{
+			ObjectMeta: api.ObjectMeta{Name: name},
+			Status:     api.NamespaceStatus{Phase: phase},
+		}
+	}
+
+	fake := &client.Fake{
+		NamespacesList: api.NamespaceList{
+			Items: []api.Namespace{
+				namespace("has-default", api.NamespaceActive),
+				namespace("missing-default", api.NamespaceActive),
+				namespace("terminating", api.NamespaceTerminating),
+			},
+		},
+		ServiceAccountList: api.ServiceAccountList{
+			Items: []api.ServiceAccount{
+				{ObjectMeta: api.ObjectMeta{Name: DefaultServiceAccountName, Namespace: "has-default"}},
+				{ObjectMeta: api.ObjectMeta{Name: "other", Namespace: "missing-default"}},
+			},
+		},
+	}
+	controller := NewServiceAccountsController(fake)
+	if err := controller.synchronize(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	created := []*api.ServiceAccount{}
+	for _, action := range fake.Actions {
+		if action.Action == "create-serviceAccount" {
+			created = append(created, action.Value.(*api.ServiceAccount))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected 1 service account to be created, got %#v", fake.Actions)
+	}
+	if created[0].Namespace != "missing-default" || created[0].Name != DefaultServiceAccountName {
+		t.Errorf("unexpected service account created: %#v", created[0])
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	utilerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/util/errors"
+	"github.com/golang/glog"
+)
+
+// TokensController makes sure every service account has an API token secret,
+// and deletes token secrets whose service account is gone.
+type TokensController struct {
+	kubeClient client.Interface
+	token      TokenGenerator
+}
+
+// NewTokensController returns a new TokensController that signs tokens with
+// the given generator.
+func NewTokensController(kubeClient client.Interface, token TokenGenerator) *TokensController {
+	return &TokensController{
+		kubeClient: kubeClient,
+		token:      token,
+	}
+}
+
+// Run begins syncing service account tokens every period.
+func (e *TokensController) Run(period time.Duration) {
+	go util.Forever(func() {
+		if err := e.synchronize(); err != nil {
+			glog.Errorf("Error synchronizing service account tokens: %v", err)
+		}
+	}, period)
+}
+
+func (e *TokensController) synchronize() error {
+	serviceAccounts, err := e.kubeClient.ServiceAccounts(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+	if err != nil {
+		return err
+	}
+	tokenSelector := fields.OneTermEqualSelector("type", string(api.SecretTypeServiceAccountToken))
+	secrets, err := e.kubeClient.Secrets(api.NamespaceAll).List(labels.Everything(), tokenSelector)
+	if err != nil {
+		return err
+	}
+
+	// Index the token secrets by namespace so each account only looks at its own
+	tokensByNamespace := map[string][]api.Secret{}
+	for _, secret := range secrets.Items {
+		if secret.Type != api.SecretTypeServiceAccountToken {
+			continue
+		}
+		tokensByNamespace[secret.Namespace] = append(tokensByNamespace[secret.Namespace], secret)
+	}
+
+	errs := []error{}
+	owned := util.StringSet{}
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		tokens := []api.Secret{}
+		for _, secret := range tokensByNamespace[sa.Namespace] {
+			if IsServiceAccountToken(&secret, sa) {
+				tokens = append(tokens, secret)
+				owned.Insert(secret.Namespace + "/" + secret.Name)
+			}
+		}
+		if err := e.syncServiceAccount(sa, tokens); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, secret := range secrets.Items {
+		if secret.Type != api.SecretTypeServiceAccountToken || owned.Has(secret.Namespace+"/"+secret.Name) {
+			continue
+		}
+		glog.V(2).Infof("Deleting token %s/%s of missing service account %s", secret.Namespace, secret.Name, secret.Annotations[api.ServiceAccountNameKey])
+		if err := e.kubeClient.Secrets(secret.Namespace).Delete(secret.Name); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// syncServiceAccount fills in the tokens of the service account and makes
+// sure the account references at least one of them.
+func (e *TokensController) syncServiceAccount(sa *api.ServiceAccount, tokens []api.Secret) error {
+	referenced := util.StringSet{}
+	for _, ref := range sa.Secrets {
+		referenced.Insert(ref.Name)
+	}
+
+	errs := []error{}
+	needsReference := []api.Secret{}
+	hasReferencedToken := false
+	for i := range tokens {
+		token := &tokens[i]
+		if len(token.Annotations[api.ServiceAccountUIDKey]) == 0 || len(token.Data[api.ServiceAccountTokenKey]) == 0 {
+			// Token secrets may be created with just the account name annotation; fill in the rest
+			if err := e.populateToken(sa, token); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if _, err := e.kubeClient.Secrets(token.Namespace).Update(token); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if referenced.Has(token.Name) {
+			hasReferencedToken = true
+		} else {
+			needsReference = append(needsReference, *token)
+		}
+	}
+
+	if !hasReferencedToken && len(needsReference) == 0 {
+		token, err := e.createToken(sa)
+		if err != nil {
+			return utilerrors.NewAggregate(append(errs, err))
+		}
+		needsReference = append(needsReference, *token)
+	}
+	if len(needsReference) == 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	for _, token := range needsReference {
+		sa.Secrets = append(sa.Secrets, api.ObjectReference{Name: token.Name})
+	}
+	if _, err := e.kubeClient.ServiceAccounts(sa.Namespace).Update(sa); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// createToken creates a new token secret for the service account.
+func (e *TokensController) createToken(sa *api.ServiceAccount) (*api.Secret, error) {
+	secret := &api.Secret{
+		ObjectMeta: api.ObjectMeta{
+			Name:      api.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-token-", sa.Name)),
+			Namespace: sa.Namespace,
+		},
+		Type: api.SecretTypeServiceAccountToken,
+	}
+	if err := e.populateToken(sa, secret); err != nil {
+		return nil, err
+	}
+	if _, err := e.kubeClient.Secrets(sa.Namespace).Create(secret); err != nil {
+		return nil, err
+	}
+	glog.V(2).Infof("Created token %s/%s for service account %s", secret.Namespace, secret.Name, sa.Name)
+	return secret, nil
+}
+
+// populateToken sets the annotations and the signed token of a token secret.
+func (e *TokensController) populateToken(sa *api.ServiceAccount, secret *api.Secret) error {
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[api.ServiceAccountNameKey] = sa.Name
+	secret.Annotations[api.ServiceAccountUIDKey] = string(sa.UID)
+
+	token, err := e.token.GenerateToken(*sa, *secret)
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[api.ServiceAccountTokenKey] = []byte(token)
+	return nil
+}
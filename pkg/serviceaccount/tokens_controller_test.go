@@ -0,0 +1,138 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+)
+
+// fakeTokenGenerator returns a token derived from the secret name.
+type fakeTokenGenerator struct{}
+
+func (fakeTokenGenerator) GenerateToken(serviceAccount api.ServiceAccount, secret api.Secret) (string, error) {
+	return "token-for-" + secret.Name, nil
+}
+
+func tokenSecret(name, serviceAccountName, uid, token string) api.Secret {
+	secret := api.Secret{
+		ObjectMeta: api.ObjectMeta{
+			Name:        name,
+			Namespace:   "ns",
+			Annotations: map[string]string{api.ServiceAccountNameKey: serviceAccountName},
+		},
+		Type: api.SecretTypeServiceAccountToken,
+	}
+	if len(uid) > 0 {
+		secret.Annotations[api.ServiceAccountUIDKey] = uid
+	}
+	if len(token) > 0 {
+		secret.Data = map[string][]byte{api.ServiceAccountTokenKey: []byte(token)}
+	}
+	return secret
+}
+
+func serviceAccount(secretNames ...string) api.ServiceAccount {
+	sa := api.ServiceAccount{ObjectMeta: api.ObjectMeta{Name: "default", Namespace: "ns", UID: "12345", ResourceVersion: "1"}}
+	for _, name := range secretNames {
+		sa.Secrets = append(sa.Secrets, api.ObjectReference{Name: name})
+	}
+	return sa
+}
+
+func TestTokensControllerSynchronize(t *testing.T) {
+	testCases := map[string]struct {
+		serviceAccounts []api.ServiceAccount
+		secrets         []api.Secret
+
+		expectedActions []string
+		expectedSecrets int
+	}{
+		"new service account": {
+			serviceAccounts: []api.ServiceAccount{serviceAccount()},
+			expectedActions: []string{"list-serviceAccounts", "list-secrets", "create-secret", "update-serviceAccount"},
+			expectedSecrets: 1,
+		},
+		"referenced token": {
+			serviceAccounts: []api.ServiceAccount{serviceAccount("default-token-abcde")},
+			secrets:         []api.Secret{tokenSecret("default-token-abcde", "default", "12345", "xyz")},
+			expectedActions: []string{"list-serviceAccounts", "list-secrets"},
+			expectedSecrets: 1,
+		},
+		"unreferenced token": {
+			serviceAccounts: []api.ServiceAccount{serviceAccount()},
+			secrets:         []api.Secret{tokenSecret("default-token-abcde", "default", "12345", "xyz")},
+			expectedActions: []string{"list-serviceAccounts", "list-secrets", "update-serviceAccount"},
+			expectedSecrets: 1,
+		},
+		"token without data": {
+			serviceAccounts: []api.ServiceAccount{serviceAccount("default-token-abcde")},
+			secrets:         []api.Secret{tokenSecret("default-token-abcde", "default", "", "")},
+			expectedActions: []string{"list-serviceAccounts", "list-secrets", "update-secret"},
+			expectedSecrets: 1,
+		},
+		"deleted service account": {
+			secrets:         []api.Secret{tokenSecret("default-token-abcde", "default", "12345", "xyz")},
+			expectedActions: []string{"list-serviceAccounts", "list-secrets", "delete-secret"},
+		},
+		"recreated service account": {
+			serviceAccounts: []api.ServiceAccount{serviceAccount("default-token-abcde")},
+			secrets:         []api.Secret{tokenSecret("default-token-abcde", "default", "67890", "xyz")},
+			expectedActions: []string{"list-serviceAccounts", "list-secrets", "create-secret", "update-serviceAccount", "delete-secret"},
+			expectedSecrets: 2,
+		},
+	}
+
+	for k, tc := range testCases {
+		fake := &client.Fake{
+			ServiceAccountList: api.ServiceAccountList{Items: tc.serviceAccounts},
+			SecretList:         api.SecretList{Items: tc.secrets},
+		}
+		controller := NewTokensController(fake, fakeTokenGenerator{})
+		if err := controller.synchronize(); err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+
+		if len(fake.Actions) != len(tc.expectedActions) {
+			t.Errorf("%s: expected actions %v, got %#v", k, tc.expectedActions, fake.Actions)
+			continue
+		}
+		for i, action := range fake.Actions {
+			if action.Action != tc.expectedActions[i] {
+				t.Errorf("%s: expected action %d to be %s, got %s", k, i, tc.expectedActions[i], action.Action)
+			}
+			switch action.Action {
+			case "create-secret", "update-secret":
+				secret := action.Value.(*api.Secret)
+				if secret.Annotations[api.ServiceAccountUIDKey] != "12345" {
+					t.Errorf("%s: expected token to carry the service account uid: %#v", k, secret)
+				}
+				if string(secret.Data[api.ServiceAccountTokenKey]) != "token-for-"+secret.Name {
+					t.Errorf("%s: expected token to be populated: %#v", k, secret)
+				}
+			case "update-serviceAccount":
+				sa := action.Value.(*api.ServiceAccount)
+				if len(sa.Secrets) != tc.expectedSecrets {
+					t.Errorf("%s: expected %d secrets, got %#v", k, tc.expectedSecrets, sa.Secrets)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func TestMakeSplitUsername(t *testing.T) {
+	username := MakeUsername("ns", "name")
+	ns, name, err := SplitUsername(username)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if ns != "ns" || name != "name" {
+		t.Errorf("Expected ns/name, got %s/%s", ns, name)
+	}
+
+	invalid := []string{"test", "system:serviceaccount", "system:serviceaccount:", "system:serviceaccount:ns", "system:serviceaccount:ns:name:extra", "system:serviceaccount:Bad_ns:name"}
+	for _, n := range invalid {
+		_, _, err := SplitUsername(n)
+		if err == nil {
+			t.Errorf("Expected error for %s", n)
+		}
+	}
+}
+
+func TestIsServiceAccountToken(t *testing.T) {
+	sa := &api.ServiceAccount{ObjectMeta: api.ObjectMeta{Name: "default", Namespace: "ns", UID: "12345"}}
+	token := func(name, uid string) *api.Secret {
+		return &api.Secret{
+			ObjectMeta: api.ObjectMeta{
+				Name:        "token",
+				Namespace:   "ns",
+				Annotations: map[string]string{api.ServiceAccountNameKey: name, api.ServiceAccountUIDKey: uid},
+			},
+			Type: api.SecretTypeServiceAccountToken,
+		}
+	}
+	opaque := token("default", "12345")
+	opaque.Type = api.SecretTypeOpaque
+
+	testCases := map[string]struct {
+		secret   *api.Secret
+		expected bool
+	}{
+		"matching":      {token("default", "12345"), true},
+		"no uid yet":    {token("default", ""), true},
+		"other account": {token("other", "12345"), false},
+		"other uid":     {token("default", "67890"), false},
+		"opaque secret": {opaque, false},
+	}
+	for k, tc := range testCases {
+		if actual := IsServiceAccountToken(tc.secret, sa); actual != tc.expected {
+			t.Errorf("%s: expected %v, got %v", k, tc.expected, actual)
+		}
+	}
+}
+
+func TestReadKeys(t *testing.T) {
+	key := newTestKey(t)
+	f, err := ioutil.TempFile("", "serviceaccount")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	if err := pem.Encode(f, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.Close()
+
+	privateKey, err := ReadPrivateKey(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error reading private key: %v", err)
+	}
+	if privateKey.N.Cmp(key.N) != 0 {
+		t.Errorf("private key does not match")
+	}
+	publicKey, err := ReadPublicKey(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error reading public key from private key file: %v", err)
+	}
+	if publicKey.N.Cmp(key.N) != 0 {
+		t.Errorf("public key does not match")
+	}
+
+	if _, err := ReadPublicKey("/this/file/does/not/exist"); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
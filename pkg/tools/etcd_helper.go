@@ -17,6 +17,7 @@ limitations under the License.
 package tools
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -38,6 +39,8 @@ type EtcdHelper struct {
 	Codec  runtime.Codec
 	// optional, no atomic operations can be performed without this interface
 	Versioner EtcdVersioner
+	// optional, transforms values written to and read from etcd
+	Transformer ValueTransformer
 }
 
 // NewEtcdHelper creates a helper that works against objects that use the internal
@@ -117,7 +120,7 @@ func (h *EtcdHelper) decodeNodeList(nodes []*etcd.Node, slicePtr interface{}) er
 			continue
 		}
 		obj := reflect.New(v.Type().Elem())
-		if err := h.Codec.DecodeInto([]byte(node.Value), obj.Interface().(runtime.Object)); err != nil {
+		if err := h.storageCodec().DecodeInto([]byte(node.Value), obj.Interface().(runtime.Object)); err != nil {
 			return err
 		}
 		if h.Versioner != nil {
@@ -191,7 +194,7 @@ func (h *EtcdHelper) extractObj(response *etcd.Response, inErr error, objPtr run
 		return "", 0, fmt.Errorf("unable to locate a value on the response: %#v", response)
 	}
 	body = node.Value
-	err = h.Codec.DecodeInto([]byte(body), objPtr)
+	err = h.storageCodec().DecodeInto([]byte(body), objPtr)
 	if h.Versioner != nil {
 		_ = h.Versioner.UpdateObject(objPtr, node)
 		// being unable to set the version does not prevent the object from being extracted
@@ -203,7 +206,7 @@ func (h *EtcdHelper) extractObj(response *etcd.Response, inErr error, objPtr run
 // and 0 means forever. If no error is returned and out is not nil, out will be set to the read value
 // from etcd.
 func (h *EtcdHelper) CreateObj(key string, obj, out runtime.Object, ttl uint64) error {
-	data, err := h.Codec.Encode(obj)
+	data, err := h.storageCodec().Encode(obj)
 	if err != nil {
 		return err
 	}
@@ -251,7 +254,7 @@ func (h *EtcdHelper) DeleteObj(key string, out runtime.Object) error {
 // not nil, out will be set to the read value from etcd.
 func (h *EtcdHelper) SetObj(key string, obj, out runtime.Object, ttl uint64) error {
 	var response *etcd.Response
-	data, err := h.Codec.Encode(obj)
+	data, err := h.storageCodec().Encode(obj)
 	if err != nil {
 		return err
 	}
@@ -326,10 +329,16 @@ func (h *EtcdHelper) AtomicUpdate(key string, ptrToType runtime.Object, ignoreNo
 			return err
 		}
 
-		data, err := h.Codec.Encode(ret)
+		plain, err := h.Codec.Encode(ret)
 		if err != nil {
 			return err
 		}
+		data := plain
+		if h.Transformer != nil {
+			if data, err = h.Transformer.TransformToStorage(plain); err != nil {
+				return err
+			}
+		}
 
 		// First time this key has been used, try creating new value.
 		if index == 0 {
@@ -341,7 +350,13 @@ func (h *EtcdHelper) AtomicUpdate(key string, ptrToType runtime.Object, ignoreNo
 			return err
 		}
 
-		if string(data) == origBody {
+		if h.Transformer == nil {
+			if string(data) == origBody {
+				return nil
+			}
+		} else if origPlain, err := h.Transformer.TransformFromStorage([]byte(origBody)); err == nil && bytes.Equal(plain, origPlain) {
+			// The transformed value may differ on every write, so compare
+			// the untransformed values instead.
 			return nil
 		}
 
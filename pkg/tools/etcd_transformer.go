@@ -0,0 +1,112 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tools
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/coreos/go-etcd/etcd"
+)
+
+// transformingCodec applies a ValueTransformer to the output of a Codec, so
+// that values are transformed wherever the helper or its watchers use it.
+type transformingCodec struct {
+	runtime.Codec
+	transformer ValueTransformer
+}
+
+func (c transformingCodec) Encode(obj runtime.Object) ([]byte, error) {
+	data, err := c.Codec.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	return c.transformer.TransformToStorage(data)
+}
+
+func (c transformingCodec) Decode(data []byte) (runtime.Object, error) {
+	data, err := c.transformer.TransformFromStorage(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.Codec.Decode(data)
+}
+
+func (c transformingCodec) DecodeInto(data []byte, obj runtime.Object) error {
+	data, err := c.transformer.TransformFromStorage(data)
+	if err != nil {
+		return err
+	}
+	return c.Codec.DecodeInto(data, obj)
+}
+
+// storageCodec returns the codec used for values stored in etcd.
+func (h *EtcdHelper) storageCodec() runtime.Codec {
+	if h.Transformer == nil {
+		return h.Codec
+	}
+	return transformingCodec{h.Codec, h.Transformer}
+}
+
+// RewriteList reads every value under key and writes it back through the
+// helper's Transformer, without decoding it into an object.  After the
+// Transformer's key has been rotated this re-encrypts existing data with the
+// current key.  Values changed concurrently are left alone, since they were
+// already written with the current Transformer.  Returns the number of values
+// rewritten.
+func (h *EtcdHelper) RewriteList(key string) (int, error) {
+	if h.Transformer == nil {
+		return 0, nil
+	}
+	nodes, _, err := h.listEtcdNode(key)
+	if err != nil {
+		return 0, err
+	}
+	return h.rewriteNodes(nodes)
+}
+
+func (h *EtcdHelper) rewriteNodes(nodes []*etcd.Node) (int, error) {
+	count := 0
+	for _, node := range nodes {
+		if node.Dir {
+			n, err := h.rewriteNodes(node.Nodes)
+			count += n
+			if err != nil {
+				return count, err
+			}
+			continue
+		}
+		data, err := h.Transformer.TransformFromStorage([]byte(node.Value))
+		if err != nil {
+			return count, err
+		}
+		data, err = h.Transformer.TransformToStorage(data)
+		if err != nil {
+			return count, err
+		}
+		var ttl uint64
+		if node.TTL > 0 {
+			ttl = uint64(node.TTL)
+		}
+		if _, err := h.Client.CompareAndSwap(node.Key, string(data), ttl, node.Value, node.ModifiedIndex); err != nil {
+			if IsEtcdTestFailed(err) || IsEtcdNotFound(err) {
+				continue
+			}
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
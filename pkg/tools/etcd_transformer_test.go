@@ -0,0 +1,143 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tools
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/coreos/go-etcd/etcd"
+)
+
+// fakeTransformer base64 encodes values behind a prefix which changes on every
+// write, like an encrypting transformer with a random nonce.
+type fakeTransformer struct {
+	writes int
+}
+
+func (t *fakeTransformer) TransformToStorage(data []byte) ([]byte, error) {
+	t.writes++
+	return []byte(fmt.Sprintf("fake:%d:%s", t.writes, base64.StdEncoding.EncodeToString(data))), nil
+}
+
+func (t *fakeTransformer) TransformFromStorage(data []byte) ([]byte, error) {
+	parts := strings.SplitN(string(data), ":", 3)
+	if len(parts) != 3 || parts[0] != "fake" {
+		return nil, errors.New("value was not transformed")
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+func TestTransformerCreateAndExtract(t *testing.T) {
+	fakeClient := NewFakeEtcdClient(t)
+	helper := NewEtcdHelper(fakeClient, codec)
+	helper.Transformer = &fakeTransformer{}
+
+	obj := &TestResource{ObjectMeta: api.ObjectMeta{Name: "foo"}, Value: 1}
+	if err := helper.CreateObj("/some/key", obj, nil, 0); err != nil {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+	stored := fakeClient.Data["/some/key"].R.Node.Value
+	if !strings.HasPrefix(stored, "fake:1:") {
+		t.Errorf("Expected a transformed value, got %s", stored)
+	}
+
+	out := &TestResource{}
+	if err := helper.ExtractObj("/some/key", out, false); err != nil {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+	if out.Name != "foo" || out.Value != 1 {
+		t.Errorf("Unexpected object %#v", out)
+	}
+}
+
+func TestTransformerAtomicUpdateNoChange(t *testing.T) {
+	fakeClient := NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	helper := NewEtcdHelper(fakeClient, codec)
+	helper.Transformer = &fakeTransformer{}
+
+	fakeClient.ExpectNotFoundGet("/some/key")
+	obj := &TestResource{ObjectMeta: api.ObjectMeta{Name: "foo"}, Value: 1}
+	err := helper.AtomicUpdate("/some/key", &TestResource{}, true, func(in runtime.Object) (runtime.Object, uint64, error) {
+		return obj, 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+	stored := fakeClient.Data["/some/key"].R.Node.Value
+
+	objUpdate := &TestResource{ObjectMeta: api.ObjectMeta{Name: "foo"}, Value: 1}
+	err = helper.AtomicUpdate("/some/key", &TestResource{}, true, func(in runtime.Object) (runtime.Object, uint64, error) {
+		return objUpdate, 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+	if e, a := stored, fakeClient.Data["/some/key"].R.Node.Value; e != a {
+		t.Errorf("Expected unchanged object not to be written, got %s", a)
+	}
+}
+
+func TestRewriteList(t *testing.T) {
+	fakeClient := NewFakeEtcdClient(t)
+	fakeClient.TestIndex = true
+	transformer := &fakeTransformer{}
+	helper := NewEtcdHelper(fakeClient, codec)
+	helper.Transformer = transformer
+
+	nodes := []*etcd.Node{}
+	for _, key := range []string{"/some/foo", "/some/bar"} {
+		value, _ := transformer.TransformToStorage([]byte(`{"kind":"TestResource","apiVersion":"v1beta1"}`))
+		node := &etcd.Node{Key: key, Value: string(value), ModifiedIndex: 1}
+		fakeClient.Data[key] = EtcdResponseWithError{R: &etcd.Response{Node: node}}
+		nodes = append(nodes, node)
+	}
+	fakeClient.Data["/some"] = EtcdResponseWithError{
+		R: &etcd.Response{Node: &etcd.Node{Dir: true, Nodes: nodes}},
+	}
+
+	count, err := helper.RewriteList("/some")
+	if err != nil {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 values to be rewritten, got %d", count)
+	}
+	for _, key := range []string{"/some/foo", "/some/bar"} {
+		value := fakeClient.Data[key].R.Node.Value
+		if strings.HasPrefix(value, "fake:1:") || strings.HasPrefix(value, "fake:2:") {
+			t.Errorf("Expected %s to be rewritten, got %s", key, value)
+		}
+		if _, err := helper.Codec.Decode(mustTransformFromStorage(t, transformer, value)); err != nil {
+			t.Errorf("Unexpected error decoding %s: %v", key, err)
+		}
+	}
+}
+
+func mustTransformFromStorage(t *testing.T, transformer ValueTransformer, value string) []byte {
+	data, err := transformer.TransformFromStorage([]byte(value))
+	if err != nil {
+		t.Fatalf("Unexpected error %#v", err)
+	}
+	return data
+}
@@ -75,3 +75,12 @@ type EtcdVersioner interface {
 	// Should return an error if the specified object does not have a persistable version.
 	ObjectResourceVersion(obj runtime.Object) (uint64, error)
 }
+
+// ValueTransformer converts values between their serialized form and the form
+// stored in etcd, for example by encrypting them.
+type ValueTransformer interface {
+	// TransformToStorage returns the value to store in etcd for data.
+	TransformToStorage(data []byte) ([]byte, error)
+	// TransformFromStorage returns the serialized value that was stored as data.
+	TransformFromStorage(data []byte) ([]byte, error)
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption implements envelope encryption of values stored in etcd.
+//
+// Each value is encrypted with AES-GCM under a freshly generated data key.
+// The data key is in turn encrypted with a named key from a key file and
+// stored alongside the value.  Decryption accepts any key in the file, so keys
+// can be rotated by adding a new key at the top of the file, restarting the
+// apiservers and re-encrypting the existing data.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// prefix marks values written by an AESGCMTransformer.  Values without it are
+// returned unchanged, so that data stored before encryption was enabled can
+// still be read.
+const prefix = "k8s:enc:aesgcm:v1:"
+
+// dataKeySize is the size of the generated data keys; 32 bytes selects AES-256.
+const dataKeySize = 32
+
+// AESGCMTransformer encrypts values with a data key which is itself encrypted
+// with a named key encryption key.  It implements tools.ValueTransformer.
+type AESGCMTransformer struct {
+	// primary is the name of the key used to encrypt new values.
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewAESGCMTransformer returns a transformer which encrypts with the first key
+// in names and can decrypt with any of them.  Keys must be 16, 24 or 32 bytes
+// long.
+func NewAESGCMTransformer(names []string, keys map[string][]byte) (*AESGCMTransformer, error) {
+	if len(names) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+	t := &AESGCMTransformer{primary: names[0], keys: map[string]cipher.AEAD{}}
+	for _, name := range names {
+		if len(name) == 0 || strings.Contains(name, ":") {
+			return nil, fmt.Errorf("invalid key name %q", name)
+		}
+		if _, exists := t.keys[name]; exists {
+			return nil, fmt.Errorf("duplicate key name %q", name)
+		}
+		key, ok := keys[name]
+		if !ok {
+			return nil, fmt.Errorf("no key named %q", name)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", name, err)
+		}
+		t.keys[name] = aead
+	}
+	return t, nil
+}
+
+// NewAESGCMTransformerFromFile reads keys from a file with one key per line,
+// in the form:
+//
+//	name,base64-encoded key
+//
+// The first key is used to encrypt new values.
+func NewAESGCMTransformerFromFile(path string) (*AESGCMTransformer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	names := []string{}
+	keys := map[string][]byte{}
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) != 2 {
+			return nil, fmt.Errorf("%s: expected lines of the form name,key", path)
+		}
+		name := strings.TrimSpace(record[0])
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: key %q is not valid base64: %v", path, name, err)
+		}
+		names = append(names, name)
+		keys[name] = key
+	}
+	return NewAESGCMTransformer(names, keys)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts data with aead under a random nonce, and returns the nonce
+// followed by the ciphertext.
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// open reverses seal.
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted value is too short")
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+// TransformToStorage encrypts data.  The result has the form
+//
+//	k8s:enc:aesgcm:v1:<key name>:<base64 of encrypted data key length, encrypted data key, encrypted data>
+func (t *AESGCMTransformer) TransformToStorage(data []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	sealedKey, err := seal(t.keys[t.primary], dataKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealedData, err := seal(aead, data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 0, 1+len(sealedKey)+len(sealedData))
+	payload = append(payload, byte(len(sealedKey)))
+	payload = append(payload, sealedKey...)
+	payload = append(payload, sealedData...)
+
+	out := bytes.NewBufferString(prefix)
+	out.WriteString(t.primary)
+	out.WriteByte(':')
+	out.WriteString(base64.StdEncoding.EncodeToString(payload))
+	return out.Bytes(), nil
+}
+
+// TransformFromStorage decrypts data written by TransformToStorage with any
+// known key.  Unencrypted data is returned unchanged.
+func (t *AESGCMTransformer) TransformFromStorage(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(prefix)) {
+		return data, nil
+	}
+	parts := strings.SplitN(string(data[len(prefix):]), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("encrypted value has no key name")
+	}
+	keyAEAD, ok := t.keys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("value was encrypted with unknown key %q", parts[0])
+	}
+	payload, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("encrypted value is not valid base64: %v", err)
+	}
+	if len(payload) < 1 || len(payload) < 1+int(payload[0]) {
+		return nil, errors.New("encrypted value is too short")
+	}
+	sealedKey, sealedData := payload[1:1+int(payload[0])], payload[1+int(payload[0]):]
+	dataKey, err := open(keyAEAD, sealedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt data key with key %q: %v", parts[0], err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := open(aead, sealedData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt value: %v", err)
+	}
+	return plain, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+var (
+	oldKey = bytes.Repeat([]byte{1}, 32)
+	newKey = bytes.Repeat([]byte{2}, 16)
+)
+
+func TestRoundTrip(t *testing.T) {
+	transformer, err := NewAESGCMTransformer([]string{"old"}, map[string][]byte{"old": oldKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plain := []byte(`{"kind":"Secret","data":{"password":"c2VjcmV0"}}`)
+	stored, err := transformer.TransformToStorage(plain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(stored), "k8s:enc:aesgcm:v1:old:") {
+		t.Errorf("unexpected stored value %q", stored)
+	}
+	if bytes.Contains(stored, []byte("c2VjcmV0")) {
+		t.Errorf("stored value contains the plaintext: %q", stored)
+	}
+	again, err := transformer.TransformToStorage(plain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Equal(stored, again) {
+		t.Errorf("expected a different value each time data is encrypted")
+	}
+
+	out, err := transformer.TransformFromStorage(stored)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(plain, out) {
+		t.Errorf("expected %q, got %q", plain, out)
+	}
+}
+
+func TestUnencryptedPassesThrough(t *testing.T) {
+	transformer, _ := NewAESGCMTransformer([]string{"old"}, map[string][]byte{"old": oldKey})
+	plain := []byte(`{"kind":"Secret"}`)
+	out, err := transformer.TransformFromStorage(plain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(plain, out) {
+		t.Errorf("expected %q, got %q", plain, out)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	before, _ := NewAESGCMTransformer([]string{"old"}, map[string][]byte{"old": oldKey})
+	stored, err := before.TransformToStorage([]byte("value"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	after, err := NewAESGCMTransformer([]string{"new", "old"}, map[string][]byte{"old": oldKey, "new": newKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out, err := after.TransformFromStorage(stored); err != nil || string(out) != "value" {
+		t.Errorf("expected the old key to still decrypt, got %q %v", out, err)
+	}
+	rewritten, err := after.TransformToStorage([]byte("value"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(rewritten), "k8s:enc:aesgcm:v1:new:") {
+		t.Errorf("expected new values to use the new key, got %q", rewritten)
+	}
+
+	removed, _ := NewAESGCMTransformer([]string{"new"}, map[string][]byte{"new": newKey})
+	if _, err := removed.TransformFromStorage(stored); err == nil {
+		t.Errorf("expected an error decrypting with a removed key")
+	}
+	if out, err := removed.TransformFromStorage(rewritten); err != nil || string(out) != "value" {
+		t.Errorf("expected re-encrypted value to decrypt, got %q %v", out, err)
+	}
+}
+
+func TestTamperedValue(t *testing.T) {
+	transformer, _ := NewAESGCMTransformer([]string{"old"}, map[string][]byte{"old": oldKey})
+	stored, _ := transformer.TransformToStorage([]byte("value"))
+	payload, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(string(stored), "k8s:enc:aesgcm:v1:old:"))
+	payload[len(payload)-1] ^= 0xff
+	tampered := "k8s:enc:aesgcm:v1:old:" + base64.StdEncoding.EncodeToString(payload)
+	if _, err := transformer.TransformFromStorage([]byte(tampered)); err == nil {
+		t.Errorf("expected an error decrypting a tampered value")
+	}
+	if _, err := transformer.TransformFromStorage([]byte("k8s:enc:aesgcm:v1:old")); err == nil {
+		t.Errorf("expected an error decrypting a value without a key name")
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	testCases := map[string]struct {
+		names []string
+		keys  map[string][]byte
+	}{
+		"no keys":      {names: []string{}},
+		"missing key":  {names: []string{"a"}, keys: map[string][]byte{}},
+		"bad length":   {names: []string{"a"}, keys: map[string][]byte{"a": []byte("short")}},
+		"colon in key": {names: []string{"a:b"}, keys: map[string][]byte{"a:b": oldKey}},
+		"duplicate":    {names: []string{"a", "a"}, keys: map[string][]byte{"a": oldKey}},
+	}
+	for k, testCase := range testCases {
+		if _, err := NewAESGCMTransformer(testCase.names, testCase.keys); err == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+	}
+}
+
+func TestNewAESGCMTransformerFromFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "encryption_keys")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("# newest key first\n")
+	f.WriteString("new," + base64.StdEncoding.EncodeToString(newKey) + "\n")
+	f.WriteString("old," + base64.StdEncoding.EncodeToString(oldKey) + "\n")
+	f.Close()
+
+	transformer, err := NewAESGCMTransformerFromFile(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transformer.primary != "new" || len(transformer.keys) != 2 {
+		t.Errorf("unexpected transformer %#v", transformer)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iptables
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FakeIPTables implements iptables.Interface for tests by keeping every
+// table in memory.  Rules are stored per chain, in the order they were
+// appended, as the space-joined argument list.
+type FakeIPTables struct {
+	mu     sync.Mutex
+	Ipv6   bool
+	Tables map[Table]map[Chain][]string
+}
+
+// NewFake returns a FakeIPTables with the built-in nat chains already present.
+func NewFake() *FakeIPTables {
+	return &FakeIPTables{
+		Tables: map[Table]map[Chain][]string{
+			TableNAT: {
+				ChainPrerouting:  {},
+				ChainOutput:      {},
+				ChainPostrouting: {},
+			},
+		},
+	}
+}
+
+func (f *FakeIPTables) table(table Table) map[Chain][]string {
+	t, found := f.Tables[table]
+	if !found {
+		t = map[Chain][]string{}
+		f.Tables[table] = t
+	}
+	return t
+}
+
+// EnsureChain is part of Interface.
+func (f *FakeIPTables) EnsureChain(table Table, chain Chain) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.table(table)
+	if _, found := t[chain]; found {
+		return true, nil
+	}
+	t[chain] = []string{}
+	return false, nil
+}
+
+// FlushChain is part of Interface.
+func (f *FakeIPTables) FlushChain(table Table, chain Chain) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.table(table)
+	if _, found := t[chain]; !found {
+		return fmt.Errorf("error flushing chain %q: no such chain", chain)
+	}
+	t[chain] = []string{}
+	return nil
+}
+
+// DeleteChain is part of Interface.  Like iptables, it refuses to delete a
+// chain that still holds rules.
+func (f *FakeIPTables) DeleteChain(table Table, chain Chain) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.table(table)
+	rules, found := t[chain]
+	if !found {
+		return fmt.Errorf("error deleting chain %q: no such chain", chain)
+	}
+	if len(rules) != 0 {
+		return fmt.Errorf("error deleting chain %q: chain is not empty", chain)
+	}
+	delete(t, chain)
+	return nil
+}
+
+// ListChains is part of Interface.
+func (f *FakeIPTables) ListChains(table Table) ([]Chain, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	chains := []Chain{}
+	for chain := range f.table(table) {
+		switch chain {
+		case ChainPrerouting, ChainOutput, ChainPostrouting:
+			continue
+		}
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
+
+// EnsureRule is part of Interface.
+func (f *FakeIPTables) EnsureRule(table Table, chain Chain, args ...string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.table(table)
+	rules, found := t[chain]
+	if !found {
+		return false, fmt.Errorf("error appending rule: no chain %q", chain)
+	}
+	rule := strings.Join(args, " ")
+	for _, r := range rules {
+		if r == rule {
+			return true, nil
+		}
+	}
+	t[chain] = append(rules, rule)
+	return false, nil
+}
+
+// DeleteRule is part of Interface.
+func (f *FakeIPTables) DeleteRule(table Table, chain Chain, args ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.table(table)
+	rule := strings.Join(args, " ")
+	rules := t[chain]
+	for i, r := range rules {
+		if r == rule {
+			t[chain] = append(rules[:i], rules[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// IsIpv6 is part of Interface.
+func (f *FakeIPTables) IsIpv6() bool {
+	return f.Ipv6
+}
+
+// Rules returns a copy of the rules currently in the specified chain, and
+// whether the chain exists.
+func (f *FakeIPTables) Rules(table Table, chain Chain) ([]string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rules, found := f.Tables[table][chain]
+	if !found {
+		return nil, false
+	}
+	return append([]string{}, rules...), true
+}
@@ -36,6 +36,8 @@ type Interface interface {
 	FlushChain(table Table, chain Chain) error
 	// DeleteChain deletes the specified chain.  If the chain did not exist, return error.
 	DeleteChain(table Table, chain Chain) error
+	// ListChains returns the user-defined chains in the specified table.
+	ListChains(table Table) ([]Chain, error)
 	// EnsureRule checks if the specified rule is present and, if not, creates it.  If the rule existed, return true.
 	EnsureRule(table Table, chain Chain, args ...string) (bool, error)
 	// DeleteRule checks if the specified rule is present and, if so, deletes it.
@@ -125,6 +127,27 @@ func (runner *runner) DeleteChain(table Table, chain Chain) error {
 	return nil
 }
 
+// ListChains is part of Interface.
+func (runner *runner) ListChains(table Table) ([]Chain, error) {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+
+	out, err := runner.run(opListRules, []string{"-t", string(table)})
+	if err != nil {
+		return nil, fmt.Errorf("error listing chains in table %q: %v: %s", table, err, out)
+	}
+	// Every user-defined chain is printed as "-N <chain>", built-in chains
+	// only show up in "-P" policy lines.
+	chains := []Chain{}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == string(opCreateChain) {
+			chains = append(chains, Chain(fields[1]))
+		}
+	}
+	return chains, nil
+}
+
 // EnsureRule is part of Interface.
 func (runner *runner) EnsureRule(table Table, chain Chain, args ...string) (bool, error) {
 	fullArgs := makeFullArgs(table, chain, args...)
@@ -278,6 +301,7 @@ const (
 	opAppendRule  operation = "-A"
 	opCheckRule   operation = "-C"
 	opDeleteRule  operation = "-D"
+	opListRules   operation = "-S"
 )
 
 func makeFullArgs(table Table, chain Chain, args ...string) []string {
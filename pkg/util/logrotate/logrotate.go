@@ -0,0 +1,124 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logrotate provides a file writer that rotates the file once it
+// grows past a maximum size.
+package logrotate
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// File is an io.WriteCloser that appends to the file at Path.  Before a write
+// would grow the file past MaxSize bytes, the file is renamed to Path.1 (and
+// any existing Path.N to Path.N+1) and a new file is started.  At most
+// MaxBackups rotated files are kept.  It is safe for concurrent use.
+type File struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+// New opens, or creates, the file at path for appending.  A maxSize of 0
+// disables rotation.
+func New(path string, maxSize int64, maxBackups int) (*File, error) {
+	f := &File{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write implements io.Writer.  A single write is never split across files.  If
+// the file cannot be rotated, p is still appended to the current file and the
+// rotation error is returned.
+func (f *File) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return 0, fmt.Errorf("%s is closed", f.path)
+	}
+	var rotateErr error
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		rotateErr = f.rotate()
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err == nil && rotateErr != nil {
+		err = fmt.Errorf("unable to rotate %s: %v", f.path, rotateErr)
+	}
+	return n, err
+}
+
+// rotate shifts the backups, moves the current file to the first backup and
+// starts a new file.  The current file is only closed once the new one is
+// open, so a failed rotation leaves it open for writing.
+func (f *File) rotate() error {
+	if f.maxBackups > 0 {
+		for i := f.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(f.backupName(i), f.backupName(i+1)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(f.path, f.backupName(1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	} else if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	current := f.file
+	if err := f.open(); err != nil {
+		return err
+	}
+	return current.Close()
+}
+
+func (f *File) backupName(n int) string {
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
+
+// Close implements io.Closer.
+func (f *File) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
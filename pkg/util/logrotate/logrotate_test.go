@@ -0,0 +1,137 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logrotate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotate_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	f, err := New(path, 10, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		path:        "gggg\n",
+		path + ".1": "eeee\nffff\n",
+		path + ".2": "cccc\ndddd\n",
+	}
+	for name, contents := range expected {
+		if actual := readFile(t, name); actual != contents {
+			t.Errorf("%s: expected %q, got %q", name, contents, actual)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 backups, got %v", err)
+	}
+}
+
+func TestAppendsToExistingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotate_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	if err := ioutil.WriteFile(path, []byte("old\n"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := New(path, 8, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.Write([]byte("new\n"))
+	f.Write([]byte("newer\n"))
+	f.Close()
+
+	if actual := readFile(t, path+".1"); actual != "old\nnew\n" {
+		t.Errorf("unexpected backup contents %q", actual)
+	}
+	if actual := readFile(t, path); actual != "newer\n" {
+		t.Errorf("unexpected contents %q", actual)
+	}
+	if _, err := f.Write([]byte("closed\n")); err == nil {
+		t.Errorf("expected an error writing to a closed file")
+	}
+}
+
+func TestWritesContinueWhenRotationFails(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotate_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	// The current file cannot be renamed over a directory.
+	if err := os.Mkdir(path+".1", 0700); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := New(path, 8, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte("aaaa\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := f.Write([]byte("bbbb\n")); err == nil || n != 5 {
+		t.Errorf("expected the write to succeed with a rotation error, got %d, %v", n, err)
+	}
+
+	// Once the backup can be written, rotation resumes.
+	if err := os.Remove(path + ".1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.Write([]byte("cccc\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := readFile(t, path+".1"); actual != "aaaa\nbbbb\n" {
+		t.Errorf("unexpected backup contents %q", actual)
+	}
+	if actual := readFile(t, path); actual != "cccc\n" {
+		t.Errorf("unexpected contents %q", actual)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+func TestPortRange(t *testing.T) {
+	testCases := []struct {
+		input    string
+		success  bool
+		expected string
+		included int
+		excluded int
+	}{
+		{"100-200", true, "100-200", 200, 201},
+		{" 100-200 ", true, "100-200", 200, 201},
+		{"0-0", false, "", 0, 1},
+		{"1-1", true, "1-1", 1, 2},
+		{"30000-32767", true, "30000-32767", 30000, 32768},
+		{"", true, "", -1, 0},
+		{"200-100", false, "", -1, -1},
+		{"100", false, "", -1, -1},
+		{"1-65536", false, "", -1, -1},
+		{"abc-200", false, "", -1, -1},
+		{"100-abc", false, "", -1, -1},
+	}
+
+	for i := range testCases {
+		tc := &testCases[i]
+		pr := &PortRange{}
+		var f flag.Value = pr
+		err := f.Set(tc.input)
+		if err != nil && tc.success == true {
+			t.Errorf("%q: expected success, got %q", tc.input, err)
+			continue
+		} else if err == nil && tc.success == false {
+			t.Errorf("%q: expected failure", tc.input)
+			continue
+		} else if tc.success {
+			if f.String() != tc.expected {
+				t.Errorf("%q: expected %q, got %q", tc.input, tc.expected, f.String())
+			}
+			if tc.included >= 0 && !pr.Contains(tc.included) {
+				t.Errorf("%q: expected %v to be included", tc.input, tc.included)
+			}
+			if tc.excluded >= 0 && pr.Contains(tc.excluded) {
+				t.Errorf("%q: expected %v to be excluded", tc.input, tc.excluded)
+			}
+		}
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	pr, err := ParsePortRange("30000-30002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr.Base != 30000 || pr.Size != 3 {
+		t.Errorf("unexpected port range: %#v", pr)
+	}
+	if _, err := ParsePortRange("30002-30000"); err == nil {
+		t.Errorf("expected error for inverted range")
+	}
+}
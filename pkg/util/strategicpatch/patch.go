@@ -0,0 +1,249 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package strategicpatch applies strategic merge patches, which are JSON merge
+// patches (RFC 7386) that merge lists of objects by a key instead of
+// replacing them.
+package strategicpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// directiveMarker is the key of a directive in a patch map.
+	directiveMarker = "$patch"
+	// replaceDirective replaces the original map with the patch map, instead
+	// of merging them.
+	replaceDirective = "replace"
+	// deleteDirective, in an element of a list that is merged by key, deletes
+	// the element of the original list with the same key.
+	deleteDirective = "delete"
+
+	mergeStrategy = "merge"
+)
+
+// StrategicMergePatch applies patch to the JSON document original, and returns
+// the patched document.  dataStruct is an instance of the type original
+// encodes; its struct tags describe how lists are patched.  Lists of fields
+// tagged with `patchStrategy:"merge" patchMergeKey:"<key>"` are merged: the
+// elements of the patch replace or are merged into the elements of the
+// original with the same value of the key field, and the rest are appended.
+// Other lists are replaced, and maps are merged as in a JSON merge patch.
+func StrategicMergePatch(original, patch []byte, dataStruct interface{}) ([]byte, error) {
+	originalMap := map[string]interface{}{}
+	if err := json.Unmarshal(original, &originalMap); err != nil {
+		return nil, fmt.Errorf("unable to parse the original object: %v", err)
+	}
+	patchMap := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &patchMap); err != nil {
+		return nil, fmt.Errorf("unable to parse the patch: %v", err)
+	}
+	result, err := mergeMap(originalMap, patchMap, reflect.TypeOf(dataStruct))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// mergeMap merges patch into original, whose values have the types of the
+// fields of t.  t may be nil if the type is unknown.
+func mergeMap(original, patch map[string]interface{}, t reflect.Type) (map[string]interface{}, error) {
+	if directive, ok := patch[directiveMarker]; ok {
+		if directive != replaceDirective {
+			return nil, fmt.Errorf("unknown patch directive %v", directive)
+		}
+		replaced := map[string]interface{}{}
+		for k, v := range patch {
+			if k != directiveMarker {
+				replaced[k] = v
+			}
+		}
+		return replaced, nil
+	}
+	if original == nil {
+		original = map[string]interface{}{}
+	}
+
+	for k, patchValue := range patch {
+		if patchValue == nil {
+			delete(original, k)
+			continue
+		}
+		originalValue, ok := original[k]
+		if !ok {
+			original[k] = removeDirectives(patchValue)
+			continue
+		}
+		fieldType, strategy, mergeKey := fieldPatchMetadata(t, k)
+		switch patchTyped := patchValue.(type) {
+		case map[string]interface{}:
+			originalTyped, ok := originalValue.(map[string]interface{})
+			if !ok {
+				original[k] = removeDirectives(patchValue)
+				continue
+			}
+			merged, err := mergeMap(originalTyped, patchTyped, fieldType)
+			if err != nil {
+				return nil, err
+			}
+			original[k] = merged
+		case []interface{}:
+			originalTyped, ok := originalValue.([]interface{})
+			if !ok || strategy != mergeStrategy {
+				original[k] = removeDirectives(patchValue)
+				continue
+			}
+			merged, err := mergeSlice(originalTyped, patchTyped, fieldType, mergeKey)
+			if err != nil {
+				return nil, fmt.Errorf("unable to merge %q: %v", k, err)
+			}
+			original[k] = merged
+		default:
+			original[k] = patchValue
+		}
+	}
+	return original, nil
+}
+
+// mergeSlice merges the elements of patch into the elements of original with
+// the same value of mergeKey.  Elements that are not objects are added to
+// original if it does not contain them yet.
+func mergeSlice(original, patch []interface{}, elemType reflect.Type, mergeKey string) ([]interface{}, error) {
+	for _, patchElem := range patch {
+		patchMap, ok := patchElem.(map[string]interface{})
+		if !ok {
+			if !containsValue(original, patchElem) {
+				original = append(original, patchElem)
+			}
+			continue
+		}
+		if len(mergeKey) == 0 {
+			return nil, fmt.Errorf("no merge key is defined for a list of objects")
+		}
+		keyValue, ok := patchMap[mergeKey]
+		if !ok {
+			return nil, fmt.Errorf("an element of the patch has no value for the merge key %q", mergeKey)
+		}
+		i := indexOfKey(original, mergeKey, keyValue)
+		if directive, ok := patchMap[directiveMarker]; ok && directive == deleteDirective {
+			if i != -1 {
+				original = append(original[:i], original[i+1:]...)
+			}
+			continue
+		}
+		if i == -1 {
+			original = append(original, removeDirectives(patchMap))
+			continue
+		}
+		originalMap, _ := original[i].(map[string]interface{})
+		merged, err := mergeMap(originalMap, patchMap, elemType)
+		if err != nil {
+			return nil, err
+		}
+		original[i] = merged
+	}
+	return original, nil
+}
+
+func indexOfKey(list []interface{}, key string, value interface{}) int {
+	for i, elem := range list {
+		if m, ok := elem.(map[string]interface{}); ok && reflect.DeepEqual(m[key], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, elem := range list {
+		if reflect.DeepEqual(elem, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeDirectives returns value without the patch directives and the nulls
+// of its maps, for values of the patch that have no counterpart in the
+// original.
+func removeDirectives(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, v := range typed {
+			if k == directiveMarker || v == nil {
+				continue
+			}
+			out[k] = removeDirectives(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(typed))
+		for _, v := range typed {
+			if m, ok := v.(map[string]interface{}); ok && m[directiveMarker] == deleteDirective {
+				continue
+			}
+			out = append(out, removeDirectives(v))
+		}
+		return out
+	}
+	return value
+}
+
+// fieldPatchMetadata returns the type of the field of t that is serialized as
+// jsonField, with pointers, slices and maps dereferenced to their element
+// types, and the patch strategy and merge key of the field.  The type is nil
+// if t is nil or has no such field.
+func fieldPatchMetadata(t reflect.Type, jsonField string) (reflect.Type, string, string) {
+	t = elemType(t)
+	if t == nil {
+		return nil, "", ""
+	}
+	if t.Kind() == reflect.Map {
+		return elemType(t.Elem()), "", ""
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, "", ""
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && len(name) == 0 {
+			if fieldType, strategy, mergeKey := fieldPatchMetadata(field.Type, jsonField); fieldType != nil {
+				return fieldType, strategy, mergeKey
+			}
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		if name == jsonField {
+			return elemType(field.Type), field.Tag.Get("patchStrategy"), field.Tag.Get("patchMergeKey")
+		}
+	}
+	return nil, "", ""
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategicpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/v1beta3"
+)
+
+type testStruct struct {
+	testMeta `json:",inline"`
+
+	Labels     map[string]string `json:"labels,omitempty"`
+	Containers []testContainer   `json:"containers" patchStrategy:"merge" patchMergeKey:"name"`
+	Args       []string          `json:"args,omitempty"`
+	Finalizers []string          `json:"finalizers,omitempty" patchStrategy:"merge"`
+	Nested     *testStruct       `json:"nested,omitempty"`
+}
+
+type testMeta struct {
+	Name string `json:"name"`
+}
+
+type testContainer struct {
+	Name  string    `json:"name"`
+	Image string    `json:"image,omitempty"`
+	Env   []testEnv `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	Ports []int     `json:"ports,omitempty"`
+}
+
+type testEnv struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func TestStrategicMergePatch(t *testing.T) {
+	testCases := map[string]struct {
+		original string
+		patch    string
+		expected string
+	}{
+		"merge maps": {
+			original: `{"name":"a","labels":{"x":"1","y":"2"}}`,
+			patch:    `{"labels":{"x":"3","y":null,"z":"4"}}`,
+			expected: `{"name":"a","labels":{"x":"3","z":"4"}}`,
+		},
+		"replace map": {
+			original: `{"labels":{"x":"1","y":"2"}}`,
+			patch:    `{"labels":{"$patch":"replace","z":"4"}}`,
+			expected: `{"labels":{"z":"4"}}`,
+		},
+		"delete field": {
+			original: `{"name":"a","args":["b"]}`,
+			patch:    `{"args":null}`,
+			expected: `{"name":"a"}`,
+		},
+		"merge list by key": {
+			original: `{"containers":[{"name":"a","image":"a:1"},{"name":"b","image":"b:1","ports":[80]}]}`,
+			patch:    `{"containers":[{"name":"b","image":"b:2"},{"name":"c","image":"c:1"}]}`,
+			expected: `{"containers":[{"name":"a","image":"a:1"},{"name":"b","image":"b:2","ports":[80]},{"name":"c","image":"c:1"}]}`,
+		},
+		"merge nested list by key": {
+			original: `{"containers":[{"name":"a","env":[{"name":"X","value":"1"},{"name":"Y","value":"2"}]}]}`,
+			patch:    `{"containers":[{"name":"a","env":[{"name":"Y","value":"3"}]}]}`,
+			expected: `{"containers":[{"name":"a","env":[{"name":"X","value":"1"},{"name":"Y","value":"3"}]}]}`,
+		},
+		"delete list element by key": {
+			original: `{"containers":[{"name":"a"},{"name":"b"}]}`,
+			patch:    `{"containers":[{"name":"a","$patch":"delete"},{"name":"c","$patch":"delete"}]}`,
+			expected: `{"containers":[{"name":"b"}]}`,
+		},
+		"replace list without strategy": {
+			original: `{"args":["a","b"],"containers":[{"name":"a","ports":[80,443]}]}`,
+			patch:    `{"args":["c"],"containers":[{"name":"a","ports":[8080]}]}`,
+			expected: `{"args":["c"],"containers":[{"name":"a","ports":[8080]}]}`,
+		},
+		"merge list of primitives": {
+			original: `{"finalizers":["a","b"]}`,
+			patch:    `{"finalizers":["b","c"]}`,
+			expected: `{"finalizers":["a","b","c"]}`,
+		},
+		"new list is added": {
+			original: `{"name":"a"}`,
+			patch:    `{"containers":[{"name":"a","$patch":"delete"},{"name":"b","env":[{"name":"X","value":null}]}]}`,
+			expected: `{"name":"a","containers":[{"name":"b","env":[{"name":"X"}]}]}`,
+		},
+		"pointer fields": {
+			original: `{"nested":{"containers":[{"name":"a","image":"a:1"}]}}`,
+			patch:    `{"nested":{"containers":[{"name":"b"}]}}`,
+			expected: `{"nested":{"containers":[{"name":"a","image":"a:1"},{"name":"b"}]}}`,
+		},
+		"unknown fields are merged as maps": {
+			original: `{"status":{"phase":"Running","list":[1]}}`,
+			patch:    `{"status":{"list":[2]}}`,
+			expected: `{"status":{"phase":"Running","list":[2]}}`,
+		},
+	}
+	for k, testCase := range testCases {
+		patched, err := StrategicMergePatch([]byte(testCase.original), []byte(testCase.patch), testStruct{})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		var actual, expected interface{}
+		if err := json.Unmarshal(patched, &actual); err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		if err := json.Unmarshal([]byte(testCase.expected), &expected); err != nil {
+			t.Fatalf("%s: invalid test case: %v", k, err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected %s, got %s", k, testCase.expected, string(patched))
+		}
+	}
+}
+
+func TestStrategicMergePatchErrors(t *testing.T) {
+	testCases := map[string]struct {
+		original string
+		patch    string
+	}{
+		"invalid original": {
+			original: `{`,
+			patch:    `{}`,
+		},
+		"invalid patch": {
+			original: `{}`,
+			patch:    `[]`,
+		},
+		"unknown directive": {
+			original: `{"labels":{}}`,
+			patch:    `{"labels":{"$patch":"unknown"}}`,
+		},
+		"missing merge key": {
+			original: `{"containers":[{"name":"a"}]}`,
+			patch:    `{"containers":[{"image":"b"}]}`,
+		},
+	}
+	for k, testCase := range testCases {
+		if _, err := StrategicMergePatch([]byte(testCase.original), []byte(testCase.patch), testStruct{}); err == nil {
+			t.Errorf("%s: expected error", k)
+		}
+	}
+}
+
+func TestStrategicMergePatchPod(t *testing.T) {
+	original := `{"kind":"Pod","apiVersion":"v1beta3","metadata":{"name":"foo"},"spec":{"containers":[{"name":"web","image":"nginx:1","ports":[{"containerPort":80}]},{"name":"log","image":"fluentd"}]}}`
+	patch := `{"spec":{"containers":[{"name":"web","image":"nginx:2","ports":[{"containerPort":443}]}]}}`
+	patched, err := StrategicMergePatch([]byte(original), []byte(patch), v1beta3.Pod{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := v1beta3.Pod{}
+	if err := json.Unmarshal(patched, &pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers := pod.Spec.Containers
+	if len(containers) != 2 || containers[0].Image != "nginx:2" || containers[1].Image != "fluentd" {
+		t.Errorf("expected containers to be merged by name: %s", string(patched))
+	}
+	if ports := containers[0].Ports; len(ports) != 2 || ports[0].ContainerPort != 80 || ports[1].ContainerPort != 443 {
+		t.Errorf("expected ports to be merged by container port: %s", string(patched))
+	}
+}
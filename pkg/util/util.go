@@ -180,6 +180,29 @@ func (intstr *IntOrString) Fuzz(c fuzz.Continue) {
 	}
 }
 
+// GetScaledValueFromIntOrPercent returns the int value of intstr. If intstr holds a
+// percentage ("N%"), it is applied to total and rounded up when roundUp is true,
+// or down otherwise.
+func GetScaledValueFromIntOrPercent(intstr *IntOrString, total int, roundUp bool) (int, error) {
+	switch intstr.Kind {
+	case IntstrInt:
+		return intstr.IntVal, nil
+	case IntstrString:
+		if !strings.HasSuffix(intstr.StrVal, "%") {
+			return 0, fmt.Errorf("invalid value %q: must be an int or a percentage", intstr.StrVal)
+		}
+		v, err := strconv.Atoi(strings.TrimSuffix(intstr.StrVal, "%"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid value for IntOrString: %v", err)
+		}
+		if roundUp {
+			return (v*total + 99) / 100, nil
+		}
+		return v * total / 100, nil
+	}
+	return 0, fmt.Errorf("invalid value for IntOrString: invalid kind %d", intstr.Kind)
+}
+
 // Takes a list of strings and compiles them into a list of regular expressions
 func CompileRegexps(regexpStrings []string) ([]*regexp.Regexp, error) {
 	regexps := []*regexp.Regexp{}
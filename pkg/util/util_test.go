@@ -224,6 +224,40 @@ func TestIntOrStringMarshalJSONUnmarshalYAML(t *testing.T) {
 	}
 }
 
+func TestGetScaledValueFromIntOrPercent(t *testing.T) {
+	tests := []struct {
+		input    IntOrString
+		total    int
+		roundUp  bool
+		expected int
+		expErr   bool
+	}{
+		{NewIntOrStringFromInt(7), 100, false, 7, false},
+		{NewIntOrStringFromString("10%"), 100, false, 10, false},
+		{NewIntOrStringFromString("25%"), 5, false, 1, false},
+		{NewIntOrStringFromString("25%"), 5, true, 2, false},
+		{NewIntOrStringFromString("100%"), 3, true, 3, false},
+		{NewIntOrStringFromString("10"), 100, false, 0, true},
+		{NewIntOrStringFromString("x%"), 100, false, 0, true},
+	}
+	for i, test := range tests {
+		value, err := GetScaledValueFromIntOrPercent(&test.input, test.total, test.roundUp)
+		if test.expErr {
+			if err == nil {
+				t.Errorf("case %d: expected an error for %v", i, test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if value != test.expected {
+			t.Errorf("case %d: expected %d, got %d", i, test.expected, value)
+		}
+	}
+}
+
 func TestStringDiff(t *testing.T) {
 	diff := StringDiff("aaabb", "aaacc")
 	expect := "aaa\n\nA: bb\n\nB: cc\n\n"
@@ -0,0 +1,87 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// Cache remembers values for a fixed time to live.  It is safe for
+// concurrent use.
+type Cache struct {
+	ttl   time.Duration
+	clock util.Clock
+
+	lock      sync.Mutex
+	items     map[string]cacheEntry
+	lastSweep time.Time
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewCache returns a cache whose entries expire after ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return newCache(ttl, util.RealClock{})
+}
+
+func newCache(ttl time.Duration, clock util.Clock) *Cache {
+	return &Cache{
+		ttl:       ttl,
+		clock:     clock,
+		items:     map[string]cacheEntry{},
+		lastSweep: clock.Now(),
+	}
+}
+
+// Get returns the value stored for key if it has not expired.
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.clock.Now().Before(entry.expires) {
+		delete(c.items, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores value for key, replacing any previous value.
+func (c *Cache) Set(key string, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.clock.Now()
+	c.items[key] = cacheEntry{value: value, expires: now.Add(c.ttl)}
+
+	// Entries that are never read again would otherwise be kept forever.
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, entry := range c.items {
+			if !now.Before(entry.expires) {
+				delete(c.items, k)
+			}
+		}
+		c.lastSweep = now
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements the plumbing shared by plugins that delegate
+// decisions to a remote HTTP service.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/clientcmd"
+)
+
+// GenericWebhook posts JSON encoded requests to a remote service and decodes
+// its JSON responses.
+type GenericWebhook struct {
+	url    string
+	client *http.Client
+}
+
+// defaultTimeout bounds each request made by NewGenericWebhook webhooks.
+const defaultTimeout = 30 * time.Second
+
+// NewGenericWebhook creates a webhook from a kubeconfig file.  The server of
+// the current context is the URL that requests are posted to; its
+// certificate authority and the credentials of the current user are used to
+// secure the connection.
+func NewGenericWebhook(kubeConfigFile string) (*GenericWebhook, error) {
+	return NewGenericWebhookWithTimeout(kubeConfigFile, defaultTimeout)
+}
+
+// NewGenericWebhookWithTimeout is like NewGenericWebhook, but requests fail
+// if the remote service has not answered within timeout.
+func NewGenericWebhookWithTimeout(kubeConfigFile string, timeout time.Duration) (*GenericWebhook, error) {
+	config, err := clientcmd.LoadFromFile(kubeConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	clientConfig, err := clientcmd.NewNonInteractiveClientConfig(*config, config.CurrentContext, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook config %s: %v", kubeConfigFile, err)
+	}
+	transport, err := client.TransportFor(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &GenericWebhook{
+		url:    clientConfig.Host,
+		client: &http.Client{Transport: transport, Timeout: timeout},
+	}, nil
+}
+
+// Post encodes request as JSON, posts it to the webhook and decodes the
+// response into result.  Any non-2xx response is returned as an error.
+func (w *GenericWebhook) Post(request, result interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s returned %d: %s", w.url, resp.StatusCode, string(data))
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+func newWithServer(t *testing.T, server *httptest.Server) *GenericWebhook {
+	f, err := ioutil.TempFile("", "webhook_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating kubeconfig: %v", err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	kubeConfig := fmt.Sprintf(`{
+  "current-context": "webhook",
+  "contexts": {"webhook": {"cluster": "webhook", "user": "apiserver"}},
+  "clusters": {"webhook": {"server": %q, "insecure-skip-tls-verify": true}},
+  "users": {"apiserver": {"token": "secret"}}
+}`, server.URL+"/review")
+	if err := ioutil.WriteFile(f.Name(), []byte(kubeConfig), 0600); err != nil {
+		t.Fatalf("unexpected error writing kubeconfig: %v", err)
+	}
+
+	webhook, err := NewGenericWebhook(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return webhook
+}
+
+type review struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer,omitempty"`
+}
+
+func TestPost(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "POST" || req.URL.Path != "/review" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		if auth := req.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("unexpected authorization header: %q", auth)
+		}
+		var r review
+		if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if r.Question == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		r.Answer = "42"
+		json.NewEncoder(w).Encode(r)
+	}))
+	defer server.Close()
+	webhook := newWithServer(t, server)
+
+	result := review{}
+	if err := webhook.Post(review{Question: "life"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Answer != "42" {
+		t.Errorf("unexpected result: %#v", result)
+	}
+
+	if err := webhook.Post(review{Question: "fail"}, &result); err == nil {
+		t.Errorf("expected an error for a failed webhook call")
+	}
+}
+
+func TestNewGenericWebhookMissingConfig(t *testing.T) {
+	if _, err := NewGenericWebhook("/does/not/exist"); err == nil {
+		t.Errorf("expected an error for a missing config file")
+	}
+}
+
+func TestCache(t *testing.T) {
+	clock := &util.FakeClock{Time: time.Now()}
+	cache := newCache(time.Minute, clock)
+
+	cache.Set("a", 1)
+	if value, ok := cache.Get("a"); !ok || value != 1 {
+		t.Errorf("expected cached value 1, got %v, %v", value, ok)
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("unexpected value for missing key")
+	}
+
+	clock.Time = clock.Time.Add(30 * time.Second)
+	cache.Set("b", 2)
+	if value, ok := cache.Get("a"); !ok || value != 1 {
+		t.Errorf("expected cached value 1 before expiry, got %v, %v", value, ok)
+	}
+
+	clock.Time = clock.Time.Add(time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected value to expire")
+	}
+	cache.Set("c", 3)
+	if len(cache.items) != 1 {
+		t.Errorf("expected expired entries to be swept, got %v", cache.items)
+	}
+}
@@ -194,3 +194,29 @@ func (pm *VolumePluginMgr) FindPersistentPluginByName(name string) (PersistentVo
 	}
 	return nil, fmt.Errorf("no persistent volume plugin matched")
 }
+
+// FindPersistentPluginBySpec looks for a persistent volume plugin that can
+// support a given volume specification.  If no plugin can support it or the
+// matching plugin is not persistent, return error.
+func (pm *VolumePluginMgr) FindPersistentPluginBySpec(spec *api.Volume) (PersistentVolumePlugin, error) {
+	volumePlugin, err := pm.FindPluginBySpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	if persistentVolumePlugin, ok := volumePlugin.(PersistentVolumePlugin); ok {
+		return persistentVolumePlugin, nil
+	}
+	return nil, fmt.Errorf("no persistent volume plugin matched")
+}
+
+// NewSpecFromPersistentVolume builds a volume specification from the source
+// of a PersistentVolume, so that it can be handed to the volume plugins.
+func NewSpecFromPersistentVolume(pv *api.PersistentVolume) *api.Volume {
+	return &api.Volume{
+		Name: pv.Name,
+		VolumeSource: api.VolumeSource{
+			GCEPersistentDisk: pv.Spec.GCEPersistentDisk,
+			HostPath:          pv.Spec.HostPath,
+		},
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaimbinder
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/fields"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/volume"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+	"github.com/golang/glog"
+)
+
+// PersistentVolumeClaimBinder periodically matches pending claims to
+// available volumes and keeps the phases of both in sync.
+type PersistentVolumeClaimBinder struct {
+	kubeClient      client.Interface
+	volumeStore     cache.Store
+	claimStore      cache.Store
+	volumeReflector *cache.Reflector
+	claimReflector  *cache.Reflector
+	pluginMgr       volume.VolumePluginMgr
+}
+
+// NewPersistentVolumeClaimBinder creates a new PersistentVolumeClaimBinder.
+// The plugins are used to determine the access modes a volume supports.
+func NewPersistentVolumeClaimBinder(kubeClient client.Interface, plugins []volume.VolumePlugin) (*PersistentVolumeClaimBinder, error) {
+	volumeStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	volumeReflector := cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return kubeClient.PersistentVolumes().List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return kubeClient.PersistentVolumes().Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.PersistentVolume{},
+		volumeStore,
+		0,
+	)
+	claimStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	claimReflector := cache.NewReflector(
+		&cache.ListWatch{
+			ListFunc: func() (runtime.Object, error) {
+				return kubeClient.PersistentVolumeClaims(api.NamespaceAll).List(labels.Everything(), fields.Everything())
+			},
+			WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+				return kubeClient.PersistentVolumeClaims(api.NamespaceAll).Watch(labels.Everything(), fields.Everything(), resourceVersion)
+			},
+		},
+		&api.PersistentVolumeClaim{},
+		claimStore,
+		0,
+	)
+	binder := &PersistentVolumeClaimBinder{
+		kubeClient:      kubeClient,
+		volumeStore:     volumeStore,
+		claimStore:      claimStore,
+		volumeReflector: volumeReflector,
+		claimReflector:  claimReflector,
+	}
+	if err := binder.pluginMgr.InitPlugins(plugins, nil); err != nil {
+		return nil, err
+	}
+	return binder, nil
+}
+
+// Run begins watching volumes and claims and syncing at the specified period interval
+func (b *PersistentVolumeClaimBinder) Run(period time.Duration) {
+	b.volumeReflector.Run()
+	b.claimReflector.Run()
+	go util.Forever(func() { b.synchronize() }, period)
+}
+
+// synchronize reconciles the phase of every volume with its claim reference
+// and then tries to bind every pending claim.
+func (b *PersistentVolumeClaimBinder) synchronize() {
+	claims := map[string]*api.PersistentVolumeClaim{}
+	for _, obj := range b.claimStore.List() {
+		claim := obj.(*api.PersistentVolumeClaim)
+		claims[claimKey(claim.Namespace, claim.Name)] = claim
+	}
+
+	// boundClaims maps a claim key to the volume that references it
+	boundClaims := map[string]*api.PersistentVolume{}
+	available := []*api.PersistentVolume{}
+	for _, obj := range b.volumeStore.List() {
+		pv := obj.(*api.PersistentVolume)
+		updated, err := b.syncVolume(pv, claims)
+		if err != nil {
+			glog.Errorf("Error synchronizing volume %s: %v", pv.Name, err)
+			continue
+		}
+		switch updated.Status.Phase {
+		case api.VolumeAvailable:
+			available = append(available, updated)
+		case api.VolumeBound:
+			boundClaims[claimKey(updated.Spec.ClaimRef.Namespace, updated.Spec.ClaimRef.Name)] = updated
+		}
+	}
+	sort.Sort(byCapacity(available))
+
+	for _, claim := range claims {
+		if claim.Status.Phase == api.ClaimBound {
+			continue
+		}
+		glog.V(4).Infof("Attempting to bind claim %s/%s", claim.Namespace, claim.Name)
+		pv, found := boundClaims[claimKey(claim.Namespace, claim.Name)]
+		if !found {
+			var err error
+			pv, available, err = b.bindClaim(claim, available)
+			if err != nil {
+				glog.Errorf("Error binding claim %s/%s: %v", claim.Namespace, claim.Name, err)
+				continue
+			}
+			if pv == nil {
+				glog.V(4).Infof("No volume matches claim %s/%s", claim.Namespace, claim.Name)
+				continue
+			}
+		}
+		if err := b.updateClaimStatus(claim, pv); err != nil {
+			glog.Errorf("Error updating status of claim %s/%s: %v", claim.Namespace, claim.Name, err)
+		}
+	}
+}
+
+// syncVolume sets the phase of a volume from its claim reference: volumes
+// without a claim are available, volumes whose claim still exists are bound
+// and volumes whose claim was deleted are released.  It returns the volume
+// as it is after the update.
+func (b *PersistentVolumeClaimBinder) syncVolume(pv *api.PersistentVolume, claims map[string]*api.PersistentVolumeClaim) (*api.PersistentVolume, error) {
+	phase := api.VolumeAvailable
+	if ref := pv.Spec.ClaimRef; ref != nil {
+		phase = api.VolumeBound
+		if pv.Status.Phase == api.VolumeReleased {
+			phase = api.VolumeReleased
+		} else if claim, found := claims[claimKey(ref.Namespace, ref.Name)]; !found || claim.UID != ref.UID {
+			deleted, err := b.claimDeleted(ref)
+			if err != nil {
+				return nil, err
+			}
+			if deleted {
+				phase = api.VolumeReleased
+			}
+		}
+	}
+	if pv.Status.Phase == phase {
+		return pv, nil
+	}
+	glog.V(2).Infof("Volume %s changes phase from %q to %q", pv.Name, pv.Status.Phase, phase)
+	newVolume := *pv
+	newVolume.Status.Phase = phase
+	return b.kubeClient.PersistentVolumes().UpdateStatus(&newVolume)
+}
+
+// claimDeleted asks the server whether the referenced claim is gone.  Releasing
+// a volume cannot be undone, so the claim store, which may not have finished
+// its initial list or may lag behind the watch, is not trusted for that.
+func (b *PersistentVolumeClaimBinder) claimDeleted(ref *api.ObjectReference) (bool, error) {
+	claim, err := b.kubeClient.PersistentVolumeClaims(ref.Namespace).Get(ref.Name)
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return claim.UID != ref.UID, nil
+}
+
+// bindClaim finds the smallest available volume that satisfies the claim,
+// records the claim on it and marks it bound.  It returns the bound volume,
+// or nil if no volume matches, and the remaining available volumes.
+func (b *PersistentVolumeClaimBinder) bindClaim(claim *api.PersistentVolumeClaim, available []*api.PersistentVolume) (*api.PersistentVolume, []*api.PersistentVolume, error) {
+	for i, pv := range available {
+		if !b.matches(pv, claim) {
+			continue
+		}
+		// the volume is taken for this pass whether or not the bind succeeds
+		available = append(available[:i:i], available[i+1:]...)
+
+		newVolume := *pv
+		newVolume.Spec.ClaimRef = &api.ObjectReference{
+			Kind:            "PersistentVolumeClaim",
+			Namespace:       claim.Namespace,
+			Name:            claim.Name,
+			UID:             claim.UID,
+			ResourceVersion: claim.ResourceVersion,
+		}
+		updated, err := b.kubeClient.PersistentVolumes().Update(&newVolume)
+		if err != nil {
+			return nil, available, err
+		}
+		updated.Status.Phase = api.VolumeBound
+		updated, err = b.kubeClient.PersistentVolumes().UpdateStatus(updated)
+		if err != nil {
+			return nil, available, err
+		}
+		glog.V(2).Infof("Bound volume %s to claim %s/%s", pv.Name, claim.Namespace, claim.Name)
+		return updated, available, nil
+	}
+	return nil, available, nil
+}
+
+// matches returns true if the volume is large enough for the claim and
+// supports all the access modes the claim asks for.
+func (b *PersistentVolumeClaimBinder) matches(pv *api.PersistentVolume, claim *api.PersistentVolumeClaim) bool {
+	requested := claim.Spec.Resources.Requests[api.ResourceStorage]
+	capacity := pv.Spec.Capacity[api.ResourceStorage]
+	if capacity.Value() < requested.Value() {
+		return false
+	}
+	supported := util.NewStringSet()
+	for _, mode := range b.accessModes(pv) {
+		supported.Insert(string(mode))
+	}
+	for _, mode := range claim.Spec.AccessModes {
+		if !supported.Has(string(mode)) {
+			return false
+		}
+	}
+	return true
+}
+
+// accessModes returns the access modes of the plugin backing the volume.
+func (b *PersistentVolumeClaimBinder) accessModes(pv *api.PersistentVolume) []api.AccessModeType {
+	plugin, err := b.pluginMgr.FindPersistentPluginBySpec(volume.NewSpecFromPersistentVolume(pv))
+	if err != nil {
+		glog.V(4).Infof("Unable to determine access modes of volume %s: %v", pv.Name, err)
+		return nil
+	}
+	return plugin.GetAccessModes()
+}
+
+// updateClaimStatus marks the claim bound to the given volume.
+func (b *PersistentVolumeClaimBinder) updateClaimStatus(claim *api.PersistentVolumeClaim, pv *api.PersistentVolume) error {
+	newClaim := *claim
+	newClaim.Status = api.PersistentVolumeClaimStatus{
+		Phase:       api.ClaimBound,
+		AccessModes: b.accessModes(pv),
+		Capacity:    pv.Spec.Capacity,
+		VolumeRef: &api.ObjectReference{
+			Kind:            "PersistentVolume",
+			Name:            pv.Name,
+			UID:             pv.UID,
+			ResourceVersion: pv.ResourceVersion,
+		},
+	}
+	_, err := b.kubeClient.PersistentVolumeClaims(claim.Namespace).UpdateStatus(&newClaim)
+	return err
+}
+
+func claimKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// byCapacity sorts volumes from the smallest to the largest storage capacity.
+type byCapacity []*api.PersistentVolume
+
+func (s byCapacity) Len() int      { return len(s) }
+func (s byCapacity) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byCapacity) Less(i, j int) bool {
+	left := s[i].Spec.Capacity[api.ResourceStorage]
+	right := s[j].Spec.Capacity[api.ResourceStorage]
+	return left.Value() < right.Value()
+}
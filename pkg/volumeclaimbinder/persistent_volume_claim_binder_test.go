@@ -0,0 +1,265 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaimbinder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/types"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/volume"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/volume/gce_pd"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/volume/host_path"
+)
+
+func newTestBinder(t *testing.T, kubeClient client.Interface, volumes []*api.PersistentVolume, claims []*api.PersistentVolumeClaim) *PersistentVolumeClaimBinder {
+	plugins := []volume.VolumePlugin{}
+	plugins = append(plugins, gce_pd.ProbeVolumePlugins()...)
+	plugins = append(plugins, host_path.ProbeVolumePlugins()...)
+	binder, err := NewPersistentVolumeClaimBinder(kubeClient, plugins)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, pv := range volumes {
+		binder.volumeStore.Add(pv)
+	}
+	for _, claim := range claims {
+		binder.claimStore.Add(claim)
+	}
+	return binder
+}
+
+func hostPathVolume(name, size string) *api.PersistentVolume {
+	return &api.PersistentVolume{
+		ObjectMeta: api.ObjectMeta{Name: name, UID: types.UID("uid-" + name), ResourceVersion: "1"},
+		Spec: api.PersistentVolumeSpec{
+			Capacity: api.ResourceList{api.ResourceStorage: resource.MustParse(size)},
+			PersistentVolumeSource: api.PersistentVolumeSource{
+				HostPath: &api.HostPathVolumeSource{Path: "/tmp/" + name},
+			},
+		},
+		Status: api.PersistentVolumeStatus{Phase: api.VolumeAvailable},
+	}
+}
+
+func gcePDVolume(name, size string) *api.PersistentVolume {
+	pv := hostPathVolume(name, size)
+	pv.Spec.HostPath = nil
+	pv.Spec.GCEPersistentDisk = &api.GCEPersistentDiskVolumeSource{PDName: name, FSType: "ext4"}
+	return pv
+}
+
+func pendingClaim(name, size string, modes ...api.AccessModeType) *api.PersistentVolumeClaim {
+	return &api.PersistentVolumeClaim{
+		ObjectMeta: api.ObjectMeta{Name: name, Namespace: api.NamespaceDefault, UID: types.UID("uid-" + name), ResourceVersion: "1"},
+		Spec: api.PersistentVolumeClaimSpec{
+			AccessModes: modes,
+			Resources: api.ResourceRequirements{
+				Requests: api.ResourceList{api.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+		Status: api.PersistentVolumeClaimStatus{Phase: api.ClaimPending},
+	}
+}
+
+func actionValues(fake *client.Fake, action string) []interface{} {
+	values := []interface{}{}
+	for _, a := range fake.Actions {
+		if a.Action == action {
+			values = append(values, a.Value)
+		}
+	}
+	return values
+}
+
+func TestBindSmallestMatchingVolume(t *testing.T) {
+	fake := &client.Fake{}
+	volumes := []*api.PersistentVolume{
+		hostPathVolume("large", "10Gi"),
+		hostPathVolume("small", "1Gi"),
+		hostPathVolume("medium", "5Gi"),
+		gcePDVolume("pd", "3Gi"),
+	}
+	claim := pendingClaim("claim", "2Gi", api.ReadWriteOnce)
+	newTestBinder(t, fake, volumes, []*api.PersistentVolumeClaim{claim}).synchronize()
+
+	updates := actionValues(fake, "update-persistentVolume")
+	if len(updates) != 1 {
+		t.Fatalf("expected one volume update, got %#v", fake.Actions)
+	}
+	bound := updates[0].(*api.PersistentVolume)
+	if bound.Name != "pd" {
+		t.Errorf("expected smallest matching volume pd to be bound, got %s", bound.Name)
+	}
+	if bound.Spec.ClaimRef == nil || bound.Spec.ClaimRef.Name != "claim" || bound.Spec.ClaimRef.UID != claim.UID {
+		t.Errorf("unexpected claim reference: %#v", bound.Spec.ClaimRef)
+	}
+	statuses := actionValues(fake, "update-status-persistentVolume")
+	if len(statuses) != 1 || statuses[0].(*api.PersistentVolume).Status.Phase != api.VolumeBound {
+		t.Errorf("expected volume to be marked bound, got %#v", statuses)
+	}
+
+	claims := actionValues(fake, "update-status-persistentVolumeClaim")
+	if len(claims) != 1 {
+		t.Fatalf("expected one claim status update, got %#v", fake.Actions)
+	}
+	status := claims[0].(*api.PersistentVolumeClaim).Status
+	if status.Phase != api.ClaimBound || status.VolumeRef == nil || status.VolumeRef.Name != "pd" {
+		t.Errorf("unexpected claim status: %#v", status)
+	}
+	if len(status.AccessModes) != 2 {
+		t.Errorf("expected access modes of the gce pd plugin, got %v", status.AccessModes)
+	}
+}
+
+func TestBindRespectsAccessModes(t *testing.T) {
+	fake := &client.Fake{}
+	volumes := []*api.PersistentVolume{
+		hostPathVolume("hostpath", "5Gi"),
+		gcePDVolume("pd", "10Gi"),
+	}
+	claim := pendingClaim("claim", "1Gi", api.ReadOnlyMany)
+	newTestBinder(t, fake, volumes, []*api.PersistentVolumeClaim{claim}).synchronize()
+
+	updates := actionValues(fake, "update-persistentVolume")
+	if len(updates) != 1 || updates[0].(*api.PersistentVolume).Name != "pd" {
+		t.Errorf("expected pd to be bound, got %#v", updates)
+	}
+}
+
+func TestNoMatchingVolume(t *testing.T) {
+	fake := &client.Fake{}
+	volumes := []*api.PersistentVolume{hostPathVolume("small", "1Gi")}
+	claims := []*api.PersistentVolumeClaim{
+		pendingClaim("too-large", "2Gi", api.ReadWriteOnce),
+		pendingClaim("wrong-mode", "1Gi", api.ReadWriteMany),
+	}
+	newTestBinder(t, fake, volumes, claims).synchronize()
+
+	if len(fake.Actions) != 0 {
+		t.Errorf("expected no actions, got %#v", fake.Actions)
+	}
+}
+
+func TestVolumeBoundOnlyOnce(t *testing.T) {
+	fake := &client.Fake{}
+	volumes := []*api.PersistentVolume{hostPathVolume("only", "5Gi")}
+	claims := []*api.PersistentVolumeClaim{
+		pendingClaim("first", "1Gi", api.ReadWriteOnce),
+		pendingClaim("second", "1Gi", api.ReadWriteOnce),
+	}
+	newTestBinder(t, fake, volumes, claims).synchronize()
+
+	if updates := actionValues(fake, "update-persistentVolume"); len(updates) != 1 {
+		t.Errorf("expected the volume to be bound once, got %#v", updates)
+	}
+	if claims := actionValues(fake, "update-status-persistentVolumeClaim"); len(claims) != 1 {
+		t.Errorf("expected one claim to be bound, got %#v", claims)
+	}
+}
+
+func TestReleaseVolumeOfDeletedClaim(t *testing.T) {
+	fake := &client.Fake{}
+	pv := hostPathVolume("volume", "5Gi")
+	pv.Spec.ClaimRef = &api.ObjectReference{Namespace: api.NamespaceDefault, Name: "deleted", UID: "uid-deleted"}
+	pv.Status.Phase = api.VolumeBound
+	newTestBinder(t, fake, []*api.PersistentVolume{pv}, nil).synchronize()
+
+	statuses := actionValues(fake, "update-status-persistentVolume")
+	if len(statuses) != 1 || statuses[0].(*api.PersistentVolume).Status.Phase != api.VolumeReleased {
+		t.Errorf("expected volume to be released, got %#v", fake.Actions)
+	}
+}
+
+func TestReleaseVolumeOfRecreatedClaim(t *testing.T) {
+	fake := &client.Fake{}
+	pv := hostPathVolume("volume", "5Gi")
+	pv.Spec.ClaimRef = &api.ObjectReference{Namespace: api.NamespaceDefault, Name: "claim", UID: "old-uid"}
+	pv.Status.Phase = api.VolumeBound
+	claim := pendingClaim("claim", "10Gi", api.ReadWriteOnce)
+	newTestBinder(t, fake, []*api.PersistentVolume{pv}, []*api.PersistentVolumeClaim{claim}).synchronize()
+
+	statuses := actionValues(fake, "update-status-persistentVolume")
+	if len(statuses) != 1 || statuses[0].(*api.PersistentVolume).Status.Phase != api.VolumeReleased {
+		t.Errorf("expected volume to be released, got %#v", fake.Actions)
+	}
+	if claims := actionValues(fake, "update-status-persistentVolumeClaim"); len(claims) != 0 {
+		t.Errorf("expected new claim to stay pending, got %#v", claims)
+	}
+}
+
+func TestKeepVolumeOfClaimMissingFromStore(t *testing.T) {
+	claim := pendingClaim("claim", "1Gi", api.ReadWriteOnce)
+	claim.Status.Phase = api.ClaimBound
+	fake := &client.Fake{PersistentVolumeClaim: *claim}
+	pv := hostPathVolume("volume", "5Gi")
+	pv.Spec.ClaimRef = &api.ObjectReference{Namespace: claim.Namespace, Name: claim.Name, UID: claim.UID}
+	pv.Status.Phase = api.VolumeBound
+	// the claim store has not caught up with the server yet
+	newTestBinder(t, fake, []*api.PersistentVolume{pv}, nil).synchronize()
+
+	if gets := actionValues(fake, "get-persistentVolumeClaim"); len(gets) != 1 {
+		t.Errorf("expected the claim to be looked up, got %#v", fake.Actions)
+	}
+	if statuses := actionValues(fake, "update-status-persistentVolume"); len(statuses) != 0 {
+		t.Errorf("expected volume to stay bound, got %#v", statuses)
+	}
+}
+
+func TestKeepVolumeWhenClaimLookupFails(t *testing.T) {
+	fake := &client.Fake{Err: fmt.Errorf("server unavailable")}
+	pv := hostPathVolume("volume", "5Gi")
+	pv.Spec.ClaimRef = &api.ObjectReference{Namespace: api.NamespaceDefault, Name: "claim", UID: "uid-claim"}
+	pv.Status.Phase = api.VolumeBound
+	newTestBinder(t, fake, []*api.PersistentVolume{pv}, nil).synchronize()
+
+	if statuses := actionValues(fake, "update-status-persistentVolume"); len(statuses) != 0 {
+		t.Errorf("expected volume to stay bound, got %#v", statuses)
+	}
+}
+
+func TestCompletePartialBind(t *testing.T) {
+	fake := &client.Fake{}
+	claim := pendingClaim("claim", "1Gi", api.ReadWriteOnce)
+	pv := hostPathVolume("volume", "5Gi")
+	pv.Spec.ClaimRef = &api.ObjectReference{Namespace: claim.Namespace, Name: claim.Name, UID: claim.UID}
+	pv.Status.Phase = api.VolumeBound
+	newTestBinder(t, fake, []*api.PersistentVolume{pv}, []*api.PersistentVolumeClaim{claim}).synchronize()
+
+	if updates := actionValues(fake, "update-persistentVolume"); len(updates) != 0 {
+		t.Errorf("expected no volume updates, got %#v", updates)
+	}
+	claims := actionValues(fake, "update-status-persistentVolumeClaim")
+	if len(claims) != 1 || claims[0].(*api.PersistentVolumeClaim).Status.VolumeRef.Name != "volume" {
+		t.Errorf("expected claim to be marked bound to volume, got %#v", claims)
+	}
+}
+
+func TestAvailableVolumeWithoutPhase(t *testing.T) {
+	fake := &client.Fake{}
+	pv := hostPathVolume("volume", "5Gi")
+	pv.Status.Phase = ""
+	newTestBinder(t, fake, []*api.PersistentVolume{pv}, nil).synchronize()
+
+	statuses := actionValues(fake, "update-status-persistentVolume")
+	if len(statuses) != 1 || statuses[0].(*api.PersistentVolume).Status.Phase != api.VolumeAvailable {
+		t.Errorf("expected volume to be marked available, got %#v", fake.Actions)
+	}
+}
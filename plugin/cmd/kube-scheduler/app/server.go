@@ -26,7 +26,9 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/leaderelection"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/record"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/master/ports"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
@@ -48,6 +50,7 @@ type SchedulerServer struct {
 	AlgorithmProvider string
 	PolicyConfigFile  string
 	EnableProfiling   bool
+	LeaderElection    leaderelection.LeaderElectionConfiguration
 }
 
 // NewSchedulerServer creates a new SchedulerServer with default parameters
@@ -56,6 +59,7 @@ func NewSchedulerServer() *SchedulerServer {
 		Port:              ports.SchedulerPort,
 		Address:           util.IP(net.ParseIP("127.0.0.1")),
 		AlgorithmProvider: factory.DefaultProvider,
+		LeaderElection:    leaderelection.DefaultLeaderElectionConfiguration(),
 	}
 	return &s
 }
@@ -68,6 +72,7 @@ func (s *SchedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.AlgorithmProvider, "algorithm_provider", s.AlgorithmProvider, "The scheduling algorithm provider to use")
 	fs.StringVar(&s.PolicyConfigFile, "policy_config_file", s.PolicyConfigFile, "File with scheduler policy configuration")
 	fs.BoolVar(&s.EnableProfiling, "profiling", false, "Enable profiling via web interface host:port/debug/pprof/")
+	leaderelection.BindFlags(&s.LeaderElection, fs)
 }
 
 // Run runs the specified SchedulerServer.  This should never exit.
@@ -96,9 +101,37 @@ func (s *SchedulerServer) Run(_ []string) error {
 	}
 
 	sched := scheduler.New(config)
-	sched.Run()
 
-	select {}
+	run := func(_ <-chan struct{}) {
+		sched.Run()
+		select {}
+	}
+
+	if !s.LeaderElection.LeaderElect {
+		run(nil)
+	}
+
+	le, err := leaderelection.NewLeaderElector(leaderelection.Config{
+		Client:        kubeClient,
+		Namespace:     api.NamespaceDefault,
+		Name:          "kube-scheduler",
+		Identity:      leaderelection.DefaultIdentity(),
+		LeaseDuration: s.LeaderElection.LeaseDuration,
+		RenewDeadline: s.LeaderElection.RenewDeadline,
+		RetryPeriod:   s.LeaderElection.RetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: run,
+			OnStoppedLeading: func() {
+				glog.Errorf("Lost leader election lease")
+			},
+		},
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create leader elector: %v", err)
+	}
+	// Run returns once the lease is lost, the scheduler must not keep running.
+	le.Run()
+	return fmt.Errorf("lost leader election lease, exiting")
 }
 
 func (s *SchedulerServer) createConfig(configFactory *factory.ConfigFactory) (*scheduler.Config, error) {
@@ -19,6 +19,7 @@ package resourcequota
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
@@ -59,13 +60,6 @@ func NewResourceQuota(client client.Interface) admission.Interface {
 	return &quota{client: client, indexer: indexer}
 }
 
-var resourceToResourceName = map[string]api.ResourceName{
-	"pods":                   api.ResourcePods,
-	"services":               api.ResourceServices,
-	"replicationControllers": api.ResourceReplicationControllers,
-	"resourceQuotas":         api.ResourceQuotas,
-}
-
 func (q *quota) Admit(a admission.Attributes) (err error) {
 	if a.GetOperation() == "DELETE" {
 		return nil
@@ -147,23 +141,50 @@ func IncrementUsage(a admission.Attributes, status *api.ResourceQuotaStatus, cli
 	for k := range status.Hard {
 		set[k] = true
 	}
-	// handle max counts for each kind of resource (pods, services, replicationControllers, etc.)
+	// handle max counts for each kind of resource (pods, services, secrets, count/deployments, etc.)
 	if a.GetOperation() == "CREATE" {
-		resourceName := resourceToResourceName[a.GetResource()]
-		hard, hardFound := status.Hard[resourceName]
-		if hardFound {
+		for _, resourceName := range resourcequota.ObjectCountNames(a.GetResource()) {
+			hard, hardFound := status.Hard[resourceName]
+			if !hardFound {
+				continue
+			}
 			used, usedFound := status.Used[resourceName]
 			if !usedFound {
 				return false, apierrors.NewForbidden(a.GetResource(), name, fmt.Errorf("Quota usage stats are not yet known, unable to admit resource until an accurate count is completed."))
 			}
 			if used.Value() >= hard.Value() {
-				return false, apierrors.NewForbidden(a.GetResource(), name, fmt.Errorf("Limited to %s %s", hard.String(), a.GetResource()))
+				return false, apierrors.NewForbidden(a.GetResource(), name, fmt.Errorf("Limited to %s %s", hard.String(), resourceName))
 			} else {
 				status.Used[resourceName] = *resource.NewQuantity(used.Value()+int64(1), resource.DecimalSI)
 				dirty = true
 			}
 		}
 	}
+	// handle requested storage, and any diff of the request on updates
+	if strings.ToLower(a.GetResource()) == "persistentvolumeclaims" && set[api.ResourceRequestsStorage] {
+		claim := obj.(*api.PersistentVolumeClaim)
+		deltaStorage := resourcequota.PersistentVolumeClaimStorage(claim)
+		// if this is an update, we need to find the delta storage request from previous state
+		if a.GetOperation() == "UPDATE" {
+			oldClaim, err := client.PersistentVolumeClaims(a.GetNamespace()).Get(claim.Name)
+			if err != nil {
+				return false, apierrors.NewForbidden(resourceName, name, err)
+			}
+			oldStorage := resourcequota.PersistentVolumeClaimStorage(oldClaim)
+			deltaStorage = resource.NewQuantity(deltaStorage.Value()-oldStorage.Value(), resource.BinarySI)
+		}
+
+		hardStorage := status.Hard[api.ResourceRequestsStorage]
+		used, usedFound := status.Used[api.ResourceRequestsStorage]
+		if !usedFound {
+			return false, apierrors.NewForbidden(resourceName, name, fmt.Errorf("Quota usage stats are not yet known, unable to admit resource until an accurate count is completed."))
+		}
+		if used.Value()+deltaStorage.Value() > hardStorage.Value() {
+			return false, apierrors.NewForbidden(resourceName, name, fmt.Errorf("Limited to %s requested storage", hardStorage.String()))
+		}
+		status.Used[api.ResourceRequestsStorage] = *resource.NewQuantity(used.Value()+deltaStorage.Value(), resource.BinarySI)
+		dirty = true
+	}
 	// handle memory/cpu constraints, and any diff of usage based on memory/cpu on updates
 	if a.GetResource() == "pods" && (set[api.ResourceMemory] || set[api.ResourceCPU]) {
 		pod := obj.(*api.Pod)
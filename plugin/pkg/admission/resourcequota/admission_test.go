@@ -23,6 +23,7 @@ import (
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/resource"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
 )
 
 func getResourceRequirements(cpu, memory string) api.ResourceRequirements {
@@ -41,7 +42,7 @@ func getResourceRequirements(cpu, memory string) api.ResourceRequirements {
 func TestAdmissionIgnoresDelete(t *testing.T) {
 	namespace := "default"
 	handler := NewResourceQuota(&client.Fake{})
-	err := handler.Admit(admission.NewAttributesRecord(nil, namespace, "pods", "DELETE"))
+	err := handler.Admit(admission.NewAttributesRecord(nil, namespace, "pods", "DELETE", nil))
 	if err != nil {
 		t.Errorf("ResourceQuota should admit all deletes", err)
 	}
@@ -69,7 +70,7 @@ func TestIncrementUsagePods(t *testing.T) {
 	r := api.ResourcePods
 	status.Hard[r] = resource.MustParse("2")
 	status.Used[r] = resource.MustParse("1")
-	dirty, err := IncrementUsage(admission.NewAttributesRecord(&api.Pod{}, namespace, "pods", "CREATE"), status, client)
+	dirty, err := IncrementUsage(admission.NewAttributesRecord(&api.Pod{}, namespace, "pods", "CREATE", nil), status, client)
 	if err != nil {
 		t.Errorf("Unexpected error", err)
 	}
@@ -111,7 +112,7 @@ func TestIncrementUsageMemory(t *testing.T) {
 			Volumes:    []api.Volume{{Name: "vol"}},
 			Containers: []api.Container{{Name: "ctr", Image: "image", Resources: getResourceRequirements("100m", "1Gi")}},
 		}}
-	dirty, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE"), status, client)
+	dirty, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE", nil), status, client)
 	if err != nil {
 		t.Errorf("Unexpected error", err)
 	}
@@ -154,7 +155,7 @@ func TestExceedUsageMemory(t *testing.T) {
 			Volumes:    []api.Volume{{Name: "vol"}},
 			Containers: []api.Container{{Name: "ctr", Image: "image", Resources: getResourceRequirements("100m", "3Gi")}},
 		}}
-	_, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE"), status, client)
+	_, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE", nil), status, client)
 	if err == nil {
 		t.Errorf("Expected memory usage exceeded error")
 	}
@@ -189,7 +190,7 @@ func TestIncrementUsageCPU(t *testing.T) {
 			Volumes:    []api.Volume{{Name: "vol"}},
 			Containers: []api.Container{{Name: "ctr", Image: "image", Resources: getResourceRequirements("100m", "1Gi")}},
 		}}
-	dirty, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE"), status, client)
+	dirty, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE", nil), status, client)
 	if err != nil {
 		t.Errorf("Unexpected error", err)
 	}
@@ -232,7 +233,7 @@ func TestExceedUsageCPU(t *testing.T) {
 			Volumes:    []api.Volume{{Name: "vol"}},
 			Containers: []api.Container{{Name: "ctr", Image: "image", Resources: getResourceRequirements("500m", "1Gi")}},
 		}}
-	_, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE"), status, client)
+	_, err := IncrementUsage(admission.NewAttributesRecord(newPod, namespace, "pods", "CREATE", nil), status, client)
 	if err == nil {
 		t.Errorf("Expected CPU usage exceeded error")
 	}
@@ -260,7 +261,7 @@ func TestExceedUsagePods(t *testing.T) {
 	r := api.ResourcePods
 	status.Hard[r] = resource.MustParse("1")
 	status.Used[r] = resource.MustParse("1")
-	_, err := IncrementUsage(admission.NewAttributesRecord(&api.Pod{}, namespace, "pods", "CREATE"), status, client)
+	_, err := IncrementUsage(admission.NewAttributesRecord(&api.Pod{}, namespace, "pods", "CREATE", nil), status, client)
 	if err == nil {
 		t.Errorf("Expected error because this would exceed your quota")
 	}
@@ -284,7 +285,7 @@ func TestIncrementUsageServices(t *testing.T) {
 	r := api.ResourceServices
 	status.Hard[r] = resource.MustParse("2")
 	status.Used[r] = resource.MustParse("1")
-	dirty, err := IncrementUsage(admission.NewAttributesRecord(&api.Service{}, namespace, "services", "CREATE"), status, client)
+	dirty, err := IncrementUsage(admission.NewAttributesRecord(&api.Service{}, namespace, "services", "CREATE", nil), status, client)
 	if err != nil {
 		t.Errorf("Unexpected error", err)
 	}
@@ -315,7 +316,7 @@ func TestExceedUsageServices(t *testing.T) {
 	r := api.ResourceServices
 	status.Hard[r] = resource.MustParse("1")
 	status.Used[r] = resource.MustParse("1")
-	_, err := IncrementUsage(admission.NewAttributesRecord(&api.Service{}, namespace, "services", "CREATE"), status, client)
+	_, err := IncrementUsage(admission.NewAttributesRecord(&api.Service{}, namespace, "services", "CREATE", nil), status, client)
 	if err == nil {
 		t.Errorf("Expected error because this would exceed usage")
 	}
@@ -339,7 +340,7 @@ func TestIncrementUsageReplicationControllers(t *testing.T) {
 	r := api.ResourceReplicationControllers
 	status.Hard[r] = resource.MustParse("2")
 	status.Used[r] = resource.MustParse("1")
-	dirty, err := IncrementUsage(admission.NewAttributesRecord(&api.ReplicationController{}, namespace, "replicationControllers", "CREATE"), status, client)
+	dirty, err := IncrementUsage(admission.NewAttributesRecord(&api.ReplicationController{}, namespace, "replicationControllers", "CREATE", nil), status, client)
 	if err != nil {
 		t.Errorf("Unexpected error", err)
 	}
@@ -370,8 +371,92 @@ func TestExceedUsageReplicationControllers(t *testing.T) {
 	r := api.ResourceReplicationControllers
 	status.Hard[r] = resource.MustParse("1")
 	status.Used[r] = resource.MustParse("1")
-	_, err := IncrementUsage(admission.NewAttributesRecord(&api.ReplicationController{}, namespace, "replicationControllers", "CREATE"), status, client)
+	_, err := IncrementUsage(admission.NewAttributesRecord(&api.ReplicationController{}, namespace, "replicationControllers", "CREATE", nil), status, client)
 	if err == nil {
 		t.Errorf("Expected error for exceeding hard limits")
 	}
 }
+
+func TestIncrementUsageObjectCounts(t *testing.T) {
+	namespace := "default"
+	testCases := []struct {
+		resource string
+		obj      runtime.Object
+		names    []api.ResourceName
+	}{
+		{"secrets", &api.Secret{}, []api.ResourceName{api.ResourceSecrets, "count/secrets"}},
+		{"persistentVolumeClaims", &api.PersistentVolumeClaim{}, []api.ResourceName{api.ResourcePersistentVolumeClaims}},
+		{"replicationControllers", &api.ReplicationController{}, []api.ResourceName{api.ResourceReplicationControllers}},
+		{"deployments", &api.Deployment{}, []api.ResourceName{"count/deployments"}},
+	}
+	for _, tc := range testCases {
+		status := &api.ResourceQuotaStatus{
+			Hard: api.ResourceList{},
+			Used: api.ResourceList{},
+		}
+		for _, name := range tc.names {
+			status.Hard[name] = resource.MustParse("2")
+			status.Used[name] = resource.MustParse("1")
+		}
+		dirty, err := IncrementUsage(admission.NewAttributesRecord(tc.obj, namespace, tc.resource, "CREATE", nil), status, &client.Fake{})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.resource, err)
+		}
+		if !dirty {
+			t.Errorf("%s: expected the status to get incremented, therefore should have been dirty", tc.resource)
+		}
+		for _, name := range tc.names {
+			if quantity := status.Used[name]; quantity.Value() != int64(2) {
+				t.Errorf("%s: expected new %s count to be 2, but was %s", tc.resource, name, quantity.String())
+			}
+		}
+
+		_, err = IncrementUsage(admission.NewAttributesRecord(tc.obj, namespace, tc.resource, "CREATE", nil), status, &client.Fake{})
+		if err == nil {
+			t.Errorf("%s: expected error because this would exceed the quota", tc.resource)
+		}
+	}
+}
+
+func newClaim(storage string) *api.PersistentVolumeClaim {
+	return &api.PersistentVolumeClaim{
+		ObjectMeta: api.ObjectMeta{Name: "claim", Namespace: "default"},
+		Spec: api.PersistentVolumeClaimSpec{
+			Resources: api.ResourceRequirements{
+				Requests: api.ResourceList{api.ResourceStorage: resource.MustParse(storage)},
+			},
+		},
+	}
+}
+
+func TestIncrementUsageRequestsStorage(t *testing.T) {
+	status := &api.ResourceQuotaStatus{
+		Hard: api.ResourceList{api.ResourceRequestsStorage: resource.MustParse("10Gi")},
+		Used: api.ResourceList{api.ResourceRequestsStorage: resource.MustParse("4Gi")},
+	}
+	dirty, err := IncrementUsage(admission.NewAttributesRecord(newClaim("5Gi"), "default", "persistentvolumeclaims", "CREATE", nil), status, &client.Fake{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !dirty {
+		t.Errorf("Expected the status to get incremented, therefore should have been dirty")
+	}
+	if quantity := status.Used[api.ResourceRequestsStorage]; quantity.Value() != 9*1024*1024*1024 {
+		t.Errorf("Expected 9Gi of requested storage, but was %s", quantity.String())
+	}
+
+	_, err = IncrementUsage(admission.NewAttributesRecord(newClaim("2Gi"), "default", "persistentvolumeclaims", "CREATE", nil), status, &client.Fake{})
+	if err == nil {
+		t.Errorf("Expected error because this would exceed the requested storage quota")
+	}
+
+	// growing an existing 5Gi claim to 6Gi only needs 1Gi more
+	fake := &client.Fake{PersistentVolumeClaim: *newClaim("5Gi")}
+	_, err = IncrementUsage(admission.NewAttributesRecord(newClaim("6Gi"), "default", "persistentvolumeclaims", "UPDATE", nil), status, fake)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if quantity := status.Used[api.ResourceRequestsStorage]; quantity.Value() != 10*1024*1024*1024 {
+		t.Errorf("Expected 10Gi of requested storage, but was %s", quantity.String())
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitycontext
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/securitycontext"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+func init() {
+	admission.RegisterPlugin("SecurityContext", func(client client.Interface, config io.Reader) (admission.Interface, error) {
+		return NewSecurityContext(config)
+	})
+}
+
+// Policy limits the security options of pods.  The zero value allows no
+// privileged containers, no added capabilities and no SELinux options.
+type Policy struct {
+	// AllowPrivileged allows privileged containers.
+	AllowPrivileged bool `json:"allowPrivileged,omitempty"`
+	// AllowedCapabilities lists the capabilities containers may add.  "ALL"
+	// allows any capability.
+	AllowedCapabilities []api.CapabilityType `json:"allowedCapabilities,omitempty"`
+	// AllowSELinuxOptions allows pods and containers to set SELinux labels.
+	AllowSELinuxOptions bool `json:"allowSELinuxOptions,omitempty"`
+	// RunAsUserRange, if set, is the range of UIDs pods and containers may
+	// request with runAsUser.
+	RunAsUserRange *UIDRange `json:"runAsUserRange,omitempty"`
+	// RequireRunAsNonRoot forces every container to run as a non-root user.
+	RequireRunAsNonRoot bool `json:"requireRunAsNonRoot,omitempty"`
+	// RequireReadOnlyRootFilesystem forces every container to have a read-only
+	// root filesystem.
+	RequireReadOnlyRootFilesystem bool `json:"requireReadOnlyRootFilesystem,omitempty"`
+}
+
+// UIDRange is an inclusive range of UIDs.
+type UIDRange struct {
+	Min int64 `json:"min"`
+	Max int64 `json:"max"`
+}
+
+// Config selects the Policy for a pod.  The policy of the requesting user is
+// used if there is one, then that of the first of the user's groups that has
+// one, then that of the pod's namespace, and otherwise Default.
+type Config struct {
+	Default    Policy            `json:"default"`
+	Users      map[string]Policy `json:"users,omitempty"`
+	Groups     map[string]Policy `json:"groups,omitempty"`
+	Namespaces map[string]Policy `json:"namespaces,omitempty"`
+}
+
+// configFile is the layout of the admission control configuration file, which
+// is shared by all plugins.
+type configFile struct {
+	SecurityContext *Config `json:"securityContext"`
+}
+
+// securityContext is an implementation of admission.Interface.
+// It rejects pods whose security options are not allowed by the applicable Policy.
+type securityContext struct {
+	config Config
+}
+
+// NewSecurityContext returns an admission.Interface implementation which limits the security options of pods.
+// If config is nil, or has no securityContext section, the zero Policy applies to every pod.
+func NewSecurityContext(config io.Reader) (admission.Interface, error) {
+	s := &securityContext{}
+	if config == nil {
+		return s, nil
+	}
+	data, err := ioutil.ReadAll(config)
+	if err != nil {
+		return nil, err
+	}
+	file := configFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse the securityContext admission configuration: %v", err)
+	}
+	if file.SecurityContext != nil {
+		s.config = *file.SecurityContext
+	}
+	return s, nil
+}
+
+// policyFor returns the Policy that applies to a request.
+func (s *securityContext) policyFor(a admission.Attributes) Policy {
+	if userInfo := a.GetUserInfo(); userInfo != nil {
+		if policy, ok := s.config.Users[userInfo.GetName()]; ok {
+			return policy
+		}
+		for _, group := range userInfo.GetGroups() {
+			if policy, ok := s.config.Groups[group]; ok {
+				return policy
+			}
+		}
+	}
+	if policy, ok := s.config.Namespaces[a.GetNamespace()]; ok {
+		return policy
+	}
+	return s.config.Default
+}
+
+func (s *securityContext) Admit(a admission.Attributes) (err error) {
+	if a.GetOperation() != "CREATE" && a.GetOperation() != "UPDATE" {
+		return nil
+	}
+	if a.GetResource() != "pods" {
+		return nil
+	}
+	pod, ok := a.GetObject().(*api.Pod)
+	if !ok {
+		return nil
+	}
+	// Mirror pods reflect static pods which are already running on a node.
+	if _, isMirrorPod := pod.Annotations[kubelet.ConfigMirrorAnnotationKey]; isMirrorPod {
+		return nil
+	}
+
+	policy := s.policyFor(a)
+	if policy.RequireRunAsNonRoot || policy.RequireReadOnlyRootFilesystem {
+		if pod.Spec.SecurityContext == nil {
+			pod.Spec.SecurityContext = &api.SecurityContext{}
+		}
+		// Containers cannot turn these off once they are set on the pod.
+		if policy.RequireRunAsNonRoot {
+			pod.Spec.SecurityContext.RunAsNonRoot = true
+		}
+		if policy.RequireReadOnlyRootFilesystem {
+			pod.Spec.SecurityContext.ReadOnlyRootFilesystem = true
+		}
+	}
+	// Without runAsUser a container runs as the user of its image, which may be
+	// root, so pods that leave it unset run as the lowest allowed UID.
+	if policy.RunAsUserRange != nil && (pod.Spec.SecurityContext == nil || pod.Spec.SecurityContext.RunAsUser == nil) {
+		if pod.Spec.SecurityContext == nil {
+			pod.Spec.SecurityContext = &api.SecurityContext{}
+		}
+		uid := policy.RunAsUserRange.Min
+		pod.Spec.SecurityContext.RunAsUser = &uid
+	}
+
+	if err := checkSecurityContext(&policy, pod.Spec.SecurityContext); err != nil {
+		return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("pod %v", err))
+	}
+	allowedCapabilities := util.NewStringSet()
+	for _, capability := range policy.AllowedCapabilities {
+		allowedCapabilities.Insert(string(capability))
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Privileged && !policy.AllowPrivileged {
+			return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("container %s may not be privileged", container.Name))
+		}
+		if !allowedCapabilities.Has("ALL") {
+			for _, capability := range container.Capabilities.Add {
+				if !allowedCapabilities.Has(string(capability)) {
+					return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("container %s may not add capability %s", container.Name, capability))
+				}
+			}
+		}
+		if err := checkSecurityContext(&policy, container.SecurityContext); err != nil {
+			return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("container %s %v", container.Name, err))
+		}
+		if effective := securitycontext.Effective(&pod.Spec, &container); effective != nil && effective.RunAsNonRoot && effective.RunAsUser != nil && *effective.RunAsUser == 0 {
+			return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("container %s must run as a non-root user", container.Name))
+		}
+	}
+	return nil
+}
+
+// checkSecurityContext returns an error describing the first option in sc
+// that policy does not allow.
+func checkSecurityContext(policy *Policy, sc *api.SecurityContext) error {
+	if sc == nil {
+		return nil
+	}
+	if sc.SELinuxOptions != nil && !policy.AllowSELinuxOptions {
+		return fmt.Errorf("may not set SELinux options")
+	}
+	if sc.RunAsUser != nil && policy.RunAsUserRange != nil {
+		if uid := *sc.RunAsUser; uid < policy.RunAsUserRange.Min || uid > policy.RunAsUserRange.Max {
+			return fmt.Errorf("may not run as uid %d, which is outside of the range %d-%d", uid, policy.RunAsUserRange.Min, policy.RunAsUserRange.Max)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitycontext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/securitycontext"
+)
+
+const testConfig = `{
+	"otherPlugin": {"ignored": true},
+	"securityContext": {
+		"default": {"requireRunAsNonRoot": true, "runAsUserRange": {"min": 1000, "max": 1999}},
+		"namespaces": {"infra": {"allowedCapabilities": ["NET_ADMIN"], "allowSELinuxOptions": true}},
+		"groups": {"admins": {"allowPrivileged": true, "allowedCapabilities": ["ALL"], "allowSELinuxOptions": true}},
+		"users": {"ci": {"requireReadOnlyRootFilesystem": true}}
+	}
+}`
+
+func newPod() *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "a"}, {Name: "b"}},
+		},
+	}
+}
+
+func newHandler(t *testing.T) admission.Interface {
+	handler, err := NewSecurityContext(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return handler
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestNilConfigDeniesPrivileged(t *testing.T) {
+	handler, err := NewSecurityContext(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := newPod()
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Errorf("Expected plain pod allowed, got err: %v", err)
+	}
+	pod.Spec.Containers[1].Privileged = true
+	err = handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil))
+	if !apierrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error for privileged container, got %v", err)
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	if _, err := NewSecurityContext(strings.NewReader("{")); err == nil {
+		t.Errorf("Expected error for invalid config")
+	}
+}
+
+func TestIgnoresMirrorPodAndOtherOperations(t *testing.T) {
+	handler := newHandler(t)
+	pod := newPod()
+	pod.Spec.Containers[0].Privileged = true
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "DELETE", nil)); err != nil {
+		t.Errorf("Expected delete allowed, got err: %v", err)
+	}
+	pod.Annotations = map[string]string{kubelet.ConfigMirrorAnnotationKey: "true"}
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Errorf("Expected mirror pod allowed, got err: %v", err)
+	}
+	if pod.Spec.SecurityContext != nil {
+		t.Errorf("Expected pod to be untouched, got %#v", pod.Spec.SecurityContext)
+	}
+}
+
+func TestDefaultsRequiredFields(t *testing.T) {
+	handler := newHandler(t)
+
+	pod := newPod()
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Fatalf("Expected pod allowed, got err: %v", err)
+	}
+	if sc := pod.Spec.SecurityContext; sc == nil || !sc.RunAsNonRoot || sc.ReadOnlyRootFilesystem {
+		t.Errorf("Expected runAsNonRoot to be required, got %#v", sc)
+	}
+
+	pod = newPod()
+	userInfo := &user.DefaultInfo{Name: "ci"}
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", userInfo)); err != nil {
+		t.Fatalf("Expected pod allowed, got err: %v", err)
+	}
+	if sc := pod.Spec.SecurityContext; sc == nil || sc.RunAsNonRoot || !sc.ReadOnlyRootFilesystem {
+		t.Errorf("Expected readOnlyRootFilesystem to be required, got %#v", sc)
+	}
+}
+
+func TestDefaultsRunAsUserToRange(t *testing.T) {
+	handler := newHandler(t)
+
+	pod := newPod()
+	pod.Spec.Containers[1].SecurityContext = &api.SecurityContext{RunAsUser: int64Ptr(1500)}
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Fatalf("Expected pod allowed, got err: %v", err)
+	}
+	expected := map[string]int64{"a": 1000, "b": 1500}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		effective := securitycontext.Effective(&pod.Spec, container)
+		if effective == nil || effective.RunAsUser == nil || *effective.RunAsUser != expected[container.Name] {
+			t.Errorf("Expected container %s to run as uid %d, got %#v", container.Name, expected[container.Name], effective)
+		}
+	}
+
+	// Without a range the image's user is kept.
+	pod = newPod()
+	if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", &user.DefaultInfo{Name: "ci"})); err != nil {
+		t.Fatalf("Expected pod allowed, got err: %v", err)
+	}
+	if sc := pod.Spec.SecurityContext; sc == nil || sc.RunAsUser != nil {
+		t.Errorf("Expected runAsUser to be left unset, got %#v", sc)
+	}
+}
+
+func TestPolicySelection(t *testing.T) {
+	admin := &user.DefaultInfo{Name: "alice", Groups: []string{"devs", "admins"}}
+	dev := &user.DefaultInfo{Name: "bob", Groups: []string{"devs"}}
+
+	privileged := func(pod *api.Pod) { pod.Spec.Containers[0].Privileged = true }
+	netAdmin := func(pod *api.Pod) {
+		pod.Spec.Containers[1].Capabilities.Add = []api.CapabilityType{"NET_ADMIN"}
+	}
+	sysAdmin := func(pod *api.Pod) {
+		pod.Spec.Containers[1].Capabilities.Add = []api.CapabilityType{"SYS_ADMIN"}
+	}
+	seLinux := func(pod *api.Pod) {
+		pod.Spec.Containers[0].SecurityContext = &api.SecurityContext{SELinuxOptions: &api.SELinuxOptions{Level: "s0:c1"}}
+	}
+	uid := func(uid int64) func(*api.Pod) {
+		return func(pod *api.Pod) {
+			pod.Spec.SecurityContext = &api.SecurityContext{RunAsUser: int64Ptr(uid)}
+		}
+	}
+	containerRoot := func(pod *api.Pod) {
+		pod.Spec.SecurityContext = &api.SecurityContext{RunAsUser: int64Ptr(1000)}
+		pod.Spec.Containers[1].SecurityContext = &api.SecurityContext{RunAsUser: int64Ptr(0)}
+	}
+
+	testCases := map[string]struct {
+		namespace string
+		user      user.Info
+		mutate    func(*api.Pod)
+		allowed   bool
+	}{
+		"default privileged":       {"ns", dev, privileged, false},
+		"default capability":       {"ns", dev, netAdmin, false},
+		"default selinux":          {"ns", dev, seLinux, false},
+		"default uid in range":     {"ns", dev, uid(1500), true},
+		"default uid out of range": {"ns", dev, uid(2000), false},
+		"default container root":   {"ns", nil, containerRoot, false},
+		"namespace capability":     {"infra", dev, netAdmin, true},
+		"namespace other cap":      {"infra", dev, sysAdmin, false},
+		"namespace selinux":        {"infra", nil, seLinux, true},
+		"namespace privileged":     {"infra", dev, privileged, false},
+		"group privileged":         {"ns", admin, privileged, true},
+		"group any capability":     {"ns", admin, sysAdmin, true},
+		"group over namespace":     {"infra", admin, privileged, true},
+	}
+	handler := newHandler(t)
+	for name, tc := range testCases {
+		pod := newPod()
+		tc.mutate(pod)
+		err := handler.Admit(admission.NewAttributesRecord(pod, tc.namespace, "pods", "CREATE", tc.user))
+		if tc.allowed && err != nil {
+			t.Errorf("%s: expected pod allowed, got err: %v", name, err)
+		}
+		if !tc.allowed && !apierrors.IsForbidden(err) {
+			t.Errorf("%s: expected forbidden error, got %v", name, err)
+		}
+	}
+}
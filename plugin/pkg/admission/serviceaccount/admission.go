@@ -0,0 +1,150 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/serviceaccount"
+)
+
+// DefaultAPITokenMountPath is the path that ServiceAccountToken secrets are automounted to.
+// The token file would then be accessible at /var/run/secrets/kubernetes.io/serviceaccount/token
+const DefaultAPITokenMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+func init() {
+	admission.RegisterPlugin("ServiceAccount", func(client client.Interface, config io.Reader) (admission.Interface, error) {
+		return NewServiceAccount(client), nil
+	})
+}
+
+// serviceAccount is an implementation of admission.Interface.
+// It defaults the service account of new pods, and mounts the account's API token into every container.
+type serviceAccount struct {
+	client client.Interface
+}
+
+// NewServiceAccount returns an admission.Interface implementation which assigns service accounts to pods.
+func NewServiceAccount(c client.Interface) admission.Interface {
+	return &serviceAccount{client: c}
+}
+
+func (s *serviceAccount) Admit(a admission.Attributes) (err error) {
+	// The service account of a pod cannot change, so only creates are of interest
+	if a.GetOperation() != "CREATE" {
+		return nil
+	}
+	if a.GetResource() != "pods" {
+		return nil
+	}
+	pod, ok := a.GetObject().(*api.Pod)
+	if !ok {
+		return nil
+	}
+
+	// Don't modify the spec of mirror pods.
+	// That makes the kubelet very angry and confused, and it immediately deletes the pod (because the spec doesn't match)
+	if _, isMirrorPod := pod.Annotations[kubelet.ConfigMirrorAnnotationKey]; isMirrorPod {
+		return nil
+	}
+
+	if len(pod.Spec.ServiceAccount) == 0 {
+		pod.Spec.ServiceAccount = serviceaccount.DefaultServiceAccountName
+	}
+
+	namespace := a.GetNamespace()
+	sa, err := s.client.ServiceAccounts(namespace).Get(pod.Spec.ServiceAccount)
+	if apierrors.IsNotFound(err) {
+		return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("service account %s/%s was not found, retry after the service account is created", namespace, pod.Spec.ServiceAccount))
+	}
+	if err != nil {
+		return err
+	}
+
+	tokenName, err := s.getAPITokenName(sa)
+	if err != nil {
+		return err
+	}
+	if len(tokenName) == 0 {
+		return apierrors.NewForbidden("pods", pod.Name, fmt.Errorf("no API token found for service account %s/%s, retry after the token is automatically created and added to the service account", namespace, sa.Name))
+	}
+
+	mountServiceAccountToken(tokenName, pod)
+	return nil
+}
+
+// getAPITokenName returns the name of the first API token secret referenced by the service account, if any.
+func (s *serviceAccount) getAPITokenName(sa *api.ServiceAccount) (string, error) {
+	for _, ref := range sa.Secrets {
+		secret, err := s.client.Secrets(sa.Namespace).Get(ref.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if serviceaccount.IsServiceAccountToken(secret, sa) {
+			return secret.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// mountServiceAccountToken adds a volume for the token secret to the pod, and mounts it read-only
+// at DefaultAPITokenMountPath in every container that does not mount something there already.
+func mountServiceAccountToken(tokenName string, pod *api.Pod) {
+	volumeName := ""
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == tokenName {
+			volumeName = volume.Name
+			break
+		}
+	}
+	if len(volumeName) == 0 {
+		volumeName = tokenName
+		pod.Spec.Volumes = append(pod.Spec.Volumes, api.Volume{
+			Name: volumeName,
+			VolumeSource: api.VolumeSource{
+				Secret: &api.SecretVolumeSource{SecretName: tokenName},
+			},
+		})
+	}
+
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		hasMount := false
+		for _, mount := range container.VolumeMounts {
+			if mount.MountPath == DefaultAPITokenMountPath {
+				hasMount = true
+				break
+			}
+		}
+		if !hasMount {
+			container.VolumeMounts = append(container.VolumeMounts, api.VolumeMount{
+				Name:      volumeName,
+				ReadOnly:  true,
+				MountPath: DefaultAPITokenMountPath,
+			})
+		}
+	}
+}
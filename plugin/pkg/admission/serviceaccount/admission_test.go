@@ -0,0 +1,150 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/kubelet"
+)
+
+func newPod() *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Spec: api.PodSpec{
+			Containers: []api.Container{{Name: "a"}, {Name: "b"}},
+		},
+	}
+}
+
+func newFakeClient(serviceAccountName string, secret api.Secret) *client.Fake {
+	return &client.Fake{
+		ServiceAccount: api.ServiceAccount{
+			ObjectMeta: api.ObjectMeta{Name: serviceAccountName, Namespace: "ns", UID: "12345"},
+			Secrets:    []api.ObjectReference{{Name: secret.Name}},
+		},
+		Secret: secret,
+	}
+}
+
+func tokenSecret(name, serviceAccountName string) api.Secret {
+	return api.Secret{
+		ObjectMeta: api.ObjectMeta{
+			Name:        name,
+			Namespace:   "ns",
+			Annotations: map[string]string{api.ServiceAccountNameKey: serviceAccountName, api.ServiceAccountUIDKey: "12345"},
+		},
+		Type: api.SecretTypeServiceAccountToken,
+	}
+}
+
+func TestIgnoresNonCreate(t *testing.T) {
+	pod := newPod()
+	for _, op := range []string{"UPDATE", "DELETE"} {
+		handler := NewServiceAccount(&client.Fake{})
+		if err := handler.Admit(admission.NewAttributesRecord(pod, "ns", "pods", op, nil)); err != nil {
+			t.Errorf("Expected %s operation allowed, got err: %v", op, err)
+		}
+	}
+	if len(pod.Spec.ServiceAccount) != 0 || len(pod.Spec.Volumes) != 0 {
+		t.Errorf("Expected pod to be untouched, got %#v", pod.Spec)
+	}
+}
+
+func TestIgnoresMirrorPod(t *testing.T) {
+	pod := newPod()
+	pod.Annotations = map[string]string{kubelet.ConfigMirrorAnnotationKey: "true"}
+	fake := &client.Fake{}
+	if err := NewServiceAccount(fake).Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Errorf("Expected mirror pod allowed, got err: %v", err)
+	}
+	if len(fake.Actions) != 0 {
+		t.Errorf("Expected no client calls, got %#v", fake.Actions)
+	}
+}
+
+func TestRejectsMissingServiceAccount(t *testing.T) {
+	fake := &client.Fake{Err: apierrors.NewNotFound("serviceAccount", "default")}
+	err := NewServiceAccount(fake).Admit(admission.NewAttributesRecord(newPod(), "ns", "pods", "CREATE", nil))
+	if !apierrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+}
+
+func TestRejectsServiceAccountWithoutToken(t *testing.T) {
+	opaque := tokenSecret("not-a-token", "default")
+	opaque.Type = api.SecretTypeOpaque
+	err := NewServiceAccount(newFakeClient("default", opaque)).Admit(admission.NewAttributesRecord(newPod(), "ns", "pods", "CREATE", nil))
+	if !apierrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+}
+
+func TestAssignsDefaultServiceAccountAndMountsToken(t *testing.T) {
+	pod := newPod()
+	pod.Spec.Containers[1].VolumeMounts = []api.VolumeMount{{Name: "custom", MountPath: DefaultAPITokenMountPath}}
+
+	fake := newFakeClient("default", tokenSecret("default-token-abcde", "default"))
+	if err := NewServiceAccount(fake).Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if pod.Spec.ServiceAccount != "default" {
+		t.Errorf("Expected default service account, got %q", pod.Spec.ServiceAccount)
+	}
+	expectedVolume := api.Volume{
+		Name:         "default-token-abcde",
+		VolumeSource: api.VolumeSource{Secret: &api.SecretVolumeSource{SecretName: "default-token-abcde"}},
+	}
+	if len(pod.Spec.Volumes) != 1 || !api.Semantic.DeepEqual(pod.Spec.Volumes[0], expectedVolume) {
+		t.Errorf("Expected token volume, got %#v", pod.Spec.Volumes)
+	}
+	expectedMount := api.VolumeMount{Name: "default-token-abcde", ReadOnly: true, MountPath: DefaultAPITokenMountPath}
+	if mounts := pod.Spec.Containers[0].VolumeMounts; len(mounts) != 1 || !api.Semantic.DeepEqual(mounts[0], expectedMount) {
+		t.Errorf("Expected token mount, got %#v", mounts)
+	}
+	if mounts := pod.Spec.Containers[1].VolumeMounts; len(mounts) != 1 || mounts[0].Name != "custom" {
+		t.Errorf("Expected existing mount to be kept, got %#v", mounts)
+	}
+}
+
+func TestReusesExistingTokenVolume(t *testing.T) {
+	pod := newPod()
+	pod.Spec.ServiceAccount = "builder"
+	pod.Spec.Volumes = []api.Volume{{
+		Name:         "my-token",
+		VolumeSource: api.VolumeSource{Secret: &api.SecretVolumeSource{SecretName: "builder-token-abcde"}},
+	}}
+
+	fake := newFakeClient("builder", tokenSecret("builder-token-abcde", "builder"))
+	if err := NewServiceAccount(fake).Admit(admission.NewAttributesRecord(pod, "ns", "pods", "CREATE", nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(pod.Spec.Volumes) != 1 {
+		t.Errorf("Expected existing volume to be reused, got %#v", pod.Spec.Volumes)
+	}
+	for _, container := range pod.Spec.Containers {
+		if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].Name != "my-token" {
+			t.Errorf("Expected existing volume to be mounted, got %#v", container.VolumeMounts)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/latest"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/meta"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/webhook"
+	"github.com/golang/glog"
+)
+
+func init() {
+	admission.RegisterPlugin("Webhook", func(client client.Interface, config io.Reader) (admission.Interface, error) {
+		return NewWebhook(config)
+	})
+}
+
+// AdmissionReview is posted to a remote service to ask whether a request
+// should be admitted.  The service fills in the status.
+type AdmissionReview struct {
+	Spec   AdmissionReviewSpec   `json:"spec"`
+	Status AdmissionReviewStatus `json:"status"`
+}
+
+// AdmissionReviewSpec describes the request being admitted.
+type AdmissionReviewSpec struct {
+	// Operation is CREATE, UPDATE or DELETE.
+	Operation string `json:"operation"`
+	// Resource is the lowercase plural name of the resource, e.g. "pods".
+	Resource string `json:"resource"`
+	// Kind is the kind of Object, if there is one.
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of Object, if there is one.
+	Name string `json:"name,omitempty"`
+	// Object is the object being created or updated, in the default external
+	// API version.  It is empty for deletions.
+	Object json.RawMessage `json:"object,omitempty"`
+	// UserInfo describes the user making the request, if known.
+	UserInfo *UserInfo `json:"userInfo,omitempty"`
+}
+
+// UserInfo describes the user making a request.
+type UserInfo struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// AdmissionReviewStatus is the remote service's answer.
+type AdmissionReviewStatus struct {
+	// Allowed is true if the request should be admitted.
+	Allowed bool `json:"allowed"`
+	// Reason explains why a request was not allowed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// FailurePolicy determines what happens when a remote service cannot be
+// reached or does not answer in time.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail rejects the request.
+	FailurePolicyFail FailurePolicy = "Fail"
+	// FailurePolicyIgnore admits the request as if the service had allowed it.
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+// HookConfig configures one remote service.
+type HookConfig struct {
+	// Name identifies the service in errors and logs.
+	Name string `json:"name"`
+	// KubeConfigFile is a kubeconfig file whose current context describes the
+	// service's URL, certificate authority and the credentials to present.
+	KubeConfigFile string `json:"kubeConfigFile"`
+	// Timeout bounds each review, e.g. "5s".  Defaults to 10s.
+	Timeout string `json:"timeout,omitempty"`
+	// FailurePolicy is Fail or Ignore.  Defaults to Fail.
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+	// Resources limits the service to these resources.  Empty means all.
+	Resources []string `json:"resources,omitempty"`
+	// Operations limits the service to these operations.  Empty means all.
+	Operations []string `json:"operations,omitempty"`
+}
+
+// Config lists the remote services to consult, in order.  A request is
+// admitted only if every service that applies to it allows it; the first
+// denial stops the review.
+type Config struct {
+	Hooks []HookConfig `json:"hooks"`
+}
+
+// configFile is the layout of the admission control configuration file, which
+// is shared by all plugins.
+type configFile struct {
+	Webhook *Config `json:"webhook"`
+}
+
+const defaultTimeout = 10 * time.Second
+
+// hook is a remote service and the requests it reviews.
+type hook struct {
+	name          string
+	webhook       *webhook.GenericWebhook
+	failurePolicy FailurePolicy
+	resources     util.StringSet
+	operations    util.StringSet
+}
+
+func (h *hook) appliesTo(a admission.Attributes) bool {
+	if len(h.resources) > 0 && !h.resources.Has(strings.ToLower(a.GetResource())) {
+		return false
+	}
+	if len(h.operations) > 0 && !h.operations.Has(a.GetOperation()) {
+		return false
+	}
+	return true
+}
+
+// admissionWebhook is an implementation of admission.Interface.
+// It asks remote services whether each request should be admitted.
+type admissionWebhook struct {
+	hooks []*hook
+}
+
+// NewWebhook returns an admission.Interface implementation which consults the
+// remote services described by the "webhook" section of config.  It admits
+// every request if config is nil or has no such section.
+func NewWebhook(config io.Reader) (admission.Interface, error) {
+	w := &admissionWebhook{}
+	if config == nil {
+		return w, nil
+	}
+	data, err := ioutil.ReadAll(config)
+	if err != nil {
+		return nil, err
+	}
+	file := configFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse the webhook admission configuration: %v", err)
+	}
+	if file.Webhook == nil {
+		return w, nil
+	}
+	for i, c := range file.Webhook.Hooks {
+		h, err := newHook(c)
+		if err != nil {
+			return nil, fmt.Errorf("webhook admission hook %d (%s): %v", i, c.Name, err)
+		}
+		w.hooks = append(w.hooks, h)
+	}
+	return w, nil
+}
+
+func newHook(c HookConfig) (*hook, error) {
+	timeout := defaultTimeout
+	if len(c.Timeout) > 0 {
+		var err error
+		if timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout: %v", err)
+		}
+	}
+	failurePolicy := c.FailurePolicy
+	switch failurePolicy {
+	case "":
+		failurePolicy = FailurePolicyFail
+	case FailurePolicyFail, FailurePolicyIgnore:
+	default:
+		return nil, fmt.Errorf("unknown failurePolicy %q", failurePolicy)
+	}
+	if len(c.KubeConfigFile) == 0 {
+		return nil, fmt.Errorf("kubeConfigFile is required")
+	}
+	w, err := webhook.NewGenericWebhookWithTimeout(c.KubeConfigFile, timeout)
+	if err != nil {
+		return nil, err
+	}
+	resources := util.NewStringSet()
+	for _, resource := range c.Resources {
+		resources.Insert(strings.ToLower(resource))
+	}
+	return &hook{
+		name:          c.Name,
+		webhook:       w,
+		failurePolicy: failurePolicy,
+		resources:     resources,
+		operations:    util.NewStringSet(c.Operations...),
+	}, nil
+}
+
+func (w *admissionWebhook) Admit(a admission.Attributes) (err error) {
+	var review *AdmissionReview
+	for _, h := range w.hooks {
+		if !h.appliesTo(a) {
+			continue
+		}
+		if review == nil {
+			if review, err = newReview(a); err != nil {
+				return apierrors.NewInternalError(err)
+			}
+		}
+		// Each service is sent the request as it was received.
+		r := &AdmissionReview{Spec: review.Spec}
+		if err := h.webhook.Post(r, r); err != nil {
+			if h.failurePolicy == FailurePolicyIgnore {
+				glog.Warningf("Admission webhook %s failed, admitting %s %s: %v", h.name, a.GetOperation(), a.GetResource(), err)
+				continue
+			}
+			return apierrors.NewForbidden(a.GetResource(), review.Spec.Name, fmt.Errorf("admission webhook %s failed: %v", h.name, err))
+		}
+		if !r.Status.Allowed {
+			reason := r.Status.Reason
+			if len(reason) == 0 {
+				reason = "denied the request"
+			}
+			return apierrors.NewForbidden(a.GetResource(), review.Spec.Name, fmt.Errorf("admission webhook %s: %s", h.name, reason))
+		}
+	}
+	return nil
+}
+
+// newReview describes a request for remote services.
+func newReview(a admission.Attributes) (*AdmissionReview, error) {
+	spec := AdmissionReviewSpec{
+		Operation: a.GetOperation(),
+		Resource:  strings.ToLower(a.GetResource()),
+		Namespace: a.GetNamespace(),
+	}
+	if obj := a.GetObject(); obj != nil {
+		_, kind, err := api.Scheme.ObjectVersionAndKind(obj)
+		if err != nil {
+			return nil, err
+		}
+		spec.Kind = kind
+		spec.Name, _ = meta.NewAccessor().Name(obj)
+		if spec.Object, err = latest.Codec.Encode(obj); err != nil {
+			return nil, err
+		}
+	}
+	if userInfo := a.GetUserInfo(); userInfo != nil {
+		spec.UserInfo = &UserInfo{
+			Username: userInfo.GetName(),
+			UID:      userInfo.GetUID(),
+			Groups:   userInfo.GetGroups(),
+		}
+	}
+	return &AdmissionReview{Spec: spec}, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/admission"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	apierrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+)
+
+// writeKubeConfig writes a kubeconfig file for a webhook served at url.
+func writeKubeConfig(t *testing.T, url string) string {
+	f, err := ioutil.TempFile("", "admission_webhook_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating kubeconfig: %v", err)
+	}
+	defer f.Close()
+	fmt.Fprintf(f, `{
+  "current-context": "webhook",
+  "contexts": {"webhook": {"cluster": "webhook", "user": "apiserver"}},
+  "clusters": {"webhook": {"server": %q, "insecure-skip-tls-verify": true}},
+  "users": {"apiserver": {"token": "secret"}}
+}`, url)
+	return f.Name()
+}
+
+// newPolicyServer returns a server that denies pods named "bad" and records
+// the reviews it receives.
+func newPolicyServer(t *testing.T, reviews *[]AdmissionReview) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var review AdmissionReview
+		if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		*reviews = append(*reviews, review)
+		review.Status.Allowed = review.Spec.Name != "bad"
+		if !review.Status.Allowed {
+			review.Status.Reason = "bad pods are not allowed"
+		}
+		json.NewEncoder(w).Encode(review)
+	}))
+}
+
+func newHandler(t *testing.T, hooks ...HookConfig) admission.Interface {
+	data, err := json.Marshal(configFile{Webhook: &Config{Hooks: hooks}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler, err := NewWebhook(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return handler
+}
+
+func newPod(name string) *api.Pod {
+	return &api.Pod{ObjectMeta: api.ObjectMeta{Name: name, Namespace: "ns"}}
+}
+
+func TestAdmit(t *testing.T) {
+	var reviews []AdmissionReview
+	server := newPolicyServer(t, &reviews)
+	defer server.Close()
+	kubeConfig := writeKubeConfig(t, server.URL)
+	defer os.Remove(kubeConfig)
+
+	handler := newHandler(t, HookConfig{Name: "policy", KubeConfigFile: kubeConfig})
+	userInfo := &user.DefaultInfo{Name: "alice", Groups: []string{"devs"}}
+
+	if err := handler.Admit(admission.NewAttributesRecord(newPod("good"), "ns", "pods", "CREATE", userInfo)); err != nil {
+		t.Errorf("Expected pod allowed, got err: %v", err)
+	}
+	err := handler.Admit(admission.NewAttributesRecord(newPod("bad"), "ns", "pods", "CREATE", userInfo))
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "bad pods are not allowed") {
+		t.Errorf("Expected forbidden error with the webhook's reason, got %v", err)
+	}
+
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 reviews, got %d", len(reviews))
+	}
+	spec := reviews[0].Spec
+	if spec.Operation != "CREATE" || spec.Resource != "pods" || spec.Kind != "Pod" || spec.Namespace != "ns" || spec.Name != "good" {
+		t.Errorf("Unexpected review spec: %#v", spec)
+	}
+	if spec.UserInfo == nil || spec.UserInfo.Username != "alice" || len(spec.UserInfo.Groups) != 1 {
+		t.Errorf("Unexpected user info: %#v", spec.UserInfo)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(spec.Object, &object); err != nil || object["kind"] != "Pod" {
+		t.Errorf("Expected the encoded pod, got %s (%v)", string(spec.Object), err)
+	}
+}
+
+func TestAdmitFiltersAndOrder(t *testing.T) {
+	var first, second []AdmissionReview
+	firstServer := newPolicyServer(t, &first)
+	defer firstServer.Close()
+	secondServer := newPolicyServer(t, &second)
+	defer secondServer.Close()
+	firstConfig := writeKubeConfig(t, firstServer.URL)
+	defer os.Remove(firstConfig)
+	secondConfig := writeKubeConfig(t, secondServer.URL)
+	defer os.Remove(secondConfig)
+
+	handler := newHandler(t,
+		HookConfig{Name: "first", KubeConfigFile: firstConfig, Resources: []string{"Pods"}},
+		HookConfig{Name: "second", KubeConfigFile: secondConfig, Operations: []string{"CREATE"}},
+	)
+
+	// the first hook denies, so the second is never asked
+	if err := handler.Admit(admission.NewAttributesRecord(newPod("bad"), "ns", "pods", "CREATE", nil)); !apierrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error, got %v", err)
+	}
+	if len(first) != 1 || len(second) != 0 {
+		t.Errorf("Expected only the first hook to be asked, got %d and %d reviews", len(first), len(second))
+	}
+
+	// the first hook only reviews pods, the second only creations
+	if err := handler.Admit(admission.NewAttributesRecord(&api.Service{}, "ns", "services", "UPDATE", nil)); err != nil {
+		t.Errorf("Expected service allowed, got err: %v", err)
+	}
+	if err := handler.Admit(admission.NewAttributesRecord(&api.Service{}, "ns", "services", "CREATE", nil)); err != nil {
+		t.Errorf("Expected service allowed, got err: %v", err)
+	}
+	if err := handler.Admit(admission.NewAttributesRecord(nil, "ns", "pods", "DELETE", nil)); err != nil {
+		t.Errorf("Expected delete allowed, got err: %v", err)
+	}
+	if len(first) != 2 || len(second) != 1 {
+		t.Errorf("Expected 2 and 1 reviews, got %d and %d", len(first), len(second))
+	}
+	if spec := first[1].Spec; spec.Operation != "DELETE" || spec.Kind != "" || len(spec.Object) != 0 {
+		t.Errorf("Unexpected review of a delete: %#v", spec)
+	}
+}
+
+func TestAdmitFailurePolicy(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	kubeConfig := writeKubeConfig(t, server.URL)
+	defer os.Remove(kubeConfig)
+
+	closed := newHandler(t, HookConfig{Name: "slow", KubeConfigFile: kubeConfig, Timeout: "50ms"})
+	if err := closed.Admit(admission.NewAttributesRecord(newPod("good"), "ns", "pods", "CREATE", nil)); !apierrors.IsForbidden(err) {
+		t.Errorf("Expected forbidden error when the webhook times out, got %v", err)
+	}
+
+	open := newHandler(t, HookConfig{Name: "slow", KubeConfigFile: kubeConfig, Timeout: "50ms", FailurePolicy: FailurePolicyIgnore})
+	if err := open.Admit(admission.NewAttributesRecord(newPod("good"), "ns", "pods", "CREATE", nil)); err != nil {
+		t.Errorf("Expected pod allowed when failing open, got err: %v", err)
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	testCases := map[string]string{
+		"syntax":         `{`,
+		"no kubeconfig":  `{"webhook": {"hooks": [{"name": "a"}]}}`,
+		"bad timeout":    `{"webhook": {"hooks": [{"name": "a", "kubeConfigFile": "x", "timeout": "soon"}]}}`,
+		"bad policy":     `{"webhook": {"hooks": [{"name": "a", "kubeConfigFile": "x", "failurePolicy": "Maybe"}]}}`,
+		"missing config": `{"webhook": {"hooks": [{"name": "a", "kubeConfigFile": "/does/not/exist"}]}}`,
+	}
+	for name, config := range testCases {
+		if _, err := NewWebhook(strings.NewReader(config)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	handler, err := NewWebhook(strings.NewReader(`{"other": {}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := handler.Admit(admission.NewAttributesRecord(newPod("bad"), "ns", "pods", "CREATE", nil)); err != nil {
+		t.Errorf("Expected pod allowed without hooks, got err: %v", err)
+	}
+}
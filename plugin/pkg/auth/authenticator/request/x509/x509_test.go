@@ -22,6 +22,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
@@ -379,6 +380,7 @@ func TestX509(t *testing.T) {
 		User UserConversion
 
 		ExpectUserName string
+		ExpectGroups   []string
 		ExpectOK       bool
 		ExpectErr      bool
 	}{
@@ -425,6 +427,7 @@ func TestX509(t *testing.T) {
 			User:  CommonNameUserConversion,
 
 			ExpectUserName: "client_cn",
+			ExpectGroups:   []string{"My Org"},
 			ExpectOK:       true,
 			ExpectErr:      false,
 		},
@@ -540,6 +543,10 @@ func TestX509(t *testing.T) {
 				t.Errorf("%s: Expected user.name=%v, got %v", k, testCase.ExpectUserName, user.GetName())
 				continue
 			}
+			if testCase.ExpectGroups != nil && !reflect.DeepEqual(testCase.ExpectGroups, user.GetGroups()) {
+				t.Errorf("%s: Expected user.groups=%v, got %v", k, testCase.ExpectGroups, user.GetGroups())
+				continue
+			}
 		}
 	}
 }
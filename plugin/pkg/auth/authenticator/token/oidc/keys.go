@@ -0,0 +1,269 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval limits how often keys are fetched from the provider when
+// tokens signed by unknown keys are presented.
+const minRefreshInterval = 30 * time.Second
+
+// jsonWebKey is a public key from a JSON Web Key Set (RFC 7517).
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC keys
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// publicKey is a signing key and its identifier.
+type publicKey struct {
+	id  string
+	key crypto.PublicKey
+}
+
+// keySet holds the signing keys of a provider.  It is safe for concurrent use.
+type keySet struct {
+	// fetch loads the current keys.  It is nil if the keys never change.
+	fetch func() ([]publicKey, error)
+
+	lock      sync.Mutex
+	keys      []publicKey
+	lastFetch time.Time
+}
+
+// newKeySetFromFile loads a JSON Web Key Set from a file.
+func newKeySetFromFile(path string) (*keySet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key set %s: %v", path, err)
+	}
+	return &keySet{keys: keys}, nil
+}
+
+// providerConfig is the part of an OpenID Connect discovery document used to
+// find the provider's keys.
+type providerConfig struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// newKeySetFromDiscovery returns a key set that is fetched from the jwks_uri
+// of the issuer's discovery document.
+func newKeySetFromDiscovery(issuerURL, caFile string) (*keySet, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if len(caFile) > 0 {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+			Proxy:           http.ProxyFromEnvironment,
+		}
+	}
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	return &keySet{
+		fetch: func() ([]publicKey, error) {
+			config := providerConfig{}
+			if err := getJSON(client, discoveryURL, &config); err != nil {
+				return nil, err
+			}
+			if config.Issuer != issuerURL {
+				return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", config.Issuer, issuerURL)
+			}
+			if len(config.JWKSURI) == 0 {
+				return nil, errors.New("discovery document has no jwks_uri")
+			}
+			set := jsonWebKeySet{}
+			if err := getJSON(client, config.JWKSURI, &set); err != nil {
+				return nil, err
+			}
+			return parseJSONWebKeys(set.Keys)
+		},
+	}, nil
+}
+
+func getJSON(client *http.Client, url string, obj interface{}) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
+
+// verify checks the signature of a token, fetching the keys if none are known
+// or the token names a key that is not known.
+func (s *keySet) verify(header jwtHeader, signingInput string, signature []byte) error {
+	keys, err := s.keysFor(header.KeyID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := verifySignature(header.Algorithm, key.key, signingInput, signature); err == nil {
+			return nil
+		}
+	}
+	return errors.New("token signature could not be verified")
+}
+
+// keysFor returns the keys that may have signed a token with the given key ID.
+// Tokens without a key ID may have been signed by any key.
+func (s *keySet) keysFor(id string) ([]publicKey, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if matching := matchKeys(s.keys, id); len(matching) > 0 {
+		return matching, nil
+	}
+	if s.fetch == nil || time.Since(s.lastFetch) < minRefreshInterval {
+		return nil, fmt.Errorf("no signing key %q is known", id)
+	}
+	keys, err := s.fetch()
+	s.lastFetch = time.Now()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch signing keys: %v", err)
+	}
+	s.keys = keys
+	if matching := matchKeys(keys, id); len(matching) > 0 {
+		return matching, nil
+	}
+	return nil, fmt.Errorf("no signing key %q is known", id)
+}
+
+func matchKeys(keys []publicKey, id string) []publicKey {
+	if len(id) == 0 {
+		return keys
+	}
+	for _, key := range keys {
+		if key.id == id {
+			return []publicKey{key}
+		}
+	}
+	return nil
+}
+
+// parseKeySet parses a JSON Web Key Set.
+func parseKeySet(data []byte) ([]publicKey, error) {
+	set := jsonWebKeySet{}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	return parseJSONWebKeys(set.Keys)
+}
+
+// parseJSONWebKeys returns the signing keys of a key set.  Encryption keys
+// and keys of unknown types are skipped.
+func parseJSONWebKeys(jwks []jsonWebKey) ([]publicKey, error) {
+	keys := []publicKey{}
+	for _, jwk := range jwks {
+		if len(jwk.Use) > 0 && jwk.Use != "sig" {
+			continue
+		}
+		var key crypto.PublicKey
+		switch jwk.KeyType {
+		case "RSA":
+			n, err := decodeBigInt(jwk.N)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: invalid modulus: %v", jwk.KeyID, err)
+			}
+			e, err := decodeBigInt(jwk.E)
+			if err != nil || e.Sign() <= 0 || e.BitLen() > 31 {
+				return nil, fmt.Errorf("key %q: invalid exponent", jwk.KeyID)
+			}
+			key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch jwk.Curve {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				return nil, fmt.Errorf("key %q: unsupported curve %q", jwk.KeyID, jwk.Curve)
+			}
+			x, err := decodeBigInt(jwk.X)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: invalid x coordinate: %v", jwk.KeyID, err)
+			}
+			y, err := decodeBigInt(jwk.Y)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: invalid y coordinate: %v", jwk.KeyID, err)
+			}
+			key = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		default:
+			continue
+		}
+		keys = append(keys, publicKey{id: jwk.KeyID, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+	return keys, nil
+}
+
+// decodeBigInt decodes an unpadded base64url big-endian integer.
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.URLEncoding.DecodeString(padSegment(value))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
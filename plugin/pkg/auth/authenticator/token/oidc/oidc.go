@@ -0,0 +1,272 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oidc implements an authenticator.Token that accepts OpenID Connect
+// ID tokens, which are JWTs signed by an identity provider.
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// Options configures an OIDCAuthenticator.
+type Options struct {
+	// IssuerURL is the provider's issuer, which must match the "iss" claim of
+	// accepted tokens.  Unless JWKSFile is set, the signing keys are found by
+	// OpenID Connect discovery at IssuerURL/.well-known/openid-configuration.
+	IssuerURL string
+	// ClientID must be one of the audiences ("aud" claim) of accepted tokens.
+	ClientID string
+	// CAFile, if set, verifies the provider's certificate during discovery.
+	CAFile string
+	// JWKSFile, if set, is a local JSON Web Key Set holding the signing keys.
+	JWKSFile string
+	// UsernameClaim is the claim used as the user name.  Defaults to "sub".
+	UsernameClaim string
+	// GroupsClaim, if set, is a claim holding the user's groups, either as a
+	// string or a list of strings.
+	GroupsClaim string
+}
+
+// OIDCAuthenticator authenticates bearer tokens that are ID tokens issued by
+// an OpenID Connect provider.
+type OIDCAuthenticator struct {
+	issuerURL     string
+	clientID      string
+	usernameClaim string
+	groupsClaim   string
+	keys          *keySet
+	clock         util.Clock
+}
+
+// New creates an OIDCAuthenticator.  When the keys come from discovery, they
+// are fetched when the first token is presented and again whenever a token is
+// signed by an unknown key, so the provider may be unavailable at startup.
+func New(opts Options) (*OIDCAuthenticator, error) {
+	if !strings.HasPrefix(opts.IssuerURL, "https://") {
+		return nil, fmt.Errorf("the issuer URL must use https, got %q", opts.IssuerURL)
+	}
+	if len(opts.ClientID) == 0 {
+		return nil, errors.New("a client ID is required")
+	}
+	var keys *keySet
+	if len(opts.JWKSFile) > 0 {
+		var err error
+		if keys, err = newKeySetFromFile(opts.JWKSFile); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if keys, err = newKeySetFromDiscovery(opts.IssuerURL, opts.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	usernameClaim := opts.UsernameClaim
+	if len(usernameClaim) == 0 {
+		usernameClaim = "sub"
+	}
+	return &OIDCAuthenticator{
+		issuerURL:     opts.IssuerURL,
+		clientID:      opts.ClientID,
+		usernameClaim: usernameClaim,
+		groupsClaim:   opts.GroupsClaim,
+		keys:          keys,
+		clock:         util.RealClock{},
+	}, nil
+}
+
+// jwtHeader is the JOSE header of a signed token.
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// AuthenticateToken implements authenticator.Token.
+func (a *OIDCAuthenticator) AuthenticateToken(token string) (user.Info, bool, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		// Not a JWT, leave it to other authenticators
+		return nil, false, nil
+	}
+	header := jwtHeader{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, false, nil
+	}
+	claims := map[string]interface{}{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, false, nil
+	}
+	if iss, _ := claims["iss"].(string); iss != a.issuerURL {
+		// Issued by someone else, leave it to other authenticators
+		return nil, false, nil
+	}
+
+	signature, err := base64.URLEncoding.DecodeString(padSegment(parts[2]))
+	if err != nil {
+		return nil, false, err
+	}
+	if err := a.keys.verify(header, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, false, err
+	}
+
+	if !hasAudience(claims["aud"], a.clientID) {
+		return nil, false, fmt.Errorf("token was not issued for client %q", a.clientID)
+	}
+	now := a.clock.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, false, errors.New("token has no expiry")
+	}
+	if !now.Before(time.Unix(int64(exp), 0)) {
+		return nil, false, errors.New("token has expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Before(time.Unix(int64(nbf), 0)) {
+		return nil, false, errors.New("token is not valid yet")
+	}
+
+	username, _ := claims[a.usernameClaim].(string)
+	if len(username) == 0 {
+		return nil, false, fmt.Errorf("token has no %q claim", a.usernameClaim)
+	}
+	if a.usernameClaim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return nil, false, errors.New("token email address is not verified")
+		}
+	}
+	info := &user.DefaultInfo{Name: username}
+	if sub, ok := claims["sub"].(string); ok {
+		info.UID = sub
+	}
+	if len(a.groupsClaim) > 0 {
+		if info.Groups, err = stringsClaim(claims[a.groupsClaim]); err != nil {
+			return nil, false, fmt.Errorf("invalid %q claim: %v", a.groupsClaim, err)
+		}
+	}
+	return info, true, nil
+}
+
+// hasAudience returns true if the "aud" claim, a string or list of strings,
+// includes audience.
+func hasAudience(aud interface{}, audience string) bool {
+	audiences, err := stringsClaim(aud)
+	if err != nil {
+		return false
+	}
+	for _, a := range audiences {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// stringsClaim returns the value of a claim that is a string or a list of
+// strings.  A missing claim is an empty list.
+func stringsClaim(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %v", value)
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("expected a string or a list of strings, got %v", value)
+}
+
+// signingAlgorithms maps the supported JWS algorithms to their hash.
+var signingAlgorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+// verifySignature checks a JWS signature over signingInput with key.
+func verifySignature(algorithm string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	hash, ok := signingAlgorithms[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	h := hash.New()
+	h.Write([]byte(signingInput))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "RS") {
+			return fmt.Errorf("algorithm %q cannot be used with an RSA key", algorithm)
+		}
+		return rsa.VerifyPKCS1v15(k, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "ES") {
+			return fmt.Errorf("algorithm %q cannot be used with an EC key", algorithm)
+		}
+		// The signature is the concatenation of r and s, each the size of the curve.
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid EC signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid EC signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported key type %T", key)
+}
+
+// decodeSegment decodes an unpadded base64url JWT segment into obj.
+func decodeSegment(segment string, obj interface{}) error {
+	data, err := base64.URLEncoding.DecodeString(padSegment(segment))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// padSegment restores the base64 padding stripped from a JWT segment.
+func padSegment(segment string) string {
+	if l := len(segment) % 4; l > 0 {
+		segment += strings.Repeat("=", 4-l)
+	}
+	return segment
+}
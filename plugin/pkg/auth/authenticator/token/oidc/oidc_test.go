@@ -0,0 +1,336 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+const (
+	issuer   = "https://idp.example.com"
+	clientID = "kubernetes"
+)
+
+func encodeSegment(data []byte) string {
+	return strings.TrimRight(base64.URLEncoding.EncodeToString(data), "=")
+}
+
+// signToken returns a JWT with the given claims signed by key.
+func signToken(t *testing.T, key crypto.Signer, keyID string, claims map[string]interface{}) string {
+	algorithm := "RS256"
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		algorithm = "ES256"
+	}
+	header, _ := json.Marshal(map[string]string{"alg": algorithm, "kid": keyID, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := encodeSegment(header) + "." + encodeSegment(payload)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signingInput))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest.Sum(nil)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest.Sum(nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// r and s are each left padded to the size of the curve.
+		signature = make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(signature[32-len(rb):32], rb)
+		copy(signature[64-len(sb):], sb)
+	}
+	return signingInput + "." + encodeSegment(signature)
+}
+
+// keySetJSON returns a JSON Web Key Set holding the public halves of keys.
+func keySetJSON(keys map[string]crypto.Signer) []byte {
+	set := jsonWebKeySet{}
+	for id, key := range keys {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			set.Keys = append(set.Keys, jsonWebKey{
+				KeyType: "RSA", KeyID: id, Use: "sig",
+				N: encodeSegment(k.N.Bytes()),
+				E: encodeSegment(big.NewInt(int64(k.E)).Bytes()),
+			})
+		case *ecdsa.PrivateKey:
+			set.Keys = append(set.Keys, jsonWebKey{
+				KeyType: "EC", KeyID: id, Curve: "P-256",
+				X: encodeSegment(k.X.Bytes()),
+				Y: encodeSegment(k.Y.Bytes()),
+			})
+		}
+	}
+	data, _ := json.Marshal(set)
+	return data
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+func writeTempFile(t *testing.T, data []byte) string {
+	f, err := ioutil.TempFile("", "oidc_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+	f.Write(data)
+	return f.Name()
+}
+
+func claimsFor(now time.Time, extra map[string]interface{}) map[string]interface{} {
+	claims := map[string]interface{}{
+		"iss": issuer,
+		"aud": clientID,
+		"sub": "1234",
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	rsaKey, ecKey, otherKey := newRSAKey(t), newECKey(t), newRSAKey(t)
+	jwksFile := writeTempFile(t, keySetJSON(map[string]crypto.Signer{"rsa": rsaKey, "ec": ecKey}))
+	defer os.Remove(jwksFile)
+
+	authenticator, err := New(Options{
+		IssuerURL:     issuer,
+		ClientID:      clientID,
+		JWKSFile:      jwksFile,
+		UsernameClaim: "email",
+		GroupsClaim:   "groups",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Unix(1430000000, 0)
+	authenticator.clock = &util.FakeClock{Time: now}
+
+	email := map[string]interface{}{"email": "alice@example.com", "groups": []string{"devs", "admins"}}
+	testCases := map[string]struct {
+		token  string
+		ok     bool
+		err    bool
+		groups []string
+	}{
+		"rsa": {
+			token:  signToken(t, rsaKey, "rsa", claimsFor(now, email)),
+			ok:     true,
+			groups: []string{"devs", "admins"},
+		},
+		"ec without key id": {
+			token:  signToken(t, ecKey, "", claimsFor(now, email)),
+			ok:     true,
+			groups: []string{"devs", "admins"},
+		},
+		"single group and audience list": {
+			token:  signToken(t, rsaKey, "rsa", claimsFor(now, map[string]interface{}{"email": "alice@example.com", "groups": "devs", "aud": []string{"other", clientID}})),
+			ok:     true,
+			groups: []string{"devs"},
+		},
+		"not a jwt": {
+			token: "abc123",
+		},
+		"other issuer": {
+			token: signToken(t, otherKey, "rsa", claimsFor(now, map[string]interface{}{"iss": "https://other.example.com", "email": "alice@example.com"})),
+		},
+		"bad signature": {
+			token: signToken(t, otherKey, "rsa", claimsFor(now, email)),
+			err:   true,
+		},
+		"unknown key": {
+			token: signToken(t, rsaKey, "unknown", claimsFor(now, email)),
+			err:   true,
+		},
+		"wrong audience": {
+			token: signToken(t, rsaKey, "rsa", claimsFor(now, map[string]interface{}{"email": "alice@example.com", "aud": "other"})),
+			err:   true,
+		},
+		"expired": {
+			token: signToken(t, rsaKey, "rsa", claimsFor(now, map[string]interface{}{"email": "alice@example.com", "exp": now.Add(-time.Second).Unix()})),
+			err:   true,
+		},
+		"not yet valid": {
+			token: signToken(t, rsaKey, "rsa", claimsFor(now, map[string]interface{}{"email": "alice@example.com", "nbf": now.Add(time.Minute).Unix()})),
+			err:   true,
+		},
+		"no username": {
+			token: signToken(t, rsaKey, "rsa", claimsFor(now, nil)),
+			err:   true,
+		},
+		"unverified email": {
+			token: signToken(t, rsaKey, "rsa", claimsFor(now, map[string]interface{}{"email": "alice@example.com", "email_verified": false})),
+			err:   true,
+		},
+	}
+	for name, tc := range testCases {
+		info, ok, err := authenticator.AuthenticateToken(tc.token)
+		if (err != nil) != tc.err {
+			t.Errorf("%s: expected error %v, got %v", name, tc.err, err)
+		}
+		if ok != tc.ok {
+			t.Errorf("%s: expected ok %v, got %v", name, tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if info.GetName() != "alice@example.com" || info.GetUID() != "1234" {
+			t.Errorf("%s: unexpected user %#v", name, info)
+		}
+		if strings.Join(info.GetGroups(), ",") != strings.Join(tc.groups, ",") {
+			t.Errorf("%s: expected groups %v, got %v", name, tc.groups, info.GetGroups())
+		}
+	}
+}
+
+func TestDiscovery(t *testing.T) {
+	oldKey, newKey := newRSAKey(t), newRSAKey(t)
+	keys := map[string]crypto.Signer{"old": oldKey}
+	fetches := 0
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(providerConfig{Issuer: server.URL, JWKSURI: server.URL + "/keys"})
+		case "/keys":
+			fetches++
+			w.Write(keySetJSON(keys))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+	caFile := writeTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.TLS.Certificates[0].Certificate[0]}))
+	defer os.Remove(caFile)
+
+	authenticator, err := New(Options{IssuerURL: server.URL, ClientID: clientID, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims := claimsFor(time.Now(), map[string]interface{}{"iss": server.URL})
+
+	info, ok, err := authenticator.AuthenticateToken(signToken(t, oldKey, "old", claims))
+	if err != nil || !ok || info.GetName() != "1234" {
+		t.Fatalf("expected token to be authenticated as 1234, got %v %v %v", info, ok, err)
+	}
+
+	// the provider rotates its key; the new key is fetched when first used
+	keys["new"] = newKey
+	authenticator.keys.lastFetch = time.Time{}
+	if _, ok, err := authenticator.AuthenticateToken(signToken(t, newKey, "new", claims)); err != nil || !ok {
+		t.Errorf("expected token signed with the new key to be authenticated, got %v %v", ok, err)
+	}
+	if fetches != 2 {
+		t.Errorf("expected keys to be fetched twice, got %d", fetches)
+	}
+
+	// unknown keys do not cause the provider to be polled again right away
+	if _, _, err := authenticator.AuthenticateToken(signToken(t, newKey, "unknown", claims)); err == nil {
+		t.Errorf("expected error for an unknown key")
+	}
+	if fetches != 2 {
+		t.Errorf("expected keys to be fetched twice, got %d", fetches)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	invalidFile := writeTempFile(t, []byte(`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`))
+	defer os.Remove(invalidFile)
+	testCases := map[string]Options{
+		"no issuer":      {ClientID: clientID, JWKSFile: invalidFile},
+		"http issuer":    {IssuerURL: "http://idp.example.com", ClientID: clientID, JWKSFile: invalidFile},
+		"no client":      {IssuerURL: issuer, JWKSFile: invalidFile},
+		"no signing key": {IssuerURL: issuer, ClientID: clientID, JWKSFile: invalidFile},
+		"missing file":   {IssuerURL: issuer, ClientID: clientID, JWKSFile: "/does/not/exist"},
+		"missing ca":     {IssuerURL: issuer, ClientID: clientID, CAFile: "/does/not/exist"},
+	}
+	for name, opts := range testCases {
+		if _, err := New(opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseJSONWebKeysExponent(t *testing.T) {
+	n := encodeSegment(big.NewInt(0).Lsh(big.NewInt(1), 2047).Bytes())
+	testCases := map[string]struct {
+		e     *big.Int
+		valid bool
+	}{
+		"common exponent":  {big.NewInt(65537), true},
+		"largest exponent": {big.NewInt(1<<31 - 1), true},
+		"too large":        {big.NewInt(1 << 31), false},
+		"much too large":   {big.NewInt(0).Lsh(big.NewInt(1), 100), false},
+		"zero":             {big.NewInt(0), false},
+	}
+	for name, testCase := range testCases {
+		keys, err := parseJSONWebKeys([]jsonWebKey{{KeyType: "RSA", KeyID: "k", N: n, E: encodeSegment(testCase.e.Bytes())}})
+		if !testCase.valid {
+			if err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if len(keys) != 1 || keys[0].key.(*rsa.PublicKey).E != int(testCase.e.Int64()) {
+			t.Errorf("%s: unexpected keys %#v", name, keys)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements an authenticator.Token that asks a remote
+// service to review bearer tokens.
+package webhook
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/webhook"
+)
+
+// TokenReview is posted to the remote service to ask who a token belongs to.
+// The service fills in the status.
+type TokenReview struct {
+	Spec   TokenReviewSpec   `json:"spec"`
+	Status TokenReviewStatus `json:"status"`
+}
+
+// TokenReviewSpec holds the token being reviewed.
+type TokenReviewSpec struct {
+	Token string `json:"token"`
+}
+
+// TokenReviewStatus is the remote service's answer.
+type TokenReviewStatus struct {
+	// Authenticated is true if the token is valid.
+	Authenticated bool `json:"authenticated"`
+	// User describes the owner of a valid token.
+	User UserInfo `json:"user"`
+}
+
+// UserInfo describes an authenticated user.
+type UserInfo struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid"`
+	Groups   []string `json:"groups"`
+}
+
+// WebhookTokenAuthenticator authenticates bearer tokens by posting a
+// TokenReview to a remote service.  Answers are cached for a fixed time.
+type WebhookTokenAuthenticator struct {
+	webhook *webhook.GenericWebhook
+	cache   *webhook.Cache
+}
+
+// New creates a token authenticator for the service described by the given
+// kubeconfig file.  Reviews are cached for cacheTTL.
+func New(kubeConfigFile string, cacheTTL time.Duration) (*WebhookTokenAuthenticator, error) {
+	w, err := webhook.NewGenericWebhook(kubeConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookTokenAuthenticator{w, webhook.NewCache(cacheTTL)}, nil
+}
+
+// AuthenticateToken implements authenticator.Token.
+func (a *WebhookTokenAuthenticator) AuthenticateToken(token string) (user.Info, bool, error) {
+	var status TokenReviewStatus
+	if cached, ok := a.cache.Get(token); ok {
+		status = cached.(TokenReviewStatus)
+	} else {
+		review := &TokenReview{Spec: TokenReviewSpec{Token: token}}
+		if err := a.webhook.Post(review, review); err != nil {
+			return nil, false, err
+		}
+		status = review.Status
+		a.cache.Set(token, status)
+	}
+
+	if !status.Authenticated {
+		return nil, false, nil
+	}
+	return &user.DefaultInfo{
+		Name:   status.User.Username,
+		UID:    status.User.UID,
+		Groups: status.User.Groups,
+	}, true, nil
+}
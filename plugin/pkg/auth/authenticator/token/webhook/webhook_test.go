@@ -0,0 +1,158 @@
+/*
+Copyright 2015 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+)
+
+func newWithServer(t *testing.T, server *httptest.Server, cacheTTL time.Duration) *WebhookTokenAuthenticator {
+	f, err := ioutil.TempFile("", "webhook_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating kubeconfig: %v", err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	kubeConfig := fmt.Sprintf(`{
+  "current-context": "webhook",
+  "contexts": {"webhook": {"cluster": "webhook"}},
+  "clusters": {"webhook": {"server": %q, "insecure-skip-tls-verify": true}}
+}`, server.URL)
+	if err := ioutil.WriteFile(f.Name(), []byte(kubeConfig), 0600); err != nil {
+		t.Fatalf("unexpected error writing kubeconfig: %v", err)
+	}
+
+	auth, err := New(f.Name(), cacheTTL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return auth
+}
+
+// fakeIdentityService answers token reviews from a fixed set of users and
+// counts the reviews it is asked for.
+type fakeIdentityService struct {
+	users   map[string]UserInfo
+	fail    bool
+	reviews int
+}
+
+func (s *fakeIdentityService) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.reviews++
+	if s.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var review TokenReview
+	if err := json.NewDecoder(req.Body).Decode(&review); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if info, ok := s.users[review.Spec.Token]; ok {
+		review.Status = TokenReviewStatus{Authenticated: true, User: info}
+	}
+	json.NewEncoder(w).Encode(review)
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	service := &fakeIdentityService{
+		users: map[string]UserInfo{
+			"token1": {Username: "alice", UID: "1", Groups: []string{"admins"}},
+			"token2": {Username: "bob", UID: "2"},
+		},
+	}
+	server := httptest.NewTLSServer(service)
+	defer server.Close()
+	auth := newWithServer(t, server, time.Minute)
+
+	testCases := []struct {
+		Token string
+		User  *user.DefaultInfo
+		Ok    bool
+	}{
+		{
+			Token: "token1",
+			User:  &user.DefaultInfo{Name: "alice", UID: "1", Groups: []string{"admins"}},
+			Ok:    true,
+		},
+		{
+			Token: "token2",
+			User:  &user.DefaultInfo{Name: "bob", UID: "2"},
+			Ok:    true,
+		},
+		{
+			Token: "token3",
+		},
+	}
+	for i, testCase := range testCases {
+		user, ok, err := auth.AuthenticateToken(testCase.Token)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if testCase.User == nil {
+			if user != nil {
+				t.Errorf("%d: unexpected non-nil user %#v", i, user)
+			}
+		} else if !reflect.DeepEqual(testCase.User, user) {
+			t.Errorf("%d: expected user %#v, got %#v", i, testCase.User, user)
+		}
+		if testCase.Ok != ok {
+			t.Errorf("%d: expected auth %v, got %v", i, testCase.Ok, ok)
+		}
+	}
+}
+
+func TestAuthenticateTokenCachesReviews(t *testing.T) {
+	service := &fakeIdentityService{
+		users: map[string]UserInfo{"token1": {Username: "alice"}},
+	}
+	server := httptest.NewTLSServer(service)
+	defer server.Close()
+	auth := newWithServer(t, server, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, ok, err := auth.AuthenticateToken("token1"); !ok || err != nil {
+			t.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+		if _, ok, err := auth.AuthenticateToken("token2"); ok || err != nil {
+			t.Fatalf("unexpected result: %v, %v", ok, err)
+		}
+	}
+	if service.reviews != 2 {
+		t.Errorf("expected 2 reviews, got %d", service.reviews)
+	}
+
+	// Cached answers are used even if the service becomes unavailable.
+	service.fail = true
+	if _, ok, err := auth.AuthenticateToken("token1"); !ok || err != nil {
+		t.Errorf("unexpected result: %v, %v", ok, err)
+	}
+	if _, _, err := auth.AuthenticateToken("token3"); err == nil {
+		t.Errorf("expected an error when the service fails")
+	}
+}